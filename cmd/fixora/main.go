@@ -9,13 +9,19 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"fixora/internal/adapter/ai"
+	"fixora/internal/adapter/email"
 	"fixora/internal/adapter/http"
 	"fixora/internal/adapter/persistence"
+	"fixora/internal/adapter/slack"
+	"fixora/internal/adapter/webhook"
 	"fixora/internal/config"
+	"fixora/internal/domain"
+	"fixora/internal/infra/events"
 	"fixora/internal/infra/sse"
 	"fixora/internal/usecase"
 
@@ -36,6 +42,7 @@ func main() {
 		version    = flag.Bool("version", false, "Show version information")
 		migrate    = flag.Bool("migrate", false, "Run database migrations and exit")
 		seed       = flag.Bool("seed", false, "Seed database with sample data and exit")
+		reindex    = flag.Bool("reindex", false, "Re-embed the knowledge base with the configured embedding model and exit")
 	)
 	flag.Parse()
 
@@ -94,18 +101,36 @@ func main() {
 		os.Exit(0)
 	}
 
-	// Initialize repositories
-	repos := initRepositories(db, cfg)
+	// Handle knowledge base reindexing
+	if *reindex {
+		if err := reindexKnowledgeBase(ctx, db, cfg); err != nil {
+			log.Fatalf("Failed to reindex knowledge base: %v", err)
+		}
+		log.Println("Knowledge base reindexed successfully")
+		os.Exit(0)
+	}
 
 	// Initialize AI services
 	aiFactory := initAIServices(cfg)
+	embeddings := initEmbeddings(db, cfg, aiFactory)
+
+	// Initialize repositories
+	repos := initRepositories(db, cfg, embeddings)
+
+	// Ground AI suggestions in the knowledge base when the provider supports it
+	if groundable, ok := aiFactory.(ports.KnowledgeGroundable); ok {
+		groundable.SetKnowledgeBase(repos.Knowledge)
+	}
 
 	// Initialize SSE streamer
 	streamer := sse.NewStreamer()
 	streamer.Start(ctx)
 
 	// Initialize use cases
-	useCases := initUseCases(repos, aiFactory, streamer)
+	useCases := initUseCases(ctx, cfg, repos, aiFactory, embeddings, streamer)
+
+	// Start receiving email for the helpdesk mailbox
+	smtpServer := startInboundEmail(ctx, cfg, useCases.EmailIngest)
 
 	// Initialize HTTP server
 	server := initHTTPServer(cfg, useCases)
@@ -136,6 +161,19 @@ func main() {
 		log.Printf("Error during server shutdown: %v", err)
 	}
 
+	if smtpServer != nil {
+		if err := smtpServer.Close(); err != nil {
+			log.Printf("Error during SMTP server shutdown: %v", err)
+		}
+	}
+
+	// Save tickets the classifier learned since its last save
+	if useCases.Classifier != nil {
+		if err := useCases.Classifier.Flush(shutdownCtx); err != nil {
+			log.Printf("Error saving ticket classifier: %v", err)
+		}
+	}
+
 	log.Println("Server stopped successfully")
 }
 
@@ -176,11 +214,30 @@ func initDatabase(ctx context.Context, cfg *config.Config) (*sql.DB, error) {
 }
 
 // initRepositories initializes all repository implementations
-func initRepositories(db *sql.DB, cfg *config.Config) Repositories {
+func initRepositories(db *sql.DB, cfg *config.Config, embeddings ports.EmbeddingProvider) Repositories {
 	return Repositories{
 		Ticket:    persistence.NewPostgresTicketRepository(db),
 		Comment:   persistence.NewPostgresCommentRepository(db),
-		Knowledge: persistence.NewPostgresKnowledgeRepository(db, nil), // Will be updated with embedding provider
+		Knowledge: persistence.NewPostgresKnowledgeRepository(db, embeddings),
+		IndexJob:  persistence.NewPostgresIndexJobRepository(db),
+		Classifier: persistence.NewPostgresClassifierRepository(db),
+		Feedback:   persistence.NewPostgresFeedbackRepository(db),
+		Deflection: persistence.NewPostgresDeflectionRepository(db),
+		TicketEmbedding: persistence.NewPostgresTicketEmbeddingRepository(db),
+		Incident:   persistence.NewPostgresIncidentRepository(db),
+		Problem:    persistence.NewPostgresProblemRepository(db),
+		Agent:      persistence.NewPostgresAgentRepository(db),
+		Team:       persistence.NewPostgresTeamRepository(db),
+		Queue:      persistence.NewPostgresQueueRepository(db),
+		AutomationRule:      persistence.NewPostgresAutomationRuleRepository(db),
+		AutomationExecution: persistence.NewPostgresAutomationExecutionRepository(db),
+		JobRun:              persistence.NewPostgresJobRunRepository(db),
+		CSAT:                persistence.NewPostgresCSATRepository(db),
+		EmailMessage:        persistence.NewPostgresEmailMessageRepository(db),
+		WebhookSubscription: persistence.NewPostgresWebhookSubscriptionRepository(db),
+		WebhookDelivery:     persistence.NewPostgresWebhookDeliveryRepository(db),
+		SchedulerLock:       persistence.NewPostgresAdvisoryLock(db, schedulerLockKey),
+		JobLock:             persistence.NewPostgresJobLock(db, jobLockClass),
 	}
 }
 
@@ -189,67 +246,425 @@ type Repositories struct {
 	Ticket    ports.TicketRepository
 	Comment   ports.CommentRepository
 	Knowledge ports.KnowledgeRepository
+	IndexJob  ports.IndexJobRepository
+	Classifier ports.ClassifierRepository
+	Feedback   ports.FeedbackRepository
+	Deflection ports.DeflectionRepository
+	TicketEmbedding ports.TicketEmbeddingRepository
+	Incident   ports.IncidentRepository
+	Problem    ports.ProblemRepository
+	Agent      ports.AgentRepository
+	Team       ports.TeamRepository
+	Queue      ports.QueueRepository
+	AutomationRule      ports.AutomationRuleRepository
+	AutomationExecution ports.AutomationExecutionRepository
+	JobRun              ports.JobRunRepository
+	CSAT                ports.CSATRepository
+	EmailMessage        ports.EmailMessageRepository
+	WebhookSubscription ports.WebhookSubscriptionRepository
+	WebhookDelivery     ports.WebhookDeliveryRepository
+	SchedulerLock       ports.LeaderLock
+	JobLock             ports.JobLock
 }
 
-// initAIServices initializes AI services based on configuration
-func initAIServices(cfg *config.Config) ports.AIProviderFactory {
-	var aiFactory ports.AIProviderFactory
+// schedulerLockKey is the Postgres advisory lock key held by the scheduler leader
+const schedulerLockKey int64 = 4_711_042_045
+
+// jobLockClass is the Postgres advisory lock class holding the lock of each running scheduled job
+const jobLockClass int32 = 471_104_204
 
+// initAIServices initializes AI services based on configuration. With AI_FALLBACK_PROVIDERS set,
+// the primary provider and its fallbacks are wrapped in a router with per-provider circuit breakers.
+func initAIServices(cfg *config.Config) ports.AIProviderFactory {
 	if cfg.AI.MockMode || cfg.AI.Provider == "mock" {
 		log.Println("Using mock AI services")
 		aiConfig := cfg.ToAIConfig()
-		aiFactory = ai.NewMockAIProviderFactory(aiConfig)
-	} else {
-		switch cfg.AI.Provider {
-		case "openai":
-			log.Println("Using OpenAI AI services")
-			aiConfig := cfg.ToAIConfig()
-			aiConfig.APIKey = cfg.AI.Providers["openai"]
-			aiFactory = ai.NewOpenAIAdapter(aiConfig)
-		default:
-			log.Printf("Unknown AI provider: %s, falling back to mock", cfg.AI.Provider)
-			aiConfig := cfg.ToAIConfig()
-			aiFactory = ai.NewMockAIProviderFactory(aiConfig)
+		return ai.NewMockAIProviderFactory(aiConfig)
+	}
+
+	primary, err := newAIProvider(cfg, cfg.AI.Provider)
+	if err != nil {
+		log.Printf("%v, falling back to mock", err)
+		primary = ai.NewMockAIProviderFactory(cfg.ToAIConfig())
+	}
+
+	if len(cfg.AI.Fallbacks) == 0 {
+		return primary
+	}
+
+	providers := []ports.AIProviderFactory{primary}
+	for _, name := range cfg.AI.Fallbacks {
+		provider, err := newAIProvider(cfg, name)
+		if err != nil {
+			log.Printf("Skipping AI fallback provider: %v", err)
+			continue
 		}
+		providers = append(providers, provider)
 	}
 
-	return aiFactory
+	names := make([]string, len(providers))
+	for i, provider := range providers {
+		names[i] = provider.Provider()
+	}
+	log.Printf("Routing AI requests across providers: %s", strings.Join(names, " -> "))
+
+	return ai.NewAIRouter(providers, ai.RouterConfig{
+		FailureThreshold: cfg.AI.BreakerThreshold,
+		Cooldown:         cfg.AI.BreakerCooldown,
+		TopK:             cfg.AI.TopK,
+		MinScore:         cfg.AI.MinConfidence,
+	})
+}
+
+// newAIProvider creates a single AI provider from its configuration
+func newAIProvider(cfg *config.Config, name string) (ports.AIProviderFactory, error) {
+	aiConfig := cfg.ToAIConfig()
+	aiConfig.APIKey = cfg.AIProviderKey(name)
+
+	if name != "mock" && aiConfig.APIKey == "" && cfg.AIProviderRequiresKey(name) {
+		return nil, fmt.Errorf("AI API key is required for provider: %s", name)
+	}
+
+	settings := cfg.AIEndpoint(name)
+	endpoint := ai.Endpoint{
+		BaseURL:    settings.BaseURL,
+		AuthScheme: settings.AuthScheme,
+		APIKey:     aiConfig.APIKey,
+		APIVersion: settings.APIVersion,
+		ModelMap:   settings.ModelMap,
+	}
+
+	switch name {
+	case "mock":
+		log.Println("Using mock AI services")
+		return ai.NewMockAIProviderFactory(aiConfig), nil
+	case "openai":
+		log.Println("Using OpenAI AI services")
+		if endpoint.BaseURL == "" && len(endpoint.ModelMap) == 0 {
+			return ai.NewOpenAIAdapter(aiConfig), nil
+		}
+		endpoint.Name = "openai"
+		if endpoint.BaseURL == "" {
+			endpoint.BaseURL = "https://api.openai.com/v1"
+		}
+		return ai.NewOpenAICompatibleAdapter(aiConfig, endpoint), nil
+	case "azure":
+		if endpoint.BaseURL == "" {
+			return nil, fmt.Errorf("AI base URL is required for provider: azure")
+		}
+		log.Printf("Using Azure OpenAI AI services at %s", endpoint.BaseURL)
+		return ai.NewAzureOpenAIAdapter(aiConfig, endpoint), nil
+	case "zai":
+		log.Println("Using Z.ai AI services")
+		return ai.NewZaiAdapter(aiConfig, endpoint), nil
+	case "ollama":
+		endpoint.Name = "ollama"
+		if endpoint.BaseURL == "" {
+			endpoint.BaseURL = "http://localhost:11434/v1"
+		}
+		log.Printf("Using Ollama AI services at %s", endpoint.BaseURL)
+		return ai.NewOpenAICompatibleAdapter(aiConfig, endpoint), nil
+	case "compatible":
+		if endpoint.BaseURL == "" {
+			return nil, fmt.Errorf("AI base URL is required for provider: compatible")
+		}
+		log.Printf("Using OpenAI-compatible AI services at %s", endpoint.BaseURL)
+		return ai.NewOpenAICompatibleAdapter(aiConfig, endpoint), nil
+	default:
+		return nil, fmt.Errorf("unknown AI provider: %s", name)
+	}
+}
+
+// initEmbeddings wraps the provider's embeddings with the embedding cache when enabled
+func initEmbeddings(db *sql.DB, cfg *config.Config, aiFactory ports.AIProviderFactory) ports.EmbeddingProvider {
+	embeddings := aiFactory.Embeddings()
+	if !cfg.AI.EnableCache {
+		return embeddings
+	}
+
+	var store ports.EmbeddingCacheStore
+	if cfg.AI.CachePersistent {
+		store = persistence.NewPostgresEmbeddingCacheStore(db)
+	}
+
+	log.Printf("Embedding cache enabled (size: %d, ttl: %dm, persistent: %t)", cfg.AI.CacheSize, cfg.AI.CacheTTLMin, cfg.AI.CachePersistent)
+	return ai.NewCachedEmbeddingProvider(embeddings, store, cfg.AI.CacheSize, time.Duration(cfg.AI.CacheTTLMin)*time.Minute)
 }
 
 // initUseCases initializes all use cases
-func initUseCases(repos Repositories, aiFactory ports.AIProviderFactory, streamer *sse.Streamer) UseCases {
-	// Update knowledge repository with embedding provider
-	if kbRepo, ok := repos.Knowledge.(*persistence.PostgresKnowledgeRepository); ok {
-		// In a real implementation, you would need to modify the constructor to accept embedding provider
-		_ = kbRepo
+func initUseCases(ctx context.Context, cfg *config.Config, repos Repositories, aiFactory ports.AIProviderFactory, embeddings ports.EmbeddingProvider, streamer *sse.Streamer) UseCases {
+	eventBus := events.NewBus()
+	notifyService := initNotifications(ctx, cfg, repos)
+
+	// Embed tickets to detect duplicates and related tickets
+	var similarity *usecase.TicketSimilarity
+	if embeddings != nil {
+		similarity = usecase.NewTicketSimilarity(
+			repos.TicketEmbedding,
+			embeddings,
+			usecase.TicketSimilarityConfig{
+				DuplicateThreshold: cfg.AI.TicketDuplicateScore,
+				SimilarThreshold:   cfg.AI.TicketSimilarScore,
+			},
+		)
 	}
 
+	// Signed satisfaction rating links sent with resolution notifications
+	csatUseCase := usecase.NewCSATUseCase(
+		repos.CSAT,
+		repos.Ticket,
+		eventBus,
+		usecase.CSATConfig{
+			LinkSecret: []byte(cfg.CSAT.LinkSecret),
+			LinkTTL:    cfg.CSAT.LinkTTL,
+			BaseURL:    cfg.CSAT.BaseURL,
+		},
+	)
+
 	ticketUseCase := usecase.NewTicketUseCase(
 		repos.Ticket,
 		repos.Comment,
 		aiFactory.Suggestion(),
-		nil, // Event publisher - would implement this
-		nil, // Notification service - would implement this
+		eventBus,
+		notifyService,
+		similarity,
+		csatUseCase,
 	)
 
+	var classifier ports.TicketClassifier
+	var localClassifier *ai.LocalClassifier
+	if cfg.AI.ClassifierEnabled {
+		loaded, err := ai.NewLocalClassifier(ctx, repos.Classifier, ai.LocalClassifierConfig{
+			SaveEvery:    cfg.AI.ClassifierSaveEvery,
+			SaveInterval: cfg.AI.ClassifierSaveInterval,
+		})
+		if err != nil {
+			log.Printf("Local ticket classifier disabled: %v", err)
+		} else {
+			log.Printf("Local ticket classifier loaded (%d training tickets)", loaded.Documents())
+			classifier = loaded
+			localClassifier = loaded
+		}
+	}
+
 	aiUseCase := usecase.NewAIUseCase(
 		aiFactory.Suggestion(),
-		aiFactory.Embeddings(),
+		embeddings,
 		repos.Knowledge,
 		repos.Ticket,
+		repos.Comment,
+		repos.CSAT,
+		repos.Feedback,
 		aiFactory.Training(),
+		classifier,
+		similarity,
+		cfg.AI.MinConfidence,
+	)
+
+	// Learn from tickets as they are resolved
+	trainer := usecase.NewResolvedTicketTrainer(aiUseCase)
+	_ = eventBus.Subscribe(trainer.EventType(), trainer)
+
+	// Learn from the requester's satisfaction rating once they rate the resolution
+	ratedTrainer := usecase.NewRatedTicketTrainer(aiUseCase)
+	_ = eventBus.Subscribe(ratedTrainer.EventType(), ratedTrainer)
+
+	// Draft LEARNED knowledge base entries from resolved tickets for admin review
+	if cfg.AI.LearnFromTickets {
+		var summarizer ports.ResolutionSummarizer
+		if service, ok := aiFactory.Suggestion().(ports.ResolutionSummarizer); ok {
+			summarizer = service
+		}
+
+		kbLearner := usecase.NewKBLearner(
+			repos.Ticket,
+			repos.Comment,
+			repos.Knowledge,
+			summarizer,
+			eventBus,
+			usecase.KBLearnerConfig{
+				DuplicateScore: cfg.AI.LearnDuplicateScore,
+			},
+		)
+		drafter := usecase.NewLearnedEntryDrafter(kbLearner)
+		_ = eventBus.Subscribe(drafter.EventType(), drafter)
+	}
+
+	kbIndexer := usecase.NewKBIndexer(
+		repos.Knowledge,
+		repos.IndexJob,
+		embeddings,
+		eventBus,
+		usecase.KBIndexerConfig{
+			Workers:        cfg.AI.IndexWorkers,
+			BatchSize:      cfg.AI.BatchSize,
+			MaxConcurrency: cfg.AI.MaxConcurrency,
+			MaxRetries:     cfg.AI.IndexMaxRetries,
+			PollInterval:   cfg.AI.IndexPollInterval,
+			ChunkSize:      cfg.AI.ChunkSize,
+			ChunkOverlap:   cfg.AI.ChunkOverlap,
+		},
 	)
+	kbIndexer.Start(ctx)
 
 	knowledgeUseCase := usecase.NewKnowledgeUseCase(
 		repos.Knowledge,
-		aiFactory.Embeddings(),
-		nil, // Event publisher - would implement this
+		kbIndexer,
+		eventBus,
+	)
+
+	feedbackUseCase := usecase.NewFeedbackUseCase(
+		repos.Feedback,
+		repos.Ticket,
+		repos.CSAT,
+		ticketUseCase,
+		eventBus,
+	)
+
+	deflectionUseCase := usecase.NewDeflectionUseCase(
+		repos.Deflection,
+		aiUseCase,
+		ticketUseCase,
+		feedbackUseCase,
+		eventBus,
+	)
+
+	incidentUseCase := usecase.NewIncidentUseCase(
+		repos.Incident,
+		repos.Ticket,
+		repos.Comment,
+		ticketUseCase,
+		notifyService,
+		eventBus,
 	)
 
+	problemUseCase := usecase.NewProblemUseCase(
+		repos.Problem,
+		repos.Incident,
+		knowledgeUseCase,
+		eventBus,
+	)
+
+	assignmentUseCase := usecase.NewAssignmentUseCase(
+		repos.Agent,
+		repos.Ticket,
+		ticketUseCase,
+		aiUseCase,
+		usecase.AssignmentConfig{
+			Strategy: cfg.Assignment.Strategy,
+		},
+	)
+
+	// Route new tickets to agents as they are created
+	if cfg.Assignment.AutoAssign {
+		assigner := usecase.NewTicketAutoAssigner(assignmentUseCase)
+		_ = eventBus.Subscribe(assigner.EventType(), assigner)
+	}
+
+	teamUseCase := usecase.NewTeamUseCase(
+		repos.Team,
+		repos.Queue,
+		repos.Agent,
+		repos.Ticket,
+		ticketUseCase,
+		eventBus,
+	)
+
+	automationUseCase := usecase.NewAutomationUseCase(
+		repos.AutomationRule,
+		repos.AutomationExecution,
+		repos.Ticket,
+		repos.Comment,
+		ticketUseCase,
+		notifyService,
+		usecase.AutomationConfig{},
+	)
+
+	// Run automation rules on ticket events
+	if cfg.Automation.Enabled {
+		for _, handler := range usecase.NewAutomationTriggerHandlers(automationUseCase) {
+			_ = eventBus.Subscribe(handler.EventType(), handler)
+		}
+	}
+
+	// Already checked by cfg.Validate
+	webhookNetworks, _ := domain.ParseWebhookNetworks(cfg.Webhooks.AllowedNetworks)
+	webhookUseCase := usecase.NewWebhookUseCase(
+		repos.WebhookSubscription,
+		repos.WebhookDelivery,
+		webhook.NewHTTPClient(cfg.Webhooks.Timeout, webhookNetworks),
+		usecase.WebhookConfig{
+			MaxAttempts:     cfg.Webhooks.MaxAttempts,
+			DisableAfter:    cfg.Webhooks.DisableAfter,
+			AllowedNetworks: webhookNetworks,
+			Lease:           cfg.Webhooks.Timeout + 30*time.Second,
+		},
+	)
+
+	// Deliver events to webhook subscriptions
+	if cfg.Webhooks.Enabled {
+		for _, handler := range usecase.NewWebhookEventHandlers(webhookUseCase) {
+			_ = eventBus.Subscribe(handler.EventType(), handler)
+		}
+	}
+
+	scheduler := usecase.NewScheduler(
+		repos.SchedulerLock,
+		repos.JobLock,
+		repos.JobRun,
+		usecase.SchedulerConfig{
+			Instance:     cfg.Scheduler.InstanceID,
+			TickInterval: cfg.Scheduler.TickInterval,
+		},
+	)
+	registerScheduledJobs(cfg, scheduler, repos, ticketUseCase, automationUseCase, webhookUseCase, notifyService)
+
+	// Run background jobs on whichever instance holds the scheduler lock
+	if cfg.Scheduler.Enabled {
+		scheduler.Start(ctx)
+	}
+
+	emailIngestUseCase := usecase.NewEmailIngestUseCase(
+		repos.EmailMessage,
+		repos.Ticket,
+		ticketUseCase,
+		aiUseCase,
+		usecase.EmailIngestConfig{
+			UseAI:       cfg.Inbound.UseAI,
+			ReplySecret: []byte(cfg.Outbound.ReplySecret),
+		},
+	)
+
+	// Post new tickets to Slack; their buttons are handled by the Slack endpoints
+	var chatNotifier ports.ChatNotifier
+	if cfg.Slack.WebhookURL != "" {
+		chatNotifier = slack.NewNotifier(cfg.Slack.SlackConfig)
+	}
+	chatOpsUseCase := usecase.NewChatOpsUseCase(ticketUseCase, aiUseCase, chatNotifier, usecase.ChatOpsConfig{
+		UserMap: cfg.Slack.UserMap,
+	})
+	if chatNotifier != nil {
+		chatTicketNotifier := usecase.NewChatTicketNotifier(chatOpsUseCase)
+		_ = eventBus.Subscribe(chatTicketNotifier.EventType(), chatTicketNotifier)
+	}
+
 	return UseCases{
 		Ticket:     ticketUseCase,
 		AI:         aiUseCase,
 		Knowledge:  knowledgeUseCase,
+		Feedback:   feedbackUseCase,
+		Deflection: deflectionUseCase,
+		Incident:   incidentUseCase,
+		Problem:    problemUseCase,
+		Assignment: assignmentUseCase,
+		Team:       teamUseCase,
+		Automation: automationUseCase,
+		Scheduler:  scheduler,
+		CSAT:       csatUseCase,
+		EmailIngest: emailIngestUseCase,
+		Webhook:     webhookUseCase,
+		ChatOps:     chatOpsUseCase,
+		Classifier:  localClassifier,
 	}
 }
 
@@ -258,6 +673,139 @@ type UseCases struct {
 	Ticket    *usecase.TicketUseCase
 	AI        *usecase.AIUseCase
 	Knowledge *usecase.KnowledgeUseCase
+	Feedback  *usecase.FeedbackUseCase
+	Deflection *usecase.DeflectionUseCase
+	Incident   *usecase.IncidentUseCase
+	Problem    *usecase.ProblemUseCase
+	Assignment *usecase.AssignmentUseCase
+	Team       *usecase.TeamUseCase
+	Automation *usecase.AutomationUseCase
+	Scheduler  *usecase.Scheduler
+	CSAT       *usecase.CSATUseCase
+	EmailIngest *usecase.EmailIngestUseCase
+	Webhook     *usecase.WebhookUseCase
+	ChatOps     *usecase.ChatOpsUseCase
+	Classifier  *ai.LocalClassifier // nil when the local classifier is disabled
+}
+
+// registerScheduledJobs registers the enabled background jobs with the scheduler
+func registerScheduledJobs(cfg *config.Config, scheduler *usecase.Scheduler, repos Repositories, ticketUseCase *usecase.TicketUseCase, automationUseCase *usecase.AutomationUseCase, webhookUseCase *usecase.WebhookUseCase, notifyService ports.NotificationService) {
+	maintenance := usecase.NewTicketMaintenance(
+		repos.Ticket,
+		repos.Comment,
+		ticketUseCase,
+		notifyService,
+		usecase.TicketMaintenanceConfig{
+			AutoCloseAfter:       time.Duration(cfg.Scheduler.AutoCloseAfterDays) * 24 * time.Hour,
+			PendingReminderAfter: time.Duration(cfg.Scheduler.PendingReminderDays) * 24 * time.Hour,
+			EscalateAfter:        time.Duration(cfg.Scheduler.EscalateAfterHours) * time.Hour,
+		},
+	)
+
+	if cfg.Scheduler.AutoCloseAfterDays > 0 {
+		scheduler.Register(usecase.ScheduledJob{
+			Name:        "auto_close_resolved",
+			Description: fmt.Sprintf("Close tickets resolved for more than %d days", cfg.Scheduler.AutoCloseAfterDays),
+			Interval:    cfg.Scheduler.JobInterval,
+			Run:         maintenance.AutoCloseResolved,
+		})
+	}
+
+	if cfg.Scheduler.PendingReminderDays > 0 {
+		scheduler.Register(usecase.ScheduledJob{
+			Name:        "pending_reminders",
+			Description: fmt.Sprintf("Remind requesters of pending tickets inactive for %d days", cfg.Scheduler.PendingReminderDays),
+			Interval:    cfg.Scheduler.JobInterval,
+			Run:         maintenance.RemindPendingRequesters,
+		})
+	}
+
+	if cfg.Scheduler.EscalateAfterHours > 0 {
+		scheduler.Register(usecase.ScheduledJob{
+			Name:        "stale_escalation",
+			Description: fmt.Sprintf("Escalate in-progress tickets without activity for %d hours", cfg.Scheduler.EscalateAfterHours),
+			Interval:    cfg.Scheduler.JobInterval,
+			Run:         maintenance.EscalateStaleInProgress,
+		})
+	}
+
+	if cfg.Automation.Enabled {
+		scheduler.Register(usecase.ScheduledJob{
+			Name:        "automation_scheduled_rules",
+			Description: "Run scheduled automation rules",
+			Interval:    cfg.Automation.ScheduleInterval,
+			Run:         automationUseCase.RunScheduledRules,
+		})
+	}
+
+	if cfg.Webhooks.Enabled {
+		scheduler.Register(usecase.ScheduledJob{
+			Name:        "webhook_retries",
+			Description: "Retry failed webhook deliveries that are due",
+			Interval:    cfg.Webhooks.RetryInterval,
+			Run:         webhookUseCase.RetryDueDeliveries,
+		})
+	}
+}
+
+// initNotifications starts email notifications when an SMTP server is configured. It returns a
+// nil service otherwise, so use cases skip notifying.
+func initNotifications(ctx context.Context, cfg *config.Config, repos Repositories) ports.NotificationService {
+	if cfg.Outbound.SMTPHost == "" {
+		return nil
+	}
+
+	templates, err := email.LoadTemplates()
+	if err != nil {
+		log.Fatalf("Failed to load email templates: %v", err)
+	}
+
+	notifier := email.NewNotifier(
+		email.NewSMTPSender(cfg.Outbound.EmailConfig),
+		repos.EmailMessage,
+		templates,
+		email.NotifierConfig{
+			Email:        cfg.Outbound.EmailConfig,
+			ReplyAddress: cfg.Outbound.ReplyAddress,
+			ReplySecret:  []byte(cfg.Outbound.ReplySecret),
+			Language:     cfg.Outbound.Language,
+		},
+	)
+	notifier.Start(ctx)
+	log.Printf("Sending email notifications through %s:%d", cfg.Outbound.SMTPHost, cfg.Outbound.SMTPPort)
+
+	return notifier
+}
+
+// startInboundEmail starts the configured inbound mail listeners and returns the SMTP server, if
+// one was started, so it can be closed on shutdown
+func startInboundEmail(ctx context.Context, cfg *config.Config, ingest *usecase.EmailIngestUseCase) *email.SMTPServer {
+	var smtpServer *email.SMTPServer
+	if cfg.Inbound.SMTPAddr != "" {
+		smtpServer = email.NewSMTPServer(ingest, email.SMTPConfig{
+			Addr:            cfg.Inbound.SMTPAddr,
+			Hostname:        cfg.Inbound.SMTPHostname,
+			MaxMessageBytes: cfg.Inbound.MaxMessageBytes,
+		})
+		if err := smtpServer.Start(ctx); err != nil {
+			log.Fatalf("Failed to start inbound SMTP server: %v", err)
+		}
+		log.Printf("Receiving email over SMTP on %s", cfg.Inbound.SMTPAddr)
+	}
+
+	if cfg.Inbound.MaildirPath != "" {
+		poller := email.NewMaildirPoller(ingest, email.MaildirConfig{
+			Path:            cfg.Inbound.MaildirPath,
+			PollInterval:    cfg.Inbound.PollInterval,
+			MaxMessageBytes: cfg.Inbound.MaxMessageBytes,
+		})
+		if err := poller.Start(ctx); err != nil {
+			log.Fatalf("Failed to start maildir poller: %v", err)
+		}
+		log.Printf("Receiving email from maildir %s", cfg.Inbound.MaildirPath)
+	}
+
+	return smtpServer
 }
 
 // initHTTPServer initializes the HTTP server
@@ -269,7 +817,16 @@ func initHTTPServer(cfg *config.Config, useCases UseCases) *http.Server {
 		IdleTimeout:  cfg.Server.IdleTimeout,
 	}
 
-	return http.NewServer(serverConfig, useCases.Ticket, useCases.AI, useCases.Knowledge)
+	// Slack commands and buttons are only served to requests signed with the app's secret
+	var slackHandler *slack.Handler
+	if cfg.Slack.SigningSecret != "" {
+		slackHandler = slack.NewHandler(useCases.ChatOps, slack.Config{
+			SigningSecret: cfg.Slack.SigningSecret,
+			Command:       cfg.Slack.Command,
+		})
+	}
+
+	return http.NewServer(serverConfig, useCases.Ticket, useCases.AI, useCases.Knowledge, useCases.Feedback, useCases.Deflection, useCases.Incident, useCases.Problem, useCases.Assignment, useCases.Team, useCases.Automation, useCases.Scheduler, useCases.CSAT, useCases.Webhook, slackHandler)
 }
 
 // runMigrations runs database migrations
@@ -288,6 +845,26 @@ func runMigrations(db *sql.DB) error {
 	migrationFiles := []string{
 		"001_initial_schema.sql",
 		"002_indexes_optimizations.sql",
+		"003_kb_revisions.sql",
+		"004_kb_index_jobs.sql",
+		"005_kb_embedding_spaces.sql",
+		"006_embedding_cache.sql",
+		"007_classifier_models.sql",
+		"008_kb_learned_entries.sql",
+		"009_suggestion_feedback.sql",
+		"010_deflection_sessions.sql",
+		"011_ticket_embeddings.sql",
+		"012_incidents_problems.sql",
+		"013_agents.sql",
+		"014_teams_queues.sql",
+		"015_automation.sql",
+		"016_scheduler.sql",
+		"017_csat.sql",
+		"018_email_messages.sql",
+		"019_webhooks.sql",
+		"020_kb_index_job_leases.sql",
+		"021_suggestion_candidates.sql",
+		"022_classifier_learned_tickets.sql",
 	}
 
 	for _, file := range migrationFiles {
@@ -300,6 +877,22 @@ func runMigrations(db *sql.DB) error {
 	return nil
 }
 
+// reindexKnowledgeBase re-embeds all knowledge base chunks with the configured embedding model.
+// It can be interrupted and re-run; already embedded chunks are skipped.
+func reindexKnowledgeBase(ctx context.Context, db *sql.DB, cfg *config.Config) error {
+	aiFactory := initAIServices(cfg)
+
+	reindexer := usecase.NewKBReindexer(
+		persistence.NewPostgresKBReindexRepository(db),
+		aiFactory.Embeddings(),
+		cfg.AI.BatchSize,
+		cfg.AI.IndexMaxRetries,
+	)
+
+	_, err := reindexer.Run(ctx)
+	return err
+}
+
 // seedDatabase seeds the database with sample data
 func seedDatabase(db *sql.DB) error {
 	log.Println("Seeding database with sample data...")
@@ -0,0 +1,244 @@
+package ai
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"fixora/internal/ports"
+)
+
+// CachedEmbeddingProvider decorates an EmbeddingProvider with an in-memory LRU tier and an
+// optional durable store. Entries are keyed by (model, sha256(text)), so unchanged text is never
+// embedded twice by the same model and a model change never returns stale vectors.
+type CachedEmbeddingProvider struct {
+	inner ports.EmbeddingProvider
+	store ports.EmbeddingCacheStore // optional
+	ttl   time.Duration             // 0 disables expiry of the memory tier
+	now   func() time.Time
+
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List // front is most recently used
+
+	memoryHits int64
+	storeHits  int64
+	misses     int64
+}
+
+type embeddingCacheEntry struct {
+	key       string
+	embedding []float32
+	expiresAt time.Time
+}
+
+// NewCachedEmbeddingProvider creates a caching decorator around an embedding provider
+func NewCachedEmbeddingProvider(inner ports.EmbeddingProvider, store ports.EmbeddingCacheStore, capacity int, ttl time.Duration) *CachedEmbeddingProvider {
+	if capacity <= 0 {
+		capacity = 10000
+	}
+
+	return &CachedEmbeddingProvider{
+		inner:    inner,
+		store:    store,
+		ttl:      ttl,
+		now:      time.Now,
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Embed generates embedding vector for a single text, serving it from cache when possible
+func (c *CachedEmbeddingProvider) Embed(ctx context.Context, text string) ([]float32, error) {
+	embeddings, err := c.EmbedBatch(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+
+	return embeddings[0], nil
+}
+
+// EmbedBatch generates embedding vectors for multiple texts. Cached texts are served from the
+// memory tier, then the store; only the remaining unique texts are sent to the provider.
+func (c *CachedEmbeddingProvider) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	model := c.inner.Model()
+	result := make([][]float32, len(texts))
+
+	hashes := make([]string, len(texts))
+	pending := make(map[string][]int) // hash -> positions still missing a vector
+
+	for i, text := range texts {
+		hashes[i] = contentHash(text)
+		if embedding, ok := c.getMemory(cacheKey(model, hashes[i])); ok {
+			atomic.AddInt64(&c.memoryHits, 1)
+			result[i] = embedding
+			continue
+		}
+		pending[hashes[i]] = append(pending[hashes[i]], i)
+	}
+
+	if len(pending) > 0 && c.store != nil {
+		missing := make([]string, 0, len(pending))
+		for hash := range pending {
+			missing = append(missing, hash)
+		}
+
+		stored, err := c.store.GetMany(ctx, model, missing)
+		if err != nil {
+			// The durable tier is best effort; fall through to the provider
+			log.Printf("Embedding cache store lookup failed: %v", err)
+		}
+
+		for hash, embedding := range stored {
+			if !c.inner.ValidateEmbedding(embedding) {
+				continue
+			}
+			c.putMemory(cacheKey(model, hash), embedding)
+			for _, i := range pending[hash] {
+				atomic.AddInt64(&c.storeHits, 1)
+				result[i] = embedding
+			}
+			delete(pending, hash)
+		}
+	}
+
+	if len(pending) == 0 {
+		return result, nil
+	}
+
+	// Embed each unique missing text once
+	missingHashes := make([]string, 0, len(pending))
+	missingTexts := make([]string, 0, len(pending))
+	for hash, positions := range pending {
+		missingHashes = append(missingHashes, hash)
+		missingTexts = append(missingTexts, texts[positions[0]])
+	}
+
+	embeddings, err := c.inner.EmbedBatch(ctx, missingTexts)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(embeddings) != len(missingTexts) {
+		return nil, fmt.Errorf("unexpected number of embeddings: got %d, want %d", len(embeddings), len(missingTexts))
+	}
+
+	fresh := make(map[string][]float32, len(embeddings))
+	for i, embedding := range embeddings {
+		hash := missingHashes[i]
+		fresh[hash] = embedding
+		c.putMemory(cacheKey(model, hash), embedding)
+		for _, pos := range pending[hash] {
+			atomic.AddInt64(&c.misses, 1)
+			result[pos] = embedding
+		}
+	}
+
+	if c.store != nil {
+		if err := c.store.PutMany(ctx, model, fresh); err != nil {
+			log.Printf("Embedding cache store write failed: %v", err)
+		}
+	}
+
+	return result, nil
+}
+
+// Dimension returns the dimension of the embedding vectors
+func (c *CachedEmbeddingProvider) Dimension() int {
+	return c.inner.Dimension()
+}
+
+// Model returns the name of the embedding model
+func (c *CachedEmbeddingProvider) Model() string {
+	return c.inner.Model()
+}
+
+// ValidateEmbedding checks if embedding dimension is correct
+func (c *CachedEmbeddingProvider) ValidateEmbedding(embedding []float32) bool {
+	return c.inner.ValidateEmbedding(embedding)
+}
+
+// CacheStats returns hit and miss counters since startup
+func (c *CachedEmbeddingProvider) CacheStats() ports.EmbeddingCacheStats {
+	stats := ports.EmbeddingCacheStats{
+		MemoryHits: atomic.LoadInt64(&c.memoryHits),
+		StoreHits:  atomic.LoadInt64(&c.storeHits),
+		Misses:     atomic.LoadInt64(&c.misses),
+		Capacity:   c.capacity,
+	}
+
+	if total := stats.MemoryHits + stats.StoreHits + stats.Misses; total > 0 {
+		stats.HitRate = float64(stats.MemoryHits+stats.StoreHits) / float64(total)
+	}
+
+	c.mu.Lock()
+	stats.Entries = c.order.Len()
+	c.mu.Unlock()
+
+	return stats
+}
+
+func (c *CachedEmbeddingProvider) getMemory(key string) ([]float32, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*embeddingCacheEntry)
+	if c.ttl > 0 && c.now().After(entry.expiresAt) {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	c.order.MoveToFront(elem)
+	return entry.embedding, true
+}
+
+func (c *CachedEmbeddingProvider) putMemory(key string, embedding []float32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.now().Add(c.ttl)
+
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*embeddingCacheEntry)
+		entry.embedding = embedding
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&embeddingCacheEntry{
+		key:       key,
+		embedding: embedding,
+		expiresAt: expiresAt,
+	})
+
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*embeddingCacheEntry).key)
+	}
+}
+
+// Helper function to hash text for cache keys
+func contentHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+func cacheKey(model, hash string) string {
+	return model + ":" + hash
+}
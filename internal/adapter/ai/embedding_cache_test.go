@@ -0,0 +1,148 @@
+package ai
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// countingEmbeddings embeds a text as its length and counts the texts it is asked to embed
+type countingEmbeddings struct {
+	embedded map[string]int
+}
+
+func (p *countingEmbeddings) Embed(ctx context.Context, text string) ([]float32, error) {
+	embeddings, err := p.EmbedBatch(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+	return embeddings[0], nil
+}
+
+func (p *countingEmbeddings) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	embeddings := make([][]float32, len(texts))
+	for i, text := range texts {
+		p.embedded[text]++
+		embeddings[i] = []float32{float32(len(text)), 1}
+	}
+	return embeddings, nil
+}
+
+func (p *countingEmbeddings) Dimension() int { return 2 }
+
+func (p *countingEmbeddings) Model() string { return "counting-embedding" }
+
+func (p *countingEmbeddings) ValidateEmbedding(embedding []float32) bool { return len(embedding) == 2 }
+
+func TestCachedEmbeddingProvider(t *testing.T) {
+	type step struct {
+		advance time.Duration // clock moves before the call
+		texts   []string
+	}
+
+	tests := []struct {
+		name         string
+		capacity     int
+		ttl          time.Duration
+		steps        []step
+		wantEmbedded map[string]int
+		wantHits     int64
+		wantMisses   int64
+		wantEntries  int
+	}{
+		{
+			name:     "repeated texts hit the cache",
+			capacity: 10,
+			steps: []step{
+				{texts: []string{"vpn", "printer", "vpn"}},
+				{texts: []string{"printer", "vpn"}},
+			},
+			wantEmbedded: map[string]int{"vpn": 1, "printer": 1},
+			wantHits:     2,
+			wantMisses:   3,
+			wantEntries:  2,
+		},
+		{
+			name:     "least recently used entry is evicted",
+			capacity: 2,
+			steps: []step{
+				{texts: []string{"vpn", "printer"}},
+				{texts: []string{"vpn"}},     // printer is now least recently used
+				{texts: []string{"laptop"}},  // evicts printer
+				{texts: []string{"vpn"}},     // still cached
+				{texts: []string{"printer"}}, // embedded again, evicts laptop
+			},
+			wantEmbedded: map[string]int{"vpn": 1, "printer": 2, "laptop": 1},
+			wantHits:     2,
+			wantMisses:   4,
+			wantEntries:  2,
+		},
+		{
+			name:     "expired entries are embedded again",
+			capacity: 10,
+			ttl:      time.Hour,
+			steps: []step{
+				{texts: []string{"vpn"}},
+				{advance: 59 * time.Minute, texts: []string{"vpn"}},
+				{advance: 2 * time.Minute, texts: []string{"vpn"}},
+			},
+			wantEmbedded: map[string]int{"vpn": 2},
+			wantHits:     1,
+			wantMisses:   2,
+			wantEntries:  1,
+		},
+		{
+			name:     "no ttl never expires",
+			capacity: 10,
+			steps: []step{
+				{texts: []string{"vpn"}},
+				{advance: 24 * 365 * time.Hour, texts: []string{"vpn"}},
+			},
+			wantEmbedded: map[string]int{"vpn": 1},
+			wantHits:     1,
+			wantMisses:   1,
+			wantEntries:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := &countingEmbeddings{embedded: make(map[string]int)}
+			cache := NewCachedEmbeddingProvider(inner, nil, tt.capacity, tt.ttl)
+
+			now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+			cache.now = func() time.Time { return now }
+
+			for i, s := range tt.steps {
+				now = now.Add(s.advance)
+				embeddings, err := cache.EmbedBatch(context.Background(), s.texts)
+				if err != nil {
+					t.Fatalf("Step %d: unexpected error %v", i, err)
+				}
+				for j, text := range s.texts {
+					if len(embeddings[j]) != 2 || embeddings[j][0] != float32(len(text)) {
+						t.Errorf("Step %d: expected the embedding of %q, got %v", i, text, embeddings[j])
+					}
+				}
+			}
+
+			for text, want := range tt.wantEmbedded {
+				if got := inner.embedded[text]; got != want {
+					t.Errorf("Expected %q to be embedded %d times, got %d", text, want, got)
+				}
+			}
+
+			stats := cache.CacheStats()
+			if stats.MemoryHits != tt.wantHits || stats.StoreHits != 0 || stats.Misses != tt.wantMisses {
+				t.Errorf("Expected %d hits and %d misses, got %+v", tt.wantHits, tt.wantMisses, stats)
+			}
+			if stats.Entries != tt.wantEntries || stats.Capacity != tt.capacity {
+				t.Errorf("Expected %d of %d entries, got %d of %d", tt.wantEntries, tt.capacity, stats.Entries, stats.Capacity)
+			}
+			wantRate := float64(tt.wantHits) / float64(tt.wantHits+tt.wantMisses)
+			if stats.HitRate != wantRate {
+				t.Errorf("Expected hit rate %v, got %v", wantRate, stats.HitRate)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package ai
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Authentication schemes supported by OpenAI-compatible endpoints
+const (
+	AuthBearer = "bearer"  // Authorization: Bearer <key> (OpenAI, Z.ai, vLLM)
+	AuthAPIKey = "api-key" // api-key: <key> (Azure OpenAI)
+	AuthNone   = "none"    // no credentials (local Ollama)
+)
+
+// Endpoint describes where and how to reach an API that speaks the OpenAI chat completions
+// and embeddings protocol
+type Endpoint struct {
+	Name        string            // provider name reported by Provider(), result sources and errors
+	BaseURL     string            // API root, e.g. https://api.openai.com/v1
+	AuthScheme  string            // one of AuthBearer, AuthAPIKey, AuthNone
+	APIKey      string            // credential sent according to AuthScheme
+	APIVersion  string            // api-version query parameter, when the API requires one
+	Deployments bool              // route requests through /openai/deployments/{model}, as Azure does
+	ModelMap    map[string]string // configured model name -> provider model or deployment name
+}
+
+// mapModel returns the provider's name for a configured model
+func (e Endpoint) mapModel(model string) string {
+	if mapped, ok := e.ModelMap[model]; ok && mapped != "" {
+		return mapped
+	}
+	return model
+}
+
+// url builds the request URL for an API path, addressing the model's deployment when required
+func (e Endpoint) url(path, model string) string {
+	base := strings.TrimRight(e.BaseURL, "/")
+
+	if e.Deployments {
+		if model != "" {
+			base += "/openai/deployments/" + url.PathEscape(model)
+		} else {
+			base += "/openai"
+		}
+	}
+
+	if e.APIVersion == "" {
+		return base + path
+	}
+
+	return base + path + "?api-version=" + url.QueryEscape(e.APIVersion)
+}
+
+// authorize sets the credential header for the endpoint's auth scheme
+func (e Endpoint) authorize(req *http.Request) {
+	if e.APIKey == "" {
+		return
+	}
+
+	switch e.AuthScheme {
+	case AuthAPIKey:
+		req.Header.Set("api-key", e.APIKey)
+	case AuthNone:
+	default:
+		req.Header.Set("Authorization", "Bearer "+e.APIKey)
+	}
+}
@@ -0,0 +1,112 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// noAnswerMarker is the reply the model is instructed to give when the sources do not cover the issue
+const noAnswerMarker = "NO_GROUNDED_ANSWER"
+
+// groundedSystemPrompt instructs the model to answer only from the retrieved knowledge base sources
+const groundedSystemPrompt = `You are an experienced IT support assistant. Answer only with information from the numbered knowledge base sources provided by the user. Cite every step with the number of the source it comes from, like [1]. If the sources do not describe how to resolve the issue, reply with exactly ` + noAnswerMarker + ` and nothing else.`
+
+var citationPattern = regexp.MustCompile(`\[(\d+)\]`)
+
+// retrieveGrounding fetches the most relevant published chunks for a query, dropping those below minScore
+func retrieveGrounding(ctx context.Context, repo ports.KnowledgeRepository, query string, topK int, minScore float64) ([]*domain.KBChunk, error) {
+	chunks, err := repo.SearchChunks(ctx, query, domain.KBChunkFilter{TopK: topK})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve knowledge base context: %w", err)
+	}
+
+	relevant := make([]*domain.KBChunk, 0, len(chunks))
+	for _, chunk := range chunks {
+		if chunk.Score >= minScore {
+			relevant = append(relevant, chunk)
+		}
+	}
+
+	return relevant, nil
+}
+
+// buildGroundedPrompt numbers the sources so the model can cite them
+func buildGroundedPrompt(description string, sources []*domain.KBChunk) string {
+	var b strings.Builder
+
+	b.WriteString("Knowledge base sources:\n\n")
+	for i, chunk := range sources {
+		fmt.Fprintf(&b, "[%d] %s\n\n", i+1, chunk.Content)
+	}
+
+	fmt.Fprintf(&b, "Issue: %s\n\n", description)
+	b.WriteString("Using only the sources above, give 2-3 specific steps to mitigate the issue and say whether it should be escalated to IT support. Be concise.")
+
+	return b.String()
+}
+
+// groundedResult turns a model answer into a suggestion with citations for the sources it referenced
+func groundedResult(content, description, source string, sources []*domain.KBChunk) ports.SuggestionResult {
+	content = strings.TrimSpace(content)
+	if content == "" || strings.Contains(content, noAnswerMarker) {
+		return noGroundedAnswerResult(source)
+	}
+
+	citations := citeSources(content, sources)
+
+	confidence := 0.0
+	for _, citation := range citations {
+		if citation.Score > confidence {
+			confidence = citation.Score
+		}
+	}
+
+	return ports.SuggestionResult{
+		Suggestion: content,
+		Confidence: confidence,
+		Category:   determineCategory(content, description),
+		Source:     source,
+		Grounded:   true,
+		Citations:  citations,
+	}
+}
+
+// citeSources returns citations for the sources referenced as [n] in the answer, or all sources if none are
+func citeSources(content string, sources []*domain.KBChunk) []ports.Citation {
+	cited := make(map[int]bool)
+	for _, match := range citationPattern.FindAllStringSubmatch(content, -1) {
+		n, err := strconv.Atoi(match[1])
+		if err == nil && n >= 1 && n <= len(sources) {
+			cited[n-1] = true
+		}
+	}
+
+	citations := make([]ports.Citation, 0, len(sources))
+	for i, chunk := range sources {
+		if len(cited) > 0 && !cited[i] {
+			continue
+		}
+		citations = append(citations, ports.Citation{
+			EntryID:    chunk.EntryID,
+			ChunkIndex: chunk.ChunkIndex,
+			Score:      chunk.Score,
+		})
+	}
+
+	return citations
+}
+
+func noGroundedAnswerResult(source string) ports.SuggestionResult {
+	return ports.SuggestionResult{
+		Suggestion: ports.NoGroundedAnswer,
+		Confidence: 0,
+		Source:     source,
+		Grounded:   false,
+	}
+}
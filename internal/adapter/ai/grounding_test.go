@@ -0,0 +1,104 @@
+package ai
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+func groundingSources() []*domain.KBChunk {
+	return []*domain.KBChunk{
+		{EntryID: "kb_vpn", ChunkIndex: 0, Content: "Reinstall the VPN profile from the self-service portal.", Score: 0.82},
+		{EntryID: "kb_vpn", ChunkIndex: 1, Content: "Sign in to the VPN client with your SSO account.", Score: 0.64},
+		{EntryID: "kb_wifi", ChunkIndex: 0, Content: "Forget the office Wi-Fi network and join it again.", Score: 0.51},
+	}
+}
+
+func TestBuildGroundedPrompt(t *testing.T) {
+	prompt := buildGroundedPrompt("VPN keeps dropping", groundingSources())
+
+	for _, want := range []string{
+		"[1] Reinstall the VPN profile",
+		"[2] Sign in to the VPN client",
+		"[3] Forget the office Wi-Fi",
+		"Issue: VPN keeps dropping",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("Expected the prompt to contain %q, got %q", want, prompt)
+		}
+	}
+}
+
+func TestCiteSources(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string // entry ID and chunk index of each citation
+	}{
+		{"cited sources only", "1. Reinstall the profile [1].\n2. Rejoin the network [3].", []string{"kb_vpn/0", "kb_wifi/0"}},
+		{"repeated citation", "Reinstall the profile [1] and sign in again [1][2].", []string{"kb_vpn/0", "kb_vpn/1"}},
+		{"out of range citations ignored", "Reinstall the profile [1], see also [0] and [7].", []string{"kb_vpn/0"}},
+		{"no citations cites all sources", "Reinstall the VPN profile.", []string{"kb_vpn/0", "kb_vpn/1", "kb_wifi/0"}},
+		{"only invalid citations cites all sources", "See [9].", []string{"kb_vpn/0", "kb_vpn/1", "kb_wifi/0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			citations := citeSources(tt.content, groundingSources())
+
+			got := make([]string, len(citations))
+			for i, citation := range citations {
+				got[i] = fmt.Sprintf("%s/%d", citation.EntryID, citation.ChunkIndex)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Expected citations %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGroundedResult(t *testing.T) {
+	content := "  1. Sign in with SSO [2].\n2. Rejoin the Wi-Fi network [3].\nEscalate if it keeps dropping.  "
+	result := groundedResult(content, "VPN keeps dropping", "openai-rag", groundingSources())
+
+	if !result.Grounded || result.Source != "openai-rag" {
+		t.Errorf("Expected a grounded openai-rag result, got %+v", result)
+	}
+	if result.Suggestion != strings.TrimSpace(content) {
+		t.Errorf("Expected the trimmed answer, got %q", result.Suggestion)
+	}
+	if len(result.Citations) != 2 || result.Citations[0].ChunkIndex != 1 || result.Citations[1].EntryID != "kb_wifi" {
+		t.Errorf("Expected citations for sources 2 and 3, got %+v", result.Citations)
+	}
+	// Confidence is the best score among the cited sources, not among all sources
+	if result.Confidence != 0.64 {
+		t.Errorf("Expected confidence 0.64, got %v", result.Confidence)
+	}
+}
+
+func TestGroundedResult_NoGroundedAnswer(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"marker", noAnswerMarker},
+		{"marker with punctuation", "NO_GROUNDED_ANSWER."},
+		{"empty answer", "  \n "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := groundedResult(tt.content, "Printer is jammed", "openai-rag", groundingSources())
+
+			if result.Suggestion != ports.NoGroundedAnswer || result.Grounded || result.Confidence != 0 || len(result.Citations) != 0 {
+				t.Errorf("Expected no grounded answer, got %+v", result)
+			}
+			if result.Source != "openai-rag" {
+				t.Errorf("Expected the source to be kept, got %s", result.Source)
+			}
+		})
+	}
+}
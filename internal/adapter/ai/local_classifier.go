@@ -0,0 +1,172 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// classifierSource marks predictions made by the locally trained classifier
+const classifierSource = "classifier"
+
+// classifierModelName is the key the ticket classifier is stored under
+const classifierModelName = "ticket_attributes"
+
+// LocalClassifierConfig configures how often a learning classifier is saved
+type LocalClassifierConfig struct {
+	SaveEvery    int           // learned tickets between saves
+	SaveInterval time.Duration // longest a learned ticket waits to be saved
+}
+
+// LocalClassifier implements TicketClassifier with an in-process naive Bayes model trained on
+// resolved tickets. When a repository is configured, learned tickets are recorded there and the
+// model is saved in batches.
+type LocalClassifier struct {
+	mu      sync.RWMutex
+	model   *domain.TicketClassifier
+	repo    ports.ClassifierRepository
+	config  LocalClassifierConfig
+	unsaved int // tickets learned since the model was last saved
+	savedAt time.Time
+}
+
+// NewLocalClassifier loads the stored model, or starts untrained if there is none
+func NewLocalClassifier(ctx context.Context, repo ports.ClassifierRepository, config LocalClassifierConfig) (*LocalClassifier, error) {
+	if config.SaveEvery <= 0 {
+		config.SaveEvery = 25
+	}
+	if config.SaveInterval <= 0 {
+		config.SaveInterval = 5 * time.Minute
+	}
+
+	classifier := &LocalClassifier{
+		model:   domain.NewTicketClassifier(),
+		repo:    repo,
+		config:  config,
+		savedAt: time.Now(),
+	}
+
+	if repo == nil {
+		return classifier, nil
+	}
+
+	model, err := repo.Load(ctx, classifierModelName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ticket classifier: %w", err)
+	}
+	if model != nil {
+		classifier.model = model
+	}
+
+	return classifier, nil
+}
+
+// PredictAttributes predicts category and priority; fields are empty until the model has enough training data
+func (c *LocalClassifier) PredictAttributes(ctx context.Context, description string) (ports.PredictedAttributes, error) {
+	c.mu.RLock()
+	category, categoryConfidence, priority, priorityConfidence := c.model.Predict(description)
+	c.mu.RUnlock()
+
+	var preds ports.PredictedAttributes
+	if category != "" {
+		preds.Category = ports.FieldPrediction{Value: string(category), Confidence: categoryConfidence, Source: classifierSource}
+	}
+	if priority != "" {
+		preds.Priority = ports.FieldPrediction{Value: string(priority), Confidence: priorityConfidence, Source: classifierSource}
+	}
+
+	return preds, nil
+}
+
+// Learn trains the model on one resolved ticket. A ticket that was already learned, for example
+// when it is resolved again after reopening, is skipped. The model is saved once SaveEvery tickets
+// are unsaved or SaveInterval has passed since the last save; Flush saves the rest. A save that
+// fails is retried with the next ticket, and unsaved tickets are lost if the process stops
+// without a flush until the model is retrained.
+func (c *LocalClassifier) Learn(ctx context.Context, ticket *ports.TicketTrainingData) error {
+	if ticket == nil || ticket.Category == "" || ticket.Priority == "" {
+		return fmt.Errorf("invalid ticket training data")
+	}
+
+	if c.repo != nil && ticket.TicketID != "" {
+		learned, err := c.repo.MarkLearned(ctx, classifierModelName, ticket.TicketID)
+		if err != nil {
+			return err
+		}
+		if !learned {
+			return nil
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.model.Learn(ticket.Description, domain.TicketCategory(ticket.Category), domain.TicketPriority(ticket.Priority))
+	c.unsaved++
+
+	if c.unsaved < c.config.SaveEvery && time.Since(c.savedAt) < c.config.SaveInterval {
+		return nil
+	}
+	return c.flush(ctx)
+}
+
+// Flush saves tickets learned since the last save
+func (c *LocalClassifier) Flush(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.flush(ctx)
+}
+
+// Retrain replaces the model with one trained on the given tickets
+func (c *LocalClassifier) Retrain(ctx context.Context, tickets []*ports.TicketTrainingData) error {
+	model := domain.NewTicketClassifier()
+	ticketIDs := make([]string, 0, len(tickets))
+	for _, ticket := range tickets {
+		model.Learn(ticket.Description, domain.TicketCategory(ticket.Category), domain.TicketPriority(ticket.Priority))
+		if ticket.TicketID != "" {
+			ticketIDs = append(ticketIDs, ticket.TicketID)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.repo != nil {
+		if err := c.repo.Replace(ctx, classifierModelName, model, ticketIDs); err != nil {
+			return fmt.Errorf("failed to save ticket classifier: %w", err)
+		}
+	}
+	c.model = model
+	c.unsaved = 0
+	c.savedAt = time.Now()
+
+	return nil
+}
+
+// Documents returns the number of tickets the model was trained on
+func (c *LocalClassifier) Documents() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.model.Documents()
+}
+
+// flush saves the model if it has unsaved tickets; the caller holds the write lock
+func (c *LocalClassifier) flush(ctx context.Context) error {
+	if c.unsaved == 0 {
+		return nil
+	}
+	if c.repo != nil {
+		if err := c.repo.Save(ctx, classifierModelName, c.model); err != nil {
+			return fmt.Errorf("failed to save ticket classifier: %w", err)
+		}
+	}
+	c.unsaved = 0
+	c.savedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,126 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// memoryClassifierRepo counts saves and fails them while err is set
+type memoryClassifierRepo struct {
+	saved   *domain.TicketClassifier
+	learned map[string]bool
+	saves   int
+	err     error
+}
+
+func (r *memoryClassifierRepo) Load(ctx context.Context, name string) (*domain.TicketClassifier, error) {
+	return r.saved, nil
+}
+
+func (r *memoryClassifierRepo) Save(ctx context.Context, name string, model *domain.TicketClassifier) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.saves++
+	r.saved = model
+	return nil
+}
+
+func (r *memoryClassifierRepo) MarkLearned(ctx context.Context, name, ticketID string) (bool, error) {
+	if r.learned[ticketID] {
+		return false, nil
+	}
+	r.learned[ticketID] = true
+	return true, nil
+}
+
+func (r *memoryClassifierRepo) Replace(ctx context.Context, name string, model *domain.TicketClassifier, ticketIDs []string) error {
+	if err := r.Save(ctx, name, model); err != nil {
+		return err
+	}
+	r.learned = make(map[string]bool)
+	for _, ticketID := range ticketIDs {
+		r.learned[ticketID] = true
+	}
+	return nil
+}
+
+func TestLocalClassifierLearn(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryClassifierRepo{learned: make(map[string]bool)}
+	classifier, err := NewLocalClassifier(ctx, repo, LocalClassifierConfig{SaveEvery: 2})
+	if err != nil {
+		t.Fatalf("Failed to create classifier: %v", err)
+	}
+
+	ticket := &ports.TicketTrainingData{TicketID: "ticket_1", Description: "wifi keeps disconnecting", Category: "NETWORK", Priority: "MEDIUM"}
+	if err := classifier.Learn(ctx, ticket); err != nil {
+		t.Fatalf("Failed to learn: %v", err)
+	}
+
+	// Resolving the same ticket again does not count it twice, and one ticket does not fill a batch
+	if err := classifier.Learn(ctx, ticket); err != nil {
+		t.Fatalf("Failed to learn: %v", err)
+	}
+	if classifier.Documents() != 1 || repo.saves != 0 {
+		t.Errorf("Expected 1 unsaved document, got %d documents and %d saves", classifier.Documents(), repo.saves)
+	}
+
+	// A failed save keeps the tickets learned and retries with the next one
+	repo.err = errors.New("connection refused")
+	other := &ports.TicketTrainingData{TicketID: "ticket_2", Description: "excel crashes on startup", Category: "SOFTWARE", Priority: "HIGH"}
+	if err := classifier.Learn(ctx, other); err == nil {
+		t.Fatal("Expected the failed save to be reported")
+	}
+	if classifier.Documents() != 2 {
+		t.Errorf("Expected 2 documents after a failed save, got %d", classifier.Documents())
+	}
+
+	repo.err = nil
+	third := &ports.TicketTrainingData{TicketID: "ticket_3", Description: "printer offline", Category: "HARDWARE", Priority: "LOW"}
+	if err := classifier.Learn(ctx, third); err != nil {
+		t.Fatalf("Failed to learn: %v", err)
+	}
+	if repo.saves != 1 || repo.saved.Documents() != 3 {
+		t.Errorf("Expected all 3 tickets saved once, got %d saves", repo.saves)
+	}
+
+	// Flush saves what is left of a batch, and only when something is unsaved
+	fourth := &ports.TicketTrainingData{TicketID: "ticket_4", Description: "vpn drops hourly", Category: "NETWORK", Priority: "HIGH"}
+	if err := classifier.Learn(ctx, fourth); err != nil {
+		t.Fatalf("Failed to learn: %v", err)
+	}
+	if err := classifier.Flush(ctx); err != nil {
+		t.Fatalf("Failed to flush: %v", err)
+	}
+	if err := classifier.Flush(ctx); err != nil {
+		t.Fatalf("Failed to flush: %v", err)
+	}
+	if repo.saves != 2 || repo.saved.Documents() != 4 {
+		t.Errorf("Expected 4 documents after 2 saves, got %d saves", repo.saves)
+	}
+}
+
+func TestLocalClassifierRetrainReplacesLearnedTickets(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryClassifierRepo{learned: map[string]bool{"stale": true}}
+	classifier, err := NewLocalClassifier(ctx, repo, LocalClassifierConfig{})
+	if err != nil {
+		t.Fatalf("Failed to create classifier: %v", err)
+	}
+
+	err = classifier.Retrain(ctx, []*ports.TicketTrainingData{
+		{TicketID: "ticket_1", Description: "wifi keeps disconnecting", Category: "NETWORK", Priority: "MEDIUM"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to retrain: %v", err)
+	}
+
+	if repo.learned["stale"] || !repo.learned["ticket_1"] || classifier.Documents() != 1 {
+		t.Errorf("Expected learned tickets to match the retrained model, got %v", repo.learned)
+	}
+}
@@ -63,6 +63,7 @@ func (m *MockAIService) SuggestMitigation(ctx context.Context, description strin
 		Confidence: confidence,
 		Category:   category,
 		Source:     "mock",
+		Model:      "mock",
 		UsedCache:  false,
 	}
 
@@ -163,13 +164,15 @@ func (m *MockAIService) ValidateProvider(ctx context.Context) error {
 
 // MockEmbeddingProvider provides mock embedding generation
 type MockEmbeddingProvider struct {
+	model     string
 	dimension int
 	enabled   bool
 }
 
 // NewMockEmbeddingProvider creates a new mock embedding provider
-func NewMockEmbeddingProvider(dimension int) *MockEmbeddingProvider {
+func NewMockEmbeddingProvider(model string, dimension int) *MockEmbeddingProvider {
 	return &MockEmbeddingProvider{
+		model:     model,
 		dimension: dimension,
 		enabled:   true,
 	}
@@ -216,6 +219,11 @@ func (m *MockEmbeddingProvider) Dimension() int {
 	return m.dimension
 }
 
+// Model returns the name of the embedding model
+func (m *MockEmbeddingProvider) Model() string {
+	return m.model
+}
+
 // ValidateEmbedding checks if embedding dimension is correct
 func (m *MockEmbeddingProvider) ValidateEmbedding(embedding []float32) bool {
 	return len(embedding) == m.dimension
@@ -312,7 +320,7 @@ func (f *MockAIProviderFactory) Suggestion() ports.AISuggestionService {
 
 // Embeddings returns a mock embedding provider
 func (f *MockAIProviderFactory) Embeddings() ports.EmbeddingProvider {
-	return NewMockEmbeddingProvider(f.aiConfig.EmbeddingDim)
+	return NewMockEmbeddingProvider(f.aiConfig.EmbeddingModel, f.aiConfig.EmbeddingDim)
 }
 
 // Training returns a mock AI training service
@@ -404,6 +412,8 @@ func (m *MockAIService) generateMockCandidates(description string) []ports.Candi
 			Category:   category,
 			EntryID:    fmt.Sprintf("mock_entry_%d", i+1),
 			ChunkIndex: i,
+			Source:     "mock",
+			Model:      "mock",
 		}
 		candidates = append(candidates, candidate)
 	}
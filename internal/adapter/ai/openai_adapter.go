@@ -10,26 +10,80 @@ import (
     "time"
     "strings"
 
+    "fixora/internal/domain"
     "fixora/internal/ports"
 )
 
-// OpenAIAdapter implements AI services using OpenAI APIs
+// OpenAIAdapter implements AI services using OpenAI APIs, or any endpoint that speaks the same protocol
 type OpenAIAdapter struct {
-	apiKey      string
-	baseURL     string
+	endpoint    Endpoint
 	model       string
 	embeddingModel string
 	embeddings  ports.EmbeddingProvider
 	client      *http.Client
+	knowledge   ports.KnowledgeRepository
+	topK        int
+	minScore    float64
 }
 
 // NewOpenAIAdapter creates a new OpenAI adapter
 func NewOpenAIAdapter(config ports.AIConfig) ports.AIProviderFactory {
+	return NewOpenAICompatibleAdapter(config, Endpoint{
+		Name:       "openai",
+		BaseURL:    "https://api.openai.com/v1",
+		AuthScheme: AuthBearer,
+		APIKey:     config.APIKey,
+	})
+}
+
+// NewAzureOpenAIAdapter creates an adapter for an Azure OpenAI resource. BaseURL is the resource
+// endpoint (https://<resource>.openai.azure.com) and ModelMap maps model names to deployment names.
+func NewAzureOpenAIAdapter(config ports.AIConfig, endpoint Endpoint) ports.AIProviderFactory {
+	if endpoint.Name == "" {
+		endpoint.Name = "azure"
+	}
+	if endpoint.AuthScheme == "" {
+		endpoint.AuthScheme = AuthAPIKey
+	}
+	if endpoint.APIVersion == "" {
+		endpoint.APIVersion = "2024-06-01"
+	}
+	endpoint.Deployments = true
+
+	return NewOpenAICompatibleAdapter(config, endpoint)
+}
+
+// NewZaiAdapter creates an adapter for the Z.ai (GLM) API
+func NewZaiAdapter(config ports.AIConfig, endpoint Endpoint) ports.AIProviderFactory {
+	if endpoint.Name == "" {
+		endpoint.Name = "zai"
+	}
+	if endpoint.BaseURL == "" {
+		endpoint.BaseURL = "https://api.z.ai/api/paas/v4"
+	}
+	if endpoint.AuthScheme == "" {
+		endpoint.AuthScheme = AuthBearer
+	}
+
+	return NewOpenAICompatibleAdapter(config, endpoint)
+}
+
+// NewOpenAICompatibleAdapter creates an adapter for any OpenAI-compatible endpoint, such as a local
+// Ollama (http://localhost:11434/v1) or vLLM server
+func NewOpenAICompatibleAdapter(config ports.AIConfig, endpoint Endpoint) ports.AIProviderFactory {
 	client := &http.Client{
 		Timeout: time.Duration(config.TimeoutMs) * time.Millisecond,
 	}
 
-	baseURL := "https://api.openai.com/v1"
+	if endpoint.Name == "" {
+		endpoint.Name = "openai-compatible"
+	}
+	if endpoint.AuthScheme == "" {
+		endpoint.AuthScheme = AuthBearer
+		if endpoint.APIKey == "" {
+			endpoint.AuthScheme = AuthNone
+		}
+	}
 	if config.EmbeddingModel == "" {
 		config.EmbeddingModel = "text-embedding-ada-002"
 	}
@@ -38,19 +92,19 @@ func NewOpenAIAdapter(config ports.AIConfig) ports.AIProviderFactory {
 	}
 
 	adapter := &OpenAIAdapter{
-		apiKey:          config.APIKey,
-		baseURL:         baseURL,
-		model:           config.SuggestionModel,
-		embeddingModel:  config.EmbeddingModel,
+		endpoint:        endpoint,
+		model:           endpoint.mapModel(config.SuggestionModel),
+		embeddingModel:  endpoint.mapModel(config.EmbeddingModel),
 		client:          client,
+		topK:            config.TopK,
+		minScore:        config.MinConfidence,
 	}
 
 	// Create embedding provider
 	adapter.embeddings = &OpenAIEmbeddingProvider{
-		apiKey:     config.APIKey,
-		model:      config.EmbeddingModel,
+		endpoint:   endpoint,
+		model:      adapter.embeddingModel,
 		dimension:  config.EmbeddingDim,
-		baseURL:    baseURL,
 		httpClient: client,
 	}
 
@@ -60,13 +114,20 @@ func NewOpenAIAdapter(config ports.AIConfig) ports.AIProviderFactory {
 // Suggestion returns an AI suggestion service
 func (o *OpenAIAdapter) Suggestion() ports.AISuggestionService {
 	return &OpenAISuggestionService{
-		apiKey:     o.apiKey,
+		endpoint:   o.endpoint,
 		model:      o.model,
-		baseURL:    o.baseURL,
 		httpClient: o.client,
+		knowledge:  o.knowledge,
+		topK:       o.topK,
+		minScore:   o.minScore,
 	}
 }
 
+// SetKnowledgeBase enables retrieval-augmented suggestions grounded in the knowledge base
+func (o *OpenAIAdapter) SetKnowledgeBase(repo ports.KnowledgeRepository) {
+	o.knowledge = repo
+}
+
 // Embeddings returns an embedding provider
 func (o *OpenAIAdapter) Embeddings() ports.EmbeddingProvider {
 	return o.embeddings
@@ -75,35 +136,34 @@ func (o *OpenAIAdapter) Embeddings() ports.EmbeddingProvider {
 // Training returns an AI training service
 func (o *OpenAIAdapter) Training() ports.AITrainingService {
 	return &OpenAITrainingService{
-		apiKey:     o.apiKey,
-		baseURL:    o.baseURL,
+		endpoint:   o.endpoint,
 		httpClient: o.client,
 	}
 }
 
 // Provider returns the current provider type
 func (o *OpenAIAdapter) Provider() string {
-	return "openai"
+	return o.endpoint.Name
 }
 
-// IsHealthy checks if the OpenAI services are healthy
+// IsHealthy checks if the provider API is reachable
 func (o *OpenAIAdapter) IsHealthy(ctx context.Context) error {
 	// Simple health check by testing API connectivity
-	req, err := http.NewRequestWithContext(ctx, "GET", o.baseURL+"/models", nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", o.endpoint.url("/models", ""), nil)
 	if err != nil {
 		return fmt.Errorf("failed to create health check request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+o.apiKey)
+	o.endpoint.authorize(req)
 
 	resp, err := o.client.Do(req)
 	if err != nil {
-		return fmt.Errorf("OpenAI API health check failed: %w", err)
+		return fmt.Errorf("%s API health check failed: %w", o.endpoint.Name, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("OpenAI API returned status: %d", resp.StatusCode)
+		return fmt.Errorf("%s API returned status: %d", o.endpoint.Name, resp.StatusCode)
 	}
 
 	return nil
@@ -111,14 +171,63 @@ func (o *OpenAIAdapter) IsHealthy(ctx context.Context) error {
 
 // OpenAISuggestionService implements AISuggestionService for OpenAI
 type OpenAISuggestionService struct {
-	apiKey     string
+	endpoint   Endpoint
 	model      string
-	baseURL    string
 	httpClient *http.Client
+	knowledge  ports.KnowledgeRepository // optional; enables grounded suggestions
+	topK       int
+	minScore   float64
 }
 
-// SuggestMitigation provides AI-powered mitigation suggestions using OpenAI
+// SuggestMitigation provides AI-powered mitigation suggestions using OpenAI.
+// With a knowledge base configured, the answer is grounded in the top matching published chunks
+// and cites them; when nothing relevant is found it returns NoGroundedAnswer instead of guessing.
 func (s *OpenAISuggestionService) SuggestMitigation(ctx context.Context, description string) (ports.SuggestionResult, error) {
+	prompt, err := s.preparePrompt(ctx, description)
+	if err != nil {
+		return ports.SuggestionResult{}, err
+	}
+
+	if prompt.grounded && len(prompt.sources) == 0 {
+		return noGroundedAnswerResult(s.endpoint.Name + "-rag"), nil
+	}
+
+	content, totalTokens, err := s.complete(ctx, prompt)
+	if err != nil {
+		return ports.SuggestionResult{}, err
+	}
+
+	return s.buildResult(prompt, description, content, totalTokens), nil
+}
+
+// suggestionPrompt holds the chat messages and grounding sources for one suggestion
+type suggestionPrompt struct {
+	messages       []map[string]string
+	temperature    float64
+	sources        []*domain.KBChunk
+	grounded       bool
+	responseFormat map[string]interface{} // optional structured output format
+}
+
+// preparePrompt builds a grounded prompt from retrieved chunks, or a direct one without a knowledge base
+func (s *OpenAISuggestionService) preparePrompt(ctx context.Context, description string) (*suggestionPrompt, error) {
+	if s.knowledge != nil {
+		sources, err := retrieveGrounding(ctx, s.knowledge, description, s.topK, s.minScore)
+		if err != nil {
+			return nil, err
+		}
+
+		return &suggestionPrompt{
+			messages: []map[string]string{
+				{"role": "system", "content": groundedSystemPrompt},
+				{"role": "user", "content": buildGroundedPrompt(description, sources)},
+			},
+			temperature: 0.2,
+			sources:     sources,
+			grounded:    true,
+		}, nil
+	}
+
 	prompt := fmt.Sprintf(`
 As an IT support assistant, analyze the following issue and provide specific, actionable suggestions for mitigation:
 
@@ -132,39 +241,90 @@ Please provide:
 Be concise and practical. Focus on common IT issues and solutions.
 `, description)
 
-	requestBody := map[string]interface{}{
-		"model": s.model,
-		"messages": []map[string]string{
+	return &suggestionPrompt{
+		messages: []map[string]string{
 			{"role": "system", "content": "You are an experienced IT support assistant providing helpful technical guidance."},
 			{"role": "user", "content": prompt},
 		},
-		"max_tokens":   300,
-		"temperature":  0.7,
-		"top_p":        1,
+		temperature: 0.7,
+	}, nil
+}
+
+// buildResult turns the model answer into a suggestion result
+func (s *OpenAISuggestionService) buildResult(prompt *suggestionPrompt, description, content string, totalTokens int) ports.SuggestionResult {
+	if prompt.grounded {
+		result := groundedResult(content, description, s.endpoint.Name+"-rag", prompt.sources)
+		result.Model = s.model
+		return result
+	}
+
+	// Calculate confidence based on response quality and token usage
+	confidence := calculateConfidence(content, totalTokens)
+
+	// Determine category from content
+	category := determineCategory(content, description)
+
+	return ports.SuggestionResult{
+		Suggestion: content,
+		Confidence: confidence,
+		Category:   category,
+		Source:     s.endpoint.Name,
+		Model:      s.model,
+		UsedCache:  false,
+	}
+}
+
+// newChatRequest creates a chat completions request, optionally asking for a server-sent event stream
+func (s *OpenAISuggestionService) newChatRequest(ctx context.Context, prompt *suggestionPrompt, stream bool) (*http.Request, error) {
+	requestBody := map[string]interface{}{
+		"model":       s.model,
+		"messages":    prompt.messages,
+		"max_tokens":  300,
+		"temperature": prompt.temperature,
+		"top_p":       1,
+	}
+	if stream {
+		requestBody["stream"] = true
+	}
+	if prompt.responseFormat != nil {
+		requestBody["response_format"] = prompt.responseFormat
 	}
 
 	jsonBody, err := json.Marshal(requestBody)
 	if err != nil {
-		return ports.SuggestionResult{}, fmt.Errorf("failed to marshal request: %w", err)
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", s.baseURL+"/chat/completions", bytes.NewBuffer(jsonBody))
+	req, err := http.NewRequestWithContext(ctx, "POST", s.endpoint.url("/chat/completions", s.model), bytes.NewBuffer(jsonBody))
 	if err != nil {
-		return ports.SuggestionResult{}, fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	s.endpoint.authorize(req)
+	if stream {
+		req.Header.Set("Accept", "text/event-stream")
+	}
+
+	return req, nil
+}
+
+// complete calls the chat completions API and returns the first choice and total token usage
+func (s *OpenAISuggestionService) complete(ctx context.Context, prompt *suggestionPrompt) (string, int, error) {
+	req, err := s.newChatRequest(ctx, prompt, false)
+	if err != nil {
+		return "", 0, err
+	}
 
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return ports.SuggestionResult{}, fmt.Errorf("failed to call OpenAI API: %w", err)
+		return "", 0, fmt.Errorf("failed to call %s API: %w", s.endpoint.Name, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return ports.SuggestionResult{}, fmt.Errorf("OpenAI API error: %d - %s", resp.StatusCode, string(body))
+		return "", 0, &ports.ProviderError{Provider: s.endpoint.Name, StatusCode: resp.StatusCode, Message: string(body)}
 	}
 
 	var response struct {
@@ -179,120 +339,24 @@ Be concise and practical. Focus on common IT issues and solutions.
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return ports.SuggestionResult{}, fmt.Errorf("failed to decode response: %w", err)
+		return "", 0, fmt.Errorf("failed to decode response: %w", err)
 	}
 
 	if len(response.Choices) == 0 {
-		return ports.SuggestionResult{}, fmt.Errorf("no choices in response")
+		return "", 0, fmt.Errorf("no choices in response")
 	}
 
-	// Calculate confidence based on response quality and token usage
-	confidence := calculateConfidence(response.Choices[0].Message.Content, response.Usage.TotalTokens)
-
-	// Determine category from content
-	category := determineCategory(response.Choices[0].Message.Content, description)
-
-	return ports.SuggestionResult{
-		Suggestion: response.Choices[0].Message.Content,
-		Confidence: confidence,
-		Category:   category,
-		Source:     "openai",
-		UsedCache:  false,
-	}, nil
-}
-
-// PredictAttributes predicts title, category, and priority based on description using heuristics.
-func (s *OpenAISuggestionService) PredictAttributes(ctx context.Context, description string) (ports.PredictedAttributes, error) {
-    title := oaGenerateTitle(description)
-    category := determineCategory(title, description)
-    priority := oaEstimatePriority(description)
-
-    return ports.PredictedAttributes{
-        Title: ports.FieldPrediction{
-            Value:      title,
-            Confidence: 0.68,
-            Source:     "openai-heuristic",
-        },
-        Category: ports.FieldPrediction{
-            Value:      category,
-            Confidence: 0.72,
-            Source:     "openai-heuristic",
-        },
-        Priority: ports.FieldPrediction{
-            Value:      priority,
-            Confidence: 0.70,
-            Source:     "openai-heuristic",
-        },
-    }, nil
-}
-
-// StreamSuggestionMitigation provides streaming AI suggestions (simplified implementation)
-func (s *OpenAISuggestionService) StreamSuggestionMitigation(ctx context.Context, description string) (<-chan ports.SuggestionEvent, error) {
-	// For simplicity, this implementation uses the non-streaming API and simulates streaming
-	// In a production environment, you would use OpenAI's streaming API
-	eventChan := make(chan ports.SuggestionEvent, 10)
-	queryID := fmt.Sprintf("openai_%d", time.Now().UnixNano())
-
-	go func() {
-		defer close(eventChan)
-
-		// Send init event
-		eventChan <- ports.SuggestionEvent{
-			Type:    "init",
-			QueryID: queryID,
-			Data: map[string]interface{}{
-				"query":     description,
-				"startTime": time.Now().Unix(),
-			},
-		}
-
-		// Get suggestion using non-streaming API
-		result, err := s.SuggestMitigation(ctx, description)
-		if err != nil {
-			eventChan <- ports.SuggestionEvent{
-				Type:    "error",
-				QueryID: queryID,
-				Error:   err.Error(),
-			}
-			return
-		}
-
-		// Send as single candidate (in real streaming, this would be multiple candidates)
-		candidate := ports.CandidateData{
-			Rank:       1,
-			Score:      result.Confidence,
-			Suggestion: result.Suggestion,
-			Category:   result.Category,
-		}
-
-		eventChan <- ports.SuggestionEvent{
-			Type:    "candidate",
-			QueryID: queryID,
-			Data:    candidate,
-		}
-
-		// Send end event
-		eventChan <- ports.SuggestionEvent{
-			Type:    "end",
-			QueryID: queryID,
-			Data: ports.EndData{
-				TotalCandidates: 1,
-				ElapsedMs:       1000, // Mock timing
-			},
-		}
-	}()
-
-	return eventChan, nil
+	return response.Choices[0].Message.Content, response.Usage.TotalTokens, nil
 }
 
-// ValidateProvider checks if the OpenAI API is accessible
+// ValidateProvider checks if the provider API is accessible
 func (s *OpenAISuggestionService) ValidateProvider(ctx context.Context) error {
-	req, err := http.NewRequestWithContext(ctx, "GET", s.baseURL+"/models", nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", s.endpoint.url("/models", ""), nil)
 	if err != nil {
 		return err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	s.endpoint.authorize(req)
 
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
@@ -301,7 +365,7 @@ func (s *OpenAISuggestionService) ValidateProvider(ctx context.Context) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("OpenAI API validation failed: %d", resp.StatusCode)
+		return fmt.Errorf("%s API validation failed: %d", s.endpoint.Name, resp.StatusCode)
 	}
 
 	return nil
@@ -309,10 +373,9 @@ func (s *OpenAISuggestionService) ValidateProvider(ctx context.Context) error {
 
 // OpenAIEmbeddingProvider implements EmbeddingProvider for OpenAI
 type OpenAIEmbeddingProvider struct {
-	apiKey     string
+	endpoint   Endpoint
 	model      string
 	dimension  int
-	baseURL    string
 	httpClient *http.Client
 }
 
@@ -342,23 +405,23 @@ func (e *OpenAIEmbeddingProvider) EmbedBatch(ctx context.Context, texts []string
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", e.baseURL+"/embeddings", bytes.NewBuffer(jsonBody))
+	req, err := http.NewRequestWithContext(ctx, "POST", e.endpoint.url("/embeddings", e.model), bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+e.apiKey)
+	e.endpoint.authorize(req)
 
 	resp, err := e.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to call OpenAI embeddings API: %w", err)
+		return nil, fmt.Errorf("failed to call %s embeddings API: %w", e.endpoint.Name, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("OpenAI embeddings API error: %d - %s", resp.StatusCode, string(body))
+		return nil, &ports.ProviderError{Provider: e.endpoint.Name, StatusCode: resp.StatusCode, Message: string(body)}
 	}
 
 	var response struct {
@@ -391,6 +454,11 @@ func (e *OpenAIEmbeddingProvider) Dimension() int {
 	return e.dimension
 }
 
+// Model returns the name of the embedding model
+func (e *OpenAIEmbeddingProvider) Model() string {
+	return e.model
+}
+
 // ValidateEmbedding checks if embedding dimension is correct
 func (e *OpenAIEmbeddingProvider) ValidateEmbedding(embedding []float32) bool {
 	return len(embedding) == e.dimension
@@ -398,8 +466,7 @@ func (e *OpenAIEmbeddingProvider) ValidateEmbedding(embedding []float32) bool {
 
 // OpenAITrainingService implements AITrainingService for OpenAI
 type OpenAITrainingService struct {
-	apiKey     string
-	baseURL    string
+	endpoint   Endpoint
 	httpClient *http.Client
 }
 
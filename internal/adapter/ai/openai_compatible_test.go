@@ -0,0 +1,255 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"fixora/internal/ports"
+)
+
+// recordedRequest captures what a stub endpoint received
+type recordedRequest struct {
+	Method     string
+	Path       string
+	APIVersion string
+	Auth       string
+	APIKey     string
+	Model      string
+}
+
+// stubEndpoint serves canned chat completions, embeddings and models responses and records requests
+type stubEndpoint struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []recordedRequest
+}
+
+func newStubEndpoint(t *testing.T) *stubEndpoint {
+	t.Helper()
+
+	stub := &stubEndpoint{}
+	stub.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Model string   `json:"model"`
+			Input []string `json:"input"`
+		}
+		if r.Method == http.MethodPost {
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("Failed to decode request body: %v", err)
+			}
+		}
+
+		stub.mu.Lock()
+		stub.requests = append(stub.requests, recordedRequest{
+			Method:     r.Method,
+			Path:       r.URL.Path,
+			APIVersion: r.URL.Query().Get("api-version"),
+			Auth:       r.Header.Get("Authorization"),
+			APIKey:     r.Header.Get("api-key"),
+			Model:      body.Model,
+		})
+		stub.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet:
+			w.Write([]byte(`{"data":[]}`))
+		case len(body.Input) > 0:
+			data := make([]map[string]interface{}, len(body.Input))
+			for i := range body.Input {
+				data[i] = map[string]interface{}{"embedding": []float32{0.1, 0.2, 0.3}}
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+		default:
+			w.Write([]byte(`{"choices":[{"message":{"content":"Restart the router."}}],"usage":{"total_tokens":60}}`))
+		}
+	}))
+
+	return stub
+}
+
+func (s *stubEndpoint) last(t *testing.T) recordedRequest {
+	t.Helper()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.requests) == 0 {
+		t.Fatal("Expected the stub to receive a request")
+	}
+	return s.requests[len(s.requests)-1]
+}
+
+func testAIConfig() ports.AIConfig {
+	return ports.AIConfig{
+		SuggestionModel: "gpt-3.5-turbo",
+		EmbeddingModel:  "text-embedding-ada-002",
+		EmbeddingDim:    3,
+		TimeoutMs:       5000,
+	}
+}
+
+// exercise calls the chat, embeddings and health endpoints of a factory
+func exercise(t *testing.T, stub *stubEndpoint, factory ports.AIProviderFactory) (chat, embed, health recordedRequest) {
+	t.Helper()
+	ctx := context.Background()
+
+	result, err := factory.Suggestion().SuggestMitigation(ctx, "WiFi keeps disconnecting")
+	if err != nil {
+		t.Fatalf("Expected no error from SuggestMitigation, got %v", err)
+	}
+	if result.Suggestion != "Restart the router." {
+		t.Errorf("Expected stub suggestion, got %q", result.Suggestion)
+	}
+	if result.Source != factory.Provider() {
+		t.Errorf("Expected source %q, got %q", factory.Provider(), result.Source)
+	}
+	chat = stub.last(t)
+
+	embeddings, err := factory.Embeddings().EmbedBatch(ctx, []string{"one", "two"})
+	if err != nil {
+		t.Fatalf("Expected no error from EmbedBatch, got %v", err)
+	}
+	if len(embeddings) != 2 {
+		t.Errorf("Expected 2 embeddings, got %d", len(embeddings))
+	}
+	embed = stub.last(t)
+
+	if err := factory.IsHealthy(ctx); err != nil {
+		t.Fatalf("Expected healthy provider, got %v", err)
+	}
+	health = stub.last(t)
+
+	return chat, embed, health
+}
+
+func TestAzureOpenAIAdapterUsesDeploymentURLs(t *testing.T) {
+	stub := newStubEndpoint(t)
+	defer stub.Close()
+
+	factory := NewAzureOpenAIAdapter(testAIConfig(), Endpoint{
+		BaseURL:    stub.URL,
+		APIKey:     "azure-key",
+		APIVersion: "2024-06-01",
+		ModelMap: map[string]string{
+			"gpt-3.5-turbo":          "chat-deployment",
+			"text-embedding-ada-002": "embed-deployment",
+		},
+	})
+
+	if factory.Provider() != "azure" {
+		t.Errorf("Expected provider azure, got %s", factory.Provider())
+	}
+
+	chat, embed, health := exercise(t, stub, factory)
+
+	if chat.Path != "/openai/deployments/chat-deployment/chat/completions" {
+		t.Errorf("Unexpected chat path %s", chat.Path)
+	}
+	if embed.Path != "/openai/deployments/embed-deployment/embeddings" {
+		t.Errorf("Unexpected embeddings path %s", embed.Path)
+	}
+	if health.Path != "/openai/models" {
+		t.Errorf("Unexpected health path %s", health.Path)
+	}
+
+	for _, req := range []recordedRequest{chat, embed, health} {
+		if req.APIVersion != "2024-06-01" {
+			t.Errorf("Expected api-version on %s, got %q", req.Path, req.APIVersion)
+		}
+		if req.APIKey != "azure-key" || req.Auth != "" {
+			t.Errorf("Expected api-key header only on %s, got api-key=%q authorization=%q", req.Path, req.APIKey, req.Auth)
+		}
+	}
+}
+
+func TestZaiAdapterMapsModels(t *testing.T) {
+	stub := newStubEndpoint(t)
+	defer stub.Close()
+
+	factory := NewZaiAdapter(testAIConfig(), Endpoint{
+		BaseURL: stub.URL + "/api/paas/v4",
+		APIKey:  "zai-key",
+		ModelMap: map[string]string{
+			"gpt-3.5-turbo":          "glm-4.5",
+			"text-embedding-ada-002": "embedding-3",
+		},
+	})
+
+	if factory.Provider() != "zai" {
+		t.Errorf("Expected provider zai, got %s", factory.Provider())
+	}
+	if model := factory.Embeddings().Model(); model != "embedding-3" {
+		t.Errorf("Expected mapped embedding model, got %s", model)
+	}
+
+	chat, embed, _ := exercise(t, stub, factory)
+
+	if chat.Path != "/api/paas/v4/chat/completions" || chat.Model != "glm-4.5" {
+		t.Errorf("Unexpected chat request %s model=%s", chat.Path, chat.Model)
+	}
+	if embed.Path != "/api/paas/v4/embeddings" || embed.Model != "embedding-3" {
+		t.Errorf("Unexpected embeddings request %s model=%s", embed.Path, embed.Model)
+	}
+	if chat.Auth != "Bearer zai-key" || chat.APIVersion != "" {
+		t.Errorf("Expected bearer auth without api-version, got authorization=%q api-version=%q", chat.Auth, chat.APIVersion)
+	}
+}
+
+func TestOpenAICompatibleAdapterWithoutAuth(t *testing.T) {
+	stub := newStubEndpoint(t)
+	defer stub.Close()
+
+	config := testAIConfig()
+	config.SuggestionModel = "llama3.1"
+	config.EmbeddingModel = "nomic-embed-text"
+
+	factory := NewOpenAICompatibleAdapter(config, Endpoint{
+		Name:    "ollama",
+		BaseURL: stub.URL + "/v1/",
+	})
+
+	chat, embed, health := exercise(t, stub, factory)
+
+	if chat.Path != "/v1/chat/completions" || chat.Model != "llama3.1" {
+		t.Errorf("Unexpected chat request %s model=%s", chat.Path, chat.Model)
+	}
+	if embed.Path != "/v1/embeddings" || embed.Model != "nomic-embed-text" {
+		t.Errorf("Unexpected embeddings request %s model=%s", embed.Path, embed.Model)
+	}
+	if health.Path != "/v1/models" {
+		t.Errorf("Unexpected health path %s", health.Path)
+	}
+
+	for _, req := range []recordedRequest{chat, embed, health} {
+		if req.Auth != "" || req.APIKey != "" {
+			t.Errorf("Expected no credentials on %s, got authorization=%q api-key=%q", req.Path, req.Auth, req.APIKey)
+		}
+	}
+}
+
+func TestOpenAICompatibleAdapterReportsProviderErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error":{"message":"Rate limit reached"}}`))
+	}))
+	defer server.Close()
+
+	factory := NewOpenAICompatibleAdapter(testAIConfig(), Endpoint{Name: "vllm", BaseURL: server.URL, APIKey: "key"})
+
+	_, err := factory.Suggestion().SuggestMitigation(context.Background(), "VPN fails")
+	if !ports.IsRetryableAIError(err) {
+		t.Fatalf("Expected retryable provider error, got %v", err)
+	}
+
+	providerErr, ok := err.(*ports.ProviderError)
+	if !ok || providerErr.Provider != "vllm" {
+		t.Errorf("Expected provider error from vllm, got %#v", err)
+	}
+}
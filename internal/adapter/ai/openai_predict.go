@@ -0,0 +1,195 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"fixora/internal/ports"
+)
+
+// heuristicSource marks predictions made by keyword heuristics instead of the model
+const heuristicSource = "heuristic"
+
+// maxPredictedTitleLength bounds generated ticket titles
+const maxPredictedTitleLength = 80
+
+var (
+	predictableCategories = []string{"NETWORK", "SOFTWARE", "HARDWARE", "ACCOUNT", "OTHER"}
+	predictablePriorities = []string{"LOW", "MEDIUM", "HIGH", "CRITICAL"}
+)
+
+// predictSystemPrompt instructs the model to classify a ticket
+const predictSystemPrompt = `You triage IT support tickets. From the user's description, write a short ticket title (at most 10 words), pick the category and priority, and give your confidence between 0 and 1 for each field. Priority: CRITICAL for outages, security incidents or data loss; HIGH when someone cannot work; MEDIUM for degraded service; LOW for questions and minor requests. Reply with JSON only.`
+
+// predictionResponseFormat asks for output matching the prediction JSON schema
+func predictionResponseFormat() map[string]interface{} {
+	confidence := map[string]interface{}{"type": "number", "minimum": 0, "maximum": 1}
+
+	return map[string]interface{}{
+		"type": "json_schema",
+		"json_schema": map[string]interface{}{
+			"name":   "ticket_attributes",
+			"strict": true,
+			"schema": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"title":               map[string]interface{}{"type": "string"},
+					"title_confidence":    confidence,
+					"category":            map[string]interface{}{"type": "string", "enum": predictableCategories},
+					"category_confidence": confidence,
+					"priority":            map[string]interface{}{"type": "string", "enum": predictablePriorities},
+					"priority_confidence": confidence,
+				},
+				"required": []string{
+					"title", "title_confidence",
+					"category", "category_confidence",
+					"priority", "priority_confidence",
+				},
+				"additionalProperties": false,
+			},
+		},
+	}
+}
+
+// rawPrediction is the model's structured output before validation
+type rawPrediction struct {
+	Title              string   `json:"title"`
+	TitleConfidence    *float64 `json:"title_confidence"`
+	Category           string   `json:"category"`
+	CategoryConfidence *float64 `json:"category_confidence"`
+	Priority           string   `json:"priority"`
+	PriorityConfidence *float64 `json:"priority_confidence"`
+}
+
+// PredictAttributes predicts title, category and priority with a structured-output request.
+// Fields the model leaves out or gets wrong are filled by keyword heuristics and have Source
+// "heuristic". A failed request or unparseable output is returned as an error, so the router
+// can try the next provider.
+func (s *OpenAISuggestionService) PredictAttributes(ctx context.Context, description string) (ports.PredictedAttributes, error) {
+	content, _, err := s.complete(ctx, &suggestionPrompt{
+		messages: []map[string]string{
+			{"role": "system", "content": predictSystemPrompt},
+			{"role": "user", "content": description},
+		},
+		temperature:    0,
+		responseFormat: predictionResponseFormat(),
+	})
+	if err != nil {
+		return ports.PredictedAttributes{}, err
+	}
+
+	raw, err := parsePrediction(content)
+	if err != nil {
+		return ports.PredictedAttributes{}, err
+	}
+
+	return validatePrediction(raw, s.endpoint.Name, heuristicAttributes(description)), nil
+}
+
+// parsePrediction decodes the model output into a prediction
+func parsePrediction(content string) (*rawPrediction, error) {
+	var raw rawPrediction
+	if err := decodeModelJSON(content, &raw); err != nil {
+		return nil, fmt.Errorf("failed to decode prediction output: %w", err)
+	}
+	return &raw, nil
+}
+
+// decodeModelJSON decodes a JSON object from model output, repairing common deviations such as
+// code fences or prose around the object
+func decodeModelJSON(content string, v interface{}) error {
+	content = strings.TrimSpace(content)
+
+	if err := json.Unmarshal([]byte(content), v); err == nil {
+		return nil
+	}
+
+	start := strings.Index(content, "{")
+	end := strings.LastIndex(content, "}")
+	if start < 0 || end <= start {
+		return fmt.Errorf("no JSON object in model output")
+	}
+
+	return json.Unmarshal([]byte(content[start:end+1]), v)
+}
+
+// validatePrediction keeps the valid model fields and falls back to the heuristic for the rest
+func validatePrediction(raw *rawPrediction, source string, heuristic ports.PredictedAttributes) ports.PredictedAttributes {
+	result := heuristic
+
+	if title := cleanTitle(raw.Title); title != "" {
+		result.Title = ports.FieldPrediction{Value: title, Confidence: clampConfidence(raw.TitleConfidence), Source: source}
+	}
+
+	if category, ok := matchLabel(raw.Category, predictableCategories); ok {
+		result.Category = ports.FieldPrediction{Value: category, Confidence: clampConfidence(raw.CategoryConfidence), Source: source}
+	}
+
+	if priority, ok := matchLabel(raw.Priority, predictablePriorities); ok {
+		result.Priority = ports.FieldPrediction{Value: priority, Confidence: clampConfidence(raw.PriorityConfidence), Source: source}
+	}
+
+	return result
+}
+
+// heuristicAttributes predicts ticket attributes from keywords
+func heuristicAttributes(description string) ports.PredictedAttributes {
+	title := oaGenerateTitle(description)
+
+	return ports.PredictedAttributes{
+		Title: ports.FieldPrediction{
+			Value:      title,
+			Confidence: 0.68,
+			Source:     heuristicSource,
+		},
+		Category: ports.FieldPrediction{
+			Value:      determineCategory(title, description),
+			Confidence: 0.72,
+			Source:     heuristicSource,
+		},
+		Priority: ports.FieldPrediction{
+			Value:      oaEstimatePriority(description),
+			Confidence: 0.70,
+			Source:     heuristicSource,
+		},
+	}
+}
+
+// matchLabel returns the allowed label equal to value, ignoring case and surrounding whitespace
+func matchLabel(value string, allowed []string) (string, bool) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	for _, label := range allowed {
+		if value == label {
+			return label, true
+		}
+	}
+	return "", false
+}
+
+// cleanTitle trims quotes and whitespace and bounds the title length
+func cleanTitle(title string) string {
+	title = strings.Join(strings.Fields(title), " ")
+	title = strings.Trim(title, `"'`)
+
+	if runes := []rune(title); len(runes) > maxPredictedTitleLength {
+		title = strings.TrimSpace(string(runes[:maxPredictedTitleLength])) + "..."
+	}
+
+	return title
+}
+
+// clampConfidence bounds a model-reported confidence to [0, 1], treating a missing value as 0.5
+func clampConfidence(confidence *float64) float64 {
+	if confidence == nil {
+		return 0.5
+	}
+	if *confidence < 0 {
+		return 0
+	}
+	if *confidence > 1 {
+		return 1
+	}
+	return *confidence
+}
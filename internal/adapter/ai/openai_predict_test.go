@@ -0,0 +1,138 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"fixora/internal/ports"
+)
+
+// completionServer answers chat completions with content and checks the request asks for structured output
+func completionServer(t *testing.T, status int, content string) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			ResponseFormat struct {
+				Type string `json:"type"`
+			} `json:"response_format"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		if body.ResponseFormat.Type != "json_schema" {
+			t.Errorf("Expected json_schema response format, got %q", body.ResponseFormat.Type)
+		}
+
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{
+				{"message": map[string]string{"content": content}},
+			},
+		})
+	}))
+}
+
+func TestPredictAttributes(t *testing.T) {
+	description := "The whole office network is down since 9am, nobody can reach the VPN"
+
+	tests := []struct {
+		name           string
+		status         int
+		content        string
+		wantTitle      string
+		wantCategory   string
+		wantPriority   string
+		wantSources    [3]string
+		wantConfidence float64
+	}{
+		{
+			name:           "structured output",
+			status:         http.StatusOK,
+			content:        `{"title":"Office network and VPN outage","title_confidence":0.9,"category":"NETWORK","category_confidence":0.95,"priority":"CRITICAL","priority_confidence":0.85}`,
+			wantTitle:      "Office network and VPN outage",
+			wantCategory:   "NETWORK",
+			wantPriority:   "CRITICAL",
+			wantSources:    [3]string{"openai", "openai", "openai"},
+			wantConfidence: 0.95,
+		},
+		{
+			name:           "fenced output with an invalid category is repaired",
+			status:         http.StatusOK,
+			content:        "```json\n{\"title\":\"Office network outage\",\"title_confidence\":0.8,\"category\":\"Connectivity\",\"category_confidence\":0.9,\"priority\":\"high\",\"priority_confidence\":1.4}\n```",
+			wantTitle:      "Office network outage",
+			wantCategory:   "Network",
+			wantPriority:   "HIGH",
+			wantSources:    [3]string{"openai", "heuristic", "openai"},
+			wantConfidence: 0.72,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := completionServer(t, tt.status, tt.content)
+			defer server.Close()
+
+			service := newTestSuggestionService(server)
+
+			preds, err := service.PredictAttributes(context.Background(), description)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if preds.Title.Value != tt.wantTitle {
+				t.Errorf("Expected title %q, got %q", tt.wantTitle, preds.Title.Value)
+			}
+			if preds.Category.Value != tt.wantCategory {
+				t.Errorf("Expected category %q, got %q", tt.wantCategory, preds.Category.Value)
+			}
+			if preds.Priority.Value != tt.wantPriority {
+				t.Errorf("Expected priority %q, got %q", tt.wantPriority, preds.Priority.Value)
+			}
+
+			sources := [3]string{preds.Title.Source, preds.Category.Source, preds.Priority.Source}
+			if sources != tt.wantSources {
+				t.Errorf("Expected sources %v, got %v", tt.wantSources, sources)
+			}
+
+			if preds.Category.Confidence != tt.wantConfidence {
+				t.Errorf("Expected category confidence %.2f, got %.2f", tt.wantConfidence, preds.Category.Confidence)
+			}
+			if preds.Priority.Confidence < 0 || preds.Priority.Confidence > 1 {
+				t.Errorf("Expected priority confidence within [0, 1], got %.2f", preds.Priority.Confidence)
+			}
+		})
+	}
+}
+
+func TestPredictAttributesReturnsFailures(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		content   string
+		retryable bool
+	}{
+		{name: "unparseable output", status: http.StatusOK, content: "This looks like a network outage."},
+		{name: "provider error", status: http.StatusInternalServerError, retryable: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := completionServer(t, tt.status, tt.content)
+			defer server.Close()
+
+			service := newTestSuggestionService(server)
+
+			_, err := service.PredictAttributes(context.Background(), "The whole office network is down")
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			if retryable := ports.IsRetryableAIError(err); retryable != tt.retryable {
+				t.Errorf("Expected retryable %v, got %v for %v", tt.retryable, retryable, err)
+			}
+		})
+	}
+}
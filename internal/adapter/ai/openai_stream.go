@@ -0,0 +1,216 @@
+package ai
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"fixora/internal/ports"
+)
+
+// StreamSuggestionMitigation streams a suggestion token by token from the chat completions API.
+// It emits init, progress (after retrieval and on the first token), one token event per delta,
+// a final candidate with the full answer and citations, and end. Cancelling ctx aborts the
+// upstream request.
+func (s *OpenAISuggestionService) StreamSuggestionMitigation(ctx context.Context, description string) (<-chan ports.SuggestionEvent, error) {
+	eventChan := make(chan ports.SuggestionEvent, 10)
+	queryID := fmt.Sprintf("%s_%d", s.endpoint.Name, time.Now().UnixNano())
+	start := time.Now()
+
+	// send delivers an event unless the consumer has gone away
+	send := func(event ports.SuggestionEvent) bool {
+		event.QueryID = queryID
+		select {
+		case eventChan <- event:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	go func() {
+		defer close(eventChan)
+
+		if !send(ports.SuggestionEvent{
+			Type: "init",
+			Data: map[string]interface{}{
+				"query":     description,
+				"startTime": start.Unix(),
+			},
+		}) {
+			return
+		}
+
+		prompt, err := s.preparePrompt(ctx, description)
+		if err != nil {
+			send(ports.SuggestionEvent{Type: "error", Error: err.Error(), Err: err})
+			return
+		}
+
+		if prompt.grounded {
+			if !send(ports.SuggestionEvent{
+				Type: "progress",
+				Data: ports.ProgressData{
+					RetrievedCount: len(prompt.sources),
+					ElapsedMs:      time.Since(start).Milliseconds(),
+				},
+			}) {
+				return
+			}
+
+			if len(prompt.sources) == 0 {
+				result := noGroundedAnswerResult(s.endpoint.Name + "-rag")
+				if send(ports.SuggestionEvent{Type: "candidate", Data: candidateFromResult(result)}) {
+					send(ports.SuggestionEvent{
+						Type: "end",
+						Data: ports.EndData{TotalCandidates: 0, ElapsedMs: time.Since(start).Milliseconds()},
+					})
+				}
+				return
+			}
+		}
+
+		var content strings.Builder
+		tokens := 0
+
+		err = s.streamCompletion(ctx, prompt, func(delta string) bool {
+			if tokens == 0 {
+				// Time to first token
+				if !send(ports.SuggestionEvent{
+					Type: "progress",
+					Data: ports.ProgressData{
+						RetrievedCount: len(prompt.sources),
+						ElapsedMs:      time.Since(start).Milliseconds(),
+					},
+				}) {
+					return false
+				}
+			}
+
+			content.WriteString(delta)
+			ok := send(ports.SuggestionEvent{
+				Type: "token",
+				Data: ports.TokenData{Index: tokens, Text: delta},
+			})
+			tokens++
+			return ok
+		})
+		if err != nil {
+			if ctx.Err() == nil {
+				send(ports.SuggestionEvent{Type: "error", Error: err.Error(), Err: err})
+			}
+			return
+		}
+
+		result := s.buildResult(prompt, description, content.String(), tokens)
+
+		if !send(ports.SuggestionEvent{Type: "candidate", Data: candidateFromResult(result)}) {
+			return
+		}
+
+		send(ports.SuggestionEvent{
+			Type: "end",
+			Data: ports.EndData{
+				TotalCandidates: 1,
+				ElapsedMs:       time.Since(start).Milliseconds(),
+			},
+		})
+	}()
+
+	return eventChan, nil
+}
+
+// streamCompletion calls the chat completions API with stream: true and passes each content delta
+// to onDelta until the stream ends, onDelta returns false, or ctx is cancelled
+func (s *OpenAISuggestionService) streamCompletion(ctx context.Context, prompt *suggestionPrompt, onDelta func(string) bool) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	req, err := s.newChatRequest(ctx, prompt, true)
+	if err != nil {
+		return err
+	}
+
+	// The client timeout covers reading the whole body, which would cut long streams short;
+	// the stream is bounded by ctx instead.
+	client := &http.Client{Transport: s.httpClient.Transport}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call %s API: %w", s.endpoint.Name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return &ports.ProviderError{Provider: s.endpoint.Name, StatusCode: resp.StatusCode, Message: string(body)}
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "data:") {
+			// Blank separators, comments and other SSE fields
+			continue
+		}
+
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == "[DONE]" {
+			return nil
+		}
+
+		var chunk struct {
+			Choices []struct {
+				Delta struct {
+					Content string `json:"content"`
+				} `json:"delta"`
+				FinishReason *string `json:"finish_reason"`
+			} `json:"choices"`
+		}
+
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return fmt.Errorf("failed to decode stream chunk: %w", err)
+		}
+
+		for _, choice := range chunk.Choices {
+			if choice.Delta.Content == "" {
+				continue
+			}
+			if !onDelta(choice.Delta.Content) {
+				return ctx.Err()
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read stream: %w", err)
+	}
+
+	return nil
+}
+
+// candidateFromResult converts a suggestion result into a stream candidate, pointing at the top citation
+func candidateFromResult(result ports.SuggestionResult) ports.CandidateData {
+	candidate := ports.CandidateData{
+		Rank:       1,
+		Score:      result.Confidence,
+		Suggestion: result.Suggestion,
+		Category:   result.Category,
+		Source:     result.Source,
+		Model:      result.Model,
+	}
+
+	if len(result.Citations) > 0 {
+		candidate.EntryID = result.Citations[0].EntryID
+		candidate.ChunkIndex = result.Citations[0].ChunkIndex
+	}
+
+	return candidate
+}
@@ -0,0 +1,213 @@
+package ai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"fixora/internal/ports"
+)
+
+// replayStream serves a recorded chat completions stream line by line
+func replayStream(t *testing.T, path string) *httptest.Server {
+	t.Helper()
+
+	recorded, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read recorded stream: %v", err)
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		if body["stream"] != true {
+			t.Errorf("Expected stream: true in request, got %v", body["stream"])
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		flusher := w.(http.Flusher)
+
+		scanner := bufio.NewScanner(bytes.NewReader(recorded))
+		for scanner.Scan() {
+			w.Write([]byte(scanner.Text() + "\n"))
+			flusher.Flush()
+		}
+	}))
+}
+
+func newTestSuggestionService(server *httptest.Server) *OpenAISuggestionService {
+	return &OpenAISuggestionService{
+		endpoint:   Endpoint{Name: "openai", BaseURL: server.URL, AuthScheme: AuthBearer, APIKey: "test-key"},
+		model:      "gpt-3.5-turbo",
+		httpClient: server.Client(),
+	}
+}
+
+func collectEvents(t *testing.T, events <-chan ports.SuggestionEvent) []ports.SuggestionEvent {
+	t.Helper()
+
+	var collected []ports.SuggestionEvent
+	timeout := time.After(5 * time.Second)
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return collected
+			}
+			collected = append(collected, event)
+		case <-timeout:
+			t.Fatalf("Timed out waiting for stream to finish, got %d events", len(collected))
+		}
+	}
+}
+
+func TestStreamSuggestionMitigationForwardsTokens(t *testing.T) {
+	server := replayStream(t, "testdata/chat_completions_stream.txt")
+	defer server.Close()
+
+	service := newTestSuggestionService(server)
+
+	events, err := service.StreamSuggestionMitigation(context.Background(), "WiFi keeps disconnecting")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	collected := collectEvents(t, events)
+
+	var tokens []string
+	var candidate *ports.CandidateData
+	var end *ports.EndData
+	for _, event := range collected {
+		if event.QueryID == "" {
+			t.Errorf("Expected query ID on %s event", event.Type)
+		}
+
+		switch event.Type {
+		case "token":
+			data := event.Data.(ports.TokenData)
+			if data.Index != len(tokens) {
+				t.Errorf("Expected token index %d, got %d", len(tokens), data.Index)
+			}
+			tokens = append(tokens, data.Text)
+		case "candidate":
+			data := event.Data.(ports.CandidateData)
+			candidate = &data
+		case "end":
+			data := event.Data.(ports.EndData)
+			end = &data
+		case "error":
+			t.Fatalf("Unexpected error event: %s", event.Error)
+		}
+	}
+
+	if collected[0].Type != "init" {
+		t.Errorf("Expected first event to be init, got %s", collected[0].Type)
+	}
+
+	expected := []string{"Restart", " the", " WiFi", " router", "."}
+	if strings.Join(tokens, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected tokens %q, got %q", expected, tokens)
+	}
+
+	if candidate == nil {
+		t.Fatal("Expected a candidate event")
+	}
+	if candidate.Suggestion != "Restart the WiFi router." {
+		t.Errorf("Expected full suggestion in candidate, got %q", candidate.Suggestion)
+	}
+
+	if end == nil {
+		t.Fatal("Expected an end event")
+	}
+	if end.TotalCandidates != 1 {
+		t.Errorf("Expected 1 candidate, got %d", end.TotalCandidates)
+	}
+	if end.ElapsedMs >= 1000 {
+		t.Errorf("Expected real elapsed time, got %dms", end.ElapsedMs)
+	}
+
+	if last := collected[len(collected)-1]; last.Type != "end" {
+		t.Errorf("Expected last event to be end, got %s", last.Type)
+	}
+}
+
+func TestStreamSuggestionMitigationCancelAbortsUpstream(t *testing.T) {
+	upstreamDone := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte(`data: {"choices":[{"index":0,"delta":{"content":"Checking"}}]}` + "\n\n"))
+		w.(http.Flusher).Flush()
+
+		// Hold the stream open until the client goes away
+		<-r.Context().Done()
+		close(upstreamDone)
+	}))
+	defer server.Close()
+
+	service := newTestSuggestionService(server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := service.StreamSuggestionMitigation(ctx, "Printer offline")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Cancel as soon as the first token arrives
+	for event := range events {
+		if event.Type == "token" {
+			cancel()
+			break
+		}
+	}
+
+	select {
+	case <-upstreamDone:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected cancelling the context to abort the upstream request")
+	}
+
+	// The channel must be closed without an end event
+	for event := range events {
+		if event.Type == "end" || event.Type == "candidate" {
+			t.Errorf("Expected no %s event after cancellation", event.Type)
+		}
+	}
+}
+
+func TestStreamSuggestionMitigationProviderError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error":{"message":"Rate limit reached"}}`))
+	}))
+	defer server.Close()
+
+	service := newTestSuggestionService(server)
+
+	events, err := service.StreamSuggestionMitigation(context.Background(), "VPN fails")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	collected := collectEvents(t, events)
+
+	last := collected[len(collected)-1]
+	if last.Type != "error" {
+		t.Fatalf("Expected error event, got %s", last.Type)
+	}
+	if !strings.Contains(last.Error, "429") {
+		t.Errorf("Expected status code in error, got %q", last.Error)
+	}
+}
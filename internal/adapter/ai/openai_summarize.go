@@ -0,0 +1,99 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"fixora/internal/ports"
+)
+
+// maxSummaryComments bounds how much of a long ticket conversation is sent to the model
+const maxSummaryComments = 30
+
+// summarizeSystemPrompt instructs the model to turn a resolved ticket into a knowledge base article
+const summarizeSystemPrompt = `You write internal IT knowledge base articles from resolved support tickets. From the ticket and its comments, write a short title naming the problem (at most 12 words), describe the problem as users experience it, and describe the fix as steps a support agent can repeat. Leave out names, e-mail addresses, passwords and other personal details. If the conversation does not show how the problem was fixed, return an empty fix. Reply with JSON only.`
+
+// resolutionResponseFormat asks for output matching the resolution summary JSON schema
+func resolutionResponseFormat() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "json_schema",
+		"json_schema": map[string]interface{}{
+			"name":   "resolution_summary",
+			"strict": true,
+			"schema": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"title":   map[string]interface{}{"type": "string"},
+					"problem": map[string]interface{}{"type": "string"},
+					"fix":     map[string]interface{}{"type": "string"},
+				},
+				"required":             []string{"title", "problem", "fix"},
+				"additionalProperties": false,
+			},
+		},
+	}
+}
+
+// SummarizeResolution summarises problem and fix from a resolved ticket and its comments
+func (s *OpenAISuggestionService) SummarizeResolution(ctx context.Context, ticket *ports.TicketTrainingData) (*ports.ResolutionSummary, error) {
+	if ticket == nil {
+		return nil, fmt.Errorf("ticket is required")
+	}
+
+	content, _, err := s.complete(ctx, &suggestionPrompt{
+		messages: []map[string]string{
+			{"role": "system", "content": summarizeSystemPrompt},
+			{"role": "user", "content": resolutionTranscript(ticket)},
+		},
+		temperature:    0.2,
+		responseFormat: resolutionResponseFormat(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var summary ports.ResolutionSummary
+	if err := decodeModelJSON(content, &summary); err != nil {
+		return nil, fmt.Errorf("failed to decode resolution summary: %w", err)
+	}
+
+	summary.Title = strings.TrimSpace(summary.Title)
+	summary.Problem = strings.TrimSpace(summary.Problem)
+	summary.Fix = strings.TrimSpace(summary.Fix)
+	summary.Source = s.endpoint.Name
+
+	if summary.Problem == "" {
+		return nil, fmt.Errorf("resolution summary has no problem description")
+	}
+
+	return &summary, nil
+}
+
+// resolutionTranscript renders a ticket and its most recent comments as the prompt
+func resolutionTranscript(ticket *ports.TicketTrainingData) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Title: %s\n", ticket.Title)
+	if ticket.Category != "" {
+		fmt.Fprintf(&b, "Category: %s\n", ticket.Category)
+	}
+	fmt.Fprintf(&b, "Description:\n%s\n", ticket.Description)
+
+	comments := ticket.Comments
+	if len(comments) > maxSummaryComments {
+		comments = comments[len(comments)-maxSummaryComments:]
+	}
+	if len(comments) > 0 {
+		b.WriteString("\nComments, oldest first:\n")
+		for _, comment := range comments {
+			fmt.Fprintf(&b, "- %s\n", strings.TrimSpace(comment))
+		}
+	}
+
+	if ticket.Resolution != "" {
+		fmt.Fprintf(&b, "\nResolution: %s\n", ticket.Resolution)
+	}
+
+	return b.String()
+}
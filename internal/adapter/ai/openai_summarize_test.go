@@ -0,0 +1,77 @@
+package ai
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"fixora/internal/ports"
+)
+
+func resolvedTicket() *ports.TicketTrainingData {
+	return &ports.TicketTrainingData{
+		TicketID:    "ticket-1",
+		Title:       "Outlook not syncing",
+		Description: "Outlook stopped receiving new mail this morning",
+		Category:    "SOFTWARE",
+		Comments:    []string{"Cleared the cached credentials and rebuilt the profile, mail syncs again"},
+		Resolution:  "Rebuilt the Outlook profile",
+	}
+}
+
+func TestSummarizeResolution(t *testing.T) {
+	server := completionServer(t, http.StatusOK, "```json\n{\"title\":\"Outlook stops syncing mail\",\"problem\":\"Outlook no longer receives new mail.\",\"fix\":\"Clear cached credentials and rebuild the Outlook profile.\"}\n```")
+	defer server.Close()
+
+	summary, err := newTestSuggestionService(server).SummarizeResolution(context.Background(), resolvedTicket())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if summary.Title != "Outlook stops syncing mail" {
+		t.Errorf("Expected title from the model, got %q", summary.Title)
+	}
+	if summary.Fix != "Clear cached credentials and rebuild the Outlook profile." {
+		t.Errorf("Expected fix from the model, got %q", summary.Fix)
+	}
+	if summary.Source != "openai" {
+		t.Errorf("Expected source openai, got %q", summary.Source)
+	}
+}
+
+func TestSummarizeResolutionProviderError(t *testing.T) {
+	server := completionServer(t, http.StatusServiceUnavailable, "")
+	defer server.Close()
+
+	if _, err := newTestSuggestionService(server).SummarizeResolution(context.Background(), resolvedTicket()); err == nil {
+		t.Fatal("Expected an error when the provider fails")
+	}
+}
+
+func TestAIRouterSkipsProvidersWithoutSummaries(t *testing.T) {
+	server := completionServer(t, http.StatusOK, `{"title":"Outlook stops syncing mail","problem":"Outlook no longer receives new mail.","fix":"Rebuild the Outlook profile."}`)
+	defer server.Close()
+
+	mock := newFlakyProvider("mock")
+	compatible := NewOpenAICompatibleAdapter(testAIConfig(), Endpoint{Name: "ollama", BaseURL: server.URL})
+
+	router := NewAIRouter([]ports.AIProviderFactory{mock, compatible}, RouterConfig{FailureThreshold: 1})
+
+	summarizer, ok := router.Suggestion().(ports.ResolutionSummarizer)
+	if !ok {
+		t.Fatal("Expected the router suggestion service to summarise resolutions")
+	}
+
+	summary, err := summarizer.SummarizeResolution(context.Background(), resolvedTicket())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if summary.Source != "ollama" {
+		t.Errorf("Expected the summary from ollama, got %q", summary.Source)
+	}
+
+	// Lacking the operation is not a failure of the provider
+	if state := router.(*AIRouter).ProviderStatuses()[0].State; state != ports.BreakerClosed {
+		t.Errorf("Expected the mock provider breaker to stay closed, got %s", state)
+	}
+}
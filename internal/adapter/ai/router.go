@@ -0,0 +1,515 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"fixora/internal/ports"
+)
+
+// knowledgeOnlySource is the suggestion source when every provider failed and the answer comes
+// straight from the knowledge base
+const knowledgeOnlySource = "knowledge-base"
+
+// RouterConfig configures the AI provider router
+type RouterConfig struct {
+	FailureThreshold int           // consecutive failures that open a provider's breaker
+	Cooldown         time.Duration // how long a breaker stays open before a trial request
+	TopK             int           // chunks retrieved for knowledge-base-only answers
+	MinScore         float64       // minimum chunk score for knowledge-base-only answers
+}
+
+// AIRouter is an AIProviderFactory that sends each request to the first available provider in
+// priority order, falling back to the next one on errors. Each provider has a circuit breaker so a
+// rate-limited or unreachable provider is skipped until its cooldown has passed. When every provider
+// fails and a knowledge base is set, the best matching chunk is returned as the answer.
+//
+// Embeddings and training always use the primary provider: falling back to another embedding model
+// would mix vector spaces in the knowledge base.
+type AIRouter struct {
+	providers []*routedProvider
+	knowledge ports.KnowledgeRepository
+	config    RouterConfig
+}
+
+// routedProvider is a provider with its circuit breaker
+type routedProvider struct {
+	factory ports.AIProviderFactory
+	breaker *circuitBreaker
+}
+
+// NewAIRouter creates a router over providers in priority order; the first one is the primary
+func NewAIRouter(providers []ports.AIProviderFactory, config RouterConfig) ports.AIProviderFactory {
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = 5
+	}
+	if config.Cooldown <= 0 {
+		config.Cooldown = 30 * time.Second
+	}
+
+	router := &AIRouter{config: config}
+	for _, factory := range providers {
+		router.providers = append(router.providers, &routedProvider{
+			factory: factory,
+			breaker: newCircuitBreaker(config.FailureThreshold, config.Cooldown),
+		})
+	}
+
+	return router
+}
+
+// Suggestion returns a suggestion service that falls back across providers
+func (r *AIRouter) Suggestion() ports.AISuggestionService {
+	return &routerSuggestionService{router: r}
+}
+
+// Embeddings returns the primary provider's embeddings
+func (r *AIRouter) Embeddings() ports.EmbeddingProvider {
+	return r.providers[0].factory.Embeddings()
+}
+
+// Training returns the primary provider's training service
+func (r *AIRouter) Training() ports.AITrainingService {
+	return r.providers[0].factory.Training()
+}
+
+// Provider returns the current provider type
+func (r *AIRouter) Provider() string {
+	return "router"
+}
+
+// IsHealthy succeeds if at least one provider is healthy
+func (r *AIRouter) IsHealthy(ctx context.Context) error {
+	var errs []error
+	for _, p := range r.providers {
+		err := p.factory.IsHealthy(ctx)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", p.factory.Provider(), err))
+	}
+
+	return fmt.Errorf("no healthy AI provider: %w", errors.Join(errs...))
+}
+
+// SetKnowledgeBase grounds the providers that support it and enables knowledge-base-only answers
+func (r *AIRouter) SetKnowledgeBase(repo ports.KnowledgeRepository) {
+	r.knowledge = repo
+	for _, p := range r.providers {
+		if groundable, ok := p.factory.(ports.KnowledgeGroundable); ok {
+			groundable.SetKnowledgeBase(repo)
+		}
+	}
+}
+
+// ProviderStatuses returns the breaker state of each provider in priority order
+func (r *AIRouter) ProviderStatuses() []ports.ProviderStatus {
+	statuses := make([]ports.ProviderStatus, len(r.providers))
+	for i, p := range r.providers {
+		statuses[i] = p.breaker.status(p.factory.Provider())
+	}
+	return statuses
+}
+
+// errProviderUnsupported is returned by route callbacks for providers that lack the operation
+var errProviderUnsupported = errors.New("operation not supported by AI provider")
+
+// errStreamInterrupted reports a stream that closed without an end event, such as a dropped
+// connection; it counts as a transient failure
+var errStreamInterrupted = errors.New("stream ended unexpectedly")
+
+// route calls fn on each available provider in order until one succeeds. Cancellation of ctx is
+// returned immediately and does not count against the provider. Only transient errors count as
+// breaker failures; errProviderUnsupported and errors such as rejected requests fall through to
+// the next provider without opening the breaker.
+func (r *AIRouter) route(ctx context.Context, fn func(p *routedProvider) error) error {
+	var errs []error
+	unsupported := false
+
+	for _, p := range r.providers {
+		if !p.breaker.allow() {
+			continue
+		}
+
+		err := fn(p)
+		if err == nil {
+			p.breaker.success()
+			return nil
+		}
+
+		if ctx.Err() != nil {
+			p.breaker.release()
+			return ctx.Err()
+		}
+
+		if errors.Is(err, errProviderUnsupported) {
+			p.breaker.release()
+			unsupported = true
+			continue
+		}
+
+		p.breaker.fail(err)
+		errs = append(errs, fmt.Errorf("%s: %w", p.factory.Provider(), err))
+	}
+
+	if len(errs) == 0 && unsupported {
+		return errProviderUnsupported
+	}
+
+	if len(errs) == 0 {
+		return fmt.Errorf("%s: all provider circuit breakers are open", ports.ErrAIUnavailable)
+	}
+
+	return fmt.Errorf("%s: %w", ports.ErrAIUnavailable, errors.Join(errs...))
+}
+
+// knowledgeOnlyResult answers with the best matching knowledge base chunk
+func (r *AIRouter) knowledgeOnlyResult(ctx context.Context, description string) (ports.SuggestionResult, error) {
+	sources, err := retrieveGrounding(ctx, r.knowledge, description, r.config.TopK, r.config.MinScore)
+	if err != nil {
+		return ports.SuggestionResult{}, err
+	}
+
+	if len(sources) == 0 {
+		return noGroundedAnswerResult(knowledgeOnlySource), nil
+	}
+
+	top := sources[0]
+	return ports.SuggestionResult{
+		Suggestion: top.Content,
+		Confidence: top.Score,
+		Category:   determineCategory(top.Content, description),
+		Source:     knowledgeOnlySource,
+		Grounded:   true,
+		Citations:  citeSources("", sources[:1]),
+	}, nil
+}
+
+// routerSuggestionService implements AISuggestionService over the router's providers
+type routerSuggestionService struct {
+	router *AIRouter
+}
+
+// SuggestMitigation returns the first successful provider's suggestion; Source names that provider
+func (s *routerSuggestionService) SuggestMitigation(ctx context.Context, description string) (ports.SuggestionResult, error) {
+	var result ports.SuggestionResult
+
+	err := s.router.route(ctx, func(p *routedProvider) error {
+		var err error
+		result, err = p.factory.Suggestion().SuggestMitigation(ctx, description)
+		if err == nil && result.Source == "" {
+			result.Source = p.factory.Provider()
+		}
+		return err
+	})
+	if err == nil {
+		return result, nil
+	}
+
+	if s.router.knowledge != nil && ctx.Err() == nil {
+		return s.router.knowledgeOnlyResult(ctx, description)
+	}
+
+	return ports.SuggestionResult{}, err
+}
+
+// StreamSuggestionMitigation streams from the first provider that starts answering. A provider that
+// fails before sending any tokens or candidates is skipped in favour of the next one; a failure
+// mid-answer is reported as an error event.
+func (s *routerSuggestionService) StreamSuggestionMitigation(ctx context.Context, description string) (<-chan ports.SuggestionEvent, error) {
+	eventChan := make(chan ports.SuggestionEvent, 10)
+	queryID := fmt.Sprintf("router_%d", time.Now().UnixNano())
+	start := time.Now()
+
+	send := func(event ports.SuggestionEvent) bool {
+		event.QueryID = queryID
+		select {
+		case eventChan <- event:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	go func() {
+		defer close(eventChan)
+
+		initSent := false
+		var errs []error
+
+		for _, p := range s.router.providers {
+			if !p.breaker.allow() {
+				continue
+			}
+
+			events, err := p.factory.Suggestion().StreamSuggestionMitigation(ctx, description)
+			if err != nil {
+				if ctx.Err() != nil {
+					p.breaker.release()
+					return
+				}
+				p.breaker.fail(err)
+				errs = append(errs, fmt.Errorf("%s: %w", p.factory.Provider(), err))
+				continue
+			}
+
+			answering, ended := false, false
+			var streamErr error
+
+			for event := range events {
+				switch event.Type {
+				case "init":
+					if initSent {
+						continue
+					}
+					initSent = true
+				case "error":
+					streamErr = event.Err
+					if streamErr == nil {
+						streamErr = errors.New(event.Error)
+					}
+					continue
+				case "token", "candidate":
+					answering = true
+				case "end":
+					ended = true
+				}
+
+				if !send(event) {
+					p.breaker.release()
+					return
+				}
+			}
+
+			if ctx.Err() != nil {
+				p.breaker.release()
+				return
+			}
+
+			if streamErr == nil && !ended {
+				streamErr = errStreamInterrupted
+			}
+
+			if streamErr == nil {
+				p.breaker.success()
+				return
+			}
+
+			p.breaker.fail(streamErr)
+			if answering {
+				send(ports.SuggestionEvent{Type: "error", Error: streamErr.Error()})
+				return
+			}
+			errs = append(errs, fmt.Errorf("%s: %w", p.factory.Provider(), streamErr))
+		}
+
+		if !initSent && !send(ports.SuggestionEvent{
+			Type: "init",
+			Data: map[string]interface{}{
+				"query":     description,
+				"startTime": start.Unix(),
+			},
+		}) {
+			return
+		}
+
+		if s.router.knowledge == nil {
+			message := ports.ErrAIUnavailable + ": all provider circuit breakers are open"
+			if len(errs) > 0 {
+				message = fmt.Sprintf("%s: %v", ports.ErrAIUnavailable, errors.Join(errs...))
+			}
+			send(ports.SuggestionEvent{Type: "error", Error: message})
+			return
+		}
+
+		result, err := s.router.knowledgeOnlyResult(ctx, description)
+		if err != nil {
+			send(ports.SuggestionEvent{Type: "error", Error: err.Error()})
+			return
+		}
+
+		total := 1
+		if !result.Grounded {
+			total = 0
+		}
+		if send(ports.SuggestionEvent{Type: "candidate", Data: candidateFromResult(result)}) {
+			send(ports.SuggestionEvent{
+				Type: "end",
+				Data: ports.EndData{TotalCandidates: total, ElapsedMs: time.Since(start).Milliseconds()},
+			})
+		}
+	}()
+
+	return eventChan, nil
+}
+
+// PredictAttributes returns the first successful provider's predictions. When every provider
+// fails or is unavailable, it falls back to keyword heuristics.
+func (s *routerSuggestionService) PredictAttributes(ctx context.Context, description string) (ports.PredictedAttributes, error) {
+	var attributes ports.PredictedAttributes
+
+	err := s.router.route(ctx, func(p *routedProvider) error {
+		var err error
+		attributes, err = p.factory.Suggestion().PredictAttributes(ctx, description)
+		return err
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return ports.PredictedAttributes{}, err
+		}
+		return heuristicAttributes(description), nil
+	}
+
+	return attributes, nil
+}
+
+// SummarizeResolution summarises a resolved ticket with the first provider that supports it
+func (s *routerSuggestionService) SummarizeResolution(ctx context.Context, ticket *ports.TicketTrainingData) (*ports.ResolutionSummary, error) {
+	var summary *ports.ResolutionSummary
+
+	err := s.router.route(ctx, func(p *routedProvider) error {
+		summarizer, ok := p.factory.Suggestion().(ports.ResolutionSummarizer)
+		if !ok {
+			return errProviderUnsupported
+		}
+
+		var err error
+		summary, err = summarizer.SummarizeResolution(ctx, ticket)
+		return err
+	})
+
+	return summary, err
+}
+
+// ValidateProvider succeeds if at least one provider is reachable
+func (s *routerSuggestionService) ValidateProvider(ctx context.Context) error {
+	var errs []error
+	for _, p := range s.router.providers {
+		err := p.factory.Suggestion().ValidateProvider(ctx)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", p.factory.Provider(), err))
+	}
+
+	return fmt.Errorf("no AI provider available: %w", errors.Join(errs...))
+}
+
+// ProviderStatuses returns the breaker state of each provider in priority order
+func (s *routerSuggestionService) ProviderStatuses() []ports.ProviderStatus {
+	return s.router.ProviderStatuses()
+}
+
+// circuitBreaker tracks consecutive failures of one provider. It opens after threshold failures,
+// lets a single trial request through once the cooldown has passed (half open), and closes again
+// when the trial succeeds.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	state     string
+	failures  int
+	lastError string
+	openedAt  time.Time
+	now       func() time.Time
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		state:     ports.BreakerClosed,
+		now:       time.Now,
+	}
+}
+
+// allow reports whether a request may be sent, moving an open breaker to half open after the cooldown
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case ports.BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = ports.BreakerHalfOpen
+		return true
+	case ports.BreakerHalfOpen:
+		// A trial request is already in flight
+		return false
+	default:
+		return true
+	}
+}
+
+// success closes the breaker
+func (b *circuitBreaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = ports.BreakerClosed
+	b.failures = 0
+	b.lastError = ""
+}
+
+// failure records an error, opening the breaker at the threshold or when a trial request fails
+func (b *circuitBreaker) failure(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.lastError = err.Error()
+
+	if b.state == ports.BreakerHalfOpen || b.failures >= b.threshold {
+		b.state = ports.BreakerOpen
+		b.openedAt = b.now()
+	}
+}
+
+// fail ends a failed request. Transient errors count as failures; others, such as a rejected
+// request, say nothing about the provider's health and only release the request.
+func (b *circuitBreaker) fail(err error) {
+	if ports.IsRetryableAIError(err) || errors.Is(err, errStreamInterrupted) {
+		b.failure(err)
+		return
+	}
+	b.release()
+}
+
+// release ends a request without an outcome, such as one cancelled by the caller, so an
+// interrupted trial does not leave the breaker half open
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == ports.BreakerHalfOpen {
+		b.state = ports.BreakerOpen
+		b.openedAt = b.now().Add(-b.cooldown)
+	}
+}
+
+// status reports the breaker state for a provider
+func (b *circuitBreaker) status(provider string) ports.ProviderStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status := ports.ProviderStatus{
+		Provider:            provider,
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+		LastError:           b.lastError,
+	}
+
+	if b.state != ports.BreakerClosed {
+		openedAt := b.openedAt
+		retryAt := openedAt.Add(b.cooldown)
+		status.OpenedAt = &openedAt
+		status.RetryAt = &retryAt
+	}
+
+	return status
+}
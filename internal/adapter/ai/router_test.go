@@ -0,0 +1,247 @@
+package ai
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"fixora/internal/ports"
+)
+
+// flakyProvider is an AI provider whose suggestions fail while failing is set, with status
+// (429 by default)
+type flakyProvider struct {
+	*MockAIProviderFactory
+	name    string
+	failing atomic.Bool
+	status  atomic.Int32
+	calls   atomic.Int32
+}
+
+func newFlakyProvider(name string) *flakyProvider {
+	return &flakyProvider{
+		MockAIProviderFactory: NewMockAIProviderFactory(testAIConfig()).(*MockAIProviderFactory),
+		name:                  name,
+	}
+}
+
+func (f *flakyProvider) Provider() string {
+	return f.name
+}
+
+func (f *flakyProvider) Suggestion() ports.AISuggestionService {
+	return &flakySuggestionService{AISuggestionService: f.MockAIProviderFactory.Suggestion(), provider: f}
+}
+
+type flakySuggestionService struct {
+	ports.AISuggestionService
+	provider *flakyProvider
+}
+
+func (s *flakySuggestionService) SuggestMitigation(ctx context.Context, description string) (ports.SuggestionResult, error) {
+	s.provider.calls.Add(1)
+	if s.provider.failing.Load() {
+		status := int(s.provider.status.Load())
+		if status == 0 {
+			status = http.StatusTooManyRequests
+		}
+		return ports.SuggestionResult{}, &ports.ProviderError{Provider: s.provider.name, StatusCode: status, Message: http.StatusText(status)}
+	}
+
+	return ports.SuggestionResult{Suggestion: "Restart the router.", Confidence: 0.8, Source: s.provider.name}, nil
+}
+
+// PredictAttributes fails like SuggestMitigation while failing is set
+func (s *flakySuggestionService) PredictAttributes(ctx context.Context, description string) (ports.PredictedAttributes, error) {
+	if _, err := s.SuggestMitigation(ctx, description); err != nil {
+		return ports.PredictedAttributes{}, err
+	}
+	return s.AISuggestionService.PredictAttributes(ctx, description)
+}
+
+// StreamSuggestionMitigation fails the stream with a typed error event while failing is set
+func (s *flakySuggestionService) StreamSuggestionMitigation(ctx context.Context, description string) (<-chan ports.SuggestionEvent, error) {
+	s.provider.calls.Add(1)
+	if !s.provider.failing.Load() {
+		return s.AISuggestionService.StreamSuggestionMitigation(ctx, description)
+	}
+
+	status := int(s.provider.status.Load())
+	if status == 0 {
+		status = http.StatusTooManyRequests
+	}
+	err := &ports.ProviderError{Provider: s.provider.name, StatusCode: status, Message: http.StatusText(status)}
+
+	events := make(chan ports.SuggestionEvent, 2)
+	events <- ports.SuggestionEvent{Type: "init"}
+	events <- ports.SuggestionEvent{Type: "error", Error: err.Error(), Err: err}
+	close(events)
+	return events, nil
+}
+
+func TestAIRouterFallsBackAndOpensBreaker(t *testing.T) {
+	primary := newFlakyProvider("openai")
+	fallback := newFlakyProvider("zai")
+	primary.failing.Store(true)
+
+	factory := NewAIRouter([]ports.AIProviderFactory{primary, fallback}, RouterConfig{
+		FailureThreshold: 2,
+		Cooldown:         time.Minute,
+	})
+	router := factory.(*AIRouter)
+	now := time.Now()
+	router.providers[0].breaker.now = func() time.Time { return now }
+
+	service := factory.Suggestion()
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		result, err := service.SuggestMitigation(ctx, "WiFi keeps disconnecting")
+		if err != nil {
+			t.Fatalf("Expected fallback to succeed, got %v", err)
+		}
+		if result.Source != "zai" {
+			t.Errorf("Expected suggestion served by zai, got %s", result.Source)
+		}
+	}
+
+	// The breaker opens after two failures, so the third request skips the primary
+	if calls := primary.calls.Load(); calls != 2 {
+		t.Errorf("Expected 2 calls to the primary before the breaker opened, got %d", calls)
+	}
+
+	statuses := service.(ports.ProviderRouter).ProviderStatuses()
+	if statuses[0].State != ports.BreakerOpen || statuses[0].ConsecutiveFailures != 2 {
+		t.Errorf("Expected open breaker after 2 failures, got %+v", statuses[0])
+	}
+	if statuses[1].State != ports.BreakerClosed {
+		t.Errorf("Expected fallback breaker closed, got %s", statuses[1].State)
+	}
+
+	// After the cooldown a successful trial request closes the breaker again
+	primary.failing.Store(false)
+	now = now.Add(time.Minute)
+
+	result, err := service.SuggestMitigation(ctx, "WiFi keeps disconnecting")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Source != "openai" {
+		t.Errorf("Expected recovered primary to serve the suggestion, got %s", result.Source)
+	}
+	if state := router.ProviderStatuses()[0].State; state != ports.BreakerClosed {
+		t.Errorf("Expected breaker closed after a successful trial, got %s", state)
+	}
+}
+
+func TestAIRouterReportsAllProvidersFailing(t *testing.T) {
+	primary := newFlakyProvider("openai")
+	fallback := newFlakyProvider("zai")
+	primary.failing.Store(true)
+	fallback.failing.Store(true)
+
+	factory := NewAIRouter([]ports.AIProviderFactory{primary, fallback}, RouterConfig{FailureThreshold: 1})
+
+	_, err := factory.Suggestion().SuggestMitigation(context.Background(), "VPN fails")
+	if err == nil {
+		t.Fatal("Expected an error when every provider fails")
+	}
+	if !ports.IsRetryableAIError(err) {
+		t.Errorf("Expected provider errors to be preserved, got %v", err)
+	}
+}
+
+func TestAIRouterIgnoresNonRetryableErrorsInBreaker(t *testing.T) {
+	primary := newFlakyProvider("openai")
+	fallback := newFlakyProvider("zai")
+	primary.failing.Store(true)
+	primary.status.Store(http.StatusBadRequest)
+
+	factory := NewAIRouter([]ports.AIProviderFactory{primary, fallback}, RouterConfig{FailureThreshold: 2})
+	service := factory.Suggestion()
+
+	for i := 0; i < 3; i++ {
+		result, err := service.SuggestMitigation(context.Background(), "WiFi keeps disconnecting")
+		if err != nil {
+			t.Fatalf("Expected fallback to succeed, got %v", err)
+		}
+		if result.Source != "zai" {
+			t.Errorf("Expected suggestion served by zai, got %s", result.Source)
+		}
+	}
+
+	// A rejected request says nothing about the provider's health, so it is still tried
+	if calls := primary.calls.Load(); calls != 3 {
+		t.Errorf("Expected every request to try the primary, got %d calls", calls)
+	}
+	if status := factory.(*AIRouter).ProviderStatuses()[0]; status.State != ports.BreakerClosed || status.ConsecutiveFailures != 0 {
+		t.Errorf("Expected the primary breaker to stay closed, got %+v", status)
+	}
+}
+
+func TestAIRouterStreamClassifiesErrorsForBreaker(t *testing.T) {
+	for _, tc := range []struct {
+		status   int
+		state    string
+		failures int
+	}{
+		{http.StatusBadRequest, ports.BreakerClosed, 0},
+		{http.StatusServiceUnavailable, ports.BreakerOpen, 2},
+	} {
+		primary := newFlakyProvider("openai")
+		fallback := newFlakyProvider("zai")
+		primary.failing.Store(true)
+		primary.status.Store(int32(tc.status))
+
+		factory := NewAIRouter([]ports.AIProviderFactory{primary, fallback}, RouterConfig{FailureThreshold: 2})
+		service := factory.Suggestion()
+
+		for i := 0; i < 2; i++ {
+			events, err := service.StreamSuggestionMitigation(context.Background(), "WiFi keeps disconnecting")
+			if err != nil {
+				t.Fatalf("Failed to stream: %v", err)
+			}
+			ended := false
+			for event := range events {
+				if event.Type == "error" {
+					t.Errorf("Expected fallback to serve the stream, got error %s", event.Error)
+				}
+				ended = ended || event.Type == "end"
+			}
+			if !ended {
+				t.Errorf("Expected the fallback stream to end")
+			}
+		}
+
+		status := factory.(*AIRouter).ProviderStatuses()[0]
+		if status.State != tc.state || status.ConsecutiveFailures != tc.failures {
+			t.Errorf("Status %d: expected breaker %s with %d failures, got %+v", tc.status, tc.state, tc.failures, status)
+		}
+	}
+}
+
+func TestAIRouterPredictsWithHeuristicsWhenProvidersFail(t *testing.T) {
+	primary := newFlakyProvider("openai")
+	fallback := newFlakyProvider("zai")
+	primary.failing.Store(true)
+	fallback.failing.Store(true)
+	fallback.status.Store(http.StatusServiceUnavailable)
+
+	factory := NewAIRouter([]ports.AIProviderFactory{primary, fallback}, RouterConfig{})
+
+	preds, err := factory.Suggestion().PredictAttributes(context.Background(), "The whole office network is down since 9am, nobody can reach the VPN")
+	if err != nil {
+		t.Fatalf("Expected heuristic predictions, got %v", err)
+	}
+	if primary.calls.Load() != 1 || fallback.calls.Load() != 1 {
+		t.Errorf("Expected both providers to be tried, got %d and %d calls", primary.calls.Load(), fallback.calls.Load())
+	}
+	if preds.Category.Value != "Network" || preds.Priority.Value != "CRITICAL" {
+		t.Errorf("Expected Network/CRITICAL, got %s/%s", preds.Category.Value, preds.Priority.Value)
+	}
+	if preds.Category.Source != heuristicSource || preds.Priority.Source != heuristicSource {
+		t.Errorf("Expected heuristic sources, got %s/%s", preds.Category.Source, preds.Priority.Source)
+	}
+}
@@ -0,0 +1,156 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"fixora/internal/ports"
+)
+
+// MaildirConfig configures the Maildir poller
+type MaildirConfig struct {
+	Path            string        // the Maildir, containing new, cur and tmp
+	PollInterval    time.Duration // how often to look for new mail
+	MaxMessageBytes int64         // larger messages are flagged and skipped
+}
+
+// MaildirPoller ingests mail delivered to a Maildir, e.g. by the mail server's local delivery or
+// an IMAP sync tool such as mbsync or offlineimap. Each message in new is moved to cur before it
+// is handled, so several pollers can share a Maildir without handling a message twice. Handled
+// messages are marked seen; messages that can never be ingested are flagged and left for an
+// operator; messages that failed temporarily are moved back to new and retried on the next poll.
+type MaildirPoller struct {
+	handler   ports.InboundEmailHandler
+	config    MaildirConfig
+	startOnce sync.Once
+}
+
+// NewMaildirPoller creates a new Maildir poller
+func NewMaildirPoller(handler ports.InboundEmailHandler, config MaildirConfig) *MaildirPoller {
+	if config.PollInterval <= 0 {
+		config.PollInterval = 30 * time.Second
+	}
+	if config.MaxMessageBytes <= 0 {
+		config.MaxMessageBytes = 10 << 20
+	}
+
+	return &MaildirPoller{
+		handler: handler,
+		config:  config,
+	}
+}
+
+// Start polls the Maildir until the context is cancelled
+func (p *MaildirPoller) Start(ctx context.Context) error {
+	for _, dir := range []string{"new", "cur", "tmp"} {
+		if err := os.MkdirAll(filepath.Join(p.config.Path, dir), 0o750); err != nil {
+			return fmt.Errorf("failed to create maildir: %w", err)
+		}
+	}
+
+	p.startOnce.Do(func() {
+		go func() {
+			ticker := time.NewTicker(p.config.PollInterval)
+			defer ticker.Stop()
+
+			for {
+				if _, err := p.Poll(ctx); err != nil {
+					log.Printf("Failed to poll maildir %s: %v", p.config.Path, err)
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+		}()
+	})
+
+	return nil
+}
+
+// Poll handles the messages currently in new and reports how many were ingested
+func (p *MaildirPoller) Poll(ctx context.Context) (int, error) {
+	entries, err := os.ReadDir(filepath.Join(p.config.Path, "new"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to list new mail: %w", err)
+	}
+
+	// Maildir names start with the delivery time, so this handles mail in the order it arrived
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && !strings.HasPrefix(entry.Name(), ".") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	ingested := 0
+	for _, name := range names {
+		if ctx.Err() != nil {
+			break
+		}
+		if p.deliver(ctx, name) {
+			ingested++
+		}
+	}
+	return ingested, nil
+}
+
+// deliver claims, handles and files one message, reporting whether it was ingested
+func (p *MaildirPoller) deliver(ctx context.Context, name string) bool {
+	newPath := filepath.Join(p.config.Path, "new", name)
+	claimed := filepath.Join(p.config.Path, "cur", name)
+
+	// Another poller got here first
+	if err := os.Rename(newPath, claimed); err != nil {
+		return false
+	}
+
+	err := p.handle(ctx, claimed)
+	switch {
+	case err == nil:
+		p.file(claimed, name, "S")
+		return true
+	case isPermanent(err):
+		log.Printf("Skipping inbound email %s: %v", name, err)
+		p.file(claimed, name, "F")
+	default:
+		log.Printf("Failed to handle inbound email %s, will retry: %v", name, err)
+		if err := os.Rename(claimed, newPath); err != nil {
+			log.Printf("Failed to return inbound email %s to new: %v", name, err)
+		}
+	}
+	return false
+}
+
+func (p *MaildirPoller) handle(ctx context.Context, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open inbound email: %w", err)
+	}
+	defer file.Close()
+
+	raw, err := readLimited(file, p.config.MaxMessageBytes)
+	if err != nil {
+		return err
+	}
+	return handleRaw(ctx, p.handler, raw)
+}
+
+// file sets the message's Maildir flags (S seen, F flagged) in cur
+func (p *MaildirPoller) file(claimed, name, flag string) {
+	base, _, _ := strings.Cut(name, ":")
+	target := filepath.Join(p.config.Path, "cur", base+":2,"+flag)
+	if err := os.Rename(claimed, target); err != nil {
+		log.Printf("Failed to file inbound email %s: %v", name, err)
+	}
+}
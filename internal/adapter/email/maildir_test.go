@@ -0,0 +1,83 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newMaildir(t *testing.T, handler *recordingHandler, messages map[string]string) (*MaildirPoller, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	poller := NewMaildirPoller(handler, MaildirConfig{Path: dir})
+
+	for _, sub := range []string{"new", "cur", "tmp"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o750); err != nil {
+			t.Fatalf("Failed to create maildir: %v", err)
+		}
+	}
+
+	for name, raw := range messages {
+		if err := os.WriteFile(filepath.Join(dir, "new", name), []byte(raw), 0o600); err != nil {
+			t.Fatalf("Failed to deliver %s: %v", name, err)
+		}
+	}
+	return poller, dir
+}
+
+func assertExists(t *testing.T, path string) {
+	t.Helper()
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("Expected %s to exist: %v", path, err)
+	}
+}
+
+func TestMaildirPoller_Poll(t *testing.T) {
+	handler := &recordingHandler{}
+	poller, dir := newMaildir(t, handler, map[string]string{
+		"1709521200.1.host": "From: jane@example.com\r\nSubject: First\r\n\r\nFirst message\r\n",
+		"1709521300.2.host": "From: bob@example.com\r\nSubject: Second\r\n\r\nSecond message\r\n",
+		"1709521400.3.host": "Subject: No sender\r\n\r\nBroken\r\n",
+	})
+
+	ingested, err := poller.Poll(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if ingested != 2 {
+		t.Errorf("Expected 2 ingested, got %d", ingested)
+	}
+
+	emails := handler.received()
+	if len(emails) != 2 || emails[0].Subject != "First" || emails[1].Subject != "Second" {
+		t.Errorf("Expected both emails in delivery order, got %+v", emails)
+	}
+
+	assertExists(t, filepath.Join(dir, "cur", "1709521200.1.host:2,S"))
+	assertExists(t, filepath.Join(dir, "cur", "1709521400.3.host:2,F"))
+
+	if entries, _ := os.ReadDir(filepath.Join(dir, "new")); len(entries) != 0 {
+		t.Errorf("Expected new to be empty, got %d messages", len(entries))
+	}
+}
+
+func TestMaildirPoller_RetriesTemporaryFailure(t *testing.T) {
+	handler := &recordingHandler{err: errors.New("database unavailable")}
+	poller, dir := newMaildir(t, handler, map[string]string{
+		"1709521200.1.host": "From: jane@example.com\r\nSubject: First\r\n\r\nFirst message\r\n",
+	})
+
+	if ingested, _ := poller.Poll(context.Background()); ingested != 0 {
+		t.Errorf("Expected nothing ingested, got %d", ingested)
+	}
+	assertExists(t, filepath.Join(dir, "new", "1709521200.1.host"))
+
+	handler.err = nil
+	if ingested, _ := poller.Poll(context.Background()); ingested != 1 {
+		t.Errorf("Expected the retry to ingest the message, got %d", ingested)
+	}
+	assertExists(t, filepath.Join(dir, "cur", "1709521200.1.host:2,S"))
+}
@@ -0,0 +1,338 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/mail"
+	"strings"
+	"time"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// maxReferences bounds the References header of long conversations; the thread root is always kept
+const maxReferences = 10
+
+// Sender delivers an email
+type Sender interface {
+	Send(ctx context.Context, email *OutgoingEmail) error
+}
+
+// NotifierConfig configures email notifications
+type NotifierConfig struct {
+	Email ports.EmailConfig
+	// ReplyAddress is the helpdesk mailbox replies go to. A signed ticket token is added to it with
+	// plus addressing, so the inbound gateway must receive mail for mailbox+anything.
+	ReplyAddress string
+	ReplySecret  []byte
+	Language     string // default language, overridden by a notification's "language" data
+	QueueSize    int
+	MaxRetries   int
+	RetryDelay   time.Duration
+}
+
+// DefaultNotifierConfig returns the default notifier settings
+func DefaultNotifierConfig() NotifierConfig {
+	return NotifierConfig{
+		Language:   LanguageEnglish,
+		QueueSize:  1000,
+		MaxRetries: 3,
+		RetryDelay: 30 * time.Second,
+	}
+}
+
+// Notifier implements NotificationService by email. Ticket notifications go to the requester and
+// are threaded into one conversation per ticket: every email references the ticket's earlier
+// messages, including the requester's own inbound mail, and carries a reply-to address with a
+// signed ticket token so replies become comments on the ticket.
+type Notifier struct {
+	sender    Sender
+	messages  ports.EmailMessageRepository
+	templates *Templates
+	config    NotifierConfig
+	domain    string
+	queue     chan *OutgoingEmail
+}
+
+// NewNotifier creates a new email notifier. Emails are queued until Start is called.
+func NewNotifier(sender Sender, messages ports.EmailMessageRepository, templates *Templates, config NotifierConfig) *Notifier {
+	defaults := DefaultNotifierConfig()
+	if config.Language == "" {
+		config.Language = defaults.Language
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = defaults.QueueSize
+	}
+	if config.MaxRetries <= 0 {
+		config.MaxRetries = defaults.MaxRetries
+	}
+	if config.RetryDelay <= 0 {
+		config.RetryDelay = defaults.RetryDelay
+	}
+	if config.ReplyAddress == "" {
+		config.ReplyAddress = config.Email.FromEmail
+	}
+
+	domainName := "localhost"
+	if _, host, ok := strings.Cut(config.Email.FromEmail, "@"); ok && host != "" {
+		domainName = host
+	}
+
+	return &Notifier{
+		sender:    sender,
+		messages:  messages,
+		templates: templates,
+		config:    config,
+		domain:    domainName,
+		queue:     make(chan *OutgoingEmail, config.QueueSize),
+	}
+}
+
+// Start delivers queued emails until the context is cancelled
+func (n *Notifier) Start(ctx context.Context) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-n.queue:
+				n.deliver(ctx, msg)
+			}
+		}
+	}()
+}
+
+func (n *Notifier) deliver(ctx context.Context, msg *OutgoingEmail) {
+	for attempt := 1; ; attempt++ {
+		err := n.sender.Send(ctx, msg)
+		if err == nil {
+			return
+		}
+		if attempt >= n.config.MaxRetries {
+			log.Printf("Failed to send email %s to %s: %v", msg.MessageID, msg.To, err)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(n.config.RetryDelay * time.Duration(attempt)):
+		}
+	}
+}
+
+// NotifyTicketCreated acknowledges a new ticket to the requester. The acknowledgement starts the
+// ticket's email thread.
+func (n *Notifier) NotifyTicketCreated(ctx context.Context, ticket *domain.Ticket) error {
+	return n.sendTicketEmail(ctx, ports.NotificationTypeTicketCreated, ticket, n.rootMessageID(ticket.ID), nil, TemplateData{Ticket: ticket})
+}
+
+// NotifyTicketAssigned tells the requester who is working on their ticket
+func (n *Notifier) NotifyTicketAssigned(ctx context.Context, ticket *domain.Ticket, assigneeID string) error {
+	return n.sendTicketEmail(ctx, ports.NotificationTypeTicketAssigned, ticket, n.newMessageID("assigned"), nil, TemplateData{Ticket: ticket, AssigneeID: assigneeID})
+}
+
+// NotifyTicketUpdated tells the requester their ticket changed
+func (n *Notifier) NotifyTicketUpdated(ctx context.Context, ticket *domain.Ticket, updateType string) error {
+	return n.sendTicketEmail(ctx, ports.NotificationTypeTicketUpdated, ticket, n.newMessageID("updated"), nil, TemplateData{Ticket: ticket, UpdateType: updateType})
+}
+
+// NotifyCommentAdded sends agents' comments to the requester. The Message-ID is derived from the
+// comment, so notifying about the same comment twice yields the same message. Comments by the
+// requester and AI comments are not sent.
+func (n *Notifier) NotifyCommentAdded(ctx context.Context, comment *domain.Comment, ticket *domain.Ticket) error {
+	if comment.Role != domain.CommentRoleAdmin {
+		return nil
+	}
+	return n.sendTicketEmail(ctx, ports.NotificationTypeCommentAdded, ticket, n.formatMessageID("comment", comment.ID), &comment.ID, TemplateData{Ticket: ticket, Comment: comment})
+}
+
+// NotifyTicketResolved tells the requester their ticket was resolved, with the satisfaction
+// survey links when there is a survey
+func (n *Notifier) NotifyTicketResolved(ctx context.Context, ticket *domain.Ticket, survey *domain.CSATSurvey) error {
+	return n.sendTicketEmail(ctx, ports.NotificationTypeTicketResolved, ticket, n.newMessageID("resolved"), nil, TemplateData{Ticket: ticket, Survey: survey})
+}
+
+// NotifySLABreached warns the ticket's assignee. It is not part of the requester's thread.
+func (n *Notifier) NotifySLABreached(ctx context.Context, ticket *domain.Ticket, slaType string) error {
+	if ticket.AssignedTo == nil {
+		return nil
+	}
+	recipient, ok := emailAddress(*ticket.AssignedTo)
+	if !ok {
+		return nil
+	}
+
+	rendered, err := n.templates.Render(ports.NotificationTypeSLABreached, n.config.Language, TemplateData{
+		Ticket:     ticket,
+		SLAType:    slaType,
+		SenderName: n.config.Email.FromName,
+	})
+	if err != nil {
+		return err
+	}
+
+	msg := n.newEmail(rendered, recipient, n.newMessageID("sla"))
+	msg.Subject = rendered.Subject + " " + domain.TicketSubjectTag(ticket.ID)
+	return n.enqueue(msg)
+}
+
+// SendCustomNotification sends incident, maintenance and custom notifications. A notification
+// with "ticket_id" data is threaded into that ticket's conversation; "language" data picks the
+// language.
+func (n *Notifier) SendCustomNotification(ctx context.Context, notification *ports.Notification) error {
+	recipient, ok := emailAddress(notification.Recipient)
+	if !ok {
+		return nil
+	}
+
+	ntype := notification.Type
+	switch ntype {
+	case ports.NotificationTypeIncidentUpdate, ports.NotificationTypeSystemMaintenance:
+	default:
+		ntype = ports.NotificationTypeCustom
+	}
+
+	language := n.config.Language
+	if l, ok := notification.Data["language"].(string); ok && l != "" {
+		language = l
+	}
+
+	ticketID, _ := notification.Data["ticket_id"].(string)
+	rendered, err := n.templates.Render(ntype, language, TemplateData{
+		Notification: notification,
+		SenderName:   n.config.Email.FromName,
+		Replyable:    ticketID != "",
+	})
+	if err != nil {
+		return err
+	}
+
+	msg := n.newEmail(rendered, recipient, n.formatMessageID("notification", notification.ID))
+	if ticketID == "" {
+		return n.enqueue(msg)
+	}
+	return n.thread(ctx, msg, ticketID, nil)
+}
+
+// ValidateRecipient checks the recipient is an email address
+func (n *Notifier) ValidateRecipient(ctx context.Context, recipientID string) error {
+	if _, ok := emailAddress(recipientID); !ok {
+		return fmt.Errorf("recipient %q is not an email address", recipientID)
+	}
+	return nil
+}
+
+// sendTicketEmail renders a notification for the ticket's requester and sends it in the ticket's
+// thread. Requesters known only by a user ID have no address and get no email.
+func (n *Notifier) sendTicketEmail(ctx context.Context, ntype ports.NotificationType, ticket *domain.Ticket, messageID string, commentID *string, data TemplateData) error {
+	recipient, ok := emailAddress(ticket.CreatedBy)
+	if !ok {
+		return nil
+	}
+
+	data.SenderName = n.config.Email.FromName
+	data.Replyable = true
+	rendered, err := n.templates.Render(ntype, n.config.Language, data)
+	if err != nil {
+		return err
+	}
+
+	return n.thread(ctx, n.newEmail(rendered, recipient, messageID), ticket.ID, commentID)
+}
+
+// thread adds the ticket's threading headers, subject tag and reply address to an email, records
+// it in the ticket's conversation and queues it
+func (n *Notifier) thread(ctx context.Context, msg *OutgoingEmail, ticketID string, commentID *string) error {
+	conversation, err := n.messages.ListByTicket(ctx, ticketID)
+	if err != nil {
+		return fmt.Errorf("failed to load email thread: %w", err)
+	}
+
+	msg.References = threadReferences(n.rootMessageID(ticketID), conversation, msg.MessageID)
+	if len(msg.References) > 0 {
+		msg.InReplyTo = msg.References[len(msg.References)-1]
+	}
+	msg.Subject = domain.TicketSubjectTag(ticketID) + " " + msg.Subject
+	if len(n.config.ReplySecret) > 0 {
+		msg.ReplyTo = domain.ReplyAddress(n.config.ReplyAddress, domain.SignReplyToken(n.config.ReplySecret, ticketID))
+	} else {
+		msg.ReplyTo = n.config.ReplyAddress
+	}
+
+	record := domain.NewEmailMessage(msg.MessageID, ticketID, domain.EmailDirectionOutbound, msg.To, msg.Subject)
+	record.CommentID = commentID
+	if err := n.messages.Create(ctx, record); err != nil {
+		return fmt.Errorf("failed to record email: %w", err)
+	}
+
+	return n.enqueue(msg)
+}
+
+// threadReferences returns the References of a new message in a conversation, oldest first: the
+// thread root followed by the most recent messages
+func threadReferences(root string, conversation []*domain.EmailMessage, messageID string) []string {
+	if messageID == root {
+		return nil
+	}
+
+	refs := []string{root}
+	var earlier []string
+	for _, m := range conversation {
+		if m.MessageID != root && m.MessageID != messageID {
+			earlier = append(earlier, m.MessageID)
+		}
+	}
+	if len(earlier) > maxReferences-1 {
+		earlier = earlier[len(earlier)-(maxReferences-1):]
+	}
+	return append(refs, earlier...)
+}
+
+func (n *Notifier) newEmail(rendered *RenderedEmail, recipient, messageID string) *OutgoingEmail {
+	msg := &OutgoingEmail{
+		MessageID: messageID,
+		From:      mail.Address{Name: n.config.Email.FromName, Address: n.config.Email.FromEmail},
+		To:        recipient,
+		Subject:   rendered.Subject,
+		Text:      rendered.Text,
+		Date:      time.Now(),
+	}
+	if n.config.Email.UseHTML {
+		msg.HTML = rendered.HTML
+	}
+	return msg
+}
+
+func (n *Notifier) enqueue(msg *OutgoingEmail) error {
+	select {
+	case n.queue <- msg:
+		return nil
+	default:
+		return fmt.Errorf("email queue is full, dropping %s", msg.MessageID)
+	}
+}
+
+// rootMessageID is the Message-ID of a ticket's first email, which all later emails reference
+func (n *Notifier) rootMessageID(ticketID string) string {
+	return n.formatMessageID("ticket", ticketID)
+}
+
+func (n *Notifier) newMessageID(kind string) string {
+	return n.formatMessageID(kind, fmt.Sprintf("%d", time.Now().UnixNano()))
+}
+
+func (n *Notifier) formatMessageID(kind, id string) string {
+	return "<" + kind + "." + id + "@" + n.domain + ">"
+}
+
+// emailAddress returns the address when a user ID is an email address
+func emailAddress(userID string) (string, bool) {
+	addr, err := mail.ParseAddress(userID)
+	if err != nil || !strings.Contains(addr.Address, "@") {
+		return "", false
+	}
+	return strings.ToLower(addr.Address), true
+}
@@ -0,0 +1,203 @@
+package email
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// memoryEmailMessages is an in-memory EmailMessageRepository
+type memoryEmailMessages struct {
+	mu       sync.Mutex
+	messages []*domain.EmailMessage
+}
+
+func (r *memoryEmailMessages) Create(ctx context.Context, message *domain.EmailMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, m := range r.messages {
+		if m.MessageID == message.MessageID {
+			return nil
+		}
+	}
+	r.messages = append(r.messages, message)
+	return nil
+}
+
+func (r *memoryEmailMessages) FindByMessageID(ctx context.Context, messageID string) (*domain.EmailMessage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, m := range r.messages {
+		if m.MessageID == messageID {
+			return m, nil
+		}
+	}
+	return nil, domain.ErrEmailMessageNotFound
+}
+
+func (r *memoryEmailMessages) ListByTicket(ctx context.Context, ticketID string) ([]*domain.EmailMessage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var messages []*domain.EmailMessage
+	for _, m := range r.messages {
+		if m.TicketID == ticketID {
+			messages = append(messages, m)
+		}
+	}
+	return messages, nil
+}
+
+// channelSender hands sent emails to the test
+type channelSender chan *OutgoingEmail
+
+func (s channelSender) Send(ctx context.Context, email *OutgoingEmail) error {
+	s <- email
+	return nil
+}
+
+func (s channelSender) next(t *testing.T) *OutgoingEmail {
+	t.Helper()
+
+	select {
+	case email := <-s:
+		return email
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected an email to be sent")
+		return nil
+	}
+}
+
+func newTestNotifier(t *testing.T) (*Notifier, channelSender, *memoryEmailMessages) {
+	t.Helper()
+
+	sender := make(channelSender, 10)
+	messages := &memoryEmailMessages{}
+	notifier := NewNotifier(sender, messages, loadTemplates(t), NotifierConfig{
+		Email:       ports.EmailConfig{FromEmail: "helpdesk@example.com", FromName: "Fixora Helpdesk", UseHTML: true},
+		ReplySecret: []byte("secret"),
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	notifier.Start(ctx)
+
+	return notifier, sender, messages
+}
+
+func TestNotifier_ThreadsTicketConversation(t *testing.T) {
+	notifier, sender, messages := newTestNotifier(t)
+	ctx := context.Background()
+	ticket := &domain.Ticket{ID: "ticket_20240304100000", Title: "VPN down", CreatedBy: "jane.doe@example.com"}
+
+	if err := notifier.NotifyTicketCreated(ctx, ticket); err != nil {
+		t.Fatalf("Failed to notify: %v", err)
+	}
+	created := sender.next(t)
+	if created.MessageID != "<ticket.ticket_20240304100000@example.com>" || created.InReplyTo != "" {
+		t.Errorf("Expected the acknowledgement to start the thread, got %s in reply to %q", created.MessageID, created.InReplyTo)
+	}
+
+	// The requester replies by email
+	reply := domain.NewEmailMessage("<reply-1@mail.example.com>", ticket.ID, domain.EmailDirectionInbound, "jane.doe@example.com", "Re: VPN down")
+	messages.Create(ctx, reply)
+
+	comment := domain.NewComment(ticket.ID, "agent-1", domain.CommentRoleAdmin, "Please restart the laptop.")
+	if err := notifier.NotifyCommentAdded(ctx, comment, ticket); err != nil {
+		t.Fatalf("Failed to notify: %v", err)
+	}
+	sent := sender.next(t)
+
+	if sent.MessageID != "<comment."+comment.ID+"@example.com>" {
+		t.Errorf("Expected a Message-ID derived from the comment, got %s", sent.MessageID)
+	}
+	if sent.InReplyTo != "<reply-1@mail.example.com>" {
+		t.Errorf("Expected a reply to the requester's email, got %s", sent.InReplyTo)
+	}
+	if strings.Join(sent.References, " ") != "<ticket.ticket_20240304100000@example.com> <reply-1@mail.example.com>" {
+		t.Errorf("Unexpected references %v", sent.References)
+	}
+	if !strings.HasPrefix(sent.Subject, domain.TicketSubjectTag(ticket.ID)) {
+		t.Errorf("Expected the ticket tag in the subject, got %q", sent.Subject)
+	}
+	if !strings.Contains(sent.Text, "Please restart the laptop.") || sent.HTML == "" {
+		t.Errorf("Expected the comment in the text and HTML parts, got %q", sent.Text)
+	}
+
+	token, ok := domain.ReplyTokenFromAddress(sent.ReplyTo)
+	if !ok {
+		t.Fatalf("Expected a reply token in %s", sent.ReplyTo)
+	}
+	if ticketID, err := domain.ParseReplyToken([]byte("secret"), token); err != nil || ticketID != ticket.ID {
+		t.Errorf("Expected a reply token for %s, got %q (%v)", ticket.ID, ticketID, err)
+	}
+
+	recorded, err := messages.FindByMessageID(ctx, sent.MessageID)
+	if err != nil {
+		t.Fatalf("Expected the comment email to be recorded: %v", err)
+	}
+	if recorded.CommentID == nil || *recorded.CommentID != comment.ID || recorded.Direction != domain.EmailDirectionOutbound {
+		t.Errorf("Unexpected record %+v", recorded)
+	}
+}
+
+func TestNotifier_SkipsNonEmailRecipients(t *testing.T) {
+	notifier, sender, _ := newTestNotifier(t)
+	ctx := context.Background()
+
+	ticket := &domain.Ticket{ID: "ticket_1", Title: "VPN down", CreatedBy: "user-123"}
+	if err := notifier.NotifyTicketCreated(ctx, ticket); err != nil {
+		t.Fatalf("Failed to notify: %v", err)
+	}
+
+	ticket.CreatedBy = "jane.doe@example.com"
+	requesterComment := domain.NewComment(ticket.ID, "jane.doe@example.com", domain.CommentRoleEmployee, "Still broken")
+	if err := notifier.NotifyCommentAdded(ctx, requesterComment, ticket); err != nil {
+		t.Fatalf("Failed to notify: %v", err)
+	}
+
+	select {
+	case email := <-sender:
+		t.Errorf("Expected no email, got %s to %s", email.MessageID, email.To)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	if err := notifier.ValidateRecipient(ctx, "user-123"); err == nil {
+		t.Error("Expected a user ID to be rejected as a recipient")
+	}
+}
+
+func TestNotifier_CustomNotificationLanguage(t *testing.T) {
+	notifier, sender, _ := newTestNotifier(t)
+
+	notification := ports.NewNotification(ports.NotificationTypeSystemMaintenance, "jane.doe@example.com", "Email maintenance", "Email is down tonight", ports.NotificationPriorityMedium, nil)
+	notification.AddData("language", LanguageIndonesian)
+	notification.AddData("ticket_id", "ticket_1")
+
+	if err := notifier.SendCustomNotification(context.Background(), notification); err != nil {
+		t.Fatalf("Failed to notify: %v", err)
+	}
+	sent := sender.next(t)
+
+	rendered, err := notifier.templates.Render(ports.NotificationTypeSystemMaintenance, LanguageIndonesian, TemplateData{
+		Notification: notification,
+		SenderName:   "Fixora Helpdesk",
+		Replyable:    true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to render: %v", err)
+	}
+	if sent.Text != rendered.Text {
+		t.Errorf("Expected the Indonesian text, got %q", sent.Text)
+	}
+	if sent.InReplyTo != "<ticket.ticket_1@example.com>" {
+		t.Errorf("Expected the notification in the ticket's thread, got %q", sent.InReplyTo)
+	}
+}
@@ -0,0 +1,216 @@
+package email
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	"fixora/internal/domain"
+)
+
+// errMalformedMessage marks email that can never be ingested, as opposed to a temporary failure
+var errMalformedMessage = errors.New("malformed email")
+
+var (
+	messageIDPattern = regexp.MustCompile(`<[^<>\s]+>`)
+	headerDecoder    = &mime.WordDecoder{CharsetReader: charsetReader}
+)
+
+// ParseMessage parses an RFC 5322 message into what ticket intake needs. The body is the
+// text/plain part, or the text of the text/html part when there is no plain part.
+func ParseMessage(r io.Reader) (*domain.InboundEmail, error) {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errMalformedMessage, err)
+	}
+
+	from, err := msg.Header.AddressList("From")
+	if err != nil || len(from) == 0 {
+		return nil, fmt.Errorf("%w: invalid From header", errMalformedMessage)
+	}
+
+	email := &domain.InboundEmail{
+		MessageID:     firstMessageID(msg.Header.Get("Message-ID")),
+		InReplyTo:     firstMessageID(msg.Header.Get("In-Reply-To")),
+		References:    messageIDPattern.FindAllString(msg.Header.Get("References"), -1),
+		From:          strings.ToLower(from[0].Address),
+		FromName:      from[0].Name,
+		Subject:       decodeHeader(msg.Header.Get("Subject")),
+		AutoSubmitted: isAutoSubmitted(msg.Header),
+		ReceivedAt:    time.Now(),
+	}
+
+	if to, err := msg.Header.AddressList("To"); err == nil {
+		for _, addr := range to {
+			email.To = append(email.To, strings.ToLower(addr.Address))
+		}
+	}
+
+	plain, htmlText, err := readBody(msg.Header.Get("Content-Type"), msg.Header.Get("Content-Transfer-Encoding"), msg.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errMalformedMessage, err)
+	}
+	if plain != "" {
+		email.Body = plain
+	} else {
+		email.Body = htmlToText(htmlText)
+	}
+
+	return email, nil
+}
+
+// readBody returns the first text/plain and text/html parts of a message body
+func readBody(contentType, transferEncoding string, body io.Reader) (plain, htmlText string, err error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		// Missing or broken Content-Type means plain text
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextRawPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return "", "", err
+			}
+
+			if disposition, _, _ := mime.ParseMediaType(part.Header.Get("Content-Disposition")); disposition == "attachment" {
+				continue
+			}
+
+			partPlain, partHTML, err := readBody(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"), part)
+			if err != nil {
+				return "", "", err
+			}
+			if plain == "" {
+				plain = partPlain
+			}
+			if htmlText == "" {
+				htmlText = partHTML
+			}
+		}
+		return plain, htmlText, nil
+	}
+
+	if mediaType != "text/plain" && mediaType != "text/html" {
+		return "", "", nil
+	}
+
+	content, err := io.ReadAll(decodeTransfer(body, transferEncoding))
+	if err != nil {
+		return "", "", err
+	}
+	text := decodeCharset(content, params["charset"])
+
+	if mediaType == "text/html" {
+		return "", text, nil
+	}
+	return text, "", nil
+}
+
+func decodeTransfer(body io.Reader, encoding string) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, body)
+	}
+	return body
+}
+
+// decodeCharset converts text to UTF-8. Latin-1 and Windows-1252 are converted; other charsets
+// are assumed to be UTF-8 compatible.
+func decodeCharset(content []byte, charset string) string {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "latin1", "windows-1252", "cp1252":
+		runes := make([]rune, len(content))
+		for i, b := range content {
+			runes[i] = rune(b)
+		}
+		return string(runes)
+	}
+	return strings.ToValidUTF8(string(content), "�")
+}
+
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	content, err := io.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+	return strings.NewReader(decodeCharset(content, charset)), nil
+}
+
+func decodeHeader(value string) string {
+	decoded, err := headerDecoder.DecodeHeader(value)
+	if err != nil {
+		return strings.TrimSpace(value)
+	}
+	return strings.TrimSpace(decoded)
+}
+
+func firstMessageID(value string) string {
+	return messageIDPattern.FindString(value)
+}
+
+// isAutoSubmitted reports whether the message was sent by software, e.g. an out-of-office reply
+// or a mailing list, rather than typed by a person (RFC 3834)
+func isAutoSubmitted(header mail.Header) bool {
+	if auto := strings.ToLower(strings.TrimSpace(header.Get("Auto-Submitted"))); auto != "" && auto != "no" {
+		return true
+	}
+	switch strings.ToLower(strings.TrimSpace(header.Get("Precedence"))) {
+	case "bulk", "junk", "list", "auto_reply":
+		return true
+	}
+	return header.Get("X-Autoreply") != "" || header.Get("X-Autorespond") != ""
+}
+
+var (
+	htmlDropBlocks = regexp.MustCompile(`(?is)<(style|script|head|blockquote)\b.*?</(style|script|head|blockquote)>`)
+	htmlLineBreaks = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|li|tr|h[1-6])>`)
+	htmlTags       = regexp.MustCompile(`(?s)<[^>]*>`)
+	blankLines     = regexp.MustCompile(`\n{3,}`)
+)
+
+// htmlToText reduces an HTML body to its text. Quoted replies, which mail clients put in
+// blockquotes, are dropped.
+func htmlToText(body string) string {
+	body = htmlDropBlocks.ReplaceAllString(body, "")
+	body = htmlLineBreaks.ReplaceAllString(body, "\n")
+	body = htmlTags.ReplaceAllString(body, "")
+	body = html.UnescapeString(body)
+
+	var lines []string
+	for _, line := range strings.Split(body, "\n") {
+		lines = append(lines, strings.TrimSpace(line))
+	}
+	body = blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.TrimSpace(body)
+}
+
+// readLimited reads a whole message, failing when it is larger than max bytes
+func readLimited(r io.Reader, max int64) ([]byte, error) {
+	var buf bytes.Buffer
+	n, err := io.Copy(&buf, io.LimitReader(r, max+1))
+	if err != nil {
+		return nil, err
+	}
+	if n > max {
+		return nil, fmt.Errorf("%w: larger than %d bytes", errMalformedMessage, max)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,90 @@
+package email
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"fixora/internal/domain"
+)
+
+func parseFile(t *testing.T, path string) *domain.InboundEmail {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open %s: %v", path, err)
+	}
+	defer file.Close()
+
+	email, err := ParseMessage(file)
+	if err != nil {
+		t.Fatalf("Failed to parse %s: %v", path, err)
+	}
+	return email
+}
+
+func TestParseMessage_MultipartReply(t *testing.T) {
+	email := parseFile(t, "testdata/reply_multipart.eml")
+
+	if email.From != "jane.doe@example.com" || email.FromName != "Jane Doe" {
+		t.Errorf("Unexpected sender %q <%s>", email.FromName, email.From)
+	}
+	if email.Subject != "VPN tidak bisa terhubung" {
+		t.Errorf("Expected the decoded subject, got %q", email.Subject)
+	}
+	if email.MessageID != "<reply-1@mail.example.com>" || email.InReplyTo != "<notify-2@fixora.local>" {
+		t.Errorf("Unexpected message IDs %s / %s", email.MessageID, email.InReplyTo)
+	}
+	if want := []string{"<notify-1@fixora.local>", "<notify-2@fixora.local>"}; !reflect.DeepEqual(email.References, want) {
+		t.Errorf("Expected references %v, got %v", want, email.References)
+	}
+	if !strings.HasPrefix(email.Body, "Masih tidak bisa terhubung setelah restart — tolong dibantu.") {
+		t.Errorf("Expected the decoded plain text part, got %q", email.Body)
+	}
+	if !strings.Contains(email.Body, "> Silakan restart") {
+		t.Error("Expected the quoted reply to be kept for the use case to strip")
+	}
+	if email.AutoSubmitted {
+		t.Error("Expected a reply typed by a person")
+	}
+}
+
+func TestParseMessage_HTMLOnly(t *testing.T) {
+	email := parseFile(t, "testdata/html_only.eml")
+
+	want := "The printer on floor 3 is jammed & blinking.\nCafé side."
+	if email.Body != want {
+		t.Errorf("Expected %q, got %q", want, email.Body)
+	}
+}
+
+func TestParseMessage_AutoReply(t *testing.T) {
+	raw := "From: jane@example.com\r\nSubject: Out of office\r\nAuto-Submitted: auto-replied\r\n\r\nI am away.\r\n"
+
+	email, err := ParseMessage(strings.NewReader(raw))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !email.AutoSubmitted {
+		t.Error("Expected an auto-reply to be marked auto-submitted")
+	}
+	if email.Body != "I am away.\r\n" {
+		t.Errorf("Unexpected body %q", email.Body)
+	}
+}
+
+func TestParseMessage_Malformed(t *testing.T) {
+	for name, raw := range map[string]string{
+		"no headers": "just some text",
+		"no sender":  "Subject: Hello\r\n\r\nBody\r\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseMessage(strings.NewReader(raw)); !errors.Is(err, errMalformedMessage) {
+				t.Errorf("Expected a malformed message error, got %v", err)
+			}
+		})
+	}
+}
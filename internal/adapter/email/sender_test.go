@@ -0,0 +1,108 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/mail"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"fixora/internal/ports"
+)
+
+func testEmail() *OutgoingEmail {
+	return &OutgoingEmail{
+		MessageID:  "<comment.comment_1@example.com>",
+		InReplyTo:  "<reply-1@mail.example.com>",
+		References: []string{"<ticket.ticket_1@example.com>", "<reply-1@mail.example.com>"},
+		From:       mail.Address{Name: "Fixora Helpdesk", Address: "helpdesk@example.com"},
+		ReplyTo:    "helpdesk+ticket_1.0123456789abcdef@example.com",
+		To:         "jane.doe@example.com",
+		Subject:    "[#ticket_1] Laptop tidak menyala",
+		Text:       "Please restart the laptop.\n\n-- \nFixora Helpdesk\n",
+		HTML:       "<p>Please restart the laptop.</p>",
+		Date:       time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestOutgoingEmail_Bytes(t *testing.T) {
+	raw, err := testEmail().Bytes()
+	if err != nil {
+		t.Fatalf("Failed to build email: %v", err)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("Failed to read email: %v", err)
+	}
+
+	headers := map[string]string{
+		"Message-ID":     "<comment.comment_1@example.com>",
+		"In-Reply-To":    "<reply-1@mail.example.com>",
+		"References":     "<ticket.ticket_1@example.com> <reply-1@mail.example.com>",
+		"Reply-To":       "helpdesk+ticket_1.0123456789abcdef@example.com",
+		"Auto-Submitted": "auto-generated",
+	}
+	for name, want := range headers {
+		if got := msg.Header.Get(name); got != want {
+			t.Errorf("Expected %s %q, got %q", name, want, got)
+		}
+	}
+	if !strings.HasPrefix(msg.Header.Get("Content-Type"), "multipart/alternative;") {
+		t.Errorf("Expected a multipart/alternative email, got %s", msg.Header.Get("Content-Type"))
+	}
+}
+
+func TestOutgoingEmail_BytesTextOnly(t *testing.T) {
+	email := testEmail()
+	email.HTML = ""
+
+	raw, err := email.Bytes()
+	if err != nil {
+		t.Fatalf("Failed to build email: %v", err)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("Failed to read email: %v", err)
+	}
+	if !strings.HasPrefix(msg.Header.Get("Content-Type"), "text/plain") {
+		t.Errorf("Expected a text email, got %s", msg.Header.Get("Content-Type"))
+	}
+}
+
+func TestSMTPSender_RoundTrip(t *testing.T) {
+	handler := &recordingHandler{}
+	host, port, _ := net.SplitHostPort(startSMTPServer(t, handler, 0))
+	portNumber, _ := strconv.Atoi(port)
+
+	sender := NewSMTPSender(ports.EmailConfig{SMTPHost: host, SMTPPort: portNumber})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := sender.Send(ctx, testEmail()); err != nil {
+		t.Fatalf("Failed to send email: %v", err)
+	}
+
+	emails := handler.received()
+	if len(emails) != 1 {
+		t.Fatalf("Expected 1 email, got %d", len(emails))
+	}
+
+	received := emails[0]
+	if received.MessageID != "<comment.comment_1@example.com>" || received.InReplyTo != "<reply-1@mail.example.com>" {
+		t.Errorf("Expected the threading headers to survive, got %s in reply to %s", received.MessageID, received.InReplyTo)
+	}
+	if received.Subject != "[#ticket_1] Laptop tidak menyala" {
+		t.Errorf("Unexpected subject %q", received.Subject)
+	}
+	if !strings.HasPrefix(received.Body, "Please restart the laptop.") {
+		t.Errorf("Unexpected body %q", received.Body)
+	}
+	if !received.AutoSubmitted {
+		t.Error("Expected notifications to be marked auto-submitted")
+	}
+}
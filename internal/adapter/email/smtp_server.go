@@ -0,0 +1,273 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"time"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// SMTPConfig configures the inbound SMTP listener
+type SMTPConfig struct {
+	Addr            string        // address to listen on, e.g. 127.0.0.1:2525
+	Hostname        string        // name announced in the greeting
+	MaxMessageBytes int64         // larger messages are rejected
+	Timeout         time.Duration // idle time before a connection is dropped
+}
+
+// SMTPServer receives email over SMTP and hands each message to the inbound email handler. It
+// speaks just enough SMTP for a mail server to relay to it, so it should listen on a local or
+// private address behind the mail server, which handles TLS, authentication and spam filtering.
+type SMTPServer struct {
+	handler  ports.InboundEmailHandler
+	config   SMTPConfig
+	mu       sync.Mutex
+	listener net.Listener
+	wg       sync.WaitGroup
+}
+
+// NewSMTPServer creates a new inbound SMTP server
+func NewSMTPServer(handler ports.InboundEmailHandler, config SMTPConfig) *SMTPServer {
+	if config.Hostname == "" {
+		config.Hostname = "localhost"
+	}
+	if config.MaxMessageBytes <= 0 {
+		config.MaxMessageBytes = 10 << 20
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 5 * time.Minute
+	}
+
+	return &SMTPServer{
+		handler: handler,
+		config:  config,
+	}
+}
+
+// Start listens for connections until the context is cancelled or Close is called
+func (s *SMTPServer) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.config.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen for SMTP: %w", err)
+	}
+
+	s.mu.Lock()
+	s.listener = listener
+	s.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				if !errors.Is(err, net.ErrClosed) {
+					log.Printf("SMTP listener stopped: %v", err)
+				}
+				return
+			}
+
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				s.serve(ctx, conn)
+			}()
+		}
+	}()
+
+	return nil
+}
+
+// Addr returns the address the server listens on, or nil before Start
+func (s *SMTPServer) Addr() net.Addr {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
+}
+
+// Close stops accepting connections and waits for open sessions to finish
+func (s *SMTPServer) Close() error {
+	s.mu.Lock()
+	listener := s.listener
+	s.mu.Unlock()
+
+	var err error
+	if listener != nil {
+		err = listener.Close()
+		if errors.Is(err, net.ErrClosed) {
+			err = nil
+		}
+	}
+	s.wg.Wait()
+	return err
+}
+
+// smtpSession is the state of one SMTP conversation
+type smtpSession struct {
+	conn       net.Conn
+	text       *textproto.Conn
+	sender     string
+	recipients []string
+}
+
+func (s *SMTPServer) serve(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+
+	session := &smtpSession{
+		conn: conn,
+		text: textproto.NewConn(conn),
+	}
+
+	session.reply(s.config.Timeout, 220, s.config.Hostname+" ESMTP ready")
+
+	for {
+		conn.SetReadDeadline(time.Now().Add(s.config.Timeout))
+		line, err := session.text.ReadLine()
+		if err != nil {
+			return
+		}
+
+		verb, arg, _ := strings.Cut(strings.TrimSpace(line), " ")
+		switch strings.ToUpper(verb) {
+		case "HELO":
+			session.reset()
+			session.reply(s.config.Timeout, 250, s.config.Hostname)
+		case "EHLO":
+			session.reset()
+			session.reply(s.config.Timeout, 250,
+				s.config.Hostname,
+				fmt.Sprintf("SIZE %d", s.config.MaxMessageBytes),
+				"8BITMIME",
+			)
+		case "MAIL":
+			address, ok := pathArgument(arg, "FROM:")
+			if !ok {
+				session.reply(s.config.Timeout, 501, "Syntax: MAIL FROM:<address>")
+				continue
+			}
+			session.reset()
+			session.sender = address
+			session.reply(s.config.Timeout, 250, "OK")
+		case "RCPT":
+			address, ok := pathArgument(arg, "TO:")
+			if !ok || address == "" {
+				session.reply(s.config.Timeout, 501, "Syntax: RCPT TO:<address>")
+				continue
+			}
+			session.recipients = append(session.recipients, address)
+			session.reply(s.config.Timeout, 250, "OK")
+		case "DATA":
+			if len(session.recipients) == 0 {
+				session.reply(s.config.Timeout, 503, "RCPT first")
+				continue
+			}
+			session.reply(s.config.Timeout, 354, "End data with <CR><LF>.<CR><LF>")
+			code, message := s.receive(ctx, session)
+			session.reset()
+			session.reply(s.config.Timeout, code, message)
+		case "RSET":
+			session.reset()
+			session.reply(s.config.Timeout, 250, "OK")
+		case "NOOP":
+			session.reply(s.config.Timeout, 250, "OK")
+		case "VRFY":
+			session.reply(s.config.Timeout, 252, "Cannot verify user")
+		case "QUIT":
+			session.reply(s.config.Timeout, 221, "Bye")
+			return
+		default:
+			session.reply(s.config.Timeout, 502, "Command not implemented")
+		}
+	}
+}
+
+// receive reads a message after DATA and hands it to the handler, returning the reply to send
+func (s *SMTPServer) receive(ctx context.Context, session *smtpSession) (int, string) {
+	session.conn.SetReadDeadline(time.Now().Add(s.config.Timeout))
+
+	data := session.text.DotReader()
+	raw, err := readLimited(data, s.config.MaxMessageBytes)
+	if err != nil {
+		io.Copy(io.Discard, data)
+		if errors.Is(err, errMalformedMessage) {
+			return 552, "Message too large"
+		}
+		return 451, "Failed to read message"
+	}
+
+	if err := handleRaw(ctx, s.handler, raw); err != nil {
+		log.Printf("Failed to handle inbound email from %s: %v", session.sender, err)
+		if isPermanent(err) {
+			return 554, "Message rejected: " + err.Error()
+		}
+		return 451, "Temporary failure, try again later"
+	}
+	return 250, "OK: queued"
+}
+
+func (session *smtpSession) reset() {
+	session.sender = ""
+	session.recipients = nil
+}
+
+// reply writes a possibly multiline reply, e.g. the EHLO extension list
+func (session *smtpSession) reply(timeout time.Duration, code int, lines ...string) {
+	session.conn.SetWriteDeadline(time.Now().Add(timeout))
+	for i, line := range lines {
+		separator := " "
+		if i < len(lines)-1 {
+			separator = "-"
+		}
+		session.text.PrintfLine("%d%s%s", code, separator, line)
+	}
+}
+
+// pathArgument parses the address in "FROM:<address> [parameters]". The null sender <> is allowed.
+func pathArgument(arg, prefix string) (string, bool) {
+	if len(arg) < len(prefix) || !strings.EqualFold(arg[:len(prefix)], prefix) {
+		return "", false
+	}
+
+	path := strings.TrimSpace(arg[len(prefix):])
+	if !strings.HasPrefix(path, "<") {
+		return "", false
+	}
+	end := strings.Index(path, ">")
+	if end < 0 {
+		return "", false
+	}
+	return path[1:end], true
+}
+
+// handleRaw parses a raw message and hands it to the handler
+func handleRaw(ctx context.Context, handler ports.InboundEmailHandler, raw []byte) error {
+	email, err := ParseMessage(bytes.NewReader(raw))
+	if err != nil {
+		return err
+	}
+	return handler.HandleInboundEmail(ctx, email)
+}
+
+// isPermanent reports whether a message failed for good: it is malformed or the helpdesk rejects
+// it. Other failures, e.g. the database being down, are worth retrying.
+func isPermanent(err error) bool {
+	var domainErr *domain.DomainError
+	return errors.Is(err, errMalformedMessage) || errors.As(err, &domainErr)
+}
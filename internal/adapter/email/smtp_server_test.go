@@ -0,0 +1,137 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"net/smtp"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"fixora/internal/domain"
+)
+
+// recordingHandler records the emails it is given and fails with err when set
+type recordingHandler struct {
+	mu     sync.Mutex
+	emails []*domain.InboundEmail
+	err    error
+}
+
+func (h *recordingHandler) HandleInboundEmail(ctx context.Context, email *domain.InboundEmail) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.err != nil {
+		return h.err
+	}
+	h.emails = append(h.emails, email)
+	return nil
+}
+
+func (h *recordingHandler) received() []*domain.InboundEmail {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.emails
+}
+
+func startSMTPServer(t *testing.T, handler *recordingHandler, maxBytes int64) string {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	server := NewSMTPServer(handler, SMTPConfig{Addr: "127.0.0.1:0", MaxMessageBytes: maxBytes})
+	if err := server.Start(ctx); err != nil {
+		t.Fatalf("Failed to start SMTP server: %v", err)
+	}
+	t.Cleanup(func() {
+		cancel()
+		server.Close()
+	})
+
+	return server.Addr().String()
+}
+
+func TestSMTPServer_ReceivesMessage(t *testing.T) {
+	handler := &recordingHandler{}
+	addr := startSMTPServer(t, handler, 0)
+
+	raw, err := os.ReadFile("testdata/reply_multipart.eml")
+	if err != nil {
+		t.Fatalf("Failed to read message: %v", err)
+	}
+
+	if err := smtp.SendMail(addr, nil, "jane.doe@example.com", []string{"helpdesk@example.com"}, raw); err != nil {
+		t.Fatalf("Failed to send mail: %v", err)
+	}
+
+	emails := handler.received()
+	if len(emails) != 1 {
+		t.Fatalf("Expected 1 email, got %d", len(emails))
+	}
+	if emails[0].MessageID != "<reply-1@mail.example.com>" {
+		t.Errorf("Unexpected message ID %s", emails[0].MessageID)
+	}
+}
+
+func TestSMTPServer_DotStuffing(t *testing.T) {
+	handler := &recordingHandler{}
+	addr := startSMTPServer(t, handler, 0)
+
+	raw := "From: bob@example.com\r\nSubject: Dots\r\n\r\nLine one\r\n.hidden file is missing\r\n"
+	if err := smtp.SendMail(addr, nil, "bob@example.com", []string{"helpdesk@example.com"}, []byte(raw)); err != nil {
+		t.Fatalf("Failed to send mail: %v", err)
+	}
+
+	emails := handler.received()
+	if len(emails) != 1 || !strings.Contains(emails[0].Body, "\n.hidden file is missing") {
+		t.Errorf("Expected the leading dot to survive, got %+v", emails)
+	}
+}
+
+func TestSMTPServer_Rejections(t *testing.T) {
+	tests := []struct {
+		name     string
+		handler  *recordingHandler
+		maxBytes int64
+		raw      string
+		code     string
+	}{
+		{
+			name:    "temporary failure",
+			handler: &recordingHandler{err: errors.New("database unavailable")},
+			raw:     "From: bob@example.com\r\nSubject: Hi\r\n\r\nHello there\r\n",
+			code:    "451",
+		},
+		{
+			name:    "rejected by the helpdesk",
+			handler: &recordingHandler{err: domain.ErrEmptyEmailSender},
+			raw:     "From: bob@example.com\r\nSubject: Hi\r\n\r\nHello there\r\n",
+			code:    "554",
+		},
+		{
+			name:    "malformed",
+			handler: &recordingHandler{},
+			raw:     "Subject: No sender\r\n\r\nHello there\r\n",
+			code:    "554",
+		},
+		{
+			name:     "too large",
+			handler:  &recordingHandler{},
+			maxBytes: 64,
+			raw:      "From: bob@example.com\r\nSubject: Hi\r\n\r\n" + strings.Repeat("x", 200) + "\r\n",
+			code:     "552",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := startSMTPServer(t, tt.handler, tt.maxBytes)
+
+			err := smtp.SendMail(addr, nil, "bob@example.com", []string{"helpdesk@example.com"}, []byte(tt.raw))
+			if err == nil || !strings.HasPrefix(err.Error(), tt.code) {
+				t.Errorf("Expected a %s reply, got %v", tt.code, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// Languages notifications are written in
+const (
+	LanguageEnglish    = "en"
+	LanguageIndonesian = "id"
+)
+
+// Languages lists the supported languages, the default first
+var Languages = []string{LanguageEnglish, LanguageIndonesian}
+
+//go:embed templates/*.tmpl
+var templateFiles embed.FS
+
+// TemplateData is what notification templates are rendered with. Only the fields the
+// notification type uses are set.
+type TemplateData struct {
+	Ticket       *domain.Ticket
+	Comment      *domain.Comment
+	Survey       *domain.CSATSurvey
+	AssigneeID   string
+	UpdateType   string
+	SLAType      string
+	Notification *ports.Notification // incident, maintenance and custom notifications
+	SenderName   string
+	Replyable    bool // replying to the email adds a comment to the ticket
+}
+
+// RenderedEmail is a notification rendered in one language
+type RenderedEmail struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Templates renders notifications from the subject, text and HTML templates for each notification
+// type, in English and Indonesian. A language's templates are in templates/<language>.tmpl, each
+// type defining "<type>.subject", "<type>.text" and "<type>.html".
+type Templates struct {
+	text map[string]*texttemplate.Template
+	html map[string]*htmltemplate.Template
+}
+
+// LoadTemplates parses the built-in notification templates
+func LoadTemplates() (*Templates, error) {
+	t := &Templates{
+		text: make(map[string]*texttemplate.Template),
+		html: make(map[string]*htmltemplate.Template),
+	}
+
+	for _, language := range Languages {
+		files := []string{"templates/layout.tmpl", "templates/" + language + ".tmpl"}
+
+		text, err := texttemplate.ParseFS(templateFiles, files...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s text templates: %w", language, err)
+		}
+		html, err := htmltemplate.ParseFS(templateFiles, files...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s HTML templates: %w", language, err)
+		}
+
+		t.text[language] = text
+		t.html[language] = html
+	}
+
+	return t, nil
+}
+
+// Render renders a notification in the given language, falling back to English for languages
+// without templates
+func (t *Templates) Render(ntype ports.NotificationType, language string, data TemplateData) (*RenderedEmail, error) {
+	if _, ok := t.text[language]; !ok {
+		language = LanguageEnglish
+	}
+	text, html := t.text[language], t.html[language]
+	name := string(ntype)
+
+	if text.Lookup(name+".subject") == nil {
+		return nil, fmt.Errorf("no %s email template for %s notifications", language, ntype)
+	}
+
+	var subject, body, footer, content bytes.Buffer
+	if err := text.ExecuteTemplate(&subject, name+".subject", data); err != nil {
+		return nil, fmt.Errorf("failed to render email subject: %w", err)
+	}
+	if err := text.ExecuteTemplate(&body, name+".text", data); err != nil {
+		return nil, fmt.Errorf("failed to render email text: %w", err)
+	}
+	if err := text.ExecuteTemplate(&footer, "footer", data); err != nil {
+		return nil, fmt.Errorf("failed to render email footer: %w", err)
+	}
+	if err := html.ExecuteTemplate(&content, name+".html", data); err != nil {
+		return nil, fmt.Errorf("failed to render email HTML: %w", err)
+	}
+
+	rendered := &RenderedEmail{
+		Subject: strings.Join(strings.Fields(subject.String()), " "),
+		Text:    strings.TrimSpace(body.String()) + "\n\n-- \n" + footer.String() + "\n",
+	}
+
+	var page bytes.Buffer
+	err := html.ExecuteTemplate(&page, "layout.html", map[string]interface{}{
+		"Language": language,
+		"Subject":  rendered.Subject,
+		"Content":  htmltemplate.HTML(content.String()),
+		"Footer":   footer.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render email layout: %w", err)
+	}
+	rendered.HTML = page.String()
+
+	return rendered, nil
+}
@@ -0,0 +1,97 @@
+package email
+
+import (
+	"strings"
+	"testing"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+func loadTemplates(t *testing.T) *Templates {
+	t.Helper()
+
+	templates, err := LoadTemplates()
+	if err != nil {
+		t.Fatalf("Failed to load templates: %v", err)
+	}
+	return templates
+}
+
+func TestTemplates_RenderAllTypes(t *testing.T) {
+	templates := loadTemplates(t)
+
+	ticket := domain.NewTicket("VPN down", "Cannot connect <since> this morning", domain.TicketCategoryNetwork, domain.TicketPriorityHigh, "jane.doe@example.com")
+	notification := ports.NewNotification(ports.NotificationTypeCustom, "jane.doe@example.com", "Planned outage", "Email is down tonight", ports.NotificationPriorityMedium, nil)
+	data := TemplateData{
+		Ticket:       ticket,
+		Comment:      domain.NewComment(ticket.ID, "agent-1", domain.CommentRoleAdmin, "Please restart the laptop."),
+		Survey:       &domain.CSATSurvey{},
+		AssigneeID:   "agent-1",
+		UpdateType:   "status",
+		SLAType:      "resolution",
+		Notification: notification,
+		SenderName:   "Fixora Helpdesk",
+		Replyable:    true,
+	}
+
+	types := []ports.NotificationType{
+		ports.NotificationTypeTicketCreated,
+		ports.NotificationTypeTicketAssigned,
+		ports.NotificationTypeTicketUpdated,
+		ports.NotificationTypeCommentAdded,
+		ports.NotificationTypeTicketResolved,
+		ports.NotificationTypeSLABreached,
+		ports.NotificationTypeIncidentUpdate,
+		ports.NotificationTypeSystemMaintenance,
+		ports.NotificationTypeCustom,
+	}
+
+	for _, language := range Languages {
+		for _, ntype := range types {
+			t.Run(language+"/"+string(ntype), func(t *testing.T) {
+				rendered, err := templates.Render(ntype, language, data)
+				if err != nil {
+					t.Fatalf("Failed to render: %v", err)
+				}
+				if rendered.Subject == "" || strings.Contains(rendered.Subject, "\n") {
+					t.Errorf("Expected a one-line subject, got %q", rendered.Subject)
+				}
+				if !strings.Contains(rendered.Text, "\n-- \n") {
+					t.Errorf("Expected a footer in the text, got %q", rendered.Text)
+				}
+				if !strings.Contains(rendered.HTML, `<html lang="`+language+`"`) {
+					t.Errorf("Expected an HTML page in %s, got %q", language, rendered.HTML)
+				}
+				if strings.Contains(rendered.HTML, "<since>") {
+					t.Error("Expected ticket content to be escaped in HTML")
+				}
+			})
+		}
+	}
+}
+
+func TestTemplates_Localized(t *testing.T) {
+	templates := loadTemplates(t)
+	data := TemplateData{SenderName: "Fixora Helpdesk", Replyable: true, Ticket: &domain.Ticket{ID: "ticket_1", Title: "VPN down"}}
+
+	en, err := templates.Render(ports.NotificationTypeTicketCreated, LanguageEnglish, data)
+	if err != nil {
+		t.Fatalf("Failed to render: %v", err)
+	}
+	id, err := templates.Render(ports.NotificationTypeTicketCreated, LanguageIndonesian, data)
+	if err != nil {
+		t.Fatalf("Failed to render: %v", err)
+	}
+	if en.Text == id.Text {
+		t.Error("Expected the Indonesian text to differ from the English text")
+	}
+
+	fallback, err := templates.Render(ports.NotificationTypeTicketCreated, "fr", data)
+	if err != nil {
+		t.Fatalf("Failed to render: %v", err)
+	}
+	if fallback.Text != en.Text {
+		t.Error("Expected unsupported languages to fall back to English")
+	}
+}
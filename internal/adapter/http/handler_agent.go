@@ -0,0 +1,208 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"fixora/internal/domain"
+	"fixora/internal/usecase"
+
+	"github.com/gorilla/mux"
+)
+
+// AgentHandler handles HTTP requests for the agent registry and ticket routing
+type AgentHandler struct {
+	assignmentUseCase *usecase.AssignmentUseCase
+}
+
+// NewAgentHandler creates a new agent handler
+func NewAgentHandler(assignmentUseCase *usecase.AssignmentUseCase) *AgentHandler {
+	return &AgentHandler{
+		assignmentUseCase: assignmentUseCase,
+	}
+}
+
+// RegisterRoutes registers agent and assignment routes
+func (h *AgentHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/api/v1/agents", h.CreateAgent).Methods("POST")
+	router.HandleFunc("/api/v1/agents", h.ListAgents).Methods("GET")
+	router.HandleFunc("/api/v1/agents/{id}", h.GetAgent).Methods("GET")
+	router.HandleFunc("/api/v1/agents/{id}", h.UpdateAgent).Methods("PUT")
+	router.HandleFunc("/api/v1/agents/{id}", h.DeleteAgent).Methods("DELETE")
+	router.HandleFunc("/api/v1/agents/{id}/availability", h.SetAvailability).Methods("PATCH")
+
+	router.HandleFunc("/api/v1/tickets/{id}/assignment/preview", h.PreviewAssignment).Methods("GET")
+	router.HandleFunc("/api/v1/tickets/{id}/auto-assign", h.AutoAssign).Methods("POST")
+}
+
+// CreateAgent handles registering an agent
+func (h *AgentHandler) CreateAgent(w http.ResponseWriter, r *http.Request) {
+	var req usecase.AgentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.assignmentUseCase.CreateAgent(r.Context(), req)
+	if err != nil {
+		writeAgentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+}
+
+// ListAgents handles listing agents
+func (h *AgentHandler) ListAgents(w http.ResponseWriter, r *http.Request) {
+	filter := domain.AgentFilter{}
+
+	if available := r.URL.Query().Get("available"); available != "" {
+		value, err := strconv.ParseBool(available)
+		if err != nil {
+			http.Error(w, "Invalid available filter", http.StatusBadRequest)
+			return
+		}
+		filter.Available = &value
+	}
+
+	agents, err := h.assignmentUseCase.ListAgents(r.Context(), filter)
+	if err != nil {
+		writeAgentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"agents": agents,
+		"total":  len(agents),
+	})
+}
+
+// GetAgent handles retrieving an agent
+func (h *AgentHandler) GetAgent(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, err := h.assignmentUseCase.GetAgent(r.Context(), vars["id"])
+	if err != nil {
+		writeAgentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// UpdateAgent handles replacing an agent's profile
+func (h *AgentHandler) UpdateAgent(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var req usecase.AgentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.assignmentUseCase.UpdateAgent(r.Context(), vars["id"], req)
+	if err != nil {
+		writeAgentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// DeleteAgent handles removing an agent
+func (h *AgentHandler) DeleteAgent(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	if err := h.assignmentUseCase.DeleteAgent(r.Context(), vars["id"]); err != nil {
+		writeAgentError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SetAvailability handles marking an agent as available or unavailable
+func (h *AgentHandler) SetAvailability(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var req struct {
+		Available *bool `json:"available"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Available == nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.assignmentUseCase.SetAgentAvailability(r.Context(), vars["id"], *req.Available)
+	if err != nil {
+		writeAgentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// PreviewAssignment handles explaining which agent a ticket would be routed to, without assigning it
+func (h *AgentHandler) PreviewAssignment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, err := h.assignmentUseCase.PreviewAssignment(r.Context(), vars["id"], r.URL.Query().Get("strategy"))
+	if err != nil {
+		writeAgentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// AutoAssign handles routing a ticket to the best agent and assigning it
+func (h *AgentHandler) AutoAssign(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, err := h.assignmentUseCase.AutoAssign(r.Context(), vars["id"], r.URL.Query().Get("strategy"))
+	if errors.Is(err, domain.ErrNoEligibleAgent) {
+		// Explain why no agent could take the ticket
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+	if err != nil {
+		writeAgentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func writeAgentError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrAgentNotFound):
+		http.Error(w, "Agent not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrTicketNotFound):
+		http.Error(w, "Ticket not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrAgentExists),
+		errors.Is(err, domain.ErrInvalidAssignment),
+		errors.Is(err, domain.ErrAssignmentChanged),
+		errors.Is(err, domain.ErrTicketClosed):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, domain.ErrEmptyAgentID),
+		errors.Is(err, domain.ErrEmptyAgentName),
+		errors.Is(err, domain.ErrInvalidAgentCapacity),
+		errors.Is(err, domain.ErrInvalidAgentSkill),
+		errors.Is(err, domain.ErrUnknownAssignmentStrategy):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -4,6 +4,7 @@ import (
     "encoding/json"
     "fmt"
     "net/http"
+    "strconv"
     "time"
 
     "fixora/internal/domain"
@@ -33,6 +34,8 @@ func (h *AIHandler) RegisterRoutes(router *mux.Router) {
     router.HandleFunc("/api/v1/ai/analyze", h.AnalyzeTicketContent).Methods("POST")
     router.HandleFunc("/api/v1/ai/health", h.HealthCheck).Methods("GET")
     router.HandleFunc("/api/v1/ai/info", h.GetProviderInfo).Methods("GET")
+    router.HandleFunc("/api/v1/ai/classifier/retrain", h.RetrainClassifier).Methods("POST")
+    router.HandleFunc("/api/v1/ai/classifier/evaluation", h.EvaluateClassifier).Methods("GET")
     // AI-driven ticket intake
     router.HandleFunc("/api/v1/tickets/ai-intake", h.AIIntakeCreateTicket).Methods("POST")
 }
@@ -105,8 +108,10 @@ func (h *AIHandler) StreamSuggestion(w http.ResponseWriter, r *http.Request) {
 			flusher.Flush()
 		}
 
-		// Small delay to prevent overwhelming the client
-		time.Sleep(50 * time.Millisecond)
+		// Small delay to prevent overwhelming the client; tokens are forwarded as they arrive
+		if event.Type != "token" {
+			time.Sleep(50 * time.Millisecond)
+		}
 	}
 }
 
@@ -227,6 +232,10 @@ func (h *AIHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 		"timestamp": time.Now().Unix(),
 	}
 
+	if statuses := h.aiUseCase.GetProviderStatuses(); statuses != nil {
+		response["providers"] = statuses
+	}
+
 	if err != nil {
 		response["error"] = err.Error()
 		w.WriteHeader(http.StatusServiceUnavailable)
@@ -238,6 +247,42 @@ func (h *AIHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// RetrainClassifier rebuilds the local ticket classifier from all resolved tickets
+func (h *AIHandler) RetrainClassifier(w http.ResponseWriter, r *http.Request) {
+	trained, err := h.aiUseCase.RetrainClassifier(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"trained_tickets": trained,
+	})
+}
+
+// EvaluateClassifier reports the local classifier's accuracy on a held-out share of resolved tickets
+func (h *AIHandler) EvaluateClassifier(w http.ResponseWriter, r *http.Request) {
+	holdout := 20
+	if raw := r.URL.Query().Get("holdout"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			http.Error(w, "Invalid holdout parameter", http.StatusBadRequest)
+			return
+		}
+		holdout = value
+	}
+
+	evaluation, err := h.aiUseCase.EvaluateClassifier(r.Context(), holdout)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(evaluation)
+}
+
 // GetProviderInfo returns AI provider information
 func (h *AIHandler) GetProviderInfo(w http.ResponseWriter, r *http.Request) {
     info := h.aiUseCase.GetAIProviderInfo(r.Context())
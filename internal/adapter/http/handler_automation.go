@@ -0,0 +1,229 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"fixora/internal/domain"
+	"fixora/internal/usecase"
+
+	"github.com/gorilla/mux"
+)
+
+// AutomationHandler handles HTTP requests for automation rules and their execution log
+type AutomationHandler struct {
+	automationUseCase *usecase.AutomationUseCase
+}
+
+// NewAutomationHandler creates a new automation handler
+func NewAutomationHandler(automationUseCase *usecase.AutomationUseCase) *AutomationHandler {
+	return &AutomationHandler{
+		automationUseCase: automationUseCase,
+	}
+}
+
+// RegisterRoutes registers automation routes
+func (h *AutomationHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/api/v1/automation/rules", h.CreateRule).Methods("POST")
+	router.HandleFunc("/api/v1/automation/rules", h.ListRules).Methods("GET")
+	router.HandleFunc("/api/v1/automation/rules/order", h.ReorderRules).Methods("PUT")
+	router.HandleFunc("/api/v1/automation/rules/{id}", h.GetRule).Methods("GET")
+	router.HandleFunc("/api/v1/automation/rules/{id}", h.UpdateRule).Methods("PUT")
+	router.HandleFunc("/api/v1/automation/rules/{id}", h.DeleteRule).Methods("DELETE")
+
+	router.HandleFunc("/api/v1/automation/executions", h.ListExecutions).Methods("GET")
+	router.HandleFunc("/api/v1/automation/run-scheduled", h.RunScheduledRules).Methods("POST")
+}
+
+// CreateRule handles creating a rule
+func (h *AutomationHandler) CreateRule(w http.ResponseWriter, r *http.Request) {
+	var req usecase.AutomationRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	req.CreatedBy = requestUserID(r)
+
+	rule, err := h.automationUseCase.CreateRule(r.Context(), req)
+	if err != nil {
+		writeAutomationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(rule)
+}
+
+// ListRules handles listing rules in evaluation order
+func (h *AutomationHandler) ListRules(w http.ResponseWriter, r *http.Request) {
+	filter := domain.AutomationRuleFilter{}
+
+	if trigger := r.URL.Query().Get("trigger"); trigger != "" {
+		t := domain.AutomationTrigger(trigger)
+		filter.Trigger = &t
+	}
+
+	if enabledStr := r.URL.Query().Get("enabled"); enabledStr != "" {
+		if enabled, err := strconv.ParseBool(enabledStr); err == nil {
+			filter.Enabled = &enabled
+		}
+	}
+
+	rules, err := h.automationUseCase.ListRules(r.Context(), filter)
+	if err != nil {
+		writeAutomationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"rules": rules,
+		"total": len(rules),
+	})
+}
+
+// GetRule handles retrieving a rule
+func (h *AutomationHandler) GetRule(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	rule, err := h.automationUseCase.GetRule(r.Context(), vars["id"])
+	if err != nil {
+		writeAutomationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rule)
+}
+
+// UpdateRule handles replacing a rule's definition
+func (h *AutomationHandler) UpdateRule(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var req usecase.AutomationRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	rule, err := h.automationUseCase.UpdateRule(r.Context(), vars["id"], req)
+	if err != nil {
+		writeAutomationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rule)
+}
+
+// DeleteRule handles deleting a rule
+func (h *AutomationHandler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	if err := h.automationUseCase.DeleteRule(r.Context(), vars["id"]); err != nil {
+		writeAutomationError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ReorderRules handles setting the evaluation order of all rules
+func (h *AutomationHandler) ReorderRules(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		RuleIDs []string `json:"rule_ids"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	rules, err := h.automationUseCase.ReorderRules(r.Context(), req.RuleIDs)
+	if err != nil {
+		writeAutomationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"rules": rules,
+		"total": len(rules),
+	})
+}
+
+// ListExecutions handles listing the execution log
+func (h *AutomationHandler) ListExecutions(w http.ResponseWriter, r *http.Request) {
+	filter := domain.AutomationExecutionFilter{Limit: 50}
+
+	if ruleID := r.URL.Query().Get("rule_id"); ruleID != "" {
+		filter.RuleID = &ruleID
+	}
+
+	if ticketID := r.URL.Query().Get("ticket_id"); ticketID != "" {
+		filter.TicketID = &ticketID
+	}
+
+	if status := r.URL.Query().Get("status"); status != "" {
+		s := domain.AutomationExecutionStatus(status)
+		filter.Status = &s
+	}
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil {
+			filter.Limit = limit
+		}
+	}
+
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if offset, err := strconv.Atoi(offsetStr); err == nil {
+			filter.Offset = offset
+		}
+	}
+
+	executions, err := h.automationUseCase.ListExecutions(r.Context(), filter)
+	if err != nil {
+		writeAutomationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"executions": executions,
+		"limit":      filter.Limit,
+		"offset":     filter.Offset,
+	})
+}
+
+// RunScheduledRules handles evaluating scheduled rules now instead of waiting for the next run
+func (h *AutomationHandler) RunScheduledRules(w http.ResponseWriter, r *http.Request) {
+	runs, err := h.automationUseCase.RunScheduledRules(r.Context(), time.Now())
+	if err != nil {
+		writeAutomationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"runs": runs,
+	})
+}
+
+func writeAutomationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrAutomationRuleNotFound):
+		http.Error(w, "Automation rule not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrEmptyRuleName),
+		errors.Is(err, domain.ErrInvalidRuleTrigger),
+		errors.Is(err, domain.ErrInvalidRuleCondition),
+		errors.Is(err, domain.ErrInvalidRuleAction),
+		errors.Is(err, domain.ErrNoRuleActions),
+		errors.Is(err, domain.ErrInvalidRuleOrder):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,222 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"fixora/internal/domain"
+	"fixora/internal/usecase"
+
+	"github.com/gorilla/mux"
+)
+
+// CSATHandler handles requester satisfaction surveys. The /csat routes are opened from the
+// rating links in resolution notifications and are authorized by the signed token alone.
+type CSATHandler struct {
+	csatUseCase *usecase.CSATUseCase
+}
+
+// NewCSATHandler creates a new CSAT handler
+func NewCSATHandler(csatUseCase *usecase.CSATUseCase) *CSATHandler {
+	return &CSATHandler{
+		csatUseCase: csatUseCase,
+	}
+}
+
+// RegisterRoutes registers CSAT routes
+func (h *CSATHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/csat/{token}", h.ShowSurvey).Methods("GET")
+	router.HandleFunc("/csat/{token}", h.SubmitRating).Methods("POST")
+
+	router.HandleFunc("/api/v1/csat/ratings", h.ListRatings).Methods("GET")
+	router.HandleFunc("/api/v1/tickets/{id}/csat", h.GetTicketRating).Methods("GET")
+	router.HandleFunc("/api/v1/tickets/{id}/csat/survey", h.IssueSurvey).Methods("POST")
+}
+
+var csatPage = template.Must(template.New("csat").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>How did we do?</title></head>
+<body>
+<h1>How did we do?</h1>
+<p>{{.Survey.Title}}</p>
+{{if .Survey.Rating}}<p>Thanks! You rated this {{.Survey.Rating.Score}} out of 5.</p>{{end}}
+<form method="post">
+{{range .Scores}}<label><input type="radio" name="score" value="{{.}}"{{if eq . $.Selected}} checked{{end}}> {{.}}</label>
+{{end}}<p><textarea name="comment" rows="4" cols="50" placeholder="Anything you'd like to tell us?"></textarea></p>
+<button type="submit">Send</button>
+</form>
+</body>
+</html>
+`))
+
+// ShowSurvey handles opening a rating link. A score in the query string is only preselected on the
+// page: opening a link must not rate, since mail scanners and link previews open links too.
+func (h *CSATHandler) ShowSurvey(w http.ResponseWriter, r *http.Request) {
+	token := mux.Vars(r)["token"]
+
+	survey, err := h.csatUseCase.GetSurvey(r.Context(), token)
+	if err != nil {
+		writeCSATError(w, err)
+		return
+	}
+
+	selected := 0
+	if survey.Rating != nil {
+		selected = survey.Rating.Score
+	}
+	if scoreStr := r.URL.Query().Get("score"); scoreStr != "" {
+		score, err := strconv.Atoi(scoreStr)
+		if err != nil || score < domain.MinCSATScore || score > domain.MaxCSATScore {
+			http.Error(w, domain.ErrInvalidCSATScore.Error(), http.StatusBadRequest)
+			return
+		}
+		selected = score
+	}
+
+	if strings.Contains(r.Header.Get("Accept"), "application/json") {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(survey)
+		return
+	}
+
+	scores := make([]int, 0, domain.MaxCSATScore)
+	for score := domain.MinCSATScore; score <= domain.MaxCSATScore; score++ {
+		scores = append(scores, score)
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	csatPage.Execute(w, map[string]interface{}{
+		"Survey":   survey,
+		"Scores":   scores,
+		"Selected": selected,
+	})
+}
+
+// SubmitRating handles rating from the survey page (form) or an API client (JSON)
+func (h *CSATHandler) SubmitRating(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Score   int    `json:"score"`
+		Comment string `json:"comment"`
+	}
+
+	isForm := !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json")
+	if isForm {
+		score, err := strconv.Atoi(r.FormValue("score"))
+		if err != nil {
+			http.Error(w, domain.ErrInvalidCSATScore.Error(), http.StatusBadRequest)
+			return
+		}
+		req.Score = score
+		req.Comment = r.FormValue("comment")
+	} else if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	token := mux.Vars(r)["token"]
+	rating, err := h.csatUseCase.SubmitRating(r.Context(), token, req.Score, req.Comment)
+	if err != nil {
+		writeCSATError(w, err)
+		return
+	}
+
+	if isForm {
+		http.Redirect(w, r, r.URL.Path, http.StatusSeeOther)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rating)
+}
+
+// GetTicketRating handles getting a ticket's satisfaction rating
+func (h *CSATHandler) GetTicketRating(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	rating, err := h.csatUseCase.GetTicketRating(r.Context(), vars["id"])
+	if err != nil {
+		writeCSATError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rating)
+}
+
+// IssueSurvey handles issuing fresh rating links for a resolved ticket, e.g. after the first expired
+func (h *CSATHandler) IssueSurvey(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	survey, err := h.csatUseCase.IssueSurvey(r.Context(), vars["id"])
+	if err != nil {
+		writeCSATError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(survey)
+}
+
+// ListRatings handles listing ratings, optionally by date range, category and agent
+func (h *CSATHandler) ListRatings(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := domain.CSATFilter{
+		Category: strings.ToUpper(query.Get("category")),
+		AgentID:  query.Get("agent_id"),
+	}
+
+	if startStr := query.Get("start"); startStr != "" {
+		start, err := time.Parse(time.RFC3339, startStr)
+		if err != nil {
+			http.Error(w, "start must be an RFC 3339 timestamp", http.StatusBadRequest)
+			return
+		}
+		filter.StartDate = &start
+	}
+
+	if endStr := query.Get("end"); endStr != "" {
+		end, err := time.Parse(time.RFC3339, endStr)
+		if err != nil {
+			http.Error(w, "end must be an RFC 3339 timestamp", http.StatusBadRequest)
+			return
+		}
+		filter.EndDate = &end
+	}
+
+	ratings, err := h.csatUseCase.ListRatings(r.Context(), filter)
+	if err != nil {
+		writeCSATError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"ratings": ratings,
+		"total":   len(ratings),
+	})
+}
+
+func writeCSATError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrTicketNotFound):
+		http.Error(w, "Ticket not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrCSATRatingNotFound):
+		http.Error(w, "Satisfaction rating not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrInvalidCSATToken):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, domain.ErrCSATTokenExpired):
+		http.Error(w, err.Error(), http.StatusGone)
+	case errors.Is(err, domain.ErrTicketNotResolved):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, domain.ErrInvalidCSATScore):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,176 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"fixora/internal/domain"
+	"fixora/internal/usecase"
+
+	"github.com/gorilla/mux"
+)
+
+// DeflectionHandler handles HTTP requests for self-service deflection sessions
+type DeflectionHandler struct {
+	deflectionUseCase *usecase.DeflectionUseCase
+}
+
+// NewDeflectionHandler creates a new deflection handler
+func NewDeflectionHandler(deflectionUseCase *usecase.DeflectionUseCase) *DeflectionHandler {
+	return &DeflectionHandler{
+		deflectionUseCase: deflectionUseCase,
+	}
+}
+
+// RegisterRoutes registers deflection routes
+func (h *DeflectionHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/api/v1/deflection/sessions", h.StartSession).Methods("POST")
+	router.HandleFunc("/api/v1/deflection/sessions/{id}", h.GetSession).Methods("GET")
+	router.HandleFunc("/api/v1/deflection/sessions/{id}/stream", h.StreamSuggestions).Methods("GET")
+	router.HandleFunc("/api/v1/deflection/sessions/{id}/resolve", h.ResolveSession).Methods("POST")
+	router.HandleFunc("/api/v1/deflection/sessions/{id}/escalate", h.EscalateSession).Methods("POST")
+}
+
+// StartSession handles opening a deflection session for a problem description
+func (h *DeflectionHandler) StartSession(w http.ResponseWriter, r *http.Request) {
+	var req usecase.StartDeflectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	req.RequestedBy = requestUserID(r)
+
+	session, err := h.deflectionUseCase.StartSession(r.Context(), req)
+	if err != nil {
+		writeDeflectionError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(session)
+}
+
+// GetSession handles retrieving a deflection session
+func (h *DeflectionHandler) GetSession(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	session, err := h.deflectionUseCase.GetSession(r.Context(), vars["id"], requestUserID(r))
+	if err != nil {
+		writeDeflectionError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(session)
+}
+
+// StreamSuggestions handles streaming suggestions for a session's description (SSE). Events have
+// the same shape as /api/v1/ai/suggest/stream.
+func (h *DeflectionHandler) StreamSuggestions(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	eventChan, err := h.deflectionUseCase.StreamSuggestions(r.Context(), vars["id"], requestUserID(r))
+	if err != nil {
+		writeDeflectionError(w, err)
+		return
+	}
+
+	// Set SSE headers
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	flusher, _ := w.(http.Flusher)
+	if flusher != nil {
+		flusher.Flush()
+	}
+
+	for event := range eventChan {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return
+		}
+		if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+			// Client disconnected
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
+
+// ResolveSession handles "this fixed it": no ticket is created and a deflection is recorded
+func (h *DeflectionHandler) ResolveSession(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var req usecase.ResolveDeflectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	req.RequestedBy = requestUserID(r)
+
+	session, err := h.deflectionUseCase.ResolveSession(r.Context(), vars["id"], req)
+	if err != nil {
+		writeDeflectionError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(session)
+}
+
+// EscalateSession handles creating a ticket with the suggestion attached
+func (h *DeflectionHandler) EscalateSession(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var req usecase.EscalateDeflectionRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+	req.RequestedBy = requestUserID(r)
+
+	response, err := h.deflectionUseCase.EscalateSession(r.Context(), vars["id"], req)
+	if err != nil {
+		writeDeflectionError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+}
+
+// requestUserID returns the user from the X-User-ID header (in real implementation, from auth middleware)
+func requestUserID(r *http.Request) string {
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		userID = "default-user" // Fallback for development
+	}
+	return userID
+}
+
+func writeDeflectionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrDeflectionSessionNotFound):
+		http.Error(w, "Deflection session not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrNotSessionRequester):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, domain.ErrDeflectionSessionClosed),
+		errors.Is(err, domain.ErrNoDeflectionSuggestions):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, domain.ErrEmptyDeflectionDescription),
+		errors.Is(err, domain.ErrDeflectionCandidateNotFound),
+		errors.Is(err, domain.ErrEmptyAuthorID):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,145 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"fixora/internal/domain"
+	"fixora/internal/usecase"
+
+	"github.com/gorilla/mux"
+)
+
+// FeedbackHandler handles HTTP requests for AI suggestion feedback and metrics
+type FeedbackHandler struct {
+	feedbackUseCase *usecase.FeedbackUseCase
+}
+
+// NewFeedbackHandler creates a new feedback handler
+func NewFeedbackHandler(feedbackUseCase *usecase.FeedbackUseCase) *FeedbackHandler {
+	return &FeedbackHandler{
+		feedbackUseCase: feedbackUseCase,
+	}
+}
+
+// RegisterRoutes registers feedback and metrics routes
+func (h *FeedbackHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/api/v1/tickets/{id}/ai-feedback", h.SubmitTicketFeedback).Methods("POST")
+	router.HandleFunc("/api/v1/ai/feedback", h.SubmitCandidateFeedback).Methods("POST")
+	router.HandleFunc("/api/v1/metrics", h.GetMetrics).Methods("GET")
+}
+
+// SubmitTicketFeedback handles the requester's rating of a ticket's AI insight
+func (h *FeedbackHandler) SubmitTicketFeedback(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	ticketID := vars["id"]
+
+	if ticketID == "" {
+		http.Error(w, "Ticket ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var req usecase.SubmitTicketFeedbackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	req.SubmittedBy = requestUserID(r)
+
+	feedback, err := h.feedbackUseCase.SubmitTicketFeedback(r.Context(), ticketID, req)
+	if err != nil {
+		writeFeedbackError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(feedback)
+}
+
+// SubmitCandidateFeedback handles a rating of a streamed suggestion candidate
+func (h *FeedbackHandler) SubmitCandidateFeedback(w http.ResponseWriter, r *http.Request) {
+	var req usecase.SubmitCandidateFeedbackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	req.SubmittedBy = requestUserID(r)
+
+	feedback, err := h.feedbackUseCase.SubmitCandidateFeedback(r.Context(), req)
+	if err != nil {
+		writeFeedbackError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(feedback)
+}
+
+// GetMetrics handles ticket and AI accuracy metrics for a period or an explicit date range
+func (h *FeedbackHandler) GetMetrics(w http.ResponseWriter, r *http.Request) {
+	filter := domain.MetricFilter{
+		Period: domain.MetricPeriod(strings.ToLower(r.URL.Query().Get("period"))),
+	}
+
+	if category := r.URL.Query().Get("category"); category != "" {
+		c := strings.ToUpper(category)
+		filter.Category = &c
+	}
+
+	if startStr := r.URL.Query().Get("start"); startStr != "" {
+		start, err := time.Parse(time.RFC3339, startStr)
+		if err != nil {
+			http.Error(w, "start must be an RFC 3339 timestamp", http.StatusBadRequest)
+			return
+		}
+		filter.StartDate = &start
+	}
+
+	if endStr := r.URL.Query().Get("end"); endStr != "" {
+		end, err := time.Parse(time.RFC3339, endStr)
+		if err != nil {
+			http.Error(w, "end must be an RFC 3339 timestamp", http.StatusBadRequest)
+			return
+		}
+		filter.EndDate = &end
+	}
+
+	metric, err := h.feedbackUseCase.GetMetrics(r.Context(), filter)
+	if err != nil {
+		writeFeedbackError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(metric)
+}
+
+func writeFeedbackError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrTicketNotFound):
+		http.Error(w, "Ticket not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrCandidateNotFound):
+		http.Error(w, "Suggestion candidate not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrNotTicketRequester):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, domain.ErrNoAIInsight),
+		errors.Is(err, domain.ErrFeedbackAlreadyGiven):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, domain.ErrInvalidFeedbackRating),
+		errors.Is(err, domain.ErrInvalidFeedbackTarget),
+		errors.Is(err, domain.ErrFeedbackCommentTooLong),
+		errors.Is(err, domain.ErrEmptyAuthorID),
+		errors.Is(err, domain.ErrInvalidMetricPeriod),
+		errors.Is(err, domain.ErrInvalidDateRange):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,401 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"fixora/internal/domain"
+	"fixora/internal/usecase"
+
+	"github.com/gorilla/mux"
+)
+
+// IncidentHandler handles HTTP requests for incidents and problems
+type IncidentHandler struct {
+	incidentUseCase *usecase.IncidentUseCase
+	problemUseCase  *usecase.ProblemUseCase
+}
+
+// NewIncidentHandler creates a new incident handler
+func NewIncidentHandler(incidentUseCase *usecase.IncidentUseCase, problemUseCase *usecase.ProblemUseCase) *IncidentHandler {
+	return &IncidentHandler{
+		incidentUseCase: incidentUseCase,
+		problemUseCase:  problemUseCase,
+	}
+}
+
+// RegisterRoutes registers incident and problem routes
+func (h *IncidentHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/api/v1/incidents", h.CreateIncident).Methods("POST")
+	router.HandleFunc("/api/v1/incidents", h.ListIncidents).Methods("GET")
+	router.HandleFunc("/api/v1/incidents/merge", h.MergeTickets).Methods("POST")
+	router.HandleFunc("/api/v1/incidents/{id}", h.GetIncident).Methods("GET")
+	router.HandleFunc("/api/v1/incidents/{id}/tickets", h.LinkTickets).Methods("POST")
+	router.HandleFunc("/api/v1/incidents/{id}/tickets/{ticketId}", h.UnlinkTicket).Methods("DELETE")
+	router.HandleFunc("/api/v1/incidents/{id}/updates", h.PostUpdate).Methods("POST")
+	router.HandleFunc("/api/v1/incidents/{id}/resolve", h.ResolveIncident).Methods("POST")
+
+	router.HandleFunc("/api/v1/problems", h.CreateProblem).Methods("POST")
+	router.HandleFunc("/api/v1/problems", h.ListProblems).Methods("GET")
+	router.HandleFunc("/api/v1/problems/{id}", h.GetProblem).Methods("GET")
+	router.HandleFunc("/api/v1/problems/{id}", h.UpdateProblem).Methods("PATCH")
+	router.HandleFunc("/api/v1/problems/{id}/incidents", h.LinkIncidents).Methods("POST")
+	router.HandleFunc("/api/v1/problems/{id}/resolve", h.ResolveProblem).Methods("POST")
+	router.HandleFunc("/api/v1/problems/{id}/publish-workaround", h.PublishWorkaround).Methods("POST")
+}
+
+// CreateIncident handles declaring an incident
+func (h *IncidentHandler) CreateIncident(w http.ResponseWriter, r *http.Request) {
+	var req usecase.CreateIncidentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	req.CreatedBy = requestUserID(r)
+
+	response, err := h.incidentUseCase.CreateIncident(r.Context(), req)
+	if err != nil {
+		writeIncidentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+}
+
+// MergeTickets handles merging duplicate tickets into a new incident
+func (h *IncidentHandler) MergeTickets(w http.ResponseWriter, r *http.Request) {
+	var req usecase.MergeTicketsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	req.CreatedBy = requestUserID(r)
+
+	response, err := h.incidentUseCase.MergeTickets(r.Context(), req)
+	if err != nil {
+		writeIncidentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+}
+
+// ListIncidents handles listing incidents
+func (h *IncidentHandler) ListIncidents(w http.ResponseWriter, r *http.Request) {
+	filter := domain.IncidentFilter{}
+
+	if status := r.URL.Query().Get("status"); status != "" {
+		s := domain.IncidentStatus(status)
+		filter.Status = &s
+	}
+
+	if problemID := r.URL.Query().Get("problem_id"); problemID != "" {
+		filter.ProblemID = &problemID
+	}
+
+	filter.Limit, filter.Offset = pagination(r)
+
+	incidents, err := h.incidentUseCase.ListIncidents(r.Context(), filter)
+	if err != nil {
+		writeIncidentError(w, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"incidents": incidents,
+		"count":     len(incidents),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetIncident handles retrieving an incident with its tickets
+func (h *IncidentHandler) GetIncident(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, err := h.incidentUseCase.GetIncident(r.Context(), vars["id"])
+	if err != nil {
+		writeIncidentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// LinkTickets handles linking tickets to an incident
+func (h *IncidentHandler) LinkTickets(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var req struct {
+		TicketIDs []string `json:"ticket_ids"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if len(req.TicketIDs) == 0 {
+		http.Error(w, "Ticket IDs are required", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.incidentUseCase.LinkTickets(r.Context(), vars["id"], req.TicketIDs)
+	if err != nil {
+		writeIncidentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// UnlinkTicket handles removing a ticket from an incident
+func (h *IncidentHandler) UnlinkTicket(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	ticket, err := h.incidentUseCase.UnlinkTicket(r.Context(), vars["id"], vars["ticketId"])
+	if err != nil {
+		writeIncidentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ticket)
+}
+
+// PostUpdate handles broadcasting a status update to the incident's requesters
+func (h *IncidentHandler) PostUpdate(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var req usecase.PostIncidentUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	req.PostedBy = requestUserID(r)
+
+	incident, err := h.incidentUseCase.PostUpdate(r.Context(), vars["id"], req)
+	if err != nil {
+		writeIncidentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(incident)
+}
+
+// ResolveIncident handles resolving an incident, optionally with its tickets
+func (h *IncidentHandler) ResolveIncident(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var req usecase.ResolveIncidentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	req.ResolvedBy = requestUserID(r)
+
+	response, err := h.incidentUseCase.ResolveIncident(r.Context(), vars["id"], req)
+	if err != nil {
+		writeIncidentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// CreateProblem handles opening a problem
+func (h *IncidentHandler) CreateProblem(w http.ResponseWriter, r *http.Request) {
+	var req usecase.CreateProblemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	req.CreatedBy = requestUserID(r)
+
+	response, err := h.problemUseCase.CreateProblem(r.Context(), req)
+	if err != nil {
+		writeIncidentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+}
+
+// ListProblems handles listing problems
+func (h *IncidentHandler) ListProblems(w http.ResponseWriter, r *http.Request) {
+	filter := domain.ProblemFilter{}
+
+	if status := r.URL.Query().Get("status"); status != "" {
+		s := domain.ProblemStatus(status)
+		filter.Status = &s
+	}
+
+	filter.Limit, filter.Offset = pagination(r)
+
+	problems, err := h.problemUseCase.ListProblems(r.Context(), filter)
+	if err != nil {
+		writeIncidentError(w, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"problems": problems,
+		"count":    len(problems),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetProblem handles retrieving a problem with its incidents
+func (h *IncidentHandler) GetProblem(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, err := h.problemUseCase.GetProblem(r.Context(), vars["id"])
+	if err != nil {
+		writeIncidentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// UpdateProblem handles recording the root cause and workaround of a problem
+func (h *IncidentHandler) UpdateProblem(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var req usecase.UpdateProblemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	req.UpdatedBy = requestUserID(r)
+
+	problem, err := h.problemUseCase.UpdateProblem(r.Context(), vars["id"], req)
+	if err != nil {
+		writeIncidentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(problem)
+}
+
+// LinkIncidents handles linking incidents to a problem
+func (h *IncidentHandler) LinkIncidents(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var req struct {
+		IncidentIDs []string `json:"incident_ids"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if len(req.IncidentIDs) == 0 {
+		http.Error(w, "Incident IDs are required", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.problemUseCase.LinkIncidents(r.Context(), vars["id"], req.IncidentIDs)
+	if err != nil {
+		writeIncidentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// ResolveProblem handles resolving a problem
+func (h *IncidentHandler) ResolveProblem(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	problem, err := h.problemUseCase.ResolveProblem(r.Context(), vars["id"], requestUserID(r))
+	if err != nil {
+		writeIncidentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(problem)
+}
+
+// PublishWorkaround handles publishing a problem's workaround to the knowledge base
+func (h *IncidentHandler) PublishWorkaround(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, err := h.problemUseCase.PublishWorkaround(r.Context(), vars["id"], requestUserID(r))
+	if err != nil {
+		writeIncidentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if response.Job != nil {
+		w.WriteHeader(http.StatusAccepted)
+	}
+	json.NewEncoder(w).Encode(response)
+}
+
+// pagination reads the limit and offset query parameters
+func pagination(r *http.Request) (int, int) {
+	var limit, offset int
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil {
+			limit = l
+		}
+	}
+
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil {
+			offset = o
+		}
+	}
+
+	return limit, offset
+}
+
+func writeIncidentError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrIncidentNotFound):
+		http.Error(w, "Incident not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrProblemNotFound):
+		http.Error(w, "Problem not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrTicketNotFound):
+		http.Error(w, "Ticket not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrIncidentResolved),
+		errors.Is(err, domain.ErrProblemResolved),
+		errors.Is(err, domain.ErrTicketClosed),
+		errors.Is(err, domain.ErrTicketNotInIncident),
+		errors.Is(err, domain.ErrNoRootCause),
+		errors.Is(err, domain.ErrNoWorkaround):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, domain.ErrEmptyIncidentTitle),
+		errors.Is(err, domain.ErrEmptyIncidentUpdate),
+		errors.Is(err, domain.ErrInvalidIncidentStatus),
+		errors.Is(err, domain.ErrTooFewTicketsMerge),
+		errors.Is(err, domain.ErrEmptyProblemTitle),
+		errors.Is(err, domain.ErrEmptyAuthorID):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -2,8 +2,10 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"fixora/internal/domain"
 	"fixora/internal/usecase"
@@ -31,8 +33,13 @@ func (h *KBHandler) RegisterRoutes(router *mux.Router) {
 	router.HandleFunc("/api/v1/kb/entries/{id}", h.UpdateEntry).Methods("PATCH")
 	router.HandleFunc("/api/v1/kb/entries/{id}/publish", h.PublishEntry).Methods("POST")
 	router.HandleFunc("/api/v1/kb/entries/{id}", h.DeleteEntry).Methods("DELETE")
+	router.HandleFunc("/api/v1/kb/entries/{id}/revisions", h.ListRevisions).Methods("GET")
+	router.HandleFunc("/api/v1/kb/entries/{id}/revisions/diff", h.DiffRevisions).Methods("GET")
+	router.HandleFunc("/api/v1/kb/entries/{id}/revisions/{version}", h.GetRevision).Methods("GET")
+	router.HandleFunc("/api/v1/kb/entries/{id}/revisions/{version}/rollback", h.RollbackEntry).Methods("POST")
 	router.HandleFunc("/api/v1/kb/search", h.SearchEntries).Methods("POST")
 	router.HandleFunc("/api/v1/kb/upload-text", h.UploadText).Methods("POST")
+	router.HandleFunc("/api/v1/kb/jobs/{id}", h.GetIndexJob).Methods("GET")
 }
 
 // CreateEntry handles knowledge base entry creation
@@ -104,6 +111,15 @@ func (h *KBHandler) ListEntries(w http.ResponseWriter, r *http.Request) {
 		filter.Tags = tags
 	}
 
+	// source_type=LEARNED&status=draft lists learned drafts awaiting review
+	if sourceType := r.URL.Query().Get("source_type"); sourceType != "" {
+		filter.SourceType = strings.ToUpper(sourceType)
+	}
+
+	if sourceTicketID := r.URL.Query().Get("source_ticket_id"); sourceTicketID != "" {
+		filter.SourceTicketID = sourceTicketID
+	}
+
 	if topKStr := r.URL.Query().Get("top_k"); topKStr != "" {
 		if topK, err := strconv.Atoi(topKStr); err == nil {
 			filter.TopK = topK
@@ -141,12 +157,23 @@ func (h *KBHandler) UpdateEntry(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Get user ID from context (in real implementation, from auth middleware)
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		userID = "default-user" // Fallback for development
+	}
+	req.UpdatedBy = userID
+
 	entry, err := h.kbUseCase.UpdateEntry(r.Context(), entryID, req)
 	if err != nil {
-		if err.Error() == "knowledge base entry not found" {
+		if errors.Is(err, domain.ErrKBEntryNotFound) {
 			http.Error(w, "Entry not found", http.StatusNotFound)
 			return
 		}
+		if errors.Is(err, domain.ErrKBEntryArchived) || errors.Is(err, domain.ErrKBEntryModified) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -165,18 +192,71 @@ func (h *KBHandler) PublishEntry(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.kbUseCase.PublishEntry(r.Context(), entryID); err != nil {
+	job, err := h.kbUseCase.PublishEntry(r.Context(), entryID)
+	if err != nil {
 		if err.Error() == "knowledge base entry not found" {
 			http.Error(w, "Entry not found", http.StatusNotFound)
 			return
 		}
+		if errors.Is(err, domain.ErrCannotPublishArchived) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		if errors.Is(err, domain.ErrEmptyKBContent) {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	w.Header().Set("Content-Type", "application/json")
+
+	// Already published at the current version
+	if job == nil {
+		response := map[string]interface{}{
+			"message":  "Entry already published",
+			"entry_id": entryID,
+		}
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
 	response := map[string]interface{}{
-		"message": "Entry published successfully",
-		"entry_id": entryID,
+		"message":    "Entry queued for publishing",
+		"entry_id":   entryID,
+		"job_id":     job.ID,
+		"status":     job.Status,
+		"status_url": "/api/v1/kb/jobs/" + job.ID,
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetIndexJob handles retrieving the progress of a background indexing job
+func (h *KBHandler) GetIndexJob(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	jobID := vars["id"]
+
+	if jobID == "" {
+		http.Error(w, "Job ID is required", http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.kbUseCase.GetIndexJob(r.Context(), jobID)
+	if err != nil {
+		if errors.Is(err, domain.ErrIndexJobNotFound) {
+			http.Error(w, "Job not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"job":      job,
+		"progress": job.Progress(),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -205,6 +285,127 @@ func (h *KBHandler) DeleteEntry(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// ListRevisions handles listing the revision history of an entry
+func (h *KBHandler) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	entryID := vars["id"]
+
+	if entryID == "" {
+		http.Error(w, "Entry ID is required", http.StatusBadRequest)
+		return
+	}
+
+	revisions, err := h.kbUseCase.ListRevisions(r.Context(), entryID)
+	if err != nil {
+		if errors.Is(err, domain.ErrKBEntryNotFound) {
+			http.Error(w, "Entry not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"entry_id":  entryID,
+		"revisions": revisions,
+		"count":     len(revisions),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetRevision handles retrieving a single revision of an entry
+func (h *KBHandler) GetRevision(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	entryID := vars["id"]
+
+	version, err := strconv.Atoi(vars["version"])
+	if entryID == "" || err != nil {
+		http.Error(w, "Entry ID and numeric version are required", http.StatusBadRequest)
+		return
+	}
+
+	revision, err := h.kbUseCase.GetRevision(r.Context(), entryID, version)
+	if err != nil {
+		if errors.Is(err, domain.ErrKBRevisionNotFound) {
+			http.Error(w, "Revision not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(revision)
+}
+
+// DiffRevisions handles showing a line diff between two revisions
+func (h *KBHandler) DiffRevisions(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	entryID := vars["id"]
+
+	if entryID == "" {
+		http.Error(w, "Entry ID is required", http.StatusBadRequest)
+		return
+	}
+
+	from, errFrom := strconv.Atoi(r.URL.Query().Get("from"))
+	to, errTo := strconv.Atoi(r.URL.Query().Get("to"))
+	if errFrom != nil || errTo != nil {
+		http.Error(w, "Query parameters from and to must be revision versions", http.StatusBadRequest)
+		return
+	}
+
+	diff, err := h.kbUseCase.DiffRevisions(r.Context(), entryID, from, to)
+	if err != nil {
+		if errors.Is(err, domain.ErrKBRevisionNotFound) {
+			http.Error(w, "Revision not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(diff)
+}
+
+// RollbackEntry handles restoring a previous revision as a new draft
+func (h *KBHandler) RollbackEntry(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	entryID := vars["id"]
+
+	version, err := strconv.Atoi(vars["version"])
+	if entryID == "" || err != nil {
+		http.Error(w, "Entry ID and numeric version are required", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		userID = "default-user"
+	}
+
+	entry, err := h.kbUseCase.RollbackEntry(r.Context(), entryID, version, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrKBEntryNotFound):
+			http.Error(w, "Entry not found", http.StatusNotFound)
+		case errors.Is(err, domain.ErrKBRevisionNotFound):
+			http.Error(w, "Revision not found", http.StatusNotFound)
+		case errors.Is(err, domain.ErrKBEntryArchived), errors.Is(err, domain.ErrKBEntryModified):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entry)
+}
+
 // SearchEntries handles knowledge base search
 func (h *KBHandler) SearchEntries(w http.ResponseWriter, r *http.Request) {
 	var req struct {
@@ -290,19 +491,24 @@ func (h *KBHandler) UploadText(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Publish if requested
-	if publish {
-		if err := h.kbUseCase.PublishEntry(r.Context(), entry.ID); err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-	}
-
 	response := map[string]interface{}{
 		"entry_id": entry.ID,
 		"title":    entry.Title,
 		"status":   entry.Status,
-		"published": publish,
+		"published": false,
+	}
+
+	// Queue for publishing if requested
+	if publish {
+		job, err := h.kbUseCase.PublishEntry(r.Context(), entry.ID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if job != nil {
+			response["job_id"] = job.ID
+			response["status_url"] = "/api/v1/kb/jobs/" + job.ID
+		}
 	}
 
 	w.Header().Set("Content-Type", "application/json")
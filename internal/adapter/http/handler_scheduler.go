@@ -0,0 +1,93 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"fixora/internal/domain"
+	"fixora/internal/usecase"
+
+	"github.com/gorilla/mux"
+)
+
+// SchedulerHandler handles admin HTTP requests for background jobs
+type SchedulerHandler struct {
+	scheduler *usecase.Scheduler
+}
+
+// NewSchedulerHandler creates a new scheduler handler
+func NewSchedulerHandler(scheduler *usecase.Scheduler) *SchedulerHandler {
+	return &SchedulerHandler{
+		scheduler: scheduler,
+	}
+}
+
+// RegisterRoutes registers scheduler admin routes
+func (h *SchedulerHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/api/v1/admin/scheduler", h.GetStatus).Methods("GET")
+	router.HandleFunc("/api/v1/admin/scheduler/jobs/{name}/runs", h.ListJobRuns).Methods("GET")
+	router.HandleFunc("/api/v1/admin/scheduler/jobs/{name}/run", h.RunJob).Methods("POST")
+}
+
+// GetStatus handles reporting leadership and each job's last run and outcome
+func (h *SchedulerHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	status, err := h.scheduler.Status(r.Context())
+	if err != nil {
+		writeSchedulerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+// ListJobRuns handles listing a job's recent runs
+func (h *SchedulerHandler) ListJobRuns(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	limit := 20
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil {
+			limit = l
+		}
+	}
+
+	runs, err := h.scheduler.ListJobRuns(r.Context(), vars["name"], limit)
+	if err != nil {
+		writeSchedulerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"job":  vars["name"],
+		"runs": runs,
+	})
+}
+
+// RunJob handles running a job now instead of waiting for its next run
+func (h *SchedulerHandler) RunJob(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	run, err := h.scheduler.RunJob(r.Context(), vars["name"])
+	if err != nil && run == nil {
+		writeSchedulerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(run)
+}
+
+func writeSchedulerError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrJobNotFound):
+		http.Error(w, "Scheduled job not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrJobAlreadyRunning):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
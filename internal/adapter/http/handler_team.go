@@ -0,0 +1,305 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"fixora/internal/domain"
+	"fixora/internal/usecase"
+
+	"github.com/gorilla/mux"
+)
+
+// TeamHandler handles HTTP requests for teams, queues and claiming queued tickets
+type TeamHandler struct {
+	teamUseCase *usecase.TeamUseCase
+}
+
+// NewTeamHandler creates a new team handler
+func NewTeamHandler(teamUseCase *usecase.TeamUseCase) *TeamHandler {
+	return &TeamHandler{
+		teamUseCase: teamUseCase,
+	}
+}
+
+// RegisterRoutes registers team, queue and claim routes
+func (h *TeamHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/api/v1/teams", h.CreateTeam).Methods("POST")
+	router.HandleFunc("/api/v1/teams", h.ListTeams).Methods("GET")
+	router.HandleFunc("/api/v1/teams/{id}", h.GetTeam).Methods("GET")
+	router.HandleFunc("/api/v1/teams/{id}", h.UpdateTeam).Methods("PUT")
+	router.HandleFunc("/api/v1/teams/{id}/members", h.AddMember).Methods("POST")
+	router.HandleFunc("/api/v1/teams/{id}/members/{agentId}", h.RemoveMember).Methods("DELETE")
+
+	router.HandleFunc("/api/v1/queues", h.CreateQueue).Methods("POST")
+	router.HandleFunc("/api/v1/queues", h.ListQueues).Methods("GET")
+	router.HandleFunc("/api/v1/queues/{id}", h.GetQueue).Methods("GET")
+	router.HandleFunc("/api/v1/queues/{id}", h.UpdateQueue).Methods("PUT")
+	router.HandleFunc("/api/v1/queues/{id}/dashboard", h.GetQueueDashboard).Methods("GET")
+
+	router.HandleFunc("/api/v1/tickets/{id}/queue", h.QueueTicket).Methods("POST")
+	router.HandleFunc("/api/v1/tickets/{id}/claim", h.ClaimTicket).Methods("POST")
+}
+
+// CreateTeam handles creating a team
+func (h *TeamHandler) CreateTeam(w http.ResponseWriter, r *http.Request) {
+	var req usecase.TeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.teamUseCase.CreateTeam(r.Context(), req)
+	if err != nil {
+		writeTeamError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+}
+
+// ListTeams handles listing teams
+func (h *TeamHandler) ListTeams(w http.ResponseWriter, r *http.Request) {
+	teams, err := h.teamUseCase.ListTeams(r.Context())
+	if err != nil {
+		writeTeamError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"teams": teams,
+		"total": len(teams),
+	})
+}
+
+// GetTeam handles retrieving a team with its members
+func (h *TeamHandler) GetTeam(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, err := h.teamUseCase.GetTeam(r.Context(), vars["id"])
+	if err != nil {
+		writeTeamError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// UpdateTeam handles renaming a team
+func (h *TeamHandler) UpdateTeam(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var req usecase.TeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.teamUseCase.UpdateTeam(r.Context(), vars["id"], req)
+	if err != nil {
+		writeTeamError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// AddMember handles adding an agent to a team
+func (h *TeamHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var req struct {
+		AgentID string `json:"agent_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.teamUseCase.AddTeamMember(r.Context(), vars["id"], req.AgentID)
+	if err != nil {
+		writeTeamError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// RemoveMember handles removing an agent from a team
+func (h *TeamHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, err := h.teamUseCase.RemoveTeamMember(r.Context(), vars["id"], vars["agentId"])
+	if errors.Is(err, domain.ErrNotTeamMember) {
+		http.Error(w, "Agent is not a member of the team", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		writeTeamError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// CreateQueue handles creating a queue for a team
+func (h *TeamHandler) CreateQueue(w http.ResponseWriter, r *http.Request) {
+	var req usecase.QueueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.teamUseCase.CreateQueue(r.Context(), req)
+	if err != nil {
+		writeTeamError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+}
+
+// ListQueues handles listing queues
+func (h *TeamHandler) ListQueues(w http.ResponseWriter, r *http.Request) {
+	filter := domain.QueueFilter{}
+
+	if teamID := r.URL.Query().Get("team_id"); teamID != "" {
+		filter.TeamID = &teamID
+	}
+
+	queues, err := h.teamUseCase.ListQueues(r.Context(), filter)
+	if err != nil {
+		writeTeamError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"queues": queues,
+		"total":  len(queues),
+	})
+}
+
+// GetQueue handles retrieving a queue
+func (h *TeamHandler) GetQueue(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, err := h.teamUseCase.GetQueue(r.Context(), vars["id"])
+	if err != nil {
+		writeTeamError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// UpdateQueue handles renaming a queue or moving it to another team
+func (h *TeamHandler) UpdateQueue(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var req usecase.QueueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.teamUseCase.UpdateQueue(r.Context(), vars["id"], req)
+	if err != nil {
+		writeTeamError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetQueueDashboard handles retrieving a queue's workload summary
+func (h *TeamHandler) GetQueueDashboard(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, err := h.teamUseCase.GetQueueDashboard(r.Context(), vars["id"])
+	if err != nil {
+		writeTeamError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// QueueTicket handles placing a ticket in a queue
+func (h *TeamHandler) QueueTicket(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var req struct {
+		QueueID string `json:"queue_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.QueueID == "" {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.teamUseCase.QueueTicket(r.Context(), vars["id"], req.QueueID)
+	if err != nil {
+		writeTeamError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// ClaimTicket handles the requesting agent claiming a queued ticket
+func (h *TeamHandler) ClaimTicket(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, err := h.teamUseCase.ClaimTicket(r.Context(), vars["id"], requestUserID(r))
+	if errors.Is(err, domain.ErrNotTeamMember) {
+		http.Error(w, "Only members of the queue's team can claim its tickets", http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		writeTeamError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func writeTeamError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrTeamNotFound):
+		http.Error(w, "Team not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrQueueNotFound):
+		http.Error(w, "Queue not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrAgentNotFound):
+		http.Error(w, "Agent not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrTicketNotFound):
+		http.Error(w, "Ticket not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrAlreadyTeamMember),
+		errors.Is(err, domain.ErrTicketNotQueued),
+		errors.Is(err, domain.ErrTicketAlreadyClaimed),
+		errors.Is(err, domain.ErrInvalidAssignment),
+		errors.Is(err, domain.ErrTicketClosed):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, domain.ErrEmptyTeamName),
+		errors.Is(err, domain.ErrEmptyTeamID),
+		errors.Is(err, domain.ErrEmptyQueueName),
+		errors.Is(err, domain.ErrEmptyAgentID):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
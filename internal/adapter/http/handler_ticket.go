@@ -2,6 +2,7 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -32,7 +33,12 @@ func (h *TicketHandler) RegisterRoutes(router *mux.Router) {
 	router.HandleFunc("/api/v1/tickets/{id}/assign", h.AssignTicket).Methods("POST")
 	router.HandleFunc("/api/v1/tickets/{id}/resolve", h.ResolveTicket).Methods("POST")
 	router.HandleFunc("/api/v1/tickets/{id}/close", h.CloseTicket).Methods("POST")
+	router.HandleFunc("/api/v1/tickets/{id}/pending", h.MarkTicketPending).Methods("POST")
+	router.HandleFunc("/api/v1/tickets/{id}/comments", h.AddComment).Methods("POST")
+	router.HandleFunc("/api/v1/tickets/{id}/comments", h.ListComments).Methods("GET")
+	router.HandleFunc("/api/v1/tickets/{id}/resume", h.ResumeTicket).Methods("POST")
 	router.HandleFunc("/api/v1/tickets/stats", h.GetTicketStats).Methods("GET")
+	router.HandleFunc("/api/v1/tickets/{id}/similar", h.GetSimilarTickets).Methods("GET")
 }
 
 // CreateTicket handles ticket creation
@@ -113,6 +119,14 @@ func (h *TicketHandler) ListTickets(w http.ResponseWriter, r *http.Request) {
 		filter.AssignedTo = &assignedTo
 	}
 
+	if queueID := r.URL.Query().Get("queue_id"); queueID != "" {
+		filter.QueueID = &queueID
+	}
+
+	if teamID := r.URL.Query().Get("team_id"); teamID != "" {
+		filter.TeamID = &teamID
+	}
+
 	// Parse pagination
 	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
 		if limit, err := strconv.Atoi(limitStr); err == nil {
@@ -273,6 +287,34 @@ func (h *TicketHandler) CloseTicket(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(ticket)
 }
 
+// MarkTicketPending handles putting a ticket on hold while waiting on the requester
+func (h *TicketHandler) MarkTicketPending(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	ticket, err := h.ticketUseCase.MarkTicketPending(r.Context(), vars["id"])
+	if err != nil {
+		writeTicketStatusError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ticket)
+}
+
+// ResumeTicket handles taking a pending ticket off hold
+func (h *TicketHandler) ResumeTicket(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	ticket, err := h.ticketUseCase.ResumeTicket(r.Context(), vars["id"])
+	if err != nil {
+		writeTicketStatusError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ticket)
+}
+
 // GetTicketStats handles ticket statistics
 func (h *TicketHandler) GetTicketStats(w http.ResponseWriter, r *http.Request) {
 	stats, err := h.ticketUseCase.GetTicketStats(r.Context())
@@ -283,4 +325,119 @@ func (h *TicketHandler) GetTicketStats(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(stats)
-}
\ No newline at end of file
+}
+// GetSimilarTickets handles listing tickets related to a ticket
+func (h *TicketHandler) GetSimilarTickets(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	ticketID := vars["id"]
+
+	filter := domain.SimilarTicketFilter{}
+
+	if minScoreStr := r.URL.Query().Get("min_score"); minScoreStr != "" {
+		if minScore, err := strconv.ParseFloat(minScoreStr, 64); err == nil {
+			filter.MinScore = minScore
+		}
+	}
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit <= 50 {
+			filter.Limit = limit
+		}
+	}
+
+	// Only open and in-progress tickets unless all statuses are asked for
+	if r.URL.Query().Get("include_resolved") != "true" {
+		filter.Statuses = []domain.TicketStatus{domain.TicketStatusOpen, domain.TicketStatusInProgress}
+	}
+
+	similar, err := h.ticketUseCase.GetSimilarTickets(r.Context(), ticketID, filter)
+	if err != nil {
+		writeTicketStatusError(w, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"ticket_id": ticketID,
+		"similar":   similar,
+		"count":     len(similar),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// AddComment handles adding a comment to a ticket. Comments are posted by agents unless the
+// request says otherwise; agent comments are emailed to the requester.
+func (h *TicketHandler) AddComment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	ticketID := vars["id"]
+
+	var req struct {
+		Body string             `json:"body"`
+		Role domain.CommentRole `json:"role"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Role == "" {
+		req.Role = domain.CommentRoleAdmin
+	}
+
+	comment, err := h.ticketUseCase.AddComment(r.Context(), ticketID, requestUserID(r), req.Role, req.Body)
+	if err != nil {
+		writeCommentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(comment)
+}
+
+// ListComments handles listing a ticket's comments
+func (h *TicketHandler) ListComments(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	ticketID := vars["id"]
+
+	comments, err := h.ticketUseCase.ListComments(r.Context(), ticketID)
+	if err != nil {
+		writeCommentError(w, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"ticket_id": ticketID,
+		"comments":  comments,
+		"count":     len(comments),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func writeCommentError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrTicketNotFound):
+		http.Error(w, "Ticket not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrEmptyCommentBody),
+		errors.Is(err, domain.ErrInvalidCommentRole):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func writeTicketStatusError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrTicketNotFound):
+		http.Error(w, "Ticket not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrTicketClosed),
+		errors.Is(err, domain.ErrInvalidStatus):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,230 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+	"fixora/internal/usecase"
+
+	"github.com/gorilla/mux"
+)
+
+// WebhookHandler handles HTTP requests for webhook subscriptions and their delivery log
+type WebhookHandler struct {
+	webhookUseCase *usecase.WebhookUseCase
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(webhookUseCase *usecase.WebhookUseCase) *WebhookHandler {
+	return &WebhookHandler{
+		webhookUseCase: webhookUseCase,
+	}
+}
+
+// RegisterRoutes registers webhook routes
+func (h *WebhookHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/api/v1/webhooks", h.CreateSubscription).Methods("POST")
+	router.HandleFunc("/api/v1/webhooks", h.ListSubscriptions).Methods("GET")
+	router.HandleFunc("/api/v1/webhooks/event-types", h.ListEventTypes).Methods("GET")
+	router.HandleFunc("/api/v1/webhooks/deliveries", h.ListDeliveries).Methods("GET")
+	router.HandleFunc("/api/v1/webhooks/deliveries/{id}", h.GetDelivery).Methods("GET")
+	router.HandleFunc("/api/v1/webhooks/deliveries/{id}/replay", h.ReplayDelivery).Methods("POST")
+	router.HandleFunc("/api/v1/webhooks/{id}", h.GetSubscription).Methods("GET")
+	router.HandleFunc("/api/v1/webhooks/{id}", h.UpdateSubscription).Methods("PUT")
+	router.HandleFunc("/api/v1/webhooks/{id}", h.DeleteSubscription).Methods("DELETE")
+	router.HandleFunc("/api/v1/webhooks/{id}/deliveries", h.ListDeliveries).Methods("GET")
+}
+
+// CreateSubscription handles creating a subscription. The response is the only time the
+// signing secret is shown.
+func (h *WebhookHandler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+	var req usecase.WebhookSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	req.CreatedBy = requestUserID(r)
+
+	subscription, err := h.webhookUseCase.CreateSubscription(r.Context(), req)
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(struct {
+		*domain.WebhookSubscription
+		Secret string `json:"secret"`
+	}{subscription, subscription.Secret})
+}
+
+// ListSubscriptions handles listing subscriptions
+func (h *WebhookHandler) ListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	filter := domain.WebhookSubscriptionFilter{}
+	if eventType := r.URL.Query().Get("event_type"); eventType != "" {
+		filter.EventType = &eventType
+	}
+	if enabledStr := r.URL.Query().Get("enabled"); enabledStr != "" {
+		if enabled, err := strconv.ParseBool(enabledStr); err == nil {
+			filter.Enabled = &enabled
+		}
+	}
+
+	subscriptions, err := h.webhookUseCase.ListSubscriptions(r.Context(), filter)
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"webhooks": subscriptions,
+		"total":    len(subscriptions),
+	})
+}
+
+// ListEventTypes handles listing the event types subscriptions can choose from
+func (h *WebhookHandler) ListEventTypes(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"event_types": ports.EventTypes,
+	})
+}
+
+// GetSubscription handles retrieving a subscription
+func (h *WebhookHandler) GetSubscription(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	subscription, err := h.webhookUseCase.GetSubscription(r.Context(), vars["id"])
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(subscription)
+}
+
+// UpdateSubscription handles replacing a subscription's definition
+func (h *WebhookHandler) UpdateSubscription(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var req usecase.WebhookSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	req.CreatedBy = requestUserID(r)
+
+	subscription, err := h.webhookUseCase.UpdateSubscription(r.Context(), vars["id"], req)
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(subscription)
+}
+
+// DeleteSubscription handles deleting a subscription
+func (h *WebhookHandler) DeleteSubscription(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	if err := h.webhookUseCase.DeleteSubscription(r.Context(), vars["id"]); err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListDeliveries handles listing the delivery log, for all subscriptions or the one in the path
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	filter := domain.WebhookDeliveryFilter{Limit: 50}
+	if subscriptionID := mux.Vars(r)["id"]; subscriptionID != "" {
+		filter.SubscriptionID = &subscriptionID
+	} else if subscriptionID := r.URL.Query().Get("subscription_id"); subscriptionID != "" {
+		filter.SubscriptionID = &subscriptionID
+	}
+	if eventID := r.URL.Query().Get("event_id"); eventID != "" {
+		filter.EventID = &eventID
+	}
+	if status := r.URL.Query().Get("status"); status != "" {
+		s := domain.WebhookDeliveryStatus(status)
+		filter.Status = &s
+	}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil {
+			filter.Limit = limit
+		}
+	}
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if offset, err := strconv.Atoi(offsetStr); err == nil {
+			filter.Offset = offset
+		}
+	}
+
+	deliveries, err := h.webhookUseCase.ListDeliveries(r.Context(), filter)
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"deliveries": deliveries,
+		"limit":      filter.Limit,
+		"offset":     filter.Offset,
+	})
+}
+
+// GetDelivery handles retrieving a delivery with the outcome of its last attempt
+func (h *WebhookHandler) GetDelivery(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	delivery, err := h.webhookUseCase.GetDelivery(r.Context(), vars["id"])
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(delivery)
+}
+
+// ReplayDelivery handles sending a delivery's payload again
+func (h *WebhookHandler) ReplayDelivery(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	delivery, err := h.webhookUseCase.ReplayDelivery(r.Context(), vars["id"])
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(delivery)
+}
+
+func writeWebhookError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrWebhookSubscriptionNotFound):
+		http.Error(w, "Webhook subscription not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrWebhookDeliveryNotFound):
+		http.Error(w, "Webhook delivery not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrInvalidWebhookURL),
+		errors.Is(err, domain.ErrWebhookAddressNotAllowed),
+		errors.Is(err, domain.ErrWebhookSecretTooShort),
+		errors.Is(err, domain.ErrNoWebhookEvents),
+		errors.Is(err, domain.ErrInvalidWebhookEvent):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"time"
 
+	"fixora/internal/adapter/slack"
 	"fixora/internal/usecase"
 
 	"github.com/gorilla/mux"
@@ -17,6 +18,16 @@ type Server struct {
 	ticketHandler *TicketHandler
 	aiHandler    *AIHandler
 	kbHandler    *KBHandler
+	feedbackHandler *FeedbackHandler
+	deflectionHandler *DeflectionHandler
+	incidentHandler *IncidentHandler
+	agentHandler *AgentHandler
+	teamHandler *TeamHandler
+	automationHandler *AutomationHandler
+	schedulerHandler *SchedulerHandler
+	csatHandler *CSATHandler
+	webhookHandler *WebhookHandler
+	slackHandler *slack.Handler
 	server       *http.Server
 }
 
@@ -34,11 +45,31 @@ func NewServer(
 	ticketUseCase *usecase.TicketUseCase,
 	aiUseCase *usecase.AIUseCase,
 	kbUseCase *usecase.KnowledgeUseCase, // Assuming you have this
+	feedbackUseCase *usecase.FeedbackUseCase,
+	deflectionUseCase *usecase.DeflectionUseCase,
+	incidentUseCase *usecase.IncidentUseCase,
+	problemUseCase *usecase.ProblemUseCase,
+	assignmentUseCase *usecase.AssignmentUseCase,
+	teamUseCase *usecase.TeamUseCase,
+	automationUseCase *usecase.AutomationUseCase,
+	scheduler *usecase.Scheduler,
+	csatUseCase *usecase.CSATUseCase,
+	webhookUseCase *usecase.WebhookUseCase,
+	slackHandler *slack.Handler, // nil when Slack commands are not configured
 ) *Server {
 	// Create handlers
 	ticketHandler := NewTicketHandler(ticketUseCase)
 	aiHandler := NewAIHandler(aiUseCase)
 	kbHandler := NewKBHandler(kbUseCase)
+	feedbackHandler := NewFeedbackHandler(feedbackUseCase)
+	deflectionHandler := NewDeflectionHandler(deflectionUseCase)
+	incidentHandler := NewIncidentHandler(incidentUseCase, problemUseCase)
+	agentHandler := NewAgentHandler(assignmentUseCase)
+	teamHandler := NewTeamHandler(teamUseCase)
+	automationHandler := NewAutomationHandler(automationUseCase)
+	schedulerHandler := NewSchedulerHandler(scheduler)
+	csatHandler := NewCSATHandler(csatUseCase)
+	webhookHandler := NewWebhookHandler(webhookUseCase)
 
 	// Create router
 	router := mux.NewRouter()
@@ -47,6 +78,18 @@ func NewServer(
 	ticketHandler.RegisterRoutes(router)
 	aiHandler.RegisterRoutes(router)
 	kbHandler.RegisterRoutes(router)
+	feedbackHandler.RegisterRoutes(router)
+	deflectionHandler.RegisterRoutes(router)
+	incidentHandler.RegisterRoutes(router)
+	agentHandler.RegisterRoutes(router)
+	teamHandler.RegisterRoutes(router)
+	automationHandler.RegisterRoutes(router)
+	schedulerHandler.RegisterRoutes(router)
+	csatHandler.RegisterRoutes(router)
+	webhookHandler.RegisterRoutes(router)
+	if slackHandler != nil {
+		slackHandler.RegisterRoutes(router)
+	}
 
 	// Add middleware
 	router.Use(loggingMiddleware)
@@ -64,6 +107,16 @@ func NewServer(
 		ticketHandler: ticketHandler,
 		aiHandler:    aiHandler,
 		kbHandler:    kbHandler,
+		feedbackHandler: feedbackHandler,
+		deflectionHandler: deflectionHandler,
+		incidentHandler: incidentHandler,
+		agentHandler: agentHandler,
+		teamHandler: teamHandler,
+		automationHandler: automationHandler,
+		schedulerHandler: schedulerHandler,
+		csatHandler: csatHandler,
+		webhookHandler: webhookHandler,
+		slackHandler: slackHandler,
 		server: &http.Server{
 			Addr:         ":" + config.Port,
 			Handler:      router,
@@ -0,0 +1,113 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+
+	"fixora/internal/ports"
+)
+
+// PostgresAdvisoryLock implements LeaderLock with a session-level Postgres advisory lock. The lock
+// belongs to one pooled connection, which is held for as long as this instance leads; if that
+// connection dies, Postgres releases the lock and another instance can take over.
+type PostgresAdvisoryLock struct {
+	db   *sql.DB
+	key  int64
+	mu   sync.Mutex
+	conn *sql.Conn // connection holding the lock while leader
+}
+
+// NewPostgresAdvisoryLock creates a leader lock on the given advisory lock key
+func NewPostgresAdvisoryLock(db *sql.DB, key int64) ports.LeaderLock {
+	return &PostgresAdvisoryLock{db: db, key: key}
+}
+
+// TryAcquire takes or keeps leadership without blocking, reporting whether this instance leads
+func (l *PostgresAdvisoryLock) TryAcquire(ctx context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn != nil {
+		// Still leader as long as the session holding the lock is alive
+		if err := l.conn.PingContext(ctx); err == nil {
+			return true, nil
+		}
+		l.conn.Close()
+		l.conn = nil
+	}
+
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get connection for advisory lock: %w", err)
+	}
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1)`, l.key).Scan(&acquired); err != nil {
+		conn.Close()
+		return false, fmt.Errorf("failed to acquire advisory lock: %w", err)
+	}
+
+	if !acquired {
+		conn.Close()
+		return false, nil
+	}
+
+	l.conn = conn
+	return true, nil
+}
+
+// Release gives up leadership
+func (l *PostgresAdvisoryLock) Release(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn == nil {
+		return nil
+	}
+
+	_, err := l.conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1)`, l.key)
+	l.conn.Close()
+	l.conn = nil
+
+	if err != nil {
+		return fmt.Errorf("failed to release advisory lock: %w", err)
+	}
+
+	return nil
+}
+
+// PostgresJobLock implements JobLock with transaction-level advisory locks, one per job name. A job
+// runs inside the transaction holding its lock, so the lock is released when the job returns or its
+// connection dies.
+type PostgresJobLock struct {
+	db    *sql.DB
+	class int32 // first advisory lock key; the second is a hash of the job name
+}
+
+// NewPostgresJobLock creates per-job locks in the given advisory lock class
+func NewPostgresJobLock(db *sql.DB, class int32) ports.JobLock {
+	return &PostgresJobLock{db: db, class: class}
+}
+
+// TryRun runs fn while holding the job's lock, reporting false without running fn if another run holds it
+func (l *PostgresJobLock) TryRun(ctx context.Context, job string, fn func(ctx context.Context) error) (bool, error) {
+	tx, err := l.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin job lock transaction: %w", err)
+	}
+	// Nothing is written in the transaction; ending it releases the lock
+	defer tx.Rollback()
+
+	var acquired bool
+	if err := tx.QueryRowContext(ctx, `SELECT pg_try_advisory_xact_lock($1, hashtext($2))`, l.class, job).Scan(&acquired); err != nil {
+		return false, fmt.Errorf("failed to acquire job lock: %w", err)
+	}
+
+	if !acquired {
+		return false, nil
+	}
+
+	return true, fn(ctx)
+}
@@ -0,0 +1,267 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// assignmentLockKey is the Postgres advisory lock key that serializes automatic assignment
+const assignmentLockKey int64 = 4_711_042_042
+
+// PostgresAgentRepository implements AgentRepository using PostgreSQL
+type PostgresAgentRepository struct {
+	db       *sql.DB
+	assignMu sync.Mutex // queues local assignments so only one holds a connection waiting for the lock
+}
+
+// NewPostgresAgentRepository creates a new PostgreSQL agent repository
+func NewPostgresAgentRepository(db *sql.DB) ports.AgentRepository {
+	return &PostgresAgentRepository{db: db}
+}
+
+const agentColumns = `id, name, email, skills, capacity, available, last_assigned_at, created_at, updated_at`
+
+// Create registers a new agent
+func (r *PostgresAgentRepository) Create(ctx context.Context, agent *domain.Agent) error {
+	skills, err := json.Marshal(agent.Skills)
+	if err != nil {
+		return fmt.Errorf("failed to marshal agent skills: %w", err)
+	}
+
+	query := `
+		INSERT INTO agents (` + agentColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (id) DO NOTHING
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		agent.ID,
+		agent.Name,
+		sql.NullString{String: agent.Email, Valid: agent.Email != ""},
+		skills,
+		agent.Capacity,
+		agent.Available,
+		agent.LastAssignedAt,
+		agent.CreatedAt,
+		agent.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create agent: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrAgentExists
+	}
+
+	return nil
+}
+
+// FindByID retrieves an agent by ID
+func (r *PostgresAgentRepository) FindByID(ctx context.Context, id string) (*domain.Agent, error) {
+	query := `SELECT ` + agentColumns + ` FROM agents WHERE id = $1`
+
+	agent, err := scanAgent(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrAgentNotFound
+		}
+		return nil, fmt.Errorf("failed to find agent: %w", err)
+	}
+
+	return agent, nil
+}
+
+// Update updates an existing agent
+func (r *PostgresAgentRepository) Update(ctx context.Context, agent *domain.Agent) error {
+	skills, err := json.Marshal(agent.Skills)
+	if err != nil {
+		return fmt.Errorf("failed to marshal agent skills: %w", err)
+	}
+
+	query := `
+		UPDATE agents
+		SET name = $2, email = $3, skills = $4, capacity = $5, available = $6, last_assigned_at = $7, updated_at = $8
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		agent.ID,
+		agent.Name,
+		sql.NullString{String: agent.Email, Valid: agent.Email != ""},
+		skills,
+		agent.Capacity,
+		agent.Available,
+		agent.LastAssignedAt,
+		agent.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update agent: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrAgentNotFound
+	}
+
+	return nil
+}
+
+// RecordAssignment sets when the agent was last routed a ticket, leaving the rest of the agent as is
+func (r *PostgresAgentRepository) RecordAssignment(ctx context.Context, agentID string, at time.Time) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE agents SET last_assigned_at = $1 WHERE id = $2`, at, agentID)
+	if err != nil {
+		return fmt.Errorf("failed to record agent assignment: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrAgentNotFound
+	}
+
+	return nil
+}
+
+// WithAssignmentLock runs fn while holding a transaction-level advisory lock, so automatic
+// assignments on all instances pick agents one at a time. The lock is released when fn returns.
+func (r *PostgresAgentRepository) WithAssignmentLock(ctx context.Context, fn func(ctx context.Context) error) error {
+	r.assignMu.Lock()
+	defer r.assignMu.Unlock()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, assignmentLockKey); err != nil {
+		return fmt.Errorf("failed to acquire assignment lock: %w", err)
+	}
+
+	if err := fn(ctx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes an agent
+func (r *PostgresAgentRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM agents WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete agent: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrAgentNotFound
+	}
+
+	return nil
+}
+
+// List retrieves agents matching the filter, ordered by name
+func (r *PostgresAgentRepository) List(ctx context.Context, filter domain.AgentFilter) ([]*domain.Agent, error) {
+	query := `SELECT ` + agentColumns + ` FROM agents WHERE 1=1`
+
+	var conditions []string
+	var args []interface{}
+	argIndex := 1
+
+	if filter.Available != nil {
+		conditions = append(conditions, fmt.Sprintf("available = $%d", argIndex))
+		args = append(args, *filter.Available)
+		argIndex++
+	}
+
+	if len(conditions) > 0 {
+		query += " AND " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY name, id"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query agents: %w", err)
+	}
+	defer rows.Close()
+
+	var agents []*domain.Agent
+
+	for rows.Next() {
+		agent, err := scanAgent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan agent: %w", err)
+		}
+		agents = append(agents, agent)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating agents: %w", err)
+	}
+
+	return agents, nil
+}
+
+func scanAgent(row rowScanner) (*domain.Agent, error) {
+	var agent domain.Agent
+	var email sql.NullString
+	var lastAssignedAt sql.NullTime
+	var skills []byte
+
+	err := row.Scan(
+		&agent.ID,
+		&agent.Name,
+		&email,
+		&skills,
+		&agent.Capacity,
+		&agent.Available,
+		&lastAssignedAt,
+		&agent.CreatedAt,
+		&agent.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	agent.Email = email.String
+	if lastAssignedAt.Valid {
+		agent.LastAssignedAt = &lastAssignedAt.Time
+	}
+
+	if err := json.Unmarshal(skills, &agent.Skills); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal agent skills: %w", err)
+	}
+
+	return &agent, nil
+}
@@ -0,0 +1,165 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// PostgresAutomationExecutionRepository implements AutomationExecutionRepository using PostgreSQL
+type PostgresAutomationExecutionRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresAutomationExecutionRepository creates a new PostgreSQL automation execution log repository
+func NewPostgresAutomationExecutionRepository(db *sql.DB) ports.AutomationExecutionRepository {
+	return &PostgresAutomationExecutionRepository{db: db}
+}
+
+const automationExecutionColumns = `id, rule_id, rule_name, ticket_id, trigger, event_id, status, actions,
+	error, depth, executed_at`
+
+// Create records an execution
+func (r *PostgresAutomationExecutionRepository) Create(ctx context.Context, execution *domain.AutomationExecution) error {
+	actions, err := json.Marshal(execution.Actions)
+	if err != nil {
+		return fmt.Errorf("failed to marshal execution actions: %w", err)
+	}
+
+	query := `
+		INSERT INTO automation_executions (` + automationExecutionColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		execution.ID,
+		execution.RuleID,
+		execution.RuleName,
+		execution.TicketID,
+		string(execution.Trigger),
+		sql.NullString{String: execution.EventID, Valid: execution.EventID != ""},
+		string(execution.Status),
+		actions,
+		sql.NullString{String: execution.Error, Valid: execution.Error != ""},
+		execution.Depth,
+		execution.ExecutedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create automation execution: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves executions matching the filter, newest first
+func (r *PostgresAutomationExecutionRepository) List(ctx context.Context, filter domain.AutomationExecutionFilter) ([]*domain.AutomationExecution, error) {
+	query := `SELECT ` + automationExecutionColumns + ` FROM automation_executions WHERE 1=1`
+
+	var conditions []string
+	var args []interface{}
+	argIndex := 1
+
+	if filter.RuleID != nil {
+		conditions = append(conditions, fmt.Sprintf("rule_id = $%d", argIndex))
+		args = append(args, *filter.RuleID)
+		argIndex++
+	}
+
+	if filter.TicketID != nil {
+		conditions = append(conditions, fmt.Sprintf("ticket_id = $%d", argIndex))
+		args = append(args, *filter.TicketID)
+		argIndex++
+	}
+
+	if filter.Status != nil {
+		conditions = append(conditions, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, string(*filter.Status))
+		argIndex++
+	}
+
+	if len(conditions) > 0 {
+		query += " AND " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY executed_at DESC"
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argIndex)
+		args = append(args, filter.Limit)
+		argIndex++
+	}
+
+	if filter.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET $%d", argIndex)
+		args = append(args, filter.Offset)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query automation executions: %w", err)
+	}
+	defer rows.Close()
+
+	var executions []*domain.AutomationExecution
+
+	for rows.Next() {
+		var execution domain.AutomationExecution
+		var eventID, execError sql.NullString
+		var actions []byte
+
+		err := rows.Scan(
+			&execution.ID,
+			&execution.RuleID,
+			&execution.RuleName,
+			&execution.TicketID,
+			&execution.Trigger,
+			&eventID,
+			&execution.Status,
+			&actions,
+			&execError,
+			&execution.Depth,
+			&execution.ExecutedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan automation execution: %w", err)
+		}
+
+		execution.EventID = eventID.String
+		execution.Error = execError.String
+
+		if err := json.Unmarshal(actions, &execution.Actions); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal execution actions: %w", err)
+		}
+
+		executions = append(executions, &execution)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating automation executions: %w", err)
+	}
+
+	return executions, nil
+}
+
+// HasSucceeded reports whether the rule has already run successfully on the ticket
+func (r *PostgresAutomationExecutionRepository) HasSucceeded(ctx context.Context, ruleID, ticketID string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM automation_executions
+			WHERE rule_id = $1 AND ticket_id = $2 AND status = 'succeeded'
+		)
+	`
+
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, query, ruleID, ticketID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check automation executions: %w", err)
+	}
+
+	return exists, nil
+}
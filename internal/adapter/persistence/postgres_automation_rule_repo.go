@@ -0,0 +1,269 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// PostgresAutomationRuleRepository implements AutomationRuleRepository using PostgreSQL
+type PostgresAutomationRuleRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresAutomationRuleRepository creates a new PostgreSQL automation rule repository
+func NewPostgresAutomationRuleRepository(db *sql.DB) ports.AutomationRuleRepository {
+	return &PostgresAutomationRuleRepository{db: db}
+}
+
+const automationRuleColumns = `id, name, description, trigger, conditions, actions, position, enabled,
+	stop_processing, created_by, created_at, updated_at`
+
+// Create saves a new rule
+func (r *PostgresAutomationRuleRepository) Create(ctx context.Context, rule *domain.AutomationRule) error {
+	conditions, actions, err := marshalRule(rule)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO automation_rules (` + automationRuleColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		rule.ID,
+		rule.Name,
+		sql.NullString{String: rule.Description, Valid: rule.Description != ""},
+		string(rule.Trigger),
+		conditions,
+		actions,
+		rule.Position,
+		rule.Enabled,
+		rule.StopProcessing,
+		rule.CreatedBy,
+		rule.CreatedAt,
+		rule.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create automation rule: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves a rule by ID
+func (r *PostgresAutomationRuleRepository) FindByID(ctx context.Context, id string) (*domain.AutomationRule, error) {
+	query := `SELECT ` + automationRuleColumns + ` FROM automation_rules WHERE id = $1`
+
+	rule, err := scanAutomationRule(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrAutomationRuleNotFound
+		}
+		return nil, fmt.Errorf("failed to find automation rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+// Update updates an existing rule
+func (r *PostgresAutomationRuleRepository) Update(ctx context.Context, rule *domain.AutomationRule) error {
+	conditions, actions, err := marshalRule(rule)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE automation_rules
+		SET name = $2, description = $3, trigger = $4, conditions = $5, actions = $6, position = $7,
+			enabled = $8, stop_processing = $9, updated_at = $10
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		rule.ID,
+		rule.Name,
+		sql.NullString{String: rule.Description, Valid: rule.Description != ""},
+		string(rule.Trigger),
+		conditions,
+		actions,
+		rule.Position,
+		rule.Enabled,
+		rule.StopProcessing,
+		rule.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update automation rule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrAutomationRuleNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a rule and its execution log
+func (r *PostgresAutomationRuleRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM automation_rules WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete automation rule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrAutomationRuleNotFound
+	}
+
+	return nil
+}
+
+// List retrieves rules matching the filter in position order
+func (r *PostgresAutomationRuleRepository) List(ctx context.Context, filter domain.AutomationRuleFilter) ([]*domain.AutomationRule, error) {
+	query := `SELECT ` + automationRuleColumns + ` FROM automation_rules WHERE 1=1`
+
+	var conditions []string
+	var args []interface{}
+	argIndex := 1
+
+	if filter.Trigger != nil {
+		conditions = append(conditions, fmt.Sprintf("trigger = $%d", argIndex))
+		args = append(args, string(*filter.Trigger))
+		argIndex++
+	}
+
+	if filter.Enabled != nil {
+		conditions = append(conditions, fmt.Sprintf("enabled = $%d", argIndex))
+		args = append(args, *filter.Enabled)
+		argIndex++
+	}
+
+	if len(conditions) > 0 {
+		query += " AND " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY position, created_at, id"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query automation rules: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []*domain.AutomationRule
+
+	for rows.Next() {
+		rule, err := scanAutomationRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan automation rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating automation rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+// Reorder sets each rule's position to its index in ruleIDs
+func (r *PostgresAutomationRuleRepository) Reorder(ctx context.Context, ruleIDs []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	for position, id := range ruleIDs {
+		result, err := tx.ExecContext(ctx,
+			`UPDATE automation_rules SET position = $2, updated_at = $3 WHERE id = $1`,
+			id, position, now,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to reorder automation rules: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rowsAffected == 0 {
+			return domain.ErrAutomationRuleNotFound
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func marshalRule(rule *domain.AutomationRule) ([]byte, []byte, error) {
+	conditions, err := json.Marshal(rule.Conditions)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal rule conditions: %w", err)
+	}
+
+	actions, err := json.Marshal(rule.Actions)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal rule actions: %w", err)
+	}
+
+	return conditions, actions, nil
+}
+
+func scanAutomationRule(row rowScanner) (*domain.AutomationRule, error) {
+	var rule domain.AutomationRule
+	var description sql.NullString
+	var conditions, actions []byte
+
+	err := row.Scan(
+		&rule.ID,
+		&rule.Name,
+		&description,
+		&rule.Trigger,
+		&conditions,
+		&actions,
+		&rule.Position,
+		&rule.Enabled,
+		&rule.StopProcessing,
+		&rule.CreatedBy,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rule.Description = description.String
+
+	if err := json.Unmarshal(conditions, &rule.Conditions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal rule conditions: %w", err)
+	}
+	if err := json.Unmarshal(actions, &rule.Actions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal rule actions: %w", err)
+	}
+
+	return &rule, nil
+}
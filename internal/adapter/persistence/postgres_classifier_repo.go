@@ -0,0 +1,121 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+
+	"github.com/lib/pq"
+)
+
+// PostgresClassifierRepository implements ClassifierRepository using PostgreSQL
+type PostgresClassifierRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresClassifierRepository creates a new PostgreSQL classifier model repository
+func NewPostgresClassifierRepository(db *sql.DB) ports.ClassifierRepository {
+	return &PostgresClassifierRepository{db: db}
+}
+
+// Load returns the stored model, or nil if none has been saved
+func (r *PostgresClassifierRepository) Load(ctx context.Context, name string) (*domain.TicketClassifier, error) {
+	var data []byte
+
+	err := r.db.QueryRowContext(ctx, `SELECT model FROM classifier_models WHERE name = $1`, name).Scan(&data)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to load classifier model: %w", err)
+	}
+
+	model := domain.NewTicketClassifier()
+	if err := json.Unmarshal(data, model); err != nil {
+		return nil, fmt.Errorf("failed to decode classifier model: %w", err)
+	}
+
+	return model, nil
+}
+
+// Save stores the model, replacing any previous version
+func (r *PostgresClassifierRepository) Save(ctx context.Context, name string, model *domain.TicketClassifier) error {
+	return saveClassifierModel(ctx, r.db, name, model)
+}
+
+// MarkLearned records that the model was trained on a ticket; it reports false if it already was
+func (r *PostgresClassifierRepository) MarkLearned(ctx context.Context, name, ticketID string) (bool, error) {
+	query := `
+		INSERT INTO classifier_learned_tickets (model_name, ticket_id, learned_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (model_name, ticket_id) DO NOTHING
+	`
+
+	result, err := r.db.ExecContext(ctx, query, name, ticketID)
+	if err != nil {
+		return false, fmt.Errorf("failed to record learned ticket: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// Replace stores a retrained model and replaces the tickets recorded as learned with ticketIDs
+func (r *PostgresClassifierRepository) Replace(ctx context.Context, name string, model *domain.TicketClassifier, ticketIDs []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := saveClassifierModel(ctx, tx, name, model); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM classifier_learned_tickets WHERE model_name = $1`, name); err != nil {
+		return fmt.Errorf("failed to clear learned tickets: %w", err)
+	}
+
+	query := `
+		INSERT INTO classifier_learned_tickets (model_name, ticket_id, learned_at)
+		SELECT $1, ticket_id, NOW() FROM unnest($2::text[]) AS ticket_id
+		ON CONFLICT (model_name, ticket_id) DO NOTHING
+	`
+	if _, err := tx.ExecContext(ctx, query, name, pq.Array(ticketIDs)); err != nil {
+		return fmt.Errorf("failed to record learned tickets: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func saveClassifierModel(ctx context.Context, exec execer, name string, model *domain.TicketClassifier) error {
+	data, err := json.Marshal(model)
+	if err != nil {
+		return fmt.Errorf("failed to encode classifier model: %w", err)
+	}
+
+	query := `
+		INSERT INTO classifier_models (name, model, documents, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (name) DO UPDATE
+		SET model = EXCLUDED.model, documents = EXCLUDED.documents, updated_at = EXCLUDED.updated_at
+	`
+
+	if _, err := exec.ExecContext(ctx, query, name, data, model.Documents(), model.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to save classifier model: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// PostgresCSATRepository implements CSATRepository using PostgreSQL
+type PostgresCSATRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresCSATRepository creates a new PostgreSQL satisfaction rating repository
+func NewPostgresCSATRepository(db *sql.DB) ports.CSATRepository {
+	return &PostgresCSATRepository{db: db}
+}
+
+const csatColumns = `id, ticket_id, score, comment, submitted_by, agent_id, category, created_at, updated_at`
+
+// Save creates or replaces the ticket's rating, keeping when it was first rated
+func (r *PostgresCSATRepository) Save(ctx context.Context, rating *domain.CSATRating) error {
+	query := `
+		INSERT INTO csat_ratings (` + csatColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (ticket_id) DO UPDATE
+		SET score = EXCLUDED.score, comment = EXCLUDED.comment, updated_at = EXCLUDED.updated_at
+		RETURNING id, created_at
+	`
+
+	var agentID sql.NullString
+	if rating.AgentID != nil {
+		agentID = sql.NullString{String: *rating.AgentID, Valid: true}
+	}
+
+	err := r.db.QueryRowContext(ctx, query,
+		rating.ID,
+		rating.TicketID,
+		rating.Score,
+		sql.NullString{String: rating.Comment, Valid: rating.Comment != ""},
+		rating.SubmittedBy,
+		agentID,
+		string(rating.Category),
+		rating.CreatedAt,
+		rating.UpdatedAt,
+	).Scan(&rating.ID, &rating.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to save satisfaction rating: %w", err)
+	}
+
+	return nil
+}
+
+// FindByTicket retrieves the ticket's rating
+func (r *PostgresCSATRepository) FindByTicket(ctx context.Context, ticketID string) (*domain.CSATRating, error) {
+	query := `SELECT ` + csatColumns + ` FROM csat_ratings WHERE ticket_id = $1`
+
+	rating, err := scanCSATRating(r.db.QueryRowContext(ctx, query, ticketID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrCSATRatingNotFound
+		}
+		return nil, fmt.Errorf("failed to find satisfaction rating: %w", err)
+	}
+
+	return rating, nil
+}
+
+// List retrieves ratings created in the filter's date range, newest first
+func (r *PostgresCSATRepository) List(ctx context.Context, filter domain.CSATFilter) ([]*domain.CSATRating, error) {
+	query := `SELECT ` + csatColumns + ` FROM csat_ratings WHERE 1=1`
+
+	var conditions []string
+	var args []interface{}
+	argIndex := 1
+
+	if filter.StartDate != nil {
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", argIndex))
+		args = append(args, *filter.StartDate)
+		argIndex++
+	}
+
+	if filter.EndDate != nil {
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", argIndex))
+		args = append(args, *filter.EndDate)
+		argIndex++
+	}
+
+	if filter.Category != "" {
+		conditions = append(conditions, fmt.Sprintf("category = $%d", argIndex))
+		args = append(args, filter.Category)
+		argIndex++
+	}
+
+	if filter.AgentID != "" {
+		conditions = append(conditions, fmt.Sprintf("agent_id = $%d", argIndex))
+		args = append(args, filter.AgentID)
+		argIndex++
+	}
+
+	if len(conditions) > 0 {
+		query += " AND " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY created_at DESC"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query satisfaction ratings: %w", err)
+	}
+	defer rows.Close()
+
+	var ratings []*domain.CSATRating
+
+	for rows.Next() {
+		rating, err := scanCSATRating(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan satisfaction rating: %w", err)
+		}
+		ratings = append(ratings, rating)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating satisfaction ratings: %w", err)
+	}
+
+	return ratings, nil
+}
+
+func scanCSATRating(row rowScanner) (*domain.CSATRating, error) {
+	var rating domain.CSATRating
+	var comment, agentID sql.NullString
+
+	err := row.Scan(
+		&rating.ID,
+		&rating.TicketID,
+		&rating.Score,
+		&comment,
+		&rating.SubmittedBy,
+		&agentID,
+		&rating.Category,
+		&rating.CreatedAt,
+		&rating.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rating.Comment = comment.String
+	rating.AgentID = mapStringPtr(agentID)
+
+	return &rating, nil
+}
@@ -0,0 +1,219 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// PostgresDeflectionRepository implements DeflectionRepository using PostgreSQL
+type PostgresDeflectionRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresDeflectionRepository creates a new PostgreSQL deflection session repository
+func NewPostgresDeflectionRepository(db *sql.DB) ports.DeflectionRepository {
+	return &PostgresDeflectionRepository{db: db}
+}
+
+// Create saves a new deflection session
+func (r *PostgresDeflectionRepository) Create(ctx context.Context, session *domain.DeflectionSession) error {
+	candidates, err := json.Marshal(session.Candidates)
+	if err != nil {
+		return fmt.Errorf("failed to marshal candidates: %w", err)
+	}
+
+	query := `
+		INSERT INTO deflection_sessions (id, description, requested_by, status, query_id, candidates,
+			selected_rank, ticket_id, created_at, updated_at, closed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		session.ID,
+		session.Description,
+		session.RequestedBy,
+		string(session.Status),
+		sql.NullString{String: session.QueryID, Valid: session.QueryID != ""},
+		candidates,
+		sql.NullInt64{Int64: int64(session.SelectedRank), Valid: session.SelectedRank > 0},
+		sql.NullString{String: session.TicketID, Valid: session.TicketID != ""},
+		session.CreatedAt,
+		session.UpdatedAt,
+		session.ClosedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create deflection session: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves a deflection session by ID
+func (r *PostgresDeflectionRepository) FindByID(ctx context.Context, id string) (*domain.DeflectionSession, error) {
+	query := `
+		SELECT id, description, requested_by, status, query_id, candidates,
+			selected_rank, ticket_id, created_at, updated_at, closed_at
+		FROM deflection_sessions
+		WHERE id = $1
+	`
+
+	var session domain.DeflectionSession
+	var queryID, ticketID sql.NullString
+	var selectedRank sql.NullInt64
+	var closedAt sql.NullTime
+	var candidates []byte
+
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&session.ID,
+		&session.Description,
+		&session.RequestedBy,
+		&session.Status,
+		&queryID,
+		&candidates,
+		&selectedRank,
+		&ticketID,
+		&session.CreatedAt,
+		&session.UpdatedAt,
+		&closedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrDeflectionSessionNotFound
+		}
+		return nil, fmt.Errorf("failed to find deflection session: %w", err)
+	}
+
+	session.QueryID = queryID.String
+	session.TicketID = ticketID.String
+	session.SelectedRank = int(selectedRank.Int64)
+	if closedAt.Valid {
+		session.ClosedAt = &closedAt.Time
+	}
+
+	if err := json.Unmarshal(candidates, &session.Candidates); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal candidates: %w", err)
+	}
+
+	return &session, nil
+}
+
+// Update updates an existing deflection session
+func (r *PostgresDeflectionRepository) Update(ctx context.Context, session *domain.DeflectionSession) error {
+	candidates, err := json.Marshal(session.Candidates)
+	if err != nil {
+		return fmt.Errorf("failed to marshal candidates: %w", err)
+	}
+
+	query := `
+		UPDATE deflection_sessions
+		SET status = $2, query_id = $3, candidates = $4, selected_rank = $5, ticket_id = $6,
+			updated_at = $7, closed_at = $8
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		session.ID,
+		string(session.Status),
+		sql.NullString{String: session.QueryID, Valid: session.QueryID != ""},
+		candidates,
+		sql.NullInt64{Int64: int64(session.SelectedRank), Valid: session.SelectedRank > 0},
+		sql.NullString{String: session.TicketID, Valid: session.TicketID != ""},
+		session.UpdatedAt,
+		session.ClosedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update deflection session: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrDeflectionSessionNotFound
+	}
+
+	return nil
+}
+
+// SaveCandidates stores the query ID and candidates of a session that is still open. A stream
+// finishing after the session was resolved or escalated does not reopen it.
+func (r *PostgresDeflectionRepository) SaveCandidates(ctx context.Context, session *domain.DeflectionSession) error {
+	candidates, err := json.Marshal(session.Candidates)
+	if err != nil {
+		return fmt.Errorf("failed to marshal candidates: %w", err)
+	}
+
+	query := `
+		UPDATE deflection_sessions
+		SET query_id = $2, candidates = $3, updated_at = $4
+		WHERE id = $1 AND status = $5
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		session.ID,
+		session.QueryID,
+		candidates,
+		session.UpdatedAt,
+		string(domain.DeflectionStatusOpen),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to save deflection candidates: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrDeflectionSessionClosed
+	}
+
+	return nil
+}
+
+// Close stores the outcome of a session that is still open, failing with
+// ErrDeflectionSessionClosed when another request closed it first
+func (r *PostgresDeflectionRepository) Close(ctx context.Context, session *domain.DeflectionSession) error {
+	query := `
+		UPDATE deflection_sessions
+		SET status = $2, selected_rank = $3, ticket_id = $4, updated_at = $5, closed_at = $6
+		WHERE id = $1 AND status = $7
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		session.ID,
+		string(session.Status),
+		sql.NullInt64{Int64: int64(session.SelectedRank), Valid: session.SelectedRank > 0},
+		sql.NullString{String: session.TicketID, Valid: session.TicketID != ""},
+		session.UpdatedAt,
+		session.ClosedAt,
+		string(domain.DeflectionStatusOpen),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to close deflection session: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrDeflectionSessionClosed
+	}
+
+	return nil
+}
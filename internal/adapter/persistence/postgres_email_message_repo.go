@@ -0,0 +1,119 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// PostgresEmailMessageRepository implements EmailMessageRepository using PostgreSQL
+type PostgresEmailMessageRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresEmailMessageRepository creates a new PostgreSQL email message repository
+func NewPostgresEmailMessageRepository(db *sql.DB) ports.EmailMessageRepository {
+	return &PostgresEmailMessageRepository{db: db}
+}
+
+const emailMessageColumns = `id, message_id, ticket_id, comment_id, direction, address, subject, created_at`
+
+// Create records an email. Recording the same Message-ID again is a no-op, so redelivered mail
+// is harmless.
+func (r *PostgresEmailMessageRepository) Create(ctx context.Context, message *domain.EmailMessage) error {
+	query := `
+		INSERT INTO email_messages (` + emailMessageColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (message_id) DO NOTHING
+	`
+
+	var commentID sql.NullString
+	if message.CommentID != nil {
+		commentID = sql.NullString{String: *message.CommentID, Valid: true}
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		message.ID,
+		message.MessageID,
+		message.TicketID,
+		commentID,
+		string(message.Direction),
+		message.Address,
+		message.Subject,
+		message.CreatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create email message: %w", err)
+	}
+
+	return nil
+}
+
+// FindByMessageID retrieves the email with the given Message-ID
+func (r *PostgresEmailMessageRepository) FindByMessageID(ctx context.Context, messageID string) (*domain.EmailMessage, error) {
+	query := `SELECT ` + emailMessageColumns + ` FROM email_messages WHERE message_id = $1`
+
+	message, err := scanEmailMessage(r.db.QueryRowContext(ctx, query, messageID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrEmailMessageNotFound
+		}
+		return nil, fmt.Errorf("failed to find email message: %w", err)
+	}
+
+	return message, nil
+}
+
+// ListByTicket retrieves the emails in a ticket's conversation, oldest first
+func (r *PostgresEmailMessageRepository) ListByTicket(ctx context.Context, ticketID string) ([]*domain.EmailMessage, error) {
+	query := `SELECT ` + emailMessageColumns + ` FROM email_messages WHERE ticket_id = $1 ORDER BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query email messages: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []*domain.EmailMessage
+
+	for rows.Next() {
+		message, err := scanEmailMessage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan email message: %w", err)
+		}
+		messages = append(messages, message)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating email messages: %w", err)
+	}
+
+	return messages, nil
+}
+
+func scanEmailMessage(row rowScanner) (*domain.EmailMessage, error) {
+	var message domain.EmailMessage
+	var commentID sql.NullString
+
+	err := row.Scan(
+		&message.ID,
+		&message.MessageID,
+		&message.TicketID,
+		&commentID,
+		&message.Direction,
+		&message.Address,
+		&message.Subject,
+		&message.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	message.CommentID = mapStringPtr(commentID)
+
+	return &message, nil
+}
@@ -0,0 +1,120 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fixora/internal/ports"
+
+	"github.com/lib/pq"
+)
+
+// PostgresEmbeddingCacheStore implements EmbeddingCacheStore using PostgreSQL.
+// Embeddings are deterministic for a given model and text, so entries do not expire.
+type PostgresEmbeddingCacheStore struct {
+	db *sql.DB
+}
+
+// NewPostgresEmbeddingCacheStore creates a new PostgreSQL embedding cache store
+func NewPostgresEmbeddingCacheStore(db *sql.DB) ports.EmbeddingCacheStore {
+	return &PostgresEmbeddingCacheStore{db: db}
+}
+
+// GetMany retrieves cached embeddings for the given content hashes; missing hashes are omitted
+func (s *PostgresEmbeddingCacheStore) GetMany(ctx context.Context, model string, hashes []string) (map[string][]float32, error) {
+	if len(hashes) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT content_hash, embedding::text
+		FROM embedding_cache
+		WHERE model = $1 AND content_hash = ANY($2)
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, model, pq.Array(hashes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query embedding cache: %w", err)
+	}
+	defer rows.Close()
+
+	embeddings := make(map[string][]float32, len(hashes))
+
+	for rows.Next() {
+		var hash, literal string
+
+		if err := rows.Scan(&hash, &literal); err != nil {
+			return nil, fmt.Errorf("failed to scan cached embedding: %w", err)
+		}
+
+		embedding, err := parseVectorLiteral(literal)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse cached embedding: %w", err)
+		}
+
+		embeddings[hash] = embedding
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating cached embeddings: %w", err)
+	}
+
+	return embeddings, nil
+}
+
+// PutMany stores embeddings by content hash
+func (s *PostgresEmbeddingCacheStore) PutMany(ctx context.Context, model string, embeddings map[string][]float32) error {
+	if len(embeddings) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO embedding_cache (model, content_hash, embedding, created_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (model, content_hash) DO NOTHING
+	`
+
+	for hash, embedding := range embeddings {
+		if _, err := tx.ExecContext(ctx, query, model, hash, vectorLiteral(embedding)); err != nil {
+			return fmt.Errorf("failed to store cached embedding: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Helper function to decode a pgvector text value ("[0.1,0.2,...]")
+func parseVectorLiteral(literal string) ([]float32, error) {
+	literal = strings.TrimSpace(literal)
+	literal = strings.TrimPrefix(literal, "[")
+	literal = strings.TrimSuffix(literal, "]")
+
+	if literal == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(literal, ",")
+	embedding := make([]float32, len(parts))
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 32)
+		if err != nil {
+			return nil, err
+		}
+		embedding[i] = float32(v)
+	}
+
+	return embedding, nil
+}
@@ -0,0 +1,255 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// PostgresFeedbackRepository implements FeedbackRepository using PostgreSQL
+type PostgresFeedbackRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresFeedbackRepository creates a new PostgreSQL suggestion feedback repository
+func NewPostgresFeedbackRepository(db *sql.DB) ports.FeedbackRepository {
+	return &PostgresFeedbackRepository{db: db}
+}
+
+// Save stores feedback, replacing earlier feedback from the same requester on the same suggestion.
+// A deflection, once recorded, is kept when the rating is changed later.
+func (r *PostgresFeedbackRepository) Save(ctx context.Context, feedback *domain.SuggestionFeedback) error {
+	args, err := feedbackArgs(feedback)
+	if err != nil {
+		return err
+	}
+
+	query := insertFeedbackQuery + `
+		ON CONFLICT (target_type, target_id, submitted_by) DO UPDATE
+		SET rating = EXCLUDED.rating, comment = EXCLUDED.comment, created_at = EXCLUDED.created_at,
+			deflected = suggestion_feedback.deflected OR EXCLUDED.deflected
+		RETURNING id, deflected
+	`
+
+	err = r.db.QueryRowContext(ctx, query, args...).Scan(&feedback.ID, &feedback.Deflected)
+	if err != nil {
+		return fmt.Errorf("failed to save suggestion feedback: %w", err)
+	}
+
+	return nil
+}
+
+// Create stores feedback unless the requester already rated the same suggestion. For stream
+// candidates the target is the query ID and rank, so this allows one rating per candidate and person.
+func (r *PostgresFeedbackRepository) Create(ctx context.Context, feedback *domain.SuggestionFeedback) error {
+	args, err := feedbackArgs(feedback)
+	if err != nil {
+		return err
+	}
+
+	query := insertFeedbackQuery + `
+		ON CONFLICT (target_type, target_id, submitted_by) DO NOTHING
+		RETURNING id
+	`
+
+	err = r.db.QueryRowContext(ctx, query, args...).Scan(&feedback.ID)
+	if err == sql.ErrNoRows {
+		return domain.ErrFeedbackAlreadyGiven
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create suggestion feedback: %w", err)
+	}
+
+	return nil
+}
+
+const insertFeedbackQuery = `
+	INSERT INTO suggestion_feedback (id, target_type, target_id, ticket_id, query_id, candidate_rank, rating, comment,
+		category, provider, model, citations, deflected, submitted_by, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+`
+
+func feedbackArgs(feedback *domain.SuggestionFeedback) ([]interface{}, error) {
+	citations, err := json.Marshal(feedback.Citations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal citations: %w", err)
+	}
+
+	return []interface{}{
+		feedback.ID,
+		string(feedback.TargetType),
+		feedback.TargetID,
+		sql.NullString{String: feedback.TicketID, Valid: feedback.TicketID != ""},
+		sql.NullString{String: feedback.QueryID, Valid: feedback.QueryID != ""},
+		sql.NullInt64{Int64: int64(feedback.CandidateRank), Valid: feedback.CandidateRank > 0},
+		string(feedback.Rating),
+		feedback.Comment,
+		feedback.Category,
+		feedback.Provider,
+		feedback.Model,
+		citations,
+		feedback.Deflected,
+		feedback.SubmittedBy,
+		feedback.CreatedAt,
+	}, nil
+}
+
+// SaveCandidate records a candidate served by the suggestion stream. A candidate is recorded once;
+// the stream never serves a different candidate under the same query ID and rank.
+func (r *PostgresFeedbackRepository) SaveCandidate(ctx context.Context, candidate *domain.SuggestionCandidate) error {
+	query := `
+		INSERT INTO suggestion_candidates (query_id, candidate_rank, score, category, provider, model, entry_id, chunk_index, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (query_id, candidate_rank) DO NOTHING
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		candidate.QueryID,
+		candidate.Rank,
+		candidate.Score,
+		candidate.Category,
+		candidate.Provider,
+		candidate.Model,
+		candidate.EntryID,
+		candidate.ChunkIndex,
+		candidate.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save suggestion candidate: %w", err)
+	}
+
+	return nil
+}
+
+// FindCandidate retrieves a served candidate by query ID and rank
+func (r *PostgresFeedbackRepository) FindCandidate(ctx context.Context, queryID string, rank int) (*domain.SuggestionCandidate, error) {
+	query := `
+		SELECT query_id, candidate_rank, score, category, provider, model, entry_id, chunk_index, created_at
+		FROM suggestion_candidates
+		WHERE query_id = $1 AND candidate_rank = $2
+	`
+
+	var candidate domain.SuggestionCandidate
+	err := r.db.QueryRowContext(ctx, query, queryID, rank).Scan(
+		&candidate.QueryID,
+		&candidate.Rank,
+		&candidate.Score,
+		&candidate.Category,
+		&candidate.Provider,
+		&candidate.Model,
+		&candidate.EntryID,
+		&candidate.ChunkIndex,
+		&candidate.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrCandidateNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find suggestion candidate: %w", err)
+	}
+
+	return &candidate, nil
+}
+
+// List retrieves feedback matching the filter, newest first
+func (r *PostgresFeedbackRepository) List(ctx context.Context, filter domain.FeedbackFilter) ([]*domain.SuggestionFeedback, error) {
+	query := `
+		SELECT id, target_type, target_id, ticket_id, query_id, candidate_rank, rating, comment,
+			category, provider, model, citations, deflected, submitted_by, created_at
+		FROM suggestion_feedback
+		WHERE 1=1
+	`
+
+	var conditions []string
+	var args []interface{}
+	argIndex := 1
+
+	if filter.StartDate != nil {
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", argIndex))
+		args = append(args, *filter.StartDate)
+		argIndex++
+	}
+
+	if filter.EndDate != nil {
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", argIndex))
+		args = append(args, *filter.EndDate)
+		argIndex++
+	}
+
+	if filter.Category != "" {
+		conditions = append(conditions, fmt.Sprintf("category = $%d", argIndex))
+		args = append(args, filter.Category)
+		argIndex++
+	}
+
+	if filter.Provider != "" {
+		conditions = append(conditions, fmt.Sprintf("provider = $%d", argIndex))
+		args = append(args, filter.Provider)
+		argIndex++
+	}
+
+	if len(conditions) > 0 {
+		query += " AND " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY created_at DESC"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query suggestion feedback: %w", err)
+	}
+	defer rows.Close()
+
+	var feedback []*domain.SuggestionFeedback
+
+	for rows.Next() {
+		var f domain.SuggestionFeedback
+		var ticketID, queryID sql.NullString
+		var candidateRank sql.NullInt64
+		var citations []byte
+
+		err := rows.Scan(
+			&f.ID,
+			&f.TargetType,
+			&f.TargetID,
+			&ticketID,
+			&queryID,
+			&candidateRank,
+			&f.Rating,
+			&f.Comment,
+			&f.Category,
+			&f.Provider,
+			&f.Model,
+			&citations,
+			&f.Deflected,
+			&f.SubmittedBy,
+			&f.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan suggestion feedback: %w", err)
+		}
+
+		f.TicketID = ticketID.String
+		f.QueryID = queryID.String
+		f.CandidateRank = int(candidateRank.Int64)
+
+		if len(citations) > 0 {
+			if err := json.Unmarshal(citations, &f.Citations); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal citations: %w", err)
+			}
+		}
+
+		feedback = append(feedback, &f)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating suggestion feedback: %w", err)
+	}
+
+	return feedback, nil
+}
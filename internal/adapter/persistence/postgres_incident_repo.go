@@ -0,0 +1,214 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// PostgresIncidentRepository implements IncidentRepository using PostgreSQL
+type PostgresIncidentRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresIncidentRepository creates a new PostgreSQL incident repository
+func NewPostgresIncidentRepository(db *sql.DB) ports.IncidentRepository {
+	return &PostgresIncidentRepository{db: db}
+}
+
+const incidentColumns = `id, title, description, status, priority, problem_id, resolution, updates,
+	created_by, created_at, updated_at, resolved_at`
+
+// Create saves a new incident
+func (r *PostgresIncidentRepository) Create(ctx context.Context, incident *domain.Incident) error {
+	updates, err := json.Marshal(incident.Updates)
+	if err != nil {
+		return fmt.Errorf("failed to marshal incident updates: %w", err)
+	}
+
+	query := `
+		INSERT INTO incidents (` + incidentColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		incident.ID,
+		incident.Title,
+		incident.Description,
+		string(incident.Status),
+		string(incident.Priority),
+		incident.ProblemID,
+		sql.NullString{String: incident.Resolution, Valid: incident.Resolution != ""},
+		updates,
+		incident.CreatedBy,
+		incident.CreatedAt,
+		incident.UpdatedAt,
+		incident.ResolvedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create incident: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves an incident by ID
+func (r *PostgresIncidentRepository) FindByID(ctx context.Context, id string) (*domain.Incident, error) {
+	query := `SELECT ` + incidentColumns + ` FROM incidents WHERE id = $1`
+
+	incident, err := scanIncident(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrIncidentNotFound
+		}
+		return nil, fmt.Errorf("failed to find incident: %w", err)
+	}
+
+	return incident, nil
+}
+
+// Update updates an existing incident
+func (r *PostgresIncidentRepository) Update(ctx context.Context, incident *domain.Incident) error {
+	updates, err := json.Marshal(incident.Updates)
+	if err != nil {
+		return fmt.Errorf("failed to marshal incident updates: %w", err)
+	}
+
+	query := `
+		UPDATE incidents
+		SET title = $2, description = $3, status = $4, priority = $5, problem_id = $6, resolution = $7,
+			updates = $8, updated_at = $9, resolved_at = $10
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		incident.ID,
+		incident.Title,
+		incident.Description,
+		string(incident.Status),
+		string(incident.Priority),
+		incident.ProblemID,
+		sql.NullString{String: incident.Resolution, Valid: incident.Resolution != ""},
+		updates,
+		incident.UpdatedAt,
+		incident.ResolvedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update incident: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrIncidentNotFound
+	}
+
+	return nil
+}
+
+// List retrieves incidents matching the filter, newest first
+func (r *PostgresIncidentRepository) List(ctx context.Context, filter domain.IncidentFilter) ([]*domain.Incident, error) {
+	query := `SELECT ` + incidentColumns + ` FROM incidents WHERE 1=1`
+
+	var conditions []string
+	var args []interface{}
+	argIndex := 1
+
+	if filter.Status != nil {
+		conditions = append(conditions, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, string(*filter.Status))
+		argIndex++
+	}
+
+	if filter.ProblemID != nil {
+		conditions = append(conditions, fmt.Sprintf("problem_id = $%d", argIndex))
+		args = append(args, *filter.ProblemID)
+		argIndex++
+	}
+
+	if len(conditions) > 0 {
+		query += " AND " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY created_at DESC"
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argIndex)
+		args = append(args, filter.Limit)
+		argIndex++
+	}
+
+	if filter.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET $%d", argIndex)
+		args = append(args, filter.Offset)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query incidents: %w", err)
+	}
+	defer rows.Close()
+
+	var incidents []*domain.Incident
+
+	for rows.Next() {
+		incident, err := scanIncident(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan incident: %w", err)
+		}
+		incidents = append(incidents, incident)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating incidents: %w", err)
+	}
+
+	return incidents, nil
+}
+
+func scanIncident(row rowScanner) (*domain.Incident, error) {
+	var incident domain.Incident
+	var problemID, resolution sql.NullString
+	var resolvedAt sql.NullTime
+	var updates []byte
+
+	err := row.Scan(
+		&incident.ID,
+		&incident.Title,
+		&incident.Description,
+		&incident.Status,
+		&incident.Priority,
+		&problemID,
+		&resolution,
+		&updates,
+		&incident.CreatedBy,
+		&incident.CreatedAt,
+		&incident.UpdatedAt,
+		&resolvedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	incident.ProblemID = mapStringPtr(problemID)
+	incident.Resolution = resolution.String
+	if resolvedAt.Valid {
+		incident.ResolvedAt = &resolvedAt.Time
+	}
+
+	if err := json.Unmarshal(updates, &incident.Updates); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal incident updates: %w", err)
+	}
+
+	return &incident, nil
+}
@@ -0,0 +1,119 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// PostgresJobRunRepository implements JobRunRepository using PostgreSQL
+type PostgresJobRunRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresJobRunRepository creates a new PostgreSQL scheduled job run repository
+func NewPostgresJobRunRepository(db *sql.DB) ports.JobRunRepository {
+	return &PostgresJobRunRepository{db: db}
+}
+
+const jobRunColumns = `id, job, instance, status, processed, error, started_at, finished_at`
+
+// Create records a finished run
+func (r *PostgresJobRunRepository) Create(ctx context.Context, run *domain.JobRun) error {
+	query := `
+		INSERT INTO scheduler_job_runs (` + jobRunColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		run.ID,
+		run.Job,
+		run.Instance,
+		string(run.Status),
+		run.Processed,
+		sql.NullString{String: run.Error, Valid: run.Error != ""},
+		run.StartedAt,
+		run.FinishedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create job run: %w", err)
+	}
+
+	return nil
+}
+
+// LatestByJob retrieves the most recent run of each job, keyed by job name
+func (r *PostgresJobRunRepository) LatestByJob(ctx context.Context) (map[string]*domain.JobRun, error) {
+	query := `
+		SELECT DISTINCT ON (job) ` + jobRunColumns + `
+		FROM scheduler_job_runs
+		ORDER BY job, started_at DESC
+	`
+
+	runs, err := r.query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	latest := make(map[string]*domain.JobRun, len(runs))
+	for _, run := range runs {
+		latest[run.Job] = run
+	}
+
+	return latest, nil
+}
+
+// ListByJob retrieves a job's runs, newest first
+func (r *PostgresJobRunRepository) ListByJob(ctx context.Context, job string, limit int) ([]*domain.JobRun, error) {
+	query := `
+		SELECT ` + jobRunColumns + `
+		FROM scheduler_job_runs
+		WHERE job = $1
+		ORDER BY started_at DESC
+		LIMIT $2
+	`
+
+	return r.query(ctx, query, job, limit)
+}
+
+func (r *PostgresJobRunRepository) query(ctx context.Context, query string, args ...interface{}) ([]*domain.JobRun, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query job runs: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []*domain.JobRun
+
+	for rows.Next() {
+		var run domain.JobRun
+		var runError sql.NullString
+
+		err := rows.Scan(
+			&run.ID,
+			&run.Job,
+			&run.Instance,
+			&run.Status,
+			&run.Processed,
+			&runError,
+			&run.StartedAt,
+			&run.FinishedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan job run: %w", err)
+		}
+
+		run.Error = runError.String
+		runs = append(runs, &run)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating job runs: %w", err)
+	}
+
+	return runs, nil
+}
@@ -0,0 +1,224 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// PostgresIndexJobRepository implements IndexJobRepository using PostgreSQL
+type PostgresIndexJobRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresIndexJobRepository creates a new PostgreSQL indexing job repository
+func NewPostgresIndexJobRepository(db *sql.DB) ports.IndexJobRepository {
+	return &PostgresIndexJobRepository{db: db}
+}
+
+const indexJobColumns = `id, entry_id, version, status, total_chunks, processed_chunks, attempts, error,
+	created_at, started_at, finished_at, updated_at, lease_until`
+
+// Create saves a new indexing job
+func (r *PostgresIndexJobRepository) Create(ctx context.Context, job *domain.IndexJob) error {
+	query := `
+		INSERT INTO kb_index_jobs (` + indexJobColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		job.ID,
+		job.EntryID,
+		job.Version,
+		string(job.Status),
+		job.TotalChunks,
+		job.ProcessedChunks,
+		job.Attempts,
+		job.Error,
+		job.CreatedAt,
+		job.StartedAt,
+		job.FinishedAt,
+		job.UpdatedAt,
+		job.LeaseUntil,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create indexing job: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves an indexing job by its ID
+func (r *PostgresIndexJobRepository) FindByID(ctx context.Context, id string) (*domain.IndexJob, error) {
+	query := `
+		SELECT ` + indexJobColumns + `
+		FROM kb_index_jobs
+		WHERE id = $1
+	`
+
+	job, err := scanIndexJob(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrIndexJobNotFound
+		}
+		return nil, fmt.Errorf("failed to find indexing job: %w", err)
+	}
+
+	return job, nil
+}
+
+// Update updates the status and progress of an indexing job
+func (r *PostgresIndexJobRepository) Update(ctx context.Context, job *domain.IndexJob) error {
+	query := `
+		UPDATE kb_index_jobs
+		SET status = $2, total_chunks = $3, processed_chunks = $4, attempts = $5, error = $6,
+			started_at = $7, finished_at = $8, updated_at = $9, lease_until = $10
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		job.ID,
+		string(job.Status),
+		job.TotalChunks,
+		job.ProcessedChunks,
+		job.Attempts,
+		job.Error,
+		job.StartedAt,
+		job.FinishedAt,
+		job.UpdatedAt,
+		job.LeaseUntil,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update indexing job: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrIndexJobNotFound
+	}
+
+	return nil
+}
+
+// ListByStatus retrieves indexing jobs with the given statuses, oldest first
+func (r *PostgresIndexJobRepository) ListByStatus(ctx context.Context, statuses []domain.IndexJobStatus, limit int) ([]*domain.IndexJob, error) {
+	if len(statuses) == 0 {
+		return nil, nil
+	}
+
+	placeholders := make([]string, len(statuses))
+	args := make([]interface{}, 0, len(statuses)+1)
+	for i, status := range statuses {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args = append(args, string(status))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT `+indexJobColumns+`
+		FROM kb_index_jobs
+		WHERE status IN (%s)
+		ORDER BY created_at ASC
+	`, strings.Join(placeholders, ", "))
+
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", len(args)+1)
+		args = append(args, limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query indexing jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*domain.IndexJob
+
+	for rows.Next() {
+		job, err := scanIndexJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan indexing job: %w", err)
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating indexing jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// Claim atomically starts a queued job, or a running one whose lease expired, for lease. It
+// fails with ErrIndexJobClaimed when the job is finished or another worker holds it.
+func (r *PostgresIndexJobRepository) Claim(ctx context.Context, id string, lease time.Duration) (*domain.IndexJob, error) {
+	query := `
+		UPDATE kb_index_jobs
+		SET status = 'running', attempts = attempts + 1, started_at = $2, updated_at = $2, lease_until = $3
+		WHERE id = $1
+		  AND (status = 'queued' OR (status = 'running' AND (lease_until IS NULL OR lease_until < $2)))
+		RETURNING ` + indexJobColumns
+
+	now := time.Now()
+	job, err := scanIndexJob(r.db.QueryRowContext(ctx, query, id, now, now.Add(lease)))
+	if err == sql.ErrNoRows {
+		if _, err := r.FindByID(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrIndexJobClaimed
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim indexing job: %w", err)
+	}
+
+	return job, nil
+}
+
+func scanIndexJob(row rowScanner) (*domain.IndexJob, error) {
+	var job domain.IndexJob
+	var startedAt, finishedAt, leaseUntil sql.NullTime
+
+	err := row.Scan(
+		&job.ID,
+		&job.EntryID,
+		&job.Version,
+		&job.Status,
+		&job.TotalChunks,
+		&job.ProcessedChunks,
+		&job.Attempts,
+		&job.Error,
+		&job.CreatedAt,
+		&startedAt,
+		&finishedAt,
+		&job.UpdatedAt,
+		&leaseUntil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if startedAt.Valid {
+		job.StartedAt = &startedAt.Time
+	}
+
+	if finishedAt.Valid {
+		job.FinishedAt = &finishedAt.Time
+	}
+
+	if leaseUntil.Valid {
+		job.LeaseUntil = &leaseUntil.Time
+	}
+
+	return &job, nil
+}
@@ -0,0 +1,178 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// PostgresKBReindexRepository implements KBReindexRepository using a shadow vector column on kb_chunks.
+// Shadow vectors are written to embedding_next and never read by search until CutOver swaps them in.
+type PostgresKBReindexRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresKBReindexRepository creates a new PostgreSQL reindex repository
+func NewPostgresKBReindexRepository(db *sql.DB) ports.KBReindexRepository {
+	return &PostgresKBReindexRepository{db: db}
+}
+
+// ReindexProgress reports how many chunks already have vectors in the target space
+func (r *PostgresKBReindexRepository) ReindexProgress(ctx context.Context, target domain.EmbeddingSpace) (*domain.ReindexProgress, error) {
+	query := `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE embedding_next_model = $1 AND embedding_next_dim = $2),
+			COUNT(*) FILTER (WHERE embedding_model = $1 AND embedding_dim = $2 AND embedding_next_model IS NULL)
+		FROM kb_chunks
+	`
+
+	progress := &domain.ReindexProgress{Target: target}
+
+	err := r.db.QueryRowContext(ctx, query, target.Model, target.Dimension).Scan(
+		&progress.TotalChunks,
+		&progress.ReindexedChunks,
+		&progress.CurrentChunks,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reindex progress: %w", err)
+	}
+
+	return progress, nil
+}
+
+// ListChunksForReindex retrieves chunks that have no shadow vector in the target space yet
+func (r *PostgresKBReindexRepository) ListChunksForReindex(ctx context.Context, target domain.EmbeddingSpace, limit int) ([]*domain.KBChunk, error) {
+	query := `
+		SELECT id, entry_id, chunk_index, content, created_at
+		FROM kb_chunks
+		WHERE embedding_next_model IS DISTINCT FROM $1 OR embedding_next_dim IS DISTINCT FROM $2
+		ORDER BY id
+		LIMIT $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, target.Model, target.Dimension, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query chunks for reindex: %w", err)
+	}
+	defer rows.Close()
+
+	var chunks []*domain.KBChunk
+
+	for rows.Next() {
+		var chunk domain.KBChunk
+
+		err := rows.Scan(
+			&chunk.ID,
+			&chunk.EntryID,
+			&chunk.ChunkIndex,
+			&chunk.Content,
+			&chunk.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan knowledge chunk: %w", err)
+		}
+
+		chunks = append(chunks, &chunk)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating knowledge chunks: %w", err)
+	}
+
+	return chunks, nil
+}
+
+// SaveShadowEmbeddings stores target-space vectors next to the live ones without affecting search
+func (r *PostgresKBReindexRepository) SaveShadowEmbeddings(ctx context.Context, target domain.EmbeddingSpace, chunks []*domain.KBChunk) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE kb_chunks
+		SET embedding_next = $2, embedding_next_model = $3, embedding_next_dim = $4
+		WHERE id = $1
+	`
+
+	for _, chunk := range chunks {
+		if !target.Matches(chunk.EmbeddingModel, chunk.Embedding) {
+			return domain.ErrInvalidEmbedding
+		}
+
+		// Chunks deleted by a concurrent publish simply no longer match
+		if _, err := tx.ExecContext(ctx, query, chunk.ID, vectorLiteral(chunk.Embedding), target.Model, target.Dimension); err != nil {
+			return fmt.Errorf("failed to save shadow embedding: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// CutOver atomically replaces all live vectors with the shadow vectors of the target space.
+// Writers are blocked for the duration of the swap; it fails with ErrReindexIncomplete if any
+// chunk, including one published while the reindex was running, still lacks a shadow vector.
+func (r *PostgresKBReindexRepository) CutOver(ctx context.Context, target domain.EmbeddingSpace) error {
+	if target.Dimension <= 0 {
+		return domain.ErrInvalidEmbedding
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "LOCK TABLE kb_chunks IN SHARE ROW EXCLUSIVE MODE"); err != nil {
+		return fmt.Errorf("failed to lock knowledge chunks: %w", err)
+	}
+
+	var pending int
+	err = tx.QueryRowContext(ctx, `
+		SELECT COUNT(*)
+		FROM kb_chunks
+		WHERE embedding_next_model IS DISTINCT FROM $1 OR embedding_next_dim IS DISTINCT FROM $2
+	`, target.Model, target.Dimension).Scan(&pending)
+	if err != nil {
+		return fmt.Errorf("failed to count pending chunks: %w", err)
+	}
+
+	if pending > 0 {
+		return domain.ErrReindexIncomplete
+	}
+
+	for _, statement := range cutOverStatements(target.Dimension) {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("failed to cut over embeddings: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// cutOverStatements returns the statements that swap in the shadow vectors. The vector index is
+// bound to the column dimension, so it is dropped before the column type changes and recreated
+// once the new vectors are in place.
+func cutOverStatements(dimension int) []string {
+	return []string{
+		"DROP INDEX IF EXISTS idx_kb_chunks_embedding_ivfflat",
+		fmt.Sprintf("ALTER TABLE kb_chunks ALTER COLUMN embedding TYPE VECTOR(%d) USING embedding_next::VECTOR(%d)", dimension, dimension),
+		`UPDATE kb_chunks
+		 SET embedding_model = embedding_next_model, embedding_dim = embedding_next_dim,
+		     embedding_next = NULL, embedding_next_model = NULL, embedding_next_dim = NULL`,
+		"CREATE INDEX idx_kb_chunks_embedding_ivfflat ON kb_chunks USING ivfflat (embedding vector_cosine_ops)",
+	}
+}
@@ -0,0 +1,84 @@
+package persistence
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	_ "github.com/lib/pq"
+)
+
+// testDB connects to the Postgres database named by FIXORA_TEST_DATABASE_URL, which needs the
+// pgvector extension, and skips the test when it is not set
+func testDB(t *testing.T) *sql.DB {
+	t.Helper()
+	url := os.Getenv("FIXORA_TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("FIXORA_TEST_DATABASE_URL not set")
+	}
+
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestCutOverStatements(t *testing.T) {
+	db := testDB(t)
+
+	// Everything runs in a throwaway schema inside a transaction that is rolled back
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("Failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	setup := []string{
+		"CREATE EXTENSION IF NOT EXISTS vector",
+		"CREATE SCHEMA fixora_cutover_test",
+		"SET LOCAL search_path TO fixora_cutover_test, public",
+		`CREATE TABLE kb_chunks (
+		     id TEXT PRIMARY KEY,
+		     embedding VECTOR(3),
+		     embedding_model TEXT NOT NULL DEFAULT '',
+		     embedding_dim INT NOT NULL DEFAULT 0,
+		     embedding_next VECTOR,
+		     embedding_next_model TEXT,
+		     embedding_next_dim INT
+		 )`,
+		"CREATE INDEX idx_kb_chunks_embedding_ivfflat ON kb_chunks USING ivfflat (embedding vector_cosine_ops)",
+		`INSERT INTO kb_chunks (id, embedding, embedding_model, embedding_dim, embedding_next, embedding_next_model, embedding_next_dim)
+		 VALUES ('chunk_1', '[1,0,0]', 'old-model', 3, '[0,1,0,0]', 'new-model', 4)`,
+	}
+	for _, statement := range setup {
+		if _, err := tx.Exec(statement); err != nil {
+			t.Fatalf("Failed to set up %q: %v", statement, err)
+		}
+	}
+
+	for _, statement := range cutOverStatements(4) {
+		if _, err := tx.Exec(statement); err != nil {
+			t.Fatalf("Failed to run %q: %v", statement, err)
+		}
+	}
+
+	var dims int
+	var model string
+	var next sql.NullString
+	if err := tx.QueryRow(`SELECT vector_dims(embedding), embedding_model, embedding_next::TEXT FROM kb_chunks`).Scan(&dims, &model, &next); err != nil {
+		t.Fatalf("Failed to read chunk: %v", err)
+	}
+	if dims != 4 || model != "new-model" || next.Valid {
+		t.Errorf("Expected the shadow vector to be swapped in, got %d dims from %s (next %v)", dims, model, next)
+	}
+
+	var indexed bool
+	if err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM pg_indexes WHERE schemaname = 'fixora_cutover_test' AND indexname = 'idx_kb_chunks_embedding_ivfflat')`).Scan(&indexed); err != nil {
+		t.Fatalf("Failed to check index: %v", err)
+	}
+	if !indexed {
+		t.Error("Expected the vector index to be recreated")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -29,8 +30,8 @@ func NewPostgresKnowledgeRepository(db *sql.DB, embeddings ports.EmbeddingProvid
 // CreateEntry saves a new knowledge base entry
 func (r *PostgresKnowledgeRepository) CreateEntry(ctx context.Context, entry *domain.KnowledgeEntry) error {
 	query := `
-		INSERT INTO knowledge_entries (id, title, content, status, category, tags, source_type, version, created_by, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		INSERT INTO knowledge_entries (id, title, content, status, category, tags, source_type, source_ticket_id, version, published_version, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 	`
 
 	tagsJSON, err := json.Marshal(entry.Tags)
@@ -46,7 +47,9 @@ func (r *PostgresKnowledgeRepository) CreateEntry(ctx context.Context, entry *do
 		entry.Category,
 		tagsJSON,
 		string(entry.SourceType),
+		sql.NullString{String: entry.SourceTicketID, Valid: entry.SourceTicketID != ""},
 		entry.Version,
+		entry.PublishedVersion,
 		entry.CreatedBy,
 		entry.CreatedAt,
 		entry.UpdatedAt,
@@ -62,14 +65,14 @@ func (r *PostgresKnowledgeRepository) CreateEntry(ctx context.Context, entry *do
 // FindEntryByID retrieves a knowledge base entry by its ID
 func (r *PostgresKnowledgeRepository) FindEntryByID(ctx context.Context, id string) (*domain.KnowledgeEntry, error) {
 	query := `
-		SELECT id, title, content, status, category, tags, source_type, version, created_by, created_at, updated_at
+		SELECT id, title, content, status, category, tags, source_type, source_ticket_id, version, published_version, created_by, created_at, updated_at
 		FROM knowledge_entries
 		WHERE id = $1
 	`
 
 	var entry domain.KnowledgeEntry
 	var tagsJSON []byte
-	var category sql.NullString
+	var category, sourceTicketID sql.NullString
 
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&entry.ID,
@@ -79,7 +82,9 @@ func (r *PostgresKnowledgeRepository) FindEntryByID(ctx context.Context, id stri
 		&category,
 		&tagsJSON,
 		&entry.SourceType,
+		&sourceTicketID,
 		&entry.Version,
+		&entry.PublishedVersion,
 		&entry.CreatedBy,
 		&entry.CreatedAt,
 		&entry.UpdatedAt,
@@ -95,6 +100,7 @@ func (r *PostgresKnowledgeRepository) FindEntryByID(ctx context.Context, id stri
 	if category.Valid {
 		entry.Category = category.String
 	}
+	entry.SourceTicketID = sourceTicketID.String
 
 	if len(tagsJSON) > 0 {
 		if err := json.Unmarshal(tagsJSON, &entry.Tags); err != nil {
@@ -107,9 +113,13 @@ func (r *PostgresKnowledgeRepository) FindEntryByID(ctx context.Context, id stri
 
 // UpdateEntry updates an existing knowledge base entry
 func (r *PostgresKnowledgeRepository) UpdateEntry(ctx context.Context, entry *domain.KnowledgeEntry) error {
+	return updateEntry(ctx, r.db, entry)
+}
+
+func updateEntry(ctx context.Context, exec execer, entry *domain.KnowledgeEntry) error {
 	query := `
 		UPDATE knowledge_entries
-		SET title = $2, content = $3, status = $4, category = $5, tags = $6, version = $7, updated_at = $8
+		SET title = $2, content = $3, status = $4, category = $5, tags = $6, version = $7, published_version = $8, updated_at = $9
 		WHERE id = $1
 	`
 
@@ -118,7 +128,7 @@ func (r *PostgresKnowledgeRepository) UpdateEntry(ctx context.Context, entry *do
 		return fmt.Errorf("failed to marshal tags: %w", err)
 	}
 
-	result, err := r.db.ExecContext(ctx, query,
+	result, err := exec.ExecContext(ctx, query,
 		entry.ID,
 		entry.Title,
 		entry.Content,
@@ -126,6 +136,7 @@ func (r *PostgresKnowledgeRepository) UpdateEntry(ctx context.Context, entry *do
 		entry.Category,
 		tagsJSON,
 		entry.Version,
+		entry.PublishedVersion,
 		entry.UpdatedAt,
 	)
 
@@ -148,7 +159,7 @@ func (r *PostgresKnowledgeRepository) UpdateEntry(ctx context.Context, entry *do
 // ListEntries retrieves knowledge base entries based on filter
 func (r *PostgresKnowledgeRepository) ListEntries(ctx context.Context, filter domain.KBChunkFilter) ([]*domain.KnowledgeEntry, error) {
 	query := `
-		SELECT DISTINCT ke.id, ke.title, ke.content, ke.status, ke.category, ke.tags, ke.source_type, ke.version, ke.created_by, ke.created_at, ke.updated_at
+		SELECT DISTINCT ke.id, ke.title, ke.content, ke.status, ke.category, ke.tags, ke.source_type, ke.source_ticket_id, ke.version, ke.published_version, ke.created_by, ke.created_at, ke.updated_at
 		FROM knowledge_entries ke
 		LEFT JOIN kb_chunks kc ON ke.id = kc.entry_id
 		WHERE 1=1
@@ -177,6 +188,18 @@ func (r *PostgresKnowledgeRepository) ListEntries(ctx context.Context, filter do
 		argIndex++
 	}
 
+	if filter.SourceType != "" {
+		conditions = append(conditions, fmt.Sprintf("ke.source_type = $%d", argIndex))
+		args = append(args, filter.SourceType)
+		argIndex++
+	}
+
+	if filter.SourceTicketID != "" {
+		conditions = append(conditions, fmt.Sprintf("ke.source_ticket_id = $%d", argIndex))
+		args = append(args, filter.SourceTicketID)
+		argIndex++
+	}
+
 	// Add conditions to query
 	if len(conditions) > 0 {
 		query += " AND " + strings.Join(conditions, " AND ")
@@ -195,7 +218,7 @@ func (r *PostgresKnowledgeRepository) ListEntries(ctx context.Context, filter do
 	for rows.Next() {
 		var entry domain.KnowledgeEntry
 		var tagsJSON []byte
-		var category sql.NullString
+		var category, sourceTicketID sql.NullString
 
 		err := rows.Scan(
 			&entry.ID,
@@ -205,7 +228,9 @@ func (r *PostgresKnowledgeRepository) ListEntries(ctx context.Context, filter do
 			&category,
 			&tagsJSON,
 			&entry.SourceType,
+			&sourceTicketID,
 			&entry.Version,
+			&entry.PublishedVersion,
 			&entry.CreatedBy,
 			&entry.CreatedAt,
 			&entry.UpdatedAt,
@@ -218,6 +243,7 @@ func (r *PostgresKnowledgeRepository) ListEntries(ctx context.Context, filter do
 		if category.Valid {
 			entry.Category = category.String
 		}
+		entry.SourceTicketID = sourceTicketID.String
 
 		if len(tagsJSON) > 0 {
 			if err := json.Unmarshal(tagsJSON, &entry.Tags); err != nil {
@@ -264,17 +290,23 @@ func (r *PostgresKnowledgeRepository) DeleteEntry(ctx context.Context, id string
 
 // CreateChunk saves a knowledge base chunk
 func (r *PostgresKnowledgeRepository) CreateChunk(ctx context.Context, chunk *domain.KBChunk) error {
+	return insertChunk(ctx, r.db, chunk)
+}
+
+func insertChunk(ctx context.Context, exec execer, chunk *domain.KBChunk) error {
 	query := `
-		INSERT INTO kb_chunks (id, entry_id, chunk_index, content, embedding, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO kb_chunks (id, entry_id, chunk_index, content, embedding, embedding_model, embedding_dim, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := exec.ExecContext(ctx, query,
 		chunk.ID,
 		chunk.EntryID,
 		chunk.ChunkIndex,
 		chunk.Content,
-		chunk.Embedding,
+		vectorLiteral(chunk.Embedding),
+		chunk.EmbeddingModel,
+		chunk.EmbeddingDim(),
 		chunk.CreatedAt,
 	)
 
@@ -285,10 +317,60 @@ func (r *PostgresKnowledgeRepository) CreateChunk(ctx context.Context, chunk *do
 	return nil
 }
 
+// ReplaceChunks atomically swaps all chunks of an entry and publishes the entry's version in one
+// transaction. Readers see either the previous chunk set or the new one, never a mix. Only the
+// status and published version are written, and only while the entry is still at that version,
+// so a draft saved while the chunks were being embedded is kept.
+func (r *PostgresKnowledgeRepository) ReplaceChunks(ctx context.Context, entry *domain.KnowledgeEntry, chunks []*domain.KBChunk) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the entry so concurrent publishes and edits of the same entry are serialized
+	var version int
+	err = tx.QueryRowContext(ctx, "SELECT version FROM knowledge_entries WHERE id = $1 FOR UPDATE", entry.ID).Scan(&version)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.ErrKBEntryNotFound
+		}
+		return fmt.Errorf("failed to lock knowledge entry: %w", err)
+	}
+
+	if version != entry.Version {
+		return domain.ErrIndexJobSuperseded
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM kb_chunks WHERE entry_id = $1", entry.ID); err != nil {
+		return fmt.Errorf("failed to delete knowledge chunks: %w", err)
+	}
+
+	for _, chunk := range chunks {
+		if err := insertChunk(ctx, tx, chunk); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx,
+		"UPDATE knowledge_entries SET status = $2, published_version = $3, updated_at = $4 WHERE id = $1",
+		entry.ID, string(entry.Status), entry.PublishedVersion, entry.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to publish knowledge entry: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
 // FindChunksByEntry retrieves all chunks for an entry
 func (r *PostgresKnowledgeRepository) FindChunksByEntry(ctx context.Context, entryID string) ([]*domain.KBChunk, error) {
 	query := `
-		SELECT id, entry_id, chunk_index, content, embedding, created_at
+		SELECT id, entry_id, chunk_index, content, embedding, embedding_model, created_at
 		FROM kb_chunks
 		WHERE entry_id = $1
 		ORDER BY chunk_index
@@ -312,6 +394,7 @@ func (r *PostgresKnowledgeRepository) FindChunksByEntry(ctx context.Context, ent
 			&chunk.ChunkIndex,
 			&chunk.Content,
 			&embedding,
+			&chunk.EmbeddingModel,
 			&chunk.CreatedAt,
 		)
 
@@ -348,18 +431,21 @@ func (r *PostgresKnowledgeRepository) SearchChunks(ctx context.Context, queryTex
 		return nil, fmt.Errorf("failed to generate query embedding: %w", err)
 	}
 
-	// Build the similarity search query
+	// Build the similarity search query. Chunks are only written on publish, so they always
+	// belong to the published version even while a newer draft is in progress. Only vectors
+	// produced by the query's model and dimension are compared, so spaces are never mixed.
 	sqlQuery := `
-		SELECT kc.id, kc.entry_id, kc.chunk_index, kc.content, kc.embedding, kc.created_at,
+		SELECT kc.id, kc.entry_id, kc.chunk_index, kc.content, kc.embedding, kc.embedding_model, kc.created_at,
 			   1 - (kc.embedding <=> $1) as score
 		FROM kb_chunks kc
 		JOIN knowledge_entries ke ON ke.id = kc.entry_id
-		WHERE ke.status = 'active'
+		WHERE ke.published_version > 0 AND ke.status <> 'archived'
+			AND kc.embedding_model = $2 AND kc.embedding_dim = $3
 	`
 
 	var args []interface{}
-	args = append(args, queryEmbedding)
-	argIndex := 2
+	args = append(args, vectorLiteral(queryEmbedding), r.embeddings.Model(), len(queryEmbedding))
+	argIndex := 4
 
 	// Add additional filters
 	if filter.Category != "" {
@@ -402,6 +488,7 @@ func (r *PostgresKnowledgeRepository) SearchChunks(ctx context.Context, queryTex
 			&chunk.ChunkIndex,
 			&chunk.Content,
 			&embedding,
+			&chunk.EmbeddingModel,
 			&chunk.CreatedAt,
 			&score,
 		)
@@ -413,6 +500,7 @@ func (r *PostgresKnowledgeRepository) SearchChunks(ctx context.Context, queryTex
 		if len(embedding) > 0 {
 			chunk.Embedding = bytesToFloat32Slice(embedding)
 		}
+		chunk.Score = score
 
 		chunks = append(chunks, &chunk)
 	}
@@ -428,14 +516,16 @@ func (r *PostgresKnowledgeRepository) SearchChunks(ctx context.Context, queryTex
 func (r *PostgresKnowledgeRepository) UpdateChunk(ctx context.Context, chunk *domain.KBChunk) error {
 	query := `
 		UPDATE kb_chunks
-		SET content = $2, embedding = $3
+		SET content = $2, embedding = $3, embedding_model = $4, embedding_dim = $5
 		WHERE id = $1
 	`
 
 	result, err := r.db.ExecContext(ctx, query,
 		chunk.ID,
 		chunk.Content,
-		chunk.Embedding,
+		vectorLiteral(chunk.Embedding),
+		chunk.EmbeddingModel,
+		chunk.EmbeddingDim(),
 	)
 
 	if err != nil {
@@ -466,6 +556,211 @@ func (r *PostgresKnowledgeRepository) DeleteChunksByEntry(ctx context.Context, e
 	return nil
 }
 
+// CreateRevision saves an immutable revision of an entry
+func (r *PostgresKnowledgeRepository) CreateRevision(ctx context.Context, revision *domain.KnowledgeRevision) error {
+	return insertRevision(ctx, r.db, revision)
+}
+
+func insertRevision(ctx context.Context, exec execer, revision *domain.KnowledgeRevision) error {
+	query := `
+		INSERT INTO knowledge_entry_revisions (id, entry_id, version, title, content, category, tags, author_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	tagsJSON, err := json.Marshal(revision.Tags)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tags: %w", err)
+	}
+
+	_, err = exec.ExecContext(ctx, query,
+		revision.ID,
+		revision.EntryID,
+		revision.Version,
+		revision.Title,
+		revision.Content,
+		revision.Category,
+		tagsJSON,
+		revision.AuthorID,
+		revision.CreatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create knowledge revision: %w", err)
+	}
+
+	return nil
+}
+
+// SaveEntryRevision saves an edited entry and its new revision in one transaction. The update is
+// guarded by the version the edit started from, so of two concurrent edits only the first is saved
+// and the second fails with ErrKBEntryModified instead of overwriting it.
+func (r *PostgresKnowledgeRepository) SaveEntryRevision(ctx context.Context, entry *domain.KnowledgeEntry, revision *domain.KnowledgeRevision, expectedVersion int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	tagsJSON, err := json.Marshal(entry.Tags)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tags: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE knowledge_entries
+		SET title = $2, content = $3, status = $4, category = $5, tags = $6, version = $7, published_version = $8, updated_at = $9
+		WHERE id = $1 AND version = $10
+	`,
+		entry.ID,
+		entry.Title,
+		entry.Content,
+		string(entry.Status),
+		entry.Category,
+		tagsJSON,
+		entry.Version,
+		entry.PublishedVersion,
+		entry.UpdatedAt,
+		expectedVersion,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update knowledge entry: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		var exists bool
+		if err := tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM knowledge_entries WHERE id = $1)", entry.ID).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check knowledge entry: %w", err)
+		}
+		if !exists {
+			return domain.ErrKBEntryNotFound
+		}
+		return domain.ErrKBEntryModified
+	}
+
+	if err := insertRevision(ctx, tx, revision); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// FindRevision retrieves a specific revision of an entry
+func (r *PostgresKnowledgeRepository) FindRevision(ctx context.Context, entryID string, version int) (*domain.KnowledgeRevision, error) {
+	query := `
+		SELECT id, entry_id, version, title, content, category, tags, author_id, created_at
+		FROM knowledge_entry_revisions
+		WHERE entry_id = $1 AND version = $2
+	`
+
+	revision, err := scanRevision(r.db.QueryRowContext(ctx, query, entryID, version))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrKBRevisionNotFound
+		}
+		return nil, fmt.Errorf("failed to find knowledge revision: %w", err)
+	}
+
+	return revision, nil
+}
+
+// ListRevisions retrieves all revisions of an entry, newest first
+func (r *PostgresKnowledgeRepository) ListRevisions(ctx context.Context, entryID string) ([]*domain.KnowledgeRevision, error) {
+	query := `
+		SELECT id, entry_id, version, title, content, category, tags, author_id, created_at
+		FROM knowledge_entry_revisions
+		WHERE entry_id = $1
+		ORDER BY version DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, entryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query knowledge revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []*domain.KnowledgeRevision
+
+	for rows.Next() {
+		revision, err := scanRevision(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan knowledge revision: %w", err)
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating knowledge revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// execer abstracts *sql.DB and *sql.Tx so statements can run inside or outside a transaction
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// rowScanner abstracts *sql.Row and *sql.Rows for shared scan helpers
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRevision(row rowScanner) (*domain.KnowledgeRevision, error) {
+	var revision domain.KnowledgeRevision
+	var tagsJSON []byte
+	var category sql.NullString
+
+	err := row.Scan(
+		&revision.ID,
+		&revision.EntryID,
+		&revision.Version,
+		&revision.Title,
+		&revision.Content,
+		&category,
+		&tagsJSON,
+		&revision.AuthorID,
+		&revision.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if category.Valid {
+		revision.Category = category.String
+	}
+
+	if len(tagsJSON) > 0 {
+		if err := json.Unmarshal(tagsJSON, &revision.Tags); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal tags: %w", err)
+		}
+	}
+
+	return &revision, nil
+}
+
+// Helper function to encode an embedding in pgvector text format ("[0.1,0.2,...]")
+func vectorLiteral(embedding []float32) interface{} {
+	if len(embedding) == 0 {
+		return nil
+	}
+
+	parts := make([]string, len(embedding))
+	for i, v := range embedding {
+		parts[i] = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	}
+	return "[" + strings.Join(parts, ",") + "]"
+}
+
 // Helper function to convert byte array to float32 slice
 func bytesToFloat32Slice(data []byte) []float32 {
 	if len(data) == 0 {
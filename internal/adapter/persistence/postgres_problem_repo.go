@@ -0,0 +1,194 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// PostgresProblemRepository implements ProblemRepository using PostgreSQL
+type PostgresProblemRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresProblemRepository creates a new PostgreSQL problem repository
+func NewPostgresProblemRepository(db *sql.DB) ports.ProblemRepository {
+	return &PostgresProblemRepository{db: db}
+}
+
+const problemColumns = `id, title, description, status, root_cause, workaround, kb_entry_id, category,
+	created_by, created_at, updated_at, resolved_at`
+
+// Create saves a new problem
+func (r *PostgresProblemRepository) Create(ctx context.Context, problem *domain.Problem) error {
+	query := `
+		INSERT INTO problems (` + problemColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		problem.ID,
+		problem.Title,
+		problem.Description,
+		string(problem.Status),
+		sql.NullString{String: problem.RootCause, Valid: problem.RootCause != ""},
+		sql.NullString{String: problem.Workaround, Valid: problem.Workaround != ""},
+		sql.NullString{String: problem.KBEntryID, Valid: problem.KBEntryID != ""},
+		sql.NullString{String: string(problem.Category), Valid: problem.Category != ""},
+		problem.CreatedBy,
+		problem.CreatedAt,
+		problem.UpdatedAt,
+		problem.ResolvedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create problem: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves a problem by ID
+func (r *PostgresProblemRepository) FindByID(ctx context.Context, id string) (*domain.Problem, error) {
+	query := `SELECT ` + problemColumns + ` FROM problems WHERE id = $1`
+
+	problem, err := scanProblem(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrProblemNotFound
+		}
+		return nil, fmt.Errorf("failed to find problem: %w", err)
+	}
+
+	return problem, nil
+}
+
+// Update updates an existing problem
+func (r *PostgresProblemRepository) Update(ctx context.Context, problem *domain.Problem) error {
+	query := `
+		UPDATE problems
+		SET title = $2, description = $3, status = $4, root_cause = $5, workaround = $6, kb_entry_id = $7,
+			category = $8, updated_at = $9, resolved_at = $10
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		problem.ID,
+		problem.Title,
+		problem.Description,
+		string(problem.Status),
+		sql.NullString{String: problem.RootCause, Valid: problem.RootCause != ""},
+		sql.NullString{String: problem.Workaround, Valid: problem.Workaround != ""},
+		sql.NullString{String: problem.KBEntryID, Valid: problem.KBEntryID != ""},
+		sql.NullString{String: string(problem.Category), Valid: problem.Category != ""},
+		problem.UpdatedAt,
+		problem.ResolvedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update problem: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrProblemNotFound
+	}
+
+	return nil
+}
+
+// List retrieves problems matching the filter, newest first
+func (r *PostgresProblemRepository) List(ctx context.Context, filter domain.ProblemFilter) ([]*domain.Problem, error) {
+	query := `SELECT ` + problemColumns + ` FROM problems WHERE 1=1`
+
+	var conditions []string
+	var args []interface{}
+	argIndex := 1
+
+	if filter.Status != nil {
+		conditions = append(conditions, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, string(*filter.Status))
+		argIndex++
+	}
+
+	if len(conditions) > 0 {
+		query += " AND " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY created_at DESC"
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argIndex)
+		args = append(args, filter.Limit)
+		argIndex++
+	}
+
+	if filter.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET $%d", argIndex)
+		args = append(args, filter.Offset)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query problems: %w", err)
+	}
+	defer rows.Close()
+
+	var problems []*domain.Problem
+
+	for rows.Next() {
+		problem, err := scanProblem(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan problem: %w", err)
+		}
+		problems = append(problems, problem)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating problems: %w", err)
+	}
+
+	return problems, nil
+}
+
+func scanProblem(row rowScanner) (*domain.Problem, error) {
+	var problem domain.Problem
+	var rootCause, workaround, kbEntryID, category sql.NullString
+	var resolvedAt sql.NullTime
+
+	err := row.Scan(
+		&problem.ID,
+		&problem.Title,
+		&problem.Description,
+		&problem.Status,
+		&rootCause,
+		&workaround,
+		&kbEntryID,
+		&category,
+		&problem.CreatedBy,
+		&problem.CreatedAt,
+		&problem.UpdatedAt,
+		&resolvedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	problem.RootCause = rootCause.String
+	problem.Workaround = workaround.String
+	problem.KBEntryID = kbEntryID.String
+	problem.Category = domain.TicketCategory(category.String)
+	if resolvedAt.Valid {
+		problem.ResolvedAt = &resolvedAt.Time
+	}
+
+	return &problem, nil
+}
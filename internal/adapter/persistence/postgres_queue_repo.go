@@ -0,0 +1,152 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// PostgresQueueRepository implements QueueRepository using PostgreSQL
+type PostgresQueueRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresQueueRepository creates a new PostgreSQL queue repository
+func NewPostgresQueueRepository(db *sql.DB) ports.QueueRepository {
+	return &PostgresQueueRepository{db: db}
+}
+
+const queueColumns = `id, name, description, team_id, created_at, updated_at`
+
+// Create saves a new queue
+func (r *PostgresQueueRepository) Create(ctx context.Context, queue *domain.Queue) error {
+	query := `
+		INSERT INTO queues (` + queueColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		queue.ID,
+		queue.Name,
+		sql.NullString{String: queue.Description, Valid: queue.Description != ""},
+		queue.TeamID,
+		queue.CreatedAt,
+		queue.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create queue: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves a queue by ID
+func (r *PostgresQueueRepository) FindByID(ctx context.Context, id string) (*domain.Queue, error) {
+	query := `SELECT ` + queueColumns + ` FROM queues WHERE id = $1`
+
+	queue, err := scanQueue(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrQueueNotFound
+		}
+		return nil, fmt.Errorf("failed to find queue: %w", err)
+	}
+
+	return queue, nil
+}
+
+// Update updates an existing queue
+func (r *PostgresQueueRepository) Update(ctx context.Context, queue *domain.Queue) error {
+	query := `UPDATE queues SET name = $2, description = $3, team_id = $4, updated_at = $5 WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		queue.ID,
+		queue.Name,
+		sql.NullString{String: queue.Description, Valid: queue.Description != ""},
+		queue.TeamID,
+		queue.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update queue: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrQueueNotFound
+	}
+
+	return nil
+}
+
+// List retrieves queues matching the filter, ordered by name
+func (r *PostgresQueueRepository) List(ctx context.Context, filter domain.QueueFilter) ([]*domain.Queue, error) {
+	query := `SELECT ` + queueColumns + ` FROM queues WHERE 1=1`
+
+	var conditions []string
+	var args []interface{}
+	argIndex := 1
+
+	if filter.TeamID != nil {
+		conditions = append(conditions, fmt.Sprintf("team_id = $%d", argIndex))
+		args = append(args, *filter.TeamID)
+		argIndex++
+	}
+
+	if len(conditions) > 0 {
+		query += " AND " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY name, id"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query queues: %w", err)
+	}
+	defer rows.Close()
+
+	var queues []*domain.Queue
+
+	for rows.Next() {
+		queue, err := scanQueue(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan queue: %w", err)
+		}
+		queues = append(queues, queue)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating queues: %w", err)
+	}
+
+	return queues, nil
+}
+
+func scanQueue(row rowScanner) (*domain.Queue, error) {
+	var queue domain.Queue
+	var description sql.NullString
+
+	err := row.Scan(
+		&queue.ID,
+		&queue.Name,
+		&description,
+		&queue.TeamID,
+		&queue.CreatedAt,
+		&queue.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	queue.Description = description.String
+	return &queue, nil
+}
@@ -0,0 +1,186 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+
+	"github.com/lib/pq"
+)
+
+// PostgresTeamRepository implements TeamRepository using PostgreSQL
+type PostgresTeamRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresTeamRepository creates a new PostgreSQL team repository
+func NewPostgresTeamRepository(db *sql.DB) ports.TeamRepository {
+	return &PostgresTeamRepository{db: db}
+}
+
+// teamQuery selects teams with their member agent IDs, oldest member first
+const teamQuery = `
+	SELECT t.id, t.name, t.description, t.created_at, t.updated_at,
+		ARRAY(SELECT m.agent_id::text FROM team_members m WHERE m.team_id = t.id ORDER BY m.added_at, m.agent_id)
+	FROM teams t
+`
+
+// Create saves a new team
+func (r *PostgresTeamRepository) Create(ctx context.Context, team *domain.Team) error {
+	query := `
+		INSERT INTO teams (id, name, description, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		team.ID,
+		team.Name,
+		sql.NullString{String: team.Description, Valid: team.Description != ""},
+		team.CreatedAt,
+		team.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create team: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves a team with its members
+func (r *PostgresTeamRepository) FindByID(ctx context.Context, id string) (*domain.Team, error) {
+	team, err := scanTeam(r.db.QueryRowContext(ctx, teamQuery+` WHERE t.id = $1`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrTeamNotFound
+		}
+		return nil, fmt.Errorf("failed to find team: %w", err)
+	}
+
+	return team, nil
+}
+
+// Update updates a team's name and description
+func (r *PostgresTeamRepository) Update(ctx context.Context, team *domain.Team) error {
+	query := `UPDATE teams SET name = $2, description = $3, updated_at = $4 WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		team.ID,
+		team.Name,
+		sql.NullString{String: team.Description, Valid: team.Description != ""},
+		team.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update team: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrTeamNotFound
+	}
+
+	return nil
+}
+
+// List retrieves all teams with their members, ordered by name
+func (r *PostgresTeamRepository) List(ctx context.Context) ([]*domain.Team, error) {
+	rows, err := r.db.QueryContext(ctx, teamQuery+` ORDER BY t.name, t.id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query teams: %w", err)
+	}
+	defer rows.Close()
+
+	var teams []*domain.Team
+
+	for rows.Next() {
+		team, err := scanTeam(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan team: %w", err)
+		}
+		teams = append(teams, team)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating teams: %w", err)
+	}
+
+	return teams, nil
+}
+
+// AddMember adds an agent to a team
+func (r *PostgresTeamRepository) AddMember(ctx context.Context, teamID, agentID string) error {
+	query := `
+		INSERT INTO team_members (team_id, agent_id, added_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (team_id, agent_id) DO NOTHING
+	`
+
+	result, err := r.db.ExecContext(ctx, query, teamID, agentID)
+	if err != nil {
+		return fmt.Errorf("failed to add team member: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrAlreadyTeamMember
+	}
+
+	return nil
+}
+
+// RemoveMember removes an agent from a team
+func (r *PostgresTeamRepository) RemoveMember(ctx context.Context, teamID, agentID string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM team_members WHERE team_id = $1 AND agent_id = $2`, teamID, agentID)
+	if err != nil {
+		return fmt.Errorf("failed to remove team member: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotTeamMember
+	}
+
+	return nil
+}
+
+func scanTeam(row rowScanner) (*domain.Team, error) {
+	var team domain.Team
+	var description sql.NullString
+	var members pq.StringArray
+
+	err := row.Scan(
+		&team.ID,
+		&team.Name,
+		&description,
+		&team.CreatedAt,
+		&team.UpdatedAt,
+		&members,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	team.Description = description.String
+	team.Members = []string(members)
+	if team.Members == nil {
+		team.Members = []string{}
+	}
+
+	return &team, nil
+}
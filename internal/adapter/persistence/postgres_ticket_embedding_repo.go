@@ -0,0 +1,140 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+
+	"github.com/lib/pq"
+)
+
+// PostgresTicketEmbeddingRepository implements TicketEmbeddingRepository using PostgreSQL with pgvector
+type PostgresTicketEmbeddingRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresTicketEmbeddingRepository creates a new PostgreSQL ticket embedding repository
+func NewPostgresTicketEmbeddingRepository(db *sql.DB) ports.TicketEmbeddingRepository {
+	return &PostgresTicketEmbeddingRepository{db: db}
+}
+
+// SaveEmbedding stores the embedding of a ticket, replacing an earlier one
+func (r *PostgresTicketEmbeddingRepository) SaveEmbedding(ctx context.Context, ticketID, model string, embedding []float32) error {
+	if len(embedding) == 0 {
+		return fmt.Errorf("embedding is empty")
+	}
+
+	query := `
+		INSERT INTO ticket_embeddings (ticket_id, embedding, embedding_model, embedding_dim, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (ticket_id) DO UPDATE
+		SET embedding = EXCLUDED.embedding, embedding_model = EXCLUDED.embedding_model,
+			embedding_dim = EXCLUDED.embedding_dim, updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query, ticketID, vectorLiteral(embedding), model, len(embedding), time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to save ticket embedding: %w", err)
+	}
+
+	return nil
+}
+
+// FindSimilar returns tickets whose embedding from the same model is most similar, best first.
+// Only vectors of the same model and dimension are compared, so embedding spaces are never mixed.
+func (r *PostgresTicketEmbeddingRepository) FindSimilar(ctx context.Context, model string, embedding []float32, filter domain.SimilarTicketFilter) ([]*domain.SimilarTicket, error) {
+	query := `
+		SELECT t.id, t.title, t.description, t.status, t.category, t.priority, t.created_by, t.assigned_to,
+			t.ai_insight, t.incident_id, t.queue_id, t.created_at, t.updated_at, 1 - (te.embedding <=> $1) AS score
+		FROM ticket_embeddings te
+		JOIN tickets t ON t.id = te.ticket_id
+		WHERE te.embedding_model = $2 AND te.embedding_dim = $3 AND 1 - (te.embedding <=> $1) >= $4
+	`
+
+	args := []interface{}{vectorLiteral(embedding), model, len(embedding), filter.MinScore}
+	argIndex := 5
+
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			statuses[i] = string(status)
+		}
+		query += fmt.Sprintf(" AND t.status = ANY($%d)", argIndex)
+		args = append(args, pq.Array(statuses))
+		argIndex++
+	}
+
+	if filter.ExcludeID != "" {
+		query += fmt.Sprintf(" AND t.id <> $%d", argIndex)
+		args = append(args, filter.ExcludeID)
+		argIndex++
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 5
+	}
+
+	query += fmt.Sprintf(" ORDER BY te.embedding <=> $1 LIMIT $%d", argIndex)
+	args = append(args, limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search similar tickets: %w", err)
+	}
+	defer rows.Close()
+
+	var similar []*domain.SimilarTicket
+
+	for rows.Next() {
+		var ticket domain.Ticket
+		var assignedTo, incidentID, queueID sql.NullString
+		var aiInsightJSON []byte
+		var score float64
+
+		err := rows.Scan(
+			&ticket.ID,
+			&ticket.Title,
+			&ticket.Description,
+			&ticket.Status,
+			&ticket.Category,
+			&ticket.Priority,
+			&ticket.CreatedBy,
+			&assignedTo,
+			&aiInsightJSON,
+			&incidentID,
+			&queueID,
+			&ticket.CreatedAt,
+			&ticket.UpdatedAt,
+			&score,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan similar ticket: %w", err)
+		}
+
+		ticket.AssignedTo = mapStringPtr(assignedTo)
+		ticket.IncidentID = mapStringPtr(incidentID)
+		ticket.QueueID = mapStringPtr(queueID)
+
+		if len(aiInsightJSON) > 0 {
+			var aiInsight domain.AIInsight
+			if err := json.Unmarshal(aiInsightJSON, &aiInsight); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal AI insight: %w", err)
+			}
+			ticket.AIInsight = &aiInsight
+		}
+
+		similar = append(similar, &domain.SimilarTicket{Ticket: &ticket, Score: score})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating similar tickets: %w", err)
+	}
+
+	return similar, nil
+}
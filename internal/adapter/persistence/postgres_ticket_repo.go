@@ -10,6 +10,8 @@ import (
 
 	"fixora/internal/domain"
 	"fixora/internal/ports"
+
+	"github.com/lib/pq"
 )
 
 // PostgresTicketRepository implements TicketRepository using PostgreSQL
@@ -25,8 +27,8 @@ func NewPostgresTicketRepository(db *sql.DB) ports.TicketRepository {
 // Create saves a new ticket
 func (r *PostgresTicketRepository) Create(ctx context.Context, ticket *domain.Ticket) error {
 	query := `
-		INSERT INTO tickets (id, title, description, status, category, priority, created_by, assigned_to, ai_insight, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		INSERT INTO tickets (id, title, description, status, category, priority, created_by, assigned_to, ai_insight, incident_id, queue_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 	`
 
 	var aiInsightJSON []byte
@@ -54,6 +56,8 @@ func (r *PostgresTicketRepository) Create(ctx context.Context, ticket *domain.Ti
 		ticket.CreatedBy,
 		assignedTo,
 		aiInsightJSON,
+		ticket.IncidentID,
+		ticket.QueueID,
 		ticket.CreatedAt,
 		ticket.UpdatedAt,
 	)
@@ -68,13 +72,13 @@ func (r *PostgresTicketRepository) Create(ctx context.Context, ticket *domain.Ti
 // FindByID retrieves a ticket by its ID
 func (r *PostgresTicketRepository) FindByID(ctx context.Context, id string) (*domain.Ticket, error) {
 	query := `
-		SELECT id, title, description, status, category, priority, created_by, assigned_to, ai_insight, created_at, updated_at
+		SELECT id, title, description, status, category, priority, created_by, assigned_to, ai_insight, incident_id, queue_id, created_at, updated_at
 		FROM tickets
 		WHERE id = $1
 	`
 
 	var ticket domain.Ticket
-	var assignedTo sql.NullString
+	var assignedTo, incidentID, queueID sql.NullString
 	var aiInsightJSON []byte
 
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
@@ -87,6 +91,8 @@ func (r *PostgresTicketRepository) FindByID(ctx context.Context, id string) (*do
 		&ticket.CreatedBy,
 		&assignedTo,
 		&aiInsightJSON,
+		&incidentID,
+		&queueID,
 		&ticket.CreatedAt,
 		&ticket.UpdatedAt,
 	)
@@ -102,6 +108,14 @@ func (r *PostgresTicketRepository) FindByID(ctx context.Context, id string) (*do
 		ticket.AssignedTo = &assignedTo.String
 	}
 
+	if incidentID.Valid {
+		ticket.IncidentID = &incidentID.String
+	}
+
+	if queueID.Valid {
+		ticket.QueueID = &queueID.String
+	}
+
 	if len(aiInsightJSON) > 0 {
 		var aiInsight domain.AIInsight
 		if err := json.Unmarshal(aiInsightJSON, &aiInsight); err != nil {
@@ -118,7 +132,7 @@ func (r *PostgresTicketRepository) Update(ctx context.Context, ticket *domain.Ti
 	query := `
 		UPDATE tickets
 		SET title = $2, description = $3, status = $4, category = $5, priority = $6,
-			assigned_to = $7, ai_insight = $8, updated_at = $9
+			assigned_to = $7, ai_insight = $8, incident_id = $9, queue_id = $10, updated_at = $11
 		WHERE id = $1
 	`
 
@@ -146,6 +160,8 @@ func (r *PostgresTicketRepository) Update(ctx context.Context, ticket *domain.Ti
 		string(ticket.Priority),
 		assignedTo,
 		aiInsightJSON,
+		ticket.IncidentID,
+		ticket.QueueID,
 		ticket.UpdatedAt,
 	)
 
@@ -168,7 +184,7 @@ func (r *PostgresTicketRepository) Update(ctx context.Context, ticket *domain.Ti
 // List retrieves tickets based on filter criteria
 func (r *PostgresTicketRepository) List(ctx context.Context, filter domain.TicketFilter) ([]*domain.Ticket, error) {
 	query := `
-		SELECT id, title, description, status, category, priority, created_by, assigned_to, ai_insight, created_at, updated_at
+		SELECT id, title, description, status, category, priority, created_by, assigned_to, ai_insight, incident_id, queue_id, created_at, updated_at
 		FROM tickets
 		WHERE 1=1
 	`
@@ -184,6 +200,12 @@ func (r *PostgresTicketRepository) List(ctx context.Context, filter domain.Ticke
 		argIndex++
 	}
 
+	if len(filter.Statuses) > 0 {
+		conditions = append(conditions, fmt.Sprintf("status = ANY($%d)", argIndex))
+		args = append(args, pq.Array(ticketStatusStrings(filter.Statuses)))
+		argIndex++
+	}
+
 	if filter.Category != nil {
 		conditions = append(conditions, fmt.Sprintf("category = $%d", argIndex))
 		args = append(args, string(*filter.Category))
@@ -208,6 +230,30 @@ func (r *PostgresTicketRepository) List(ctx context.Context, filter domain.Ticke
 		argIndex++
 	}
 
+	if filter.IncidentID != nil {
+		conditions = append(conditions, fmt.Sprintf("incident_id = $%d", argIndex))
+		args = append(args, *filter.IncidentID)
+		argIndex++
+	}
+
+	if filter.QueueID != nil {
+		conditions = append(conditions, fmt.Sprintf("queue_id = $%d", argIndex))
+		args = append(args, *filter.QueueID)
+		argIndex++
+	}
+
+	if filter.TeamID != nil {
+		conditions = append(conditions, fmt.Sprintf("queue_id IN (SELECT id FROM queues WHERE team_id = $%d)", argIndex))
+		args = append(args, *filter.TeamID)
+		argIndex++
+	}
+
+	if filter.UpdatedBefore != nil {
+		conditions = append(conditions, fmt.Sprintf("updated_at < $%d", argIndex))
+		args = append(args, *filter.UpdatedBefore)
+		argIndex++
+	}
+
 	// Add conditions to query
 	if len(conditions) > 0 {
 		query += " AND " + strings.Join(conditions, " AND ")
@@ -238,7 +284,7 @@ func (r *PostgresTicketRepository) List(ctx context.Context, filter domain.Ticke
 
 	for rows.Next() {
 		var ticket domain.Ticket
-		var assignedTo sql.NullString
+		var assignedTo, incidentID, queueID sql.NullString
 		var aiInsightJSON []byte
 
 		err := rows.Scan(
@@ -251,6 +297,8 @@ func (r *PostgresTicketRepository) List(ctx context.Context, filter domain.Ticke
 			&ticket.CreatedBy,
 			&assignedTo,
 			&aiInsightJSON,
+			&incidentID,
+			&queueID,
 			&ticket.CreatedAt,
 			&ticket.UpdatedAt,
 		)
@@ -263,6 +311,14 @@ func (r *PostgresTicketRepository) List(ctx context.Context, filter domain.Ticke
 			ticket.AssignedTo = &assignedTo.String
 		}
 
+		if incidentID.Valid {
+			ticket.IncidentID = &incidentID.String
+		}
+
+		if queueID.Valid {
+			ticket.QueueID = &queueID.String
+		}
+
 		if len(aiInsightJSON) > 0 {
 			var aiInsight domain.AIInsight
 			if err := json.Unmarshal(aiInsightJSON, &aiInsight); err != nil {
@@ -281,6 +337,32 @@ func (r *PostgresTicketRepository) List(ctx context.Context, filter domain.Ticke
 	return tickets, nil
 }
 
+// Claim assigns a queued, unclaimed ticket to the agent in one statement, so of two agents
+// claiming at once only one succeeds
+func (r *PostgresTicketRepository) Claim(ctx context.Context, ticketID, agentID string) error {
+	query := `
+		UPDATE tickets
+		SET assigned_to = $1, status = 'IN_PROGRESS', updated_at = now()
+		WHERE id = $2 AND assigned_to IS NULL AND queue_id IS NOT NULL AND status = 'OPEN'
+	`
+
+	result, err := r.db.ExecContext(ctx, query, agentID, ticketID)
+	if err != nil {
+		return fmt.Errorf("failed to claim ticket: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrTicketAlreadyClaimed
+	}
+
+	return nil
+}
+
 // Delete removes a ticket (soft delete - update status to CLOSED)
 func (r *PostgresTicketRepository) Delete(ctx context.Context, id string) error {
 	query := `UPDATE tickets SET status = 'CLOSED', updated_at = $1 WHERE id = $2`
@@ -317,6 +399,12 @@ func (r *PostgresTicketRepository) Count(ctx context.Context, filter domain.Tick
 		argIndex++
 	}
 
+	if len(filter.Statuses) > 0 {
+		conditions = append(conditions, fmt.Sprintf("status = ANY($%d)", argIndex))
+		args = append(args, pq.Array(ticketStatusStrings(filter.Statuses)))
+		argIndex++
+	}
+
 	if filter.Category != nil {
 		conditions = append(conditions, fmt.Sprintf("category = $%d", argIndex))
 		args = append(args, string(*filter.Category))
@@ -341,6 +429,30 @@ func (r *PostgresTicketRepository) Count(ctx context.Context, filter domain.Tick
 		argIndex++
 	}
 
+	if filter.IncidentID != nil {
+		conditions = append(conditions, fmt.Sprintf("incident_id = $%d", argIndex))
+		args = append(args, *filter.IncidentID)
+		argIndex++
+	}
+
+	if filter.QueueID != nil {
+		conditions = append(conditions, fmt.Sprintf("queue_id = $%d", argIndex))
+		args = append(args, *filter.QueueID)
+		argIndex++
+	}
+
+	if filter.TeamID != nil {
+		conditions = append(conditions, fmt.Sprintf("queue_id IN (SELECT id FROM queues WHERE team_id = $%d)", argIndex))
+		args = append(args, *filter.TeamID)
+		argIndex++
+	}
+
+	if filter.UpdatedBefore != nil {
+		conditions = append(conditions, fmt.Sprintf("updated_at < $%d", argIndex))
+		args = append(args, *filter.UpdatedBefore)
+		argIndex++
+	}
+
 	// Add conditions to query
 	if len(conditions) > 0 {
 		query += " AND " + strings.Join(conditions, " AND ")
@@ -375,6 +487,12 @@ func (r *PostgresTicketRepository) buildWhereClause(filter domain.TicketFilter)
 		argIndex++
 	}
 
+	if len(filter.Statuses) > 0 {
+		conditions = append(conditions, fmt.Sprintf("status = ANY($%d)", argIndex))
+		args = append(args, pq.Array(ticketStatusStrings(filter.Statuses)))
+		argIndex++
+	}
+
 	if filter.Category != nil {
 		conditions = append(conditions, fmt.Sprintf("category = $%d", argIndex))
 		args = append(args, string(*filter.Category))
@@ -399,10 +517,42 @@ func (r *PostgresTicketRepository) buildWhereClause(filter domain.TicketFilter)
 		argIndex++
 	}
 
+	if filter.IncidentID != nil {
+		conditions = append(conditions, fmt.Sprintf("incident_id = $%d", argIndex))
+		args = append(args, *filter.IncidentID)
+		argIndex++
+	}
+
+	if filter.QueueID != nil {
+		conditions = append(conditions, fmt.Sprintf("queue_id = $%d", argIndex))
+		args = append(args, *filter.QueueID)
+		argIndex++
+	}
+
+	if filter.TeamID != nil {
+		conditions = append(conditions, fmt.Sprintf("queue_id IN (SELECT id FROM queues WHERE team_id = $%d)", argIndex))
+		args = append(args, *filter.TeamID)
+		argIndex++
+	}
+
+	if filter.UpdatedBefore != nil {
+		conditions = append(conditions, fmt.Sprintf("updated_at < $%d", argIndex))
+		args = append(args, *filter.UpdatedBefore)
+		argIndex++
+	}
+
 	var whereClause string
 	if len(conditions) > 0 {
 		whereClause = "WHERE " + strings.Join(conditions, " AND ")
 	}
 
 	return whereClause, args
-}
\ No newline at end of file
+}
+
+func ticketStatusStrings(statuses []domain.TicketStatus) []string {
+	values := make([]string, len(statuses))
+	for i, status := range statuses {
+		values[i] = string(status)
+	}
+	return values
+}
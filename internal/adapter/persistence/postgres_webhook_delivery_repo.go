@@ -0,0 +1,245 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// PostgresWebhookDeliveryRepository implements WebhookDeliveryRepository using PostgreSQL
+type PostgresWebhookDeliveryRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresWebhookDeliveryRepository creates a new PostgreSQL webhook delivery log repository
+func NewPostgresWebhookDeliveryRepository(db *sql.DB) ports.WebhookDeliveryRepository {
+	return &PostgresWebhookDeliveryRepository{db: db}
+}
+
+const webhookDeliveryColumns = `id, subscription_id, event_id, event_type, payload, status, attempts,
+	response_status, response_body, error, duration_ms, replay_of, next_attempt_at, last_attempt_at, created_at`
+
+// Create records a new delivery
+func (r *PostgresWebhookDeliveryRepository) Create(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	query := `
+		INSERT INTO webhook_deliveries (` + webhookDeliveryColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	`
+
+	var replayOf sql.NullString
+	if delivery.ReplayOf != nil {
+		replayOf = sql.NullString{String: *delivery.ReplayOf, Valid: true}
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		delivery.ID,
+		delivery.SubscriptionID,
+		delivery.EventID,
+		delivery.EventType,
+		[]byte(delivery.Payload),
+		string(delivery.Status),
+		delivery.Attempts,
+		sql.NullInt64{Int64: int64(delivery.ResponseStatus), Valid: delivery.ResponseStatus != 0},
+		sql.NullString{String: delivery.ResponseBody, Valid: delivery.ResponseBody != ""},
+		sql.NullString{String: delivery.Error, Valid: delivery.Error != ""},
+		delivery.DurationMS,
+		replayOf,
+		delivery.NextAttemptAt,
+		delivery.LastAttemptAt,
+		delivery.CreatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves a delivery by ID
+func (r *PostgresWebhookDeliveryRepository) FindByID(ctx context.Context, id string) (*domain.WebhookDelivery, error) {
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries WHERE id = $1`
+
+	delivery, err := scanWebhookDelivery(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrWebhookDeliveryNotFound
+		}
+		return nil, fmt.Errorf("failed to find webhook delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+// Update saves the outcome of a delivery attempt
+func (r *PostgresWebhookDeliveryRepository) Update(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, response_status = $4, response_body = $5, error = $6,
+			duration_ms = $7, next_attempt_at = $8, last_attempt_at = $9
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		delivery.ID,
+		string(delivery.Status),
+		delivery.Attempts,
+		sql.NullInt64{Int64: int64(delivery.ResponseStatus), Valid: delivery.ResponseStatus != 0},
+		sql.NullString{String: delivery.ResponseBody, Valid: delivery.ResponseBody != ""},
+		sql.NullString{String: delivery.Error, Valid: delivery.Error != ""},
+		delivery.DurationMS,
+		delivery.NextAttemptAt,
+		delivery.LastAttemptAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrWebhookDeliveryNotFound
+	}
+
+	return nil
+}
+
+// List retrieves deliveries matching the filter, newest first
+func (r *PostgresWebhookDeliveryRepository) List(ctx context.Context, filter domain.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error) {
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries WHERE 1=1`
+
+	var conditions []string
+	var args []interface{}
+	argIndex := 1
+
+	if filter.SubscriptionID != nil {
+		conditions = append(conditions, fmt.Sprintf("subscription_id = $%d", argIndex))
+		args = append(args, *filter.SubscriptionID)
+		argIndex++
+	}
+
+	if filter.EventID != nil {
+		conditions = append(conditions, fmt.Sprintf("event_id = $%d", argIndex))
+		args = append(args, *filter.EventID)
+		argIndex++
+	}
+
+	if filter.Status != nil {
+		conditions = append(conditions, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, string(*filter.Status))
+		argIndex++
+	}
+
+	if len(conditions) > 0 {
+		query += " AND " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY created_at DESC"
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argIndex)
+		args = append(args, filter.Limit)
+		argIndex++
+	}
+
+	if filter.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET $%d", argIndex)
+		args = append(args, filter.Offset)
+	}
+
+	return r.query(ctx, query, args...)
+}
+
+// ClaimDue takes up to limit pending deliveries due at now, oldest first, and moves their next
+// attempt to now plus lease, so no other run takes them while they are attempted. Rows locked by
+// a concurrent claim are skipped rather than waited for.
+func (r *PostgresWebhookDeliveryRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.WebhookDelivery, error) {
+	query := `
+		UPDATE webhook_deliveries SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + webhookDeliveryColumns
+
+	return r.query(ctx, query, now, now.Add(lease), limit)
+}
+
+func (r *PostgresWebhookDeliveryRepository) query(ctx context.Context, query string, args ...interface{}) ([]*domain.WebhookDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*domain.WebhookDelivery
+
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func scanWebhookDelivery(row rowScanner) (*domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	var payload []byte
+	var responseStatus sql.NullInt64
+	var responseBody, deliveryError, replayOf sql.NullString
+	var nextAttemptAt, lastAttemptAt sql.NullTime
+
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.SubscriptionID,
+		&delivery.EventID,
+		&delivery.EventType,
+		&payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&responseStatus,
+		&responseBody,
+		&deliveryError,
+		&delivery.DurationMS,
+		&replayOf,
+		&nextAttemptAt,
+		&lastAttemptAt,
+		&delivery.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery.Payload = payload
+	delivery.ResponseStatus = int(responseStatus.Int64)
+	delivery.ResponseBody = responseBody.String
+	delivery.Error = deliveryError.String
+	delivery.ReplayOf = mapStringPtr(replayOf)
+	if nextAttemptAt.Valid {
+		delivery.NextAttemptAt = &nextAttemptAt.Time
+	}
+	if lastAttemptAt.Valid {
+		delivery.LastAttemptAt = &lastAttemptAt.Time
+	}
+
+	return &delivery, nil
+}
@@ -0,0 +1,225 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+
+	"github.com/lib/pq"
+)
+
+// PostgresWebhookSubscriptionRepository implements WebhookSubscriptionRepository using PostgreSQL
+type PostgresWebhookSubscriptionRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresWebhookSubscriptionRepository creates a new PostgreSQL webhook subscription repository
+func NewPostgresWebhookSubscriptionRepository(db *sql.DB) ports.WebhookSubscriptionRepository {
+	return &PostgresWebhookSubscriptionRepository{db: db}
+}
+
+const webhookSubscriptionColumns = `id, name, url, secret, event_types, enabled, consecutive_failures,
+	disabled_reason, created_by, created_at, updated_at`
+
+// Create saves a new subscription
+func (r *PostgresWebhookSubscriptionRepository) Create(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	query := `
+		INSERT INTO webhook_subscriptions (` + webhookSubscriptionColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		subscription.ID,
+		subscription.Name,
+		subscription.URL,
+		subscription.Secret,
+		pq.Array(subscription.EventTypes),
+		subscription.Enabled,
+		subscription.ConsecutiveFailures,
+		sql.NullString{String: subscription.DisabledReason, Valid: subscription.DisabledReason != ""},
+		subscription.CreatedBy,
+		subscription.CreatedAt,
+		subscription.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves a subscription by ID
+func (r *PostgresWebhookSubscriptionRepository) FindByID(ctx context.Context, id string) (*domain.WebhookSubscription, error) {
+	query := `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscriptions WHERE id = $1`
+
+	subscription, err := scanWebhookSubscription(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrWebhookSubscriptionNotFound
+		}
+		return nil, fmt.Errorf("failed to find webhook subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+// Update updates an existing subscription
+func (r *PostgresWebhookSubscriptionRepository) Update(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	query := `
+		UPDATE webhook_subscriptions
+		SET name = $2, url = $3, secret = $4, event_types = $5, enabled = $6, consecutive_failures = $7,
+			disabled_reason = $8, updated_at = $9
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		subscription.ID,
+		subscription.Name,
+		subscription.URL,
+		subscription.Secret,
+		pq.Array(subscription.EventTypes),
+		subscription.Enabled,
+		subscription.ConsecutiveFailures,
+		sql.NullString{String: subscription.DisabledReason, Valid: subscription.DisabledReason != ""},
+		subscription.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update webhook subscription: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrWebhookSubscriptionNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a subscription and its delivery log
+func (r *PostgresWebhookSubscriptionRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webhook_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrWebhookSubscriptionNotFound
+	}
+
+	return nil
+}
+
+// List retrieves subscriptions matching the filter, oldest first
+func (r *PostgresWebhookSubscriptionRepository) List(ctx context.Context, filter domain.WebhookSubscriptionFilter) ([]*domain.WebhookSubscription, error) {
+	query := `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscriptions WHERE 1=1`
+
+	var conditions []string
+	var args []interface{}
+	argIndex := 1
+
+	if filter.Enabled != nil {
+		conditions = append(conditions, fmt.Sprintf("enabled = $%d", argIndex))
+		args = append(args, *filter.Enabled)
+		argIndex++
+	}
+
+	if filter.EventType != nil {
+		conditions = append(conditions, fmt.Sprintf("$%d = ANY(event_types)", argIndex))
+		args = append(args, *filter.EventType)
+		argIndex++
+	}
+
+	if len(conditions) > 0 {
+		query += " AND " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY created_at, id"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var subscriptions []*domain.WebhookSubscription
+
+	for rows.Next() {
+		subscription, err := scanWebhookSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook subscription: %w", err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhook subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+// RecordDeliveryResult updates the failure count in one statement, so concurrent deliveries
+// neither lose counts nor overwrite changes made to the subscription meanwhile
+func (r *PostgresWebhookSubscriptionRepository) RecordDeliveryResult(ctx context.Context, id string, succeeded bool, disableAfter int, reason string) (*domain.WebhookSubscription, error) {
+	query := `
+		UPDATE webhook_subscriptions
+		SET consecutive_failures = CASE WHEN $2 THEN 0 ELSE consecutive_failures + 1 END,
+			enabled = enabled AND ($2 OR consecutive_failures + 1 < $3),
+			disabled_reason = CASE WHEN enabled AND NOT $2 AND consecutive_failures + 1 >= $3 THEN $4 ELSE disabled_reason END,
+			updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + webhookSubscriptionColumns
+
+	subscription, err := scanWebhookSubscription(r.db.QueryRowContext(ctx, query, id, succeeded, disableAfter, reason))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrWebhookSubscriptionNotFound
+		}
+		return nil, fmt.Errorf("failed to record webhook delivery result: %w", err)
+	}
+
+	return subscription, nil
+}
+
+func scanWebhookSubscription(row rowScanner) (*domain.WebhookSubscription, error) {
+	var subscription domain.WebhookSubscription
+	var eventTypes pq.StringArray
+	var disabledReason sql.NullString
+
+	err := row.Scan(
+		&subscription.ID,
+		&subscription.Name,
+		&subscription.URL,
+		&subscription.Secret,
+		&eventTypes,
+		&subscription.Enabled,
+		&subscription.ConsecutiveFailures,
+		&disabledReason,
+		&subscription.CreatedBy,
+		&subscription.CreatedAt,
+		&subscription.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.EventTypes = []string(eventTypes)
+	subscription.DisabledReason = disabledReason.String
+
+	return &subscription, nil
+}
@@ -0,0 +1,260 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// maxRequestBytes bounds the size of a command or interaction request
+const maxRequestBytes = 1 << 20
+
+// usage is shown for help and for unknown subcommands
+const usage = "Usage:\n" +
+	"• `%[1]s new <description>` opens a ticket, filled in by AI intake\n" +
+	"• `%[1]s mine` lists your open tickets"
+
+// Config configures the Slack app endpoints
+type Config struct {
+	SigningSecret string        // from the Slack app's Basic Information page
+	Command       string        // slash command name, e.g. /fixora
+	Timeout       time.Duration // for work finished after Slack's 3 second response deadline
+}
+
+// Handler implements Slack's slash command and interactivity request URLs. Every request must
+// carry a valid Slack signature. Work that may outlast Slack's 3 second deadline is acknowledged
+// at once and its result is posted to the request's response URL.
+type Handler struct {
+	chatOps ports.ChatOpsHandler
+	config  Config
+	client  *http.Client
+	now     func() time.Time
+}
+
+// NewHandler creates a new Slack handler
+func NewHandler(chatOps ports.ChatOpsHandler, config Config) *Handler {
+	if config.Command == "" {
+		config.Command = "/fixora"
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 30 * time.Second
+	}
+
+	return &Handler{
+		chatOps: chatOps,
+		config:  config,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		now:     time.Now,
+	}
+}
+
+// RegisterRoutes registers the Slack routes
+func (h *Handler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/api/v1/slack/commands", h.HandleCommand).Methods("POST")
+	router.HandleFunc("/api/v1/slack/interactions", h.HandleInteraction).Methods("POST")
+}
+
+// HandleCommand handles the slash command: "new <description>" and "mine"
+func (h *Handler) HandleCommand(w http.ResponseWriter, r *http.Request) {
+	form, ok := h.readSignedForm(w, r)
+	if !ok {
+		return
+	}
+
+	if command := form.Get("command"); command != h.config.Command {
+		writeMessage(w, textMessage(fmt.Sprintf("Unknown command %s.", command)))
+		return
+	}
+
+	userID := form.Get("user_id")
+	subcommand, args, _ := strings.Cut(strings.TrimSpace(form.Get("text")), " ")
+	args = strings.TrimSpace(args)
+
+	switch strings.ToLower(subcommand) {
+	case "new":
+		if args == "" {
+			writeMessage(w, textMessage(fmt.Sprintf("Describe the problem: `%s new <description>`", h.config.Command)))
+			return
+		}
+
+		// AI intake can take longer than Slack waits for a response
+		writeMessage(w, textMessage("Opening your ticket…"))
+		h.respondLater(r.Context(), form.Get("response_url"), func(ctx context.Context) *Message {
+			ticket, err := h.chatOps.CreateTicket(ctx, userID, args)
+			if err != nil {
+				return errorMessage(err)
+			}
+			msg := ticketMessage(ticket, fmt.Sprintf("Ticket opened by <@%s>", userID))
+			msg.ResponseType = responseInChannel
+			return msg
+		})
+
+	case "mine":
+		tickets, err := h.chatOps.AssignedTickets(r.Context(), userID)
+		if err != nil {
+			writeMessage(w, errorMessage(err))
+			return
+		}
+		writeMessage(w, ticketListMessage(tickets))
+
+	default:
+		writeMessage(w, textMessage(fmt.Sprintf(usage, h.config.Command)))
+	}
+}
+
+// interactionPayload is the part of an interactive payload the handler uses
+type interactionPayload struct {
+	Type string `json:"type"`
+	User struct {
+		ID string `json:"id"`
+	} `json:"user"`
+	ResponseURL string `json:"response_url"`
+	Actions     []struct {
+		ActionID string `json:"action_id"`
+		BlockID  string `json:"block_id"`
+		Value    string `json:"value"`
+	} `json:"actions"`
+}
+
+// HandleInteraction handles clicks on ticket message buttons. The click is acknowledged at once;
+// the updated ticket replaces the message, or is posted below a ticket list.
+func (h *Handler) HandleInteraction(w http.ResponseWriter, r *http.Request) {
+	form, ok := h.readSignedForm(w, r)
+	if !ok {
+		return
+	}
+
+	var payload interactionPayload
+	if err := json.Unmarshal([]byte(form.Get("payload")), &payload); err != nil {
+		http.Error(w, "Invalid interaction payload", http.StatusBadRequest)
+		return
+	}
+
+	if payload.Type == "block_actions" {
+		for _, action := range payload.Actions {
+			action := action
+			h.respondLater(r.Context(), payload.ResponseURL, func(ctx context.Context) *Message {
+				chatAction := domain.ChatAction(action.ActionID)
+				ticket, err := h.chatOps.PerformAction(ctx, payload.User.ID, chatAction, action.Value)
+				if err != nil {
+					return errorMessage(err)
+				}
+
+				msg := ticketMessage(ticket, fmt.Sprintf("%s by <@%s>", actionDone(chatAction), payload.User.ID))
+				if action.BlockID == ticketActionsBlockID {
+					msg.ReplaceOriginal = true
+				} else {
+					msg.ResponseType = responseEphemeral
+				}
+				return msg
+			})
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// readSignedForm reads a request's form body and checks its signature. The response has been
+// written when it returns false.
+func (h *Handler) readSignedForm(w http.ResponseWriter, r *http.Request) (url.Values, bool) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestBytes))
+	if err != nil {
+		http.Error(w, "Failed to read request", http.StatusBadRequest)
+		return nil, false
+	}
+
+	if err := VerifySignature(h.config.SigningSecret, r.Header.Get(SignatureHeader), r.Header.Get(TimestampHeader), body, h.now()); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return nil, false
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		http.Error(w, "Invalid form body", http.StatusBadRequest)
+		return nil, false
+	}
+	return form, true
+}
+
+// respondLater runs work in the background and posts its message to the response URL
+func (h *Handler) respondLater(ctx context.Context, responseURL string, work func(ctx context.Context) *Message) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), h.config.Timeout)
+	go func() {
+		defer cancel()
+		if err := postMessage(ctx, h.client, responseURL, work(ctx)); err != nil {
+			log.Printf("Failed to respond to Slack: %v", err)
+		}
+	}()
+}
+
+// postMessage sends a message to a response URL or incoming webhook
+func postMessage(ctx context.Context, client *http.Client, endpoint string, msg *Message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal Slack message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create Slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post Slack message: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("slack returned %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// errorMessage explains to the user why their command or action failed. Only domain errors
+// are shown; anything else is logged.
+func errorMessage(err error) *Message {
+	if errors.Is(err, domain.ErrChatUserNotLinked) {
+		return textMessage("Your Slack account is not linked to a Fixora user yet. Ask an admin to link it.")
+	}
+
+	var domainErr *domain.DomainError
+	if errors.As(err, &domainErr) {
+		return textMessage("Sorry, that didn't work: " + domainErr.Error() + ".")
+	}
+
+	log.Printf("Slack command failed: %v", err)
+	return textMessage("Sorry, something went wrong. Please try again.")
+}
+
+// actionDone describes a performed action in a message headline
+func actionDone(action domain.ChatAction) string {
+	switch action {
+	case domain.ChatActionAssignToMe:
+		return "Assigned"
+	case domain.ChatActionResolve:
+		return "Resolved"
+	default:
+		return "Closed"
+	}
+}
+
+func writeMessage(w http.ResponseWriter, msg *Message) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(msg)
+}
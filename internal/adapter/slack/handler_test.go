@@ -0,0 +1,335 @@
+package slack
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"fixora/internal/domain"
+)
+
+// The fixtures in testdata are raw Slack requests signed with fixtureSecret at fixtureTime
+const fixtureSecret = "fixora-test-signing-secret"
+
+var fixtureTime = time.Unix(1760781600, 0)
+
+// stubChatOps records the commands and actions it is given and applies actions to its ticket
+type stubChatOps struct {
+	mu     sync.Mutex
+	calls  []string
+	ticket *domain.Ticket
+	err    error
+}
+
+func (s *stubChatOps) record(call string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = append(s.calls, call)
+}
+
+func (s *stubChatOps) recorded() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.calls...)
+}
+
+func (s *stubChatOps) CreateTicket(ctx context.Context, chatUserID, text string) (*domain.Ticket, error) {
+	s.record("new " + chatUserID + " " + text)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.ticket, nil
+}
+
+func (s *stubChatOps) AssignedTickets(ctx context.Context, chatUserID string) ([]*domain.Ticket, error) {
+	s.record("mine " + chatUserID)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return []*domain.Ticket{s.ticket}, nil
+}
+
+func (s *stubChatOps) PerformAction(ctx context.Context, chatUserID string, action domain.ChatAction, ticketID string) (*domain.Ticket, error) {
+	s.record(string(action) + " " + chatUserID + " " + ticketID)
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	var err error
+	switch action {
+	case domain.ChatActionAssignToMe:
+		err = s.ticket.Assign("agent-1")
+	case domain.ChatActionResolve:
+		err = s.ticket.Resolve()
+	case domain.ChatActionClose:
+		err = s.ticket.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.ticket, nil
+}
+
+// capturedPost is a message posted to a response URL
+type capturedPost struct {
+	url string
+	msg Message
+}
+
+// captureTransport answers posts to response URLs without leaving the process
+type captureTransport struct {
+	posts chan capturedPost
+}
+
+func (c *captureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var msg Message
+	if err := json.NewDecoder(req.Body).Decode(&msg); err != nil {
+		return nil, err
+	}
+	c.posts <- capturedPost{url: req.URL.String(), msg: msg}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader("ok")),
+		Header:     make(http.Header),
+		Request:    req,
+	}, nil
+}
+
+func (c *captureTransport) next(t *testing.T) capturedPost {
+	t.Helper()
+	select {
+	case post := <-c.posts:
+		return post
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected a message to be posted to the response URL")
+		return capturedPost{}
+	}
+}
+
+func newTestTicket() *domain.Ticket {
+	ticket := domain.NewTicket("VPN keeps dropping", "VPN drops every few minutes since this morning", domain.TicketCategoryNetwork, domain.TicketPriorityHigh, "dewi@example.com")
+	ticket.ID = "ticket_20251018095000"
+	return ticket
+}
+
+func newTestHandler(chatOps *stubChatOps) (*mux.Router, *captureTransport) {
+	handler := NewHandler(chatOps, Config{SigningSecret: fixtureSecret})
+	handler.now = func() time.Time { return fixtureTime.Add(2 * time.Second) }
+
+	capture := &captureTransport{posts: make(chan capturedPost, 4)}
+	handler.client = &http.Client{Transport: capture}
+
+	router := mux.NewRouter()
+	handler.RegisterRoutes(router)
+	return router, capture
+}
+
+// loadFixture reads a raw Slack request from testdata
+func loadFixture(t *testing.T, name string) *http.Request {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+
+	req, err := http.ReadRequest(bufio.NewReader(bytes.NewReader(data)))
+	if err != nil {
+		t.Fatalf("Failed to parse fixture: %v", err)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Fatalf("Failed to read fixture body: %v", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return req
+}
+
+func serve(router *mux.Router, req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func decodeMessage(t *testing.T, rec *httptest.ResponseRecorder) Message {
+	t.Helper()
+	var msg Message
+	if err := json.NewDecoder(rec.Body).Decode(&msg); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	return msg
+}
+
+// buttons returns the action IDs and block ID of a message's action blocks
+func buttons(msg Message) (actions []string, blockIDs []string) {
+	for _, block := range msg.Blocks {
+		if block.Type != "actions" {
+			continue
+		}
+		blockIDs = append(blockIDs, block.BlockID)
+		for _, element := range block.Elements {
+			actions = append(actions, element.ActionID+"="+element.Value)
+		}
+	}
+	return actions, blockIDs
+}
+
+func TestHandler_CommandNew(t *testing.T) {
+	chatOps := &stubChatOps{ticket: newTestTicket()}
+	router, capture := newTestHandler(chatOps)
+
+	rec := serve(router, loadFixture(t, "command_new.http"))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if ack := decodeMessage(t, rec); ack.ResponseType != responseEphemeral || !strings.Contains(ack.Text, "Opening") {
+		t.Errorf("Expected an ephemeral acknowledgement, got %+v", ack)
+	}
+
+	post := capture.next(t)
+	if post.url != "https://hooks.slack.com/commands/T0FIXORA/1234567890/abcdefghijklmnop" {
+		t.Errorf("Expected the command's response URL, got %s", post.url)
+	}
+	if post.msg.ResponseType != responseInChannel || !strings.Contains(post.msg.Text, "<@U0AGENT01>") {
+		t.Errorf("Expected the ticket to be shown in the channel, got %+v", post.msg)
+	}
+
+	actions, blockIDs := buttons(post.msg)
+	want := []string{"assign_to_me=ticket_20251018095000", "resolve=ticket_20251018095000"}
+	if strings.Join(actions, ",") != strings.Join(want, ",") || len(blockIDs) != 1 || blockIDs[0] != ticketActionsBlockID {
+		t.Errorf("Expected assign and resolve buttons, got %v in %v", actions, blockIDs)
+	}
+
+	if calls := chatOps.recorded(); len(calls) != 1 || calls[0] != "new U0AGENT01 VPN drops every few minutes since this morning" {
+		t.Errorf("Unexpected calls %v", calls)
+	}
+}
+
+func TestHandler_CommandMine(t *testing.T) {
+	chatOps := &stubChatOps{ticket: newTestTicket()}
+	router, _ := newTestHandler(chatOps)
+
+	rec := serve(router, loadFixture(t, "command_mine.http"))
+	msg := decodeMessage(t, rec)
+	if msg.ResponseType != responseEphemeral || !strings.Contains(msg.Text, "assigned to you: 1") {
+		t.Errorf("Expected the user's tickets, got %+v", msg)
+	}
+	if _, blockIDs := buttons(msg); len(blockIDs) != 1 || blockIDs[0] != listActionsBlockID {
+		t.Errorf("Expected list buttons, got %v", blockIDs)
+	}
+
+	chatOps.err = domain.ErrChatUserNotLinked
+	msg = decodeMessage(t, serve(router, loadFixture(t, "command_mine.http")))
+	if !strings.Contains(msg.Text, "not linked") {
+		t.Errorf("Expected unlinked users to be told, got %q", msg.Text)
+	}
+}
+
+func TestHandler_InteractionAssign(t *testing.T) {
+	chatOps := &stubChatOps{ticket: newTestTicket()}
+	router, capture := newTestHandler(chatOps)
+
+	if rec := serve(router, loadFixture(t, "interaction_assign.http")); rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	post := capture.next(t)
+	if post.url != "https://hooks.slack.com/actions/T0FIXORA/1234567890/qrstuvwxyz" {
+		t.Errorf("Expected the action's response URL, got %s", post.url)
+	}
+	if !post.msg.ReplaceOriginal || !strings.HasPrefix(post.msg.Text, "Assigned by <@U0AGENT01>") {
+		t.Errorf("Expected the ticket message to be replaced, got %+v", post.msg)
+	}
+
+	// An assigned ticket can still be taken over or resolved
+	actions, _ := buttons(post.msg)
+	want := []string{"assign_to_me=ticket_20251018095000", "resolve=ticket_20251018095000"}
+	if strings.Join(actions, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected %v, got %v", want, actions)
+	}
+
+	if calls := chatOps.recorded(); len(calls) != 1 || calls[0] != "assign_to_me U0AGENT01 ticket_20251018095000" {
+		t.Errorf("Unexpected calls %v", calls)
+	}
+}
+
+func TestHandler_InteractionFromList(t *testing.T) {
+	ticket := newTestTicket()
+	chatOps := &stubChatOps{ticket: ticket}
+	router, capture := newTestHandler(chatOps)
+
+	// Closing an unresolved ticket fails and the user is told why
+	serve(router, loadFixture(t, "interaction_close_from_list.http"))
+	post := capture.next(t)
+	if post.msg.ReplaceOriginal || post.msg.ResponseType != responseEphemeral || !strings.Contains(post.msg.Text, domain.ErrTicketNotResolved.Error()) {
+		t.Errorf("Expected an ephemeral error, got %+v", post.msg)
+	}
+
+	// A ticket closed from a list is shown below the list, which stays
+	ticket.Resolve()
+	serve(router, loadFixture(t, "interaction_close_from_list.http"))
+	post = capture.next(t)
+	if post.msg.ReplaceOriginal || post.msg.ResponseType != responseEphemeral || !strings.HasPrefix(post.msg.Text, "Closed by") {
+		t.Errorf("Expected the closed ticket below the list, got %+v", post.msg)
+	}
+	if actions, _ := buttons(post.msg); len(actions) != 0 {
+		t.Errorf("Expected no buttons on a closed ticket, got %v", actions)
+	}
+}
+
+func TestHandler_RejectsUnsignedRequests(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		modify  func(h *Handler, req *http.Request)
+	}{
+		{"wrong secret", "command_mine.http", func(h *Handler, req *http.Request) {
+			h.config.SigningSecret = "another-signing-secret"
+		}},
+		{"replayed", "command_new.http", func(h *Handler, req *http.Request) {
+			h.now = func() time.Time { return fixtureTime.Add(MaxRequestAge + time.Minute) }
+		}},
+		{"tampered body", "interaction_assign.http", func(h *Handler, req *http.Request) {
+			body, _ := io.ReadAll(req.Body)
+			req.Body = io.NopCloser(bytes.NewReader(bytes.Replace(body, []byte("assign_to_me"), []byte("close"), 1)))
+		}},
+		{"missing signature", "command_new.http", func(h *Handler, req *http.Request) {
+			req.Header.Del(SignatureHeader)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chatOps := &stubChatOps{ticket: newTestTicket()}
+			handler := NewHandler(chatOps, Config{SigningSecret: fixtureSecret})
+			handler.now = func() time.Time { return fixtureTime }
+			router := mux.NewRouter()
+			handler.RegisterRoutes(router)
+
+			req := loadFixture(t, tt.fixture)
+			tt.modify(handler, req)
+
+			rec := serve(router, req)
+			if rec.Code != http.StatusUnauthorized {
+				t.Errorf("Expected 401, got %d", rec.Code)
+			}
+			if calls := chatOps.recorded(); len(calls) != 0 {
+				t.Errorf("Expected no commands to run, got %v", calls)
+			}
+		})
+	}
+}
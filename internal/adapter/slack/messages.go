@@ -0,0 +1,149 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+
+	"fixora/internal/domain"
+)
+
+// Block IDs of action blocks. A button in a ticket message replaces the message with the
+// updated ticket; a button in a ticket list answers separately so the list stays.
+const (
+	ticketActionsBlockID = "ticket_actions"
+	listActionsBlockID   = "ticket_list_actions"
+)
+
+// Response types of command and action responses
+const (
+	responseEphemeral = "ephemeral"  // shown only to the user who ran the command
+	responseInChannel = "in_channel" // shown to everyone in the channel
+)
+
+// Message is a Slack message in Block Kit format, sent as a command response, to a response
+// URL, or to an incoming webhook
+type Message struct {
+	ResponseType    string  `json:"response_type,omitempty"`
+	ReplaceOriginal bool    `json:"replace_original,omitempty"`
+	Channel         string  `json:"channel,omitempty"`
+	Username        string  `json:"username,omitempty"`
+	IconEmoji       string  `json:"icon_emoji,omitempty"`
+	Text            string  `json:"text"` // fallback for notifications and clients without blocks
+	Blocks          []Block `json:"blocks,omitempty"`
+}
+
+// Block is a Block Kit layout block
+type Block struct {
+	Type     string    `json:"type"`
+	BlockID  string    `json:"block_id,omitempty"`
+	Text     *Text     `json:"text,omitempty"`
+	Fields   []Text    `json:"fields,omitempty"`
+	Elements []Element `json:"elements,omitempty"`
+}
+
+// Text is a Block Kit text object
+type Text struct {
+	Type string `json:"type"` // mrkdwn or plain_text
+	Text string `json:"text"`
+}
+
+// Element is an interactive Block Kit element
+type Element struct {
+	Type     string `json:"type"`
+	Text     *Text  `json:"text,omitempty"`
+	ActionID string `json:"action_id,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Style    string `json:"style,omitempty"`
+}
+
+// actionLabels are the button labels of ticket actions
+var actionLabels = map[domain.ChatAction]string{
+	domain.ChatActionAssignToMe: "Assign to me",
+	domain.ChatActionResolve:    "Resolve",
+	domain.ChatActionClose:      "Close",
+}
+
+// textMessage is a plain response to the user
+func textMessage(text string) *Message {
+	return &Message{ResponseType: responseEphemeral, Text: text}
+}
+
+// ticketMessage shows a ticket with its action buttons
+func ticketMessage(ticket *domain.Ticket, headline string) *Message {
+	assignee := "Unassigned"
+	if ticket.AssignedTo != nil {
+		assignee = escape(*ticket.AssignedTo)
+	}
+
+	blocks := []Block{
+		{Type: "section", Text: &Text{Type: "mrkdwn", Text: fmt.Sprintf("*%s:* %s", headline, escape(ticket.Title))}},
+		{Type: "section", Fields: []Text{
+			{Type: "mrkdwn", Text: "*Ticket*\n" + escape(ticket.ID)},
+			{Type: "mrkdwn", Text: "*Status*\n" + string(ticket.Status)},
+			{Type: "mrkdwn", Text: "*Priority*\n" + string(ticket.Priority)},
+			{Type: "mrkdwn", Text: "*Category*\n" + string(ticket.Category)},
+			{Type: "mrkdwn", Text: "*Assignee*\n" + assignee},
+		}},
+	}
+	if buttons := ticketButtons(ticket); len(buttons) > 0 {
+		blocks = append(blocks, Block{Type: "actions", BlockID: ticketActionsBlockID, Elements: buttons})
+	}
+
+	return &Message{
+		Text:   fmt.Sprintf("%s: %s (%s)", headline, ticket.Title, ticket.ID),
+		Blocks: blocks,
+	}
+}
+
+// ticketListMessage lists tickets, each with its action buttons
+func ticketListMessage(tickets []*domain.Ticket) *Message {
+	if len(tickets) == 0 {
+		return textMessage("You have no open tickets.")
+	}
+
+	msg := textMessage(fmt.Sprintf("Open tickets assigned to you: %d", len(tickets)))
+	for _, ticket := range tickets {
+		msg.Blocks = append(msg.Blocks, Block{Type: "section", Text: &Text{
+			Type: "mrkdwn",
+			Text: fmt.Sprintf("*%s*\n`%s` · %s · %s", escape(ticket.Title), escape(ticket.ID), ticket.Status, ticket.Priority),
+		}})
+		if buttons := ticketButtons(ticket); len(buttons) > 0 {
+			msg.Blocks = append(msg.Blocks, Block{Type: "actions", BlockID: listActionsBlockID, Elements: buttons})
+		}
+	}
+	return msg
+}
+
+// ticketButtons returns the buttons for the actions the ticket's status allows
+func ticketButtons(ticket *domain.Ticket) []Element {
+	var buttons []Element
+	for _, action := range domain.ChatActions {
+		switch action {
+		case domain.ChatActionAssignToMe, domain.ChatActionResolve:
+			if ticket.Status == domain.TicketStatusResolved || ticket.Status == domain.TicketStatusClosed {
+				continue
+			}
+		case domain.ChatActionClose:
+			if ticket.Status != domain.TicketStatusResolved {
+				continue
+			}
+		}
+
+		button := Element{
+			Type:     "button",
+			Text:     &Text{Type: "plain_text", Text: actionLabels[action]},
+			ActionID: string(action),
+			Value:    ticket.ID,
+		}
+		if action == domain.ChatActionResolve {
+			button.Style = "primary"
+		}
+		buttons = append(buttons, button)
+	}
+	return buttons
+}
+
+// escape escapes the characters Slack treats as markup in message text
+func escape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
@@ -0,0 +1,34 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// Notifier posts ticket messages with action buttons to a Slack incoming webhook. Clicks on the
+// buttons are sent to the Handler's interactions URL.
+type Notifier struct {
+	config ports.SlackConfig
+	client *http.Client
+}
+
+// NewNotifier creates a notifier posting to config.WebhookURL
+func NewNotifier(config ports.SlackConfig) *Notifier {
+	return &Notifier{
+		config: config,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// PostTicket posts the ticket under the headline
+func (n *Notifier) PostTicket(ctx context.Context, ticket *domain.Ticket, headline string) error {
+	msg := ticketMessage(ticket, headline)
+	msg.Channel = n.config.Channel
+	msg.Username = n.config.Username
+	msg.IconEmoji = n.config.IconEmoji
+	return postMessage(ctx, n.client, n.config.WebhookURL, msg)
+}
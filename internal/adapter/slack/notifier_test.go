@@ -0,0 +1,42 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"fixora/internal/ports"
+)
+
+func TestNotifier_PostTicket(t *testing.T) {
+	var received Message
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	notifier := NewNotifier(ports.SlackConfig{WebhookURL: server.URL, Channel: "#helpdesk", Username: "Fixora"})
+	if err := notifier.PostTicket(context.Background(), newTestTicket(), "New ticket"); err != nil {
+		t.Fatalf("Failed to post ticket: %v", err)
+	}
+
+	if received.Channel != "#helpdesk" || received.Username != "Fixora" || received.Text != "New ticket: VPN keeps dropping (ticket_20251018095000)" {
+		t.Errorf("Unexpected message %+v", received)
+	}
+	if actions, blockIDs := buttons(received); len(actions) != 2 || blockIDs[0] != ticketActionsBlockID {
+		t.Errorf("Expected ticket buttons, got %v in %v", actions, blockIDs)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer failing.Close()
+
+	notifier = NewNotifier(ports.SlackConfig{WebhookURL: failing.URL})
+	if err := notifier.PostTicket(context.Background(), newTestTicket(), "New ticket"); err == nil {
+		t.Error("Expected an error when Slack rejects the message")
+	}
+}
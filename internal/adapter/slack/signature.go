@@ -0,0 +1,53 @@
+package slack
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// Headers Slack signs its requests with
+const (
+	SignatureHeader = "X-Slack-Signature"         // "v0=" + hex HMAC-SHA256 of "v0:<timestamp>:<body>"
+	TimestampHeader = "X-Slack-Request-Timestamp" // Unix seconds
+)
+
+// MaxRequestAge is how old a signed request may be before it is treated as a replay
+const MaxRequestAge = 5 * time.Minute
+
+// ErrInvalidSignature is returned for requests that were not signed with the signing secret
+var ErrInvalidSignature = errors.New("invalid Slack request signature")
+
+// Sign computes the signature Slack sends for a request body sent at timestamp
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+	return "v0=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks a request's signature against the raw body, and that it was sent
+// within MaxRequestAge of now
+func VerifySignature(secret, signature, timestamp string, body []byte, now time.Time) error {
+	if secret == "" {
+		return ErrInvalidSignature
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	sent := time.Unix(unix, 0)
+	if now.Sub(sent) > MaxRequestAge || sent.Sub(now) > MaxRequestAge {
+		return ErrInvalidSignature
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, body))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
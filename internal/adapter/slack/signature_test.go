@@ -0,0 +1,55 @@
+package slack
+
+import (
+	"testing"
+	"time"
+)
+
+// The signed slash command from Slack's request verification guide
+const (
+	docsSecret    = "8f742231b10e8888abcd99yyyzzz85a5"
+	docsTimestamp = "1531420618"
+	docsSignature = "v0=a2114d57b48eac39b9ad189dd8316235a7b4a8d21a10bd27519666489c69b503"
+	docsBody      = "token=xyzz0WbapA4vBCDEFasx0q6G&team_id=T1DC2JH3J&team_domain=testteamnow&channel_id=G8PSS9T3V&channel_name=foobar&user_id=U2CERLKJA&user_name=roadrunner&command=%2Fwebhook-collect&text=&response_url=https%3A%2F%2Fhooks.slack.com%2Fcommands%2FT1DC2JH3J%2F397700885554%2F96rGlfmibIGlgcZRskXaIFfN&trigger_id=398738663015.47445629121.803a0bc887a14d10d2c447fce8b6703c"
+)
+
+func TestSign(t *testing.T) {
+	if got := Sign(docsSecret, docsTimestamp, []byte(docsBody)); got != docsSignature {
+		t.Errorf("Expected %s, got %s", docsSignature, got)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	sentAt := time.Unix(1531420618, 0)
+
+	if err := VerifySignature(docsSecret, docsSignature, docsTimestamp, []byte(docsBody), sentAt.Add(time.Minute)); err != nil {
+		t.Fatalf("Expected a valid signature, got %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+		timestamp string
+		body      string
+		now       time.Time
+	}{
+		{"other secret", "0123456789abcdef0123456789abcdef", docsSignature, docsTimestamp, docsBody, sentAt},
+		{"no secret", "", docsSignature, docsTimestamp, docsBody, sentAt},
+		{"tampered body", docsSecret, docsSignature, docsTimestamp, docsBody + "&text=mine", sentAt},
+		{"other timestamp", docsSecret, docsSignature, "1531420619", docsBody, sentAt},
+		{"missing signature", docsSecret, "", docsTimestamp, docsBody, sentAt},
+		{"replayed", docsSecret, docsSignature, docsTimestamp, docsBody, sentAt.Add(MaxRequestAge + time.Second)},
+		{"from the future", docsSecret, docsSignature, docsTimestamp, docsBody, sentAt.Add(-MaxRequestAge - time.Second)},
+		{"bad timestamp", docsSecret, docsSignature, "yesterday", docsBody, sentAt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifySignature(tt.secret, tt.signature, tt.timestamp, []byte(tt.body), tt.now)
+			if err != ErrInvalidSignature {
+				t.Errorf("Expected ErrInvalidSignature, got %v", err)
+			}
+		})
+	}
+}
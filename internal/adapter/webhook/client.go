@@ -0,0 +1,88 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// userAgent identifies webhook deliveries to receivers
+const userAgent = "Fixora-Webhooks/1.0"
+
+// HTTPClient posts webhook deliveries over HTTP. Redirects are not followed: a subscriber that
+// moved must update its URL, and the signed payload is never sent to a host it did not name.
+// Connections to internal addresses outside the allowed networks are refused once the host is
+// resolved, so a hostname that later resolves to the metadata service or localhost is caught too.
+type HTTPClient struct {
+	client *http.Client
+}
+
+// NewHTTPClient creates a webhook client that gives up on an endpoint after timeout and may reach
+// internal addresses only inside the allowed networks
+func NewHTTPClient(timeout time.Duration, allowed []*net.IPNet) *HTTPClient {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(network, address string, conn syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !domain.WebhookAddressAllowed(ip, allowed) {
+				return fmt.Errorf("%w: %s", domain.ErrWebhookAddressNotAllowed, host)
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would connect to the endpoint on our behalf, past the address check
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &HTTPClient{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Post sends body to url. Any response, whatever its status, is returned without an error.
+func (c *HTTPClient) Post(ctx context.Context, url string, headers map[string]string, body []byte) (*ports.WebhookResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", userAgent)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Only the start of the response is logged; drain the rest so the connection can be reused
+	responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, domain.MaxWebhookResponseBody))
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+
+	return &ports.WebhookResponse{
+		StatusCode: resp.StatusCode,
+		Body:       string(responseBody),
+	}, nil
+}
@@ -0,0 +1,107 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"fixora/internal/domain"
+)
+
+// loopback lets the tests reach their httptest servers
+var loopback = []*net.IPNet{{IP: net.IPv4(127, 0, 0, 0), Mask: net.CIDRMask(8, 32)}}
+
+func TestHTTPClient_Post(t *testing.T) {
+	secret := "0123456789abcdef"
+	body := []byte(`{"id":"event_1","type":"ticket_created"}`)
+
+	var verifyErr error
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ := io.ReadAll(r.Body)
+		verifyErr = domain.VerifyWebhookSignature(secret,
+			r.Header.Get(domain.WebhookSignatureHeader),
+			r.Header.Get(domain.WebhookTimestampHeader),
+			received, time.Now(), 5*time.Minute)
+
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(strings.Repeat("x", domain.MaxWebhookResponseBody+100)))
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	headers := map[string]string{
+		domain.WebhookTimestampHeader: strconv.FormatInt(now.Unix(), 10),
+		domain.WebhookSignatureHeader: domain.SignWebhookPayload(secret, now, body),
+	}
+
+	resp, err := NewHTTPClient(5*time.Second, loopback).Post(context.Background(), server.URL, headers, body)
+	if err != nil {
+		t.Fatalf("Failed to post: %v", err)
+	}
+	if verifyErr != nil {
+		t.Errorf("Expected the receiver to verify the signature, got %v", verifyErr)
+	}
+	if resp.StatusCode != http.StatusAccepted {
+		t.Errorf("Expected status 202, got %d", resp.StatusCode)
+	}
+	if len(resp.Body) != domain.MaxWebhookResponseBody {
+		t.Errorf("Expected the response body to be truncated, got %d bytes", len(resp.Body))
+	}
+}
+
+func TestHTTPClient_DoesNotFollowRedirects(t *testing.T) {
+	followed := false
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		followed = true
+	}))
+	defer target.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer server.Close()
+
+	resp, err := NewHTTPClient(5*time.Second, loopback).Post(context.Background(), server.URL, nil, []byte(`{}`))
+	if err != nil {
+		t.Fatalf("Failed to post: %v", err)
+	}
+	if resp.StatusCode != http.StatusTemporaryRedirect || followed {
+		t.Errorf("Expected the redirect to be returned, got %d (followed: %v)", resp.StatusCode, followed)
+	}
+}
+
+func TestHTTPClient_ConnectionError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	if _, err := NewHTTPClient(time.Second, loopback).Post(context.Background(), server.URL, nil, []byte(`{}`)); err == nil {
+		t.Error("Expected an error when the endpoint is unreachable")
+	}
+}
+
+func TestHTTPClient_RefusesInternalAddresses(t *testing.T) {
+	reached := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached = true
+	}))
+	defer server.Close()
+
+	// The hostname is only resolved to loopback when connecting
+	_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+	for _, url := range []string{server.URL, "http://localhost:" + port} {
+		_, err := NewHTTPClient(time.Second, nil).Post(context.Background(), url, nil, []byte(`{}`))
+		if !errors.Is(err, domain.ErrWebhookAddressNotAllowed) {
+			t.Errorf("Expected ErrWebhookAddressNotAllowed for %s, got %v", url, err)
+		}
+	}
+	if reached {
+		t.Error("Expected the internal endpoint not to be reached")
+	}
+}
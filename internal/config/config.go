@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
+	"fixora/internal/domain"
 	"fixora/internal/ports"
 )
 
@@ -18,6 +20,14 @@ type Config struct {
 	Logging  LoggingConfig  `json:"logging"`
 	Security SecurityConfig `json:"security"`
 	SSE      SSEConfig      `json:"sse"`
+	Assignment AssignmentConfig `json:"assignment"`
+	Automation AutomationConfig `json:"automation"`
+	Scheduler  SchedulerConfig  `json:"scheduler"`
+	CSAT       CSATConfig       `json:"csat"`
+	Inbound    InboundEmailConfig `json:"inbound_email"`
+	Outbound   OutboundEmailConfig `json:"outbound_email"`
+	Webhooks   WebhooksConfig     `json:"webhooks"`
+	Slack      SlackConfig        `json:"slack"`
 }
 
 // ServerConfig represents HTTP server configuration
@@ -58,12 +68,40 @@ type AIConfig struct {
 	TimeoutMs        int               `json:"timeout_ms"`
 	EnableCache      bool              `json:"enable_cache"`
 	CacheTTLMin      int               `json:"cache_ttl_min"`
+	CacheSize        int               `json:"cache_size"`
+	CachePersistent  bool              `json:"cache_persistent"`
 	ChunkSize        int               `json:"chunk_size"`
 	ChunkOverlap     int               `json:"chunk_overlap"`
 	BatchSize        int               `json:"batch_size"`
 	MaxConcurrency   int               `json:"max_concurrency"`
+	IndexWorkers     int               `json:"index_workers"`
+	IndexMaxRetries  int               `json:"index_max_retries"`
+	IndexPollInterval time.Duration    `json:"index_poll_interval"` // how often queued indexing jobs are picked up when the queue was full
 	MockMode         bool              `json:"mock_mode"`
 	Providers        map[string]string `json:"providers"`
+	BaseURL          string            `json:"base_url"`
+	AuthScheme       string            `json:"auth_scheme"`
+	APIVersion       string            `json:"api_version"`
+	ModelMap         map[string]string `json:"model_map"`
+	Fallbacks        []string          `json:"fallbacks"`
+	Endpoints        map[string]AIEndpointConfig `json:"endpoints"`
+	BreakerThreshold int               `json:"breaker_threshold"`
+	BreakerCooldown  time.Duration     `json:"breaker_cooldown"`
+	ClassifierEnabled bool             `json:"classifier_enabled"`
+	ClassifierSaveEvery    int           `json:"classifier_save_every"`
+	ClassifierSaveInterval time.Duration `json:"classifier_save_interval"`
+	LearnFromTickets  bool             `json:"learn_from_tickets"`
+	LearnDuplicateScore float64        `json:"learn_duplicate_score"`
+	TicketDuplicateScore float64       `json:"ticket_duplicate_score"`
+	TicketSimilarScore   float64       `json:"ticket_similar_score"`
+}
+
+// AIEndpointConfig holds connection settings for one AI provider
+type AIEndpointConfig struct {
+	BaseURL    string            `json:"base_url"`
+	AuthScheme string            `json:"auth_scheme"`
+	APIVersion string            `json:"api_version"`
+	ModelMap   map[string]string `json:"model_map"`
 }
 
 // RedisConfig represents Redis configuration
@@ -111,6 +149,75 @@ type SSEConfig struct {
 	ClientTimeout     time.Duration `json:"client_timeout"`
 }
 
+// AssignmentConfig represents automatic ticket assignment configuration
+type AssignmentConfig struct {
+	AutoAssign bool   `json:"auto_assign"` // route new tickets to agents as they are created
+	Strategy   string `json:"strategy"`    // round_robin, least_open or skill_match
+}
+
+// AutomationConfig represents rule-based automation configuration
+type AutomationConfig struct {
+	Enabled          bool          `json:"enabled"`           // run automation rules on ticket events and on schedule
+	ScheduleInterval time.Duration `json:"schedule_interval"` // how often scheduled rules are evaluated
+}
+
+// WebhooksConfig represents webhook subscription delivery configuration
+type WebhooksConfig struct {
+	Enabled         bool          `json:"enabled"`          // deliver events to webhook subscriptions
+	Timeout         time.Duration `json:"timeout"`          // per delivery attempt
+	MaxAttempts     int           `json:"max_attempts"`     // attempts per delivery, with exponential backoff
+	DisableAfter    int           `json:"disable_after"`    // failed deliveries in a row that disable a subscription
+	RetryInterval   time.Duration `json:"retry_interval"`   // how often due retries are sent
+	AllowedNetworks []string      `json:"allowed_networks"` // internal CIDRs or addresses webhooks may reach
+}
+
+// SchedulerConfig represents background job configuration
+type SchedulerConfig struct {
+	Enabled             bool          `json:"enabled"`
+	InstanceID          string        `json:"instance_id"`                 // identifies this instance in job runs; defaults to the hostname
+	TickInterval        time.Duration `json:"tick_interval"`               // how often to check leadership and due jobs
+	JobInterval         time.Duration `json:"job_interval"`                // how often the ticket maintenance jobs run
+	AutoCloseAfterDays  int           `json:"auto_close_after_days"`       // 0 disables auto-close
+	PendingReminderDays int           `json:"pending_reminder_after_days"` // 0 disables reminders
+	EscalateAfterHours  int           `json:"escalate_after_hours"`        // 0 disables escalation
+}
+
+// InboundEmailConfig represents email-to-ticket configuration. Mail is received by an SMTP
+// listener, a Maildir poller, or both; neither runs when its setting is empty.
+type InboundEmailConfig struct {
+	SMTPAddr        string        `json:"smtp_addr"`         // e.g. 127.0.0.1:2525
+	SMTPHostname    string        `json:"smtp_hostname"`     // name announced to connecting mail servers
+	MaildirPath     string        `json:"maildir_path"`      // Maildir to poll for delivered mail
+	PollInterval    time.Duration `json:"poll_interval"`     // how often to poll the Maildir
+	MaxMessageBytes int64         `json:"max_message_bytes"` // larger messages are rejected
+	UseAI           bool          `json:"use_ai"`            // AI intake for tickets opened by email
+}
+
+// OutboundEmailConfig represents email notification configuration. Notifications are only sent
+// when an SMTP host is set.
+type OutboundEmailConfig struct {
+	ports.EmailConfig
+	ReplyAddress string `json:"reply_address"` // mailbox replies go to; defaults to the from address
+	ReplySecret  string `json:"-"`             // signs reply addresses; defaults to the JWT secret
+	Language     string `json:"language"`      // en or id
+}
+
+// SlackConfig represents Slack configuration. New tickets are posted to the incoming webhook
+// when one is set; the slash command and button endpoints are served when a signing secret is set.
+type SlackConfig struct {
+	ports.SlackConfig
+	SigningSecret string            `json:"-"`
+	Command       string            `json:"command"`  // slash command name, e.g. /fixora
+	UserMap       map[string]string `json:"user_map"` // Slack user ID to Fixora user ID
+}
+
+// CSATConfig represents requester satisfaction survey configuration
+type CSATConfig struct {
+	LinkSecret string        `json:"-"`        // signs rating links; defaults to the JWT secret
+	LinkTTL    time.Duration `json:"link_ttl"` // how long rating links stay valid
+	BaseURL    string        `json:"base_url"` // public URL the rating links point to
+}
+
 // Load loads configuration from environment variables and defaults
 func Load() (*Config, error) {
 	config := &Config{
@@ -147,15 +254,36 @@ func Load() (*Config, error) {
 			TimeoutMs:        getEnvInt("AI_TIMEOUT_MS", 5000),
 			EnableCache:      getEnvBool("AI_ENABLE_CACHE", true),
 			CacheTTLMin:      getEnvInt("AI_CACHE_TTL_MIN", 60),
+			CacheSize:        getEnvInt("AI_CACHE_SIZE", 10000),
+			CachePersistent:  getEnvBool("AI_CACHE_PERSISTENT", false),
 			ChunkSize:        getEnvInt("AI_CHUNK_SIZE", 800),
 			ChunkOverlap:     getEnvInt("AI_CHUNK_OVERLAP", 150),
 			BatchSize:        getEnvInt("AI_BATCH_SIZE", 32),
 			MaxConcurrency:   getEnvInt("AI_MAX_CONCURRENCY", 5),
+			IndexWorkers:     getEnvInt("AI_INDEX_WORKERS", 2),
+			IndexMaxRetries:  getEnvInt("AI_INDEX_MAX_RETRIES", 3),
+			IndexPollInterval: getEnvDuration("AI_INDEX_POLL_INTERVAL", 30*time.Second),
 			MockMode:         getEnvBool("AI_MOCK_MODE", true),
 			Providers: map[string]string{
 				"openai": getEnv("OPENAI_API_KEY", ""),
 				"zai":    getEnv("ZAI_API_KEY", ""),
+				"azure":  getEnv("AZURE_OPENAI_API_KEY", ""),
 			},
+			BaseURL:          getEnv("AI_BASE_URL", ""),
+			AuthScheme:       getEnv("AI_AUTH_SCHEME", ""),
+			APIVersion:       getEnv("AI_API_VERSION", ""),
+			ModelMap:         getEnvMap("AI_MODEL_MAP"),
+			Fallbacks:        getEnvSlice("AI_FALLBACK_PROVIDERS", nil),
+			Endpoints:        loadAIEndpoints("openai", "azure", "zai", "ollama", "compatible"),
+			BreakerThreshold: getEnvInt("AI_BREAKER_THRESHOLD", 5),
+			BreakerCooldown:  getEnvDuration("AI_BREAKER_COOLDOWN", 30*time.Second),
+			ClassifierEnabled: getEnvBool("AI_CLASSIFIER_ENABLED", true),
+			ClassifierSaveEvery:    getEnvInt("AI_CLASSIFIER_SAVE_EVERY", 25),
+			ClassifierSaveInterval: getEnvDuration("AI_CLASSIFIER_SAVE_INTERVAL", 5*time.Minute),
+			LearnFromTickets:  getEnvBool("AI_LEARN_FROM_TICKETS", true),
+			LearnDuplicateScore: getEnvFloat("AI_LEARN_DUPLICATE_SCORE", 0.9),
+			TicketDuplicateScore: getEnvFloat("AI_TICKET_DUPLICATE_SCORE", 0.88),
+			TicketSimilarScore:   getEnvFloat("AI_TICKET_SIMILAR_SCORE", 0.75),
 		},
 		Redis: RedisConfig{
 			Host:     getEnv("REDIS_HOST", "localhost"),
@@ -194,6 +322,70 @@ func Load() (*Config, error) {
 			MessageBufferSize: getEnvInt("SSE_MESSAGE_BUFFER_SIZE", 256),
 			ClientTimeout:     getEnvDuration("SSE_CLIENT_TIMEOUT", 30*time.Second),
 		},
+		Assignment: AssignmentConfig{
+			AutoAssign: getEnvBool("AUTO_ASSIGN_ENABLED", true),
+			Strategy:   getEnv("AUTO_ASSIGN_STRATEGY", "skill_match"),
+		},
+		Automation: AutomationConfig{
+			Enabled:          getEnvBool("AUTOMATION_ENABLED", true),
+			ScheduleInterval: getEnvDuration("AUTOMATION_SCHEDULE_INTERVAL", 5*time.Minute),
+		},
+		Webhooks: WebhooksConfig{
+			Enabled:         getEnvBool("WEBHOOKS_ENABLED", true),
+			Timeout:         getEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+			MaxAttempts:     getEnvInt("WEBHOOK_MAX_ATTEMPTS", 8),
+			DisableAfter:    getEnvInt("WEBHOOK_DISABLE_AFTER", 5),
+			RetryInterval:   getEnvDuration("WEBHOOK_RETRY_INTERVAL", time.Minute),
+			AllowedNetworks: getEnvSlice("WEBHOOK_ALLOWED_NETWORKS", nil),
+		},
+		Slack: SlackConfig{
+			SlackConfig: ports.SlackConfig{
+				WebhookURL: getEnv("SLACK_WEBHOOK_URL", ""),
+				Channel:    getEnv("SLACK_CHANNEL", ""),
+				Username:   getEnv("SLACK_USERNAME", "Fixora"),
+				IconEmoji:  getEnv("SLACK_ICON_EMOJI", ""),
+			},
+			SigningSecret: getEnv("SLACK_SIGNING_SECRET", ""),
+			Command:       getEnv("SLACK_COMMAND", "/fixora"),
+			UserMap:       getEnvMap("SLACK_USER_MAP"),
+		},
+		CSAT: CSATConfig{
+			LinkSecret: getEnv("CSAT_LINK_SECRET", getEnv("JWT_SECRET", "your-secret-key-change-in-production")),
+			LinkTTL:    getEnvDuration("CSAT_LINK_TTL", 30*24*time.Hour),
+			BaseURL:    getEnv("CSAT_BASE_URL", "http://localhost:8080/csat"),
+		},
+		Inbound: InboundEmailConfig{
+			SMTPAddr:        getEnv("INBOUND_SMTP_ADDR", ""),
+			SMTPHostname:    getEnv("INBOUND_SMTP_HOSTNAME", "localhost"),
+			MaildirPath:     getEnv("INBOUND_MAILDIR", ""),
+			PollInterval:    getEnvDuration("INBOUND_MAILDIR_POLL_INTERVAL", 30*time.Second),
+			MaxMessageBytes: int64(getEnvInt("INBOUND_MAX_MESSAGE_BYTES", 10<<20)),
+			UseAI:           getEnvBool("INBOUND_EMAIL_AI_INTAKE", true),
+		},
+		Outbound: OutboundEmailConfig{
+			EmailConfig: ports.EmailConfig{
+				SMTPHost:  getEnv("SMTP_HOST", ""),
+				SMTPPort:  getEnvInt("SMTP_PORT", 587),
+				Username:  getEnv("SMTP_USERNAME", ""),
+				Password:  getEnv("SMTP_PASSWORD", ""),
+				FromEmail: getEnv("EMAIL_FROM", "helpdesk@localhost"),
+				FromName:  getEnv("EMAIL_FROM_NAME", "Fixora Helpdesk"),
+				UseTLS:    getEnvBool("SMTP_TLS", true),
+				UseHTML:   getEnvBool("EMAIL_HTML", true),
+			},
+			ReplyAddress: getEnv("EMAIL_REPLY_ADDRESS", ""),
+			ReplySecret:  getEnv("EMAIL_REPLY_SECRET", getEnv("JWT_SECRET", "your-secret-key-change-in-production")),
+			Language:     getEnv("EMAIL_LANGUAGE", "en"),
+		},
+		Scheduler: SchedulerConfig{
+			Enabled:             getEnvBool("SCHEDULER_ENABLED", true),
+			InstanceID:          getEnv("SCHEDULER_INSTANCE_ID", defaultInstanceID()),
+			TickInterval:        getEnvDuration("SCHEDULER_TICK_INTERVAL", 30*time.Second),
+			JobInterval:         getEnvDuration("SCHEDULER_JOB_INTERVAL", time.Hour),
+			AutoCloseAfterDays:  getEnvInt("AUTO_CLOSE_AFTER_DAYS", 7),
+			PendingReminderDays: getEnvInt("PENDING_REMINDER_AFTER_DAYS", 3),
+			EscalateAfterHours:  getEnvInt("STALE_ESCALATION_AFTER_HOURS", 48),
+		},
 	}
 
 	return config, nil
@@ -221,10 +413,56 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("AI provider is required")
 	}
 
-	if c.AI.Provider != "mock" && c.AI.APIKey == "" {
+	if c.AI.Provider != "mock" && c.AIProviderKey(c.AI.Provider) == "" && c.AIProviderRequiresKey(c.AI.Provider) {
 		return fmt.Errorf("AI API key is required for provider: %s", c.AI.Provider)
 	}
 
+	if (c.AI.Provider == "azure" || c.AI.Provider == "compatible") && c.AIEndpoint(c.AI.Provider).BaseURL == "" {
+		return fmt.Errorf("AI base URL is required for provider: %s", c.AI.Provider)
+	}
+
+	switch c.Assignment.Strategy {
+	case "round_robin", "least_open", "skill_match":
+	default:
+		return fmt.Errorf("unknown assignment strategy: %s", c.Assignment.Strategy)
+	}
+
+	if c.Webhooks.Enabled && (c.Webhooks.Timeout <= 0 || c.Webhooks.RetryInterval <= 0 || c.Webhooks.MaxAttempts <= 0 || c.Webhooks.DisableAfter <= 0) {
+		return fmt.Errorf("webhook timeout, retry interval, max attempts and disable after must be positive")
+	}
+
+	if _, err := domain.ParseWebhookNetworks(c.Webhooks.AllowedNetworks); err != nil {
+		return err
+	}
+
+	if c.Slack.SigningSecret != "" && !strings.HasPrefix(c.Slack.Command, "/") {
+		return fmt.Errorf("slack command must start with /: %s", c.Slack.Command)
+	}
+
+	if c.CSAT.LinkTTL <= 0 {
+		return fmt.Errorf("CSAT link TTL must be positive")
+	}
+
+	if c.Inbound.MaildirPath != "" && c.Inbound.PollInterval <= 0 {
+		return fmt.Errorf("inbound maildir poll interval must be positive")
+	}
+
+	if c.Outbound.SMTPHost != "" && c.Outbound.Language != "en" && c.Outbound.Language != "id" {
+		return fmt.Errorf("unsupported email language: %s", c.Outbound.Language)
+	}
+
+	if c.Scheduler.Enabled && (c.Scheduler.TickInterval <= 0 || c.Scheduler.JobInterval <= 0) {
+		return fmt.Errorf("scheduler tick and job intervals must be positive")
+	}
+
+	if c.Scheduler.AutoCloseAfterDays < 0 || c.Scheduler.PendingReminderDays < 0 || c.Scheduler.EscalateAfterHours < 0 {
+		return fmt.Errorf("scheduler job thresholds cannot be negative")
+	}
+
+	if c.Automation.Enabled && c.Automation.ScheduleInterval <= 0 {
+		return fmt.Errorf("automation schedule interval must be positive")
+	}
+
 	if c.Security.JWTSecret == "" || c.Security.JWTSecret == "your-secret-key-change-in-production" {
 		if c.Server.Environment == "production" {
 			return fmt.Errorf("JWT secret must be set in production")
@@ -264,6 +502,56 @@ func (c *Config) GetRedisURL() string {
 	return fmt.Sprintf("%s:%d/%d", c.Redis.Host, c.Redis.Port, c.Redis.DB)
 }
 
+// AIProviderKey returns the API key for an AI provider, preferring the provider-specific key.
+// AI_API_KEY applies to the primary provider only.
+func (c *Config) AIProviderKey(provider string) string {
+	if key := c.AI.Providers[provider]; key != "" {
+		return key
+	}
+	if provider == c.AI.Provider {
+		return c.AI.APIKey
+	}
+	return ""
+}
+
+// AIProviderRequiresKey reports whether an AI provider needs an API key.
+// Local OpenAI-compatible servers such as Ollama usually run without authentication.
+func (c *Config) AIProviderRequiresKey(provider string) bool {
+	scheme := c.AIEndpoint(provider).AuthScheme
+	switch provider {
+	case "mock":
+		return false
+	case "ollama", "compatible":
+		return scheme != "" && scheme != "none"
+	default:
+		return scheme != "none"
+	}
+}
+
+// AIEndpoint returns the connection settings for an AI provider from its AI_<PROVIDER>_* variables.
+// For the primary provider, unset values fall back to the generic AI_* variables.
+func (c *Config) AIEndpoint(provider string) AIEndpointConfig {
+	endpoint := c.AI.Endpoints[provider]
+	if provider != c.AI.Provider {
+		return endpoint
+	}
+
+	if endpoint.BaseURL == "" {
+		endpoint.BaseURL = c.AI.BaseURL
+	}
+	if endpoint.AuthScheme == "" {
+		endpoint.AuthScheme = c.AI.AuthScheme
+	}
+	if endpoint.APIVersion == "" {
+		endpoint.APIVersion = c.AI.APIVersion
+	}
+	if len(endpoint.ModelMap) == 0 {
+		endpoint.ModelMap = c.AI.ModelMap
+	}
+
+	return endpoint
+}
+
 // ToAIConfig converts to ports.AIConfig
 func (c *Config) ToAIConfig() ports.AIConfig {
 	return ports.AIConfig{
@@ -289,6 +577,15 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// defaultInstanceID identifies this instance by hostname and process ID
+func defaultInstanceID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "fixora"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
+
 func getEnvInt(key string, defaultValue int) int {
 	if value := os.Getenv(key); value != "" {
 		if intValue, err := strconv.Atoi(value); err == nil {
@@ -325,10 +622,43 @@ func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
 	return defaultValue
 }
 
+func getEnvMap(key string) map[string]string {
+	result := make(map[string]string)
+	// Comma-separated key=value pairs, e.g. "gpt-3.5-turbo=glm-4.5,text-embedding-ada-002=embedding-3"
+	for _, pair := range strings.Split(os.Getenv(key), ",") {
+		k, v, ok := strings.Cut(pair, "=")
+		if ok && strings.TrimSpace(k) != "" {
+			result[strings.TrimSpace(k)] = strings.TrimSpace(v)
+		}
+	}
+	return result
+}
+
 func getEnvSlice(key string, defaultValue []string) []string {
 	if value := os.Getenv(key); value != "" {
 		// Simple comma-separated parsing
-		return []string{value}
+		var values []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+		return values
 	}
 	return defaultValue
+}
+
+// loadAIEndpoints reads AI_<PROVIDER>_BASE_URL, _AUTH_SCHEME, _API_VERSION and _MODEL_MAP for each provider
+func loadAIEndpoints(providers ...string) map[string]AIEndpointConfig {
+	endpoints := make(map[string]AIEndpointConfig, len(providers))
+	for _, provider := range providers {
+		prefix := "AI_" + strings.ToUpper(provider) + "_"
+		endpoints[provider] = AIEndpointConfig{
+			BaseURL:    getEnv(prefix+"BASE_URL", ""),
+			AuthScheme: getEnv(prefix+"AUTH_SCHEME", ""),
+			APIVersion: getEnv(prefix+"API_VERSION", ""),
+			ModelMap:   getEnvMap(prefix + "MODEL_MAP"),
+		}
+	}
+	return endpoints
 }
\ No newline at end of file
@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// MaxSkillLevel is the highest proficiency an agent can have in a category
+const MaxSkillLevel = 5
+
+// Agent is a support agent tickets can be routed to. The ID is the one tickets are assigned to.
+type Agent struct {
+	ID             string                 `json:"id"`
+	Name           string                 `json:"name"`
+	Email          string                 `json:"email,omitempty"`
+	Skills         map[TicketCategory]int `json:"skills"`   // proficiency per category, 1 to MaxSkillLevel
+	Capacity       int                    `json:"capacity"` // maximum open tickets, 0 for no limit
+	Available      bool                   `json:"available"`
+	LastAssignedAt *time.Time             `json:"last_assigned_at,omitempty"`
+	OpenTickets    int                    `json:"open_tickets"` // computed when routing, not stored
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+}
+
+// NewAgent registers an available agent
+func NewAgent(id, name, email string, skills map[TicketCategory]int, capacity int) (*Agent, error) {
+	now := time.Now()
+	agent := &Agent{
+		ID:        strings.TrimSpace(id),
+		Name:      strings.TrimSpace(name),
+		Email:     strings.TrimSpace(email),
+		Skills:    skills,
+		Capacity:  capacity,
+		Available: true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := agent.validate(); err != nil {
+		return nil, err
+	}
+	return agent, nil
+}
+
+// UpdateProfile replaces the agent's name, email, skills and capacity
+func (a *Agent) UpdateProfile(name, email string, skills map[TicketCategory]int, capacity int) error {
+	updated := *a
+	updated.Name = strings.TrimSpace(name)
+	updated.Email = strings.TrimSpace(email)
+	updated.Skills = skills
+	updated.Capacity = capacity
+
+	if err := updated.validate(); err != nil {
+		return err
+	}
+
+	updated.UpdatedAt = time.Now()
+	*a = updated
+	return nil
+}
+
+// SetAvailability marks the agent as available or unavailable for new tickets
+func (a *Agent) SetAvailability(available bool) {
+	a.Available = available
+	a.UpdatedAt = time.Now()
+}
+
+// SkillLevel returns the agent's proficiency in a category, 0 when the agent lacks the skill
+func (a *Agent) SkillLevel(category TicketCategory) int {
+	return a.Skills[category]
+}
+
+// HasCapacity reports whether the agent can take another ticket
+func (a *Agent) HasCapacity() bool {
+	return a.Capacity == 0 || a.OpenTickets < a.Capacity
+}
+
+// RecordAssignment records that a ticket was routed to the agent
+func (a *Agent) RecordAssignment(at time.Time) {
+	a.LastAssignedAt = &at
+	a.OpenTickets++
+	a.UpdatedAt = at
+}
+
+func (a *Agent) validate() error {
+	if a.ID == "" {
+		return ErrEmptyAgentID
+	}
+	if a.Name == "" {
+		return ErrEmptyAgentName
+	}
+	if a.Capacity < 0 {
+		return ErrInvalidAgentCapacity
+	}
+	if a.Skills == nil {
+		a.Skills = map[TicketCategory]int{}
+	}
+	for category, level := range a.Skills {
+		if !category.IsValid() || level < 1 || level > MaxSkillLevel {
+			return ErrInvalidAgentSkill
+		}
+	}
+	return nil
+}
+
+// AgentFilter represents filters for listing agents
+type AgentFilter struct {
+	Available *bool `json:"available,omitempty"`
+}
+
+// Agent errors
+var (
+	ErrAgentNotFound        = NewDomainError("agent not found")
+	ErrAgentExists          = NewDomainError("agent already exists")
+	ErrEmptyAgentID         = NewDomainError("agent ID cannot be empty")
+	ErrEmptyAgentName       = NewDomainError("agent name cannot be empty")
+	ErrInvalidAgentCapacity = NewDomainError("agent capacity cannot be negative")
+	ErrInvalidAgentSkill    = NewDomainError("agent skills must be known categories with a level from 1 to 5")
+)
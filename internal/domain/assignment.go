@@ -0,0 +1,255 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Assignment strategy names
+const (
+	AssignmentStrategyRoundRobin = "round_robin"
+	AssignmentStrategyLeastOpen  = "least_open"
+	AssignmentStrategySkillMatch = "skill_match"
+)
+
+// AssignmentRequest is what a strategy routes: the ticket, the agents able to take it, and the
+// AI-predicted category when one is available
+type AssignmentRequest struct {
+	Ticket               *Ticket
+	Agents               []*Agent
+	PredictedCategory    TicketCategory
+	PredictionConfidence float64
+}
+
+// AssignmentCandidate is an agent considered for a ticket, with the strategy's score and reasoning
+type AssignmentCandidate struct {
+	AgentID     string  `json:"agent_id"`
+	Name        string  `json:"name"`
+	Score       float64 `json:"score"`
+	OpenTickets int     `json:"open_tickets"`
+	Reason      string  `json:"reason"`
+}
+
+// AssignmentStrategy ranks agents for a ticket, best first
+type AssignmentStrategy interface {
+	Name() string
+	Rank(req AssignmentRequest) []AssignmentCandidate
+}
+
+// AssignmentDecision explains which agent a ticket was (or would be) routed to and why
+type AssignmentDecision struct {
+	TicketID          string                `json:"ticket_id"`
+	Strategy          string                `json:"strategy"`
+	AgentID           string                `json:"agent_id,omitempty"` // empty when no agent can take the ticket
+	Reason            string                `json:"reason"`
+	PredictedCategory TicketCategory        `json:"predicted_category,omitempty"`
+	Confidence        float64               `json:"prediction_confidence,omitempty"`
+	Candidates        []AssignmentCandidate `json:"candidates"`
+	Skipped           []AssignmentCandidate `json:"skipped,omitempty"` // unavailable or at capacity
+	DryRun            bool                  `json:"dry_run"`
+}
+
+// DecideAssignment routes a ticket with the strategy. Unavailable agents and agents at capacity
+// are skipped; the others are ranked and the best one is chosen.
+func DecideAssignment(strategy AssignmentStrategy, req AssignmentRequest) *AssignmentDecision {
+	decision := &AssignmentDecision{
+		TicketID:          req.Ticket.ID,
+		Strategy:          strategy.Name(),
+		PredictedCategory: req.PredictedCategory,
+		Confidence:        req.PredictionConfidence,
+		Candidates:        []AssignmentCandidate{},
+	}
+
+	var eligible []*Agent
+	for _, agent := range req.Agents {
+		switch {
+		case !agent.Available:
+			decision.Skipped = append(decision.Skipped, skippedCandidate(agent, "unavailable"))
+		case !agent.HasCapacity():
+			decision.Skipped = append(decision.Skipped, skippedCandidate(agent,
+				fmt.Sprintf("at capacity (%d of %d open tickets)", agent.OpenTickets, agent.Capacity)))
+		default:
+			eligible = append(eligible, agent)
+		}
+	}
+
+	if len(eligible) == 0 {
+		decision.Reason = "no available agent with capacity"
+		return decision
+	}
+
+	req.Agents = eligible
+	decision.Candidates = strategy.Rank(req)
+	if len(decision.Candidates) == 0 {
+		decision.Reason = "no agent matched the strategy"
+		return decision
+	}
+
+	best := decision.Candidates[0]
+	decision.AgentID = best.AgentID
+	decision.Reason = fmt.Sprintf("%s: %s", best.Name, best.Reason)
+	return decision
+}
+
+func skippedCandidate(agent *Agent, reason string) AssignmentCandidate {
+	return AssignmentCandidate{
+		AgentID:     agent.ID,
+		Name:        agent.Name,
+		OpenTickets: agent.OpenTickets,
+		Reason:      reason,
+	}
+}
+
+// RoundRobinStrategy routes to the agent who has gone longest without a new ticket
+type RoundRobinStrategy struct{}
+
+// Name returns the strategy name
+func (RoundRobinStrategy) Name() string { return AssignmentStrategyRoundRobin }
+
+// Rank orders agents by when they were last assigned, never-assigned agents first
+func (RoundRobinStrategy) Rank(req AssignmentRequest) []AssignmentCandidate {
+	agents := sortedAgents(req.Agents, func(a, b *Agent) bool { return false })
+
+	candidates := make([]AssignmentCandidate, len(agents))
+	for i, agent := range agents {
+		reason := "never assigned a ticket"
+		if agent.LastAssignedAt != nil {
+			reason = "last assigned " + agent.LastAssignedAt.Format(time.RFC3339)
+		}
+		candidates[i] = AssignmentCandidate{
+			AgentID:     agent.ID,
+			Name:        agent.Name,
+			Score:       float64(len(agents)-i) / float64(len(agents)),
+			OpenTickets: agent.OpenTickets,
+			Reason:      reason,
+		}
+	}
+	return candidates
+}
+
+// LeastOpenStrategy routes to the agent with the fewest open tickets
+type LeastOpenStrategy struct{}
+
+// Name returns the strategy name
+func (LeastOpenStrategy) Name() string { return AssignmentStrategyLeastOpen }
+
+// Rank orders agents by open tickets, breaking ties round-robin
+func (LeastOpenStrategy) Rank(req AssignmentRequest) []AssignmentCandidate {
+	agents := sortedAgents(req.Agents, func(a, b *Agent) bool { return a.OpenTickets < b.OpenTickets })
+
+	candidates := make([]AssignmentCandidate, len(agents))
+	for i, agent := range agents {
+		candidates[i] = AssignmentCandidate{
+			AgentID:     agent.ID,
+			Name:        agent.Name,
+			Score:       1 / float64(1+agent.OpenTickets),
+			OpenTickets: agent.OpenTickets,
+			Reason:      fmt.Sprintf("%d open tickets", agent.OpenTickets),
+		}
+	}
+	return candidates
+}
+
+// SkillMatchStrategy routes to the agent most skilled in the ticket's category. When the AI
+// predicts a different category, skills are weighted between the ticket's category and the
+// predicted one by the prediction's confidence. Ties go to the agent with fewer open tickets.
+type SkillMatchStrategy struct{}
+
+// Name returns the strategy name
+func (SkillMatchStrategy) Name() string { return AssignmentStrategySkillMatch }
+
+// Rank orders agents by weighted skill
+func (SkillMatchStrategy) Rank(req AssignmentRequest) []AssignmentCandidate {
+	weights := CategoryWeights(req.Ticket.Category, req.PredictedCategory, req.PredictionConfidence)
+
+	scores := make(map[string]float64, len(req.Agents))
+	for _, agent := range req.Agents {
+		var score float64
+		for category, weight := range weights {
+			score += weight * float64(agent.SkillLevel(category)) / MaxSkillLevel
+		}
+		scores[agent.ID] = score
+	}
+
+	agents := sortedAgents(req.Agents, func(a, b *Agent) bool {
+		if scores[a.ID] != scores[b.ID] {
+			return scores[a.ID] > scores[b.ID]
+		}
+		return a.OpenTickets < b.OpenTickets
+	})
+
+	candidates := make([]AssignmentCandidate, len(agents))
+	for i, agent := range agents {
+		candidates[i] = AssignmentCandidate{
+			AgentID:     agent.ID,
+			Name:        agent.Name,
+			Score:       scores[agent.ID],
+			OpenTickets: agent.OpenTickets,
+			Reason:      skillReason(agent, weights),
+		}
+	}
+	return candidates
+}
+
+// CategoryWeights splits weight between the ticket's category and the AI-predicted category
+func CategoryWeights(category, predicted TicketCategory, confidence float64) map[TicketCategory]float64 {
+	if predicted == "" || predicted == category || confidence <= 0 {
+		return map[TicketCategory]float64{category: 1}
+	}
+	if confidence > 1 {
+		confidence = 1
+	}
+	return map[TicketCategory]float64{
+		category:  1 - confidence,
+		predicted: confidence,
+	}
+}
+
+func skillReason(agent *Agent, weights map[TicketCategory]float64) string {
+	categories := make([]TicketCategory, 0, len(weights))
+	for category := range weights {
+		categories = append(categories, category)
+	}
+	sort.Slice(categories, func(i, j int) bool { return weights[categories[i]] > weights[categories[j]] })
+
+	reason := ""
+	for i, category := range categories {
+		if i > 0 {
+			reason += ", "
+		}
+		reason += fmt.Sprintf("%s skill %d/%d (weight %.2f)", category, agent.SkillLevel(category), MaxSkillLevel, weights[category])
+	}
+	return fmt.Sprintf("%s; %d open tickets", reason, agent.OpenTickets)
+}
+
+// sortedAgents sorts a copy of the agents by less, then by who was assigned least recently, then by ID
+func sortedAgents(agents []*Agent, less func(a, b *Agent) bool) []*Agent {
+	sorted := append([]*Agent(nil), agents...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if less(a, b) {
+			return true
+		}
+		if less(b, a) {
+			return false
+		}
+		switch {
+		case a.LastAssignedAt == nil && b.LastAssignedAt != nil:
+			return true
+		case a.LastAssignedAt != nil && b.LastAssignedAt == nil:
+			return false
+		case a.LastAssignedAt != nil && !a.LastAssignedAt.Equal(*b.LastAssignedAt):
+			return a.LastAssignedAt.Before(*b.LastAssignedAt)
+		}
+		return a.ID < b.ID
+	})
+	return sorted
+}
+
+// Assignment errors
+var (
+	ErrNoEligibleAgent           = NewDomainError("no available agent can take the ticket")
+	ErrUnknownAssignmentStrategy = NewDomainError("unknown assignment strategy")
+	ErrAssignmentChanged         = NewDomainError("ticket was assigned or queued meanwhile")
+)
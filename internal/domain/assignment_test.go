@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestAgent(t *testing.T, id string, skills map[TicketCategory]int, capacity, open int) *Agent {
+	t.Helper()
+	agent, err := NewAgent(id, "Agent "+id, "", skills, capacity)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	agent.OpenTickets = open
+	return agent
+}
+
+func TestNewAgent_Validation(t *testing.T) {
+	if _, err := NewAgent("", "Ana", "", nil, 0); !errors.Is(err, ErrEmptyAgentID) {
+		t.Errorf("Expected ErrEmptyAgentID, got %v", err)
+	}
+	if _, err := NewAgent("a1", "Ana", "", nil, -1); !errors.Is(err, ErrInvalidAgentCapacity) {
+		t.Errorf("Expected ErrInvalidAgentCapacity, got %v", err)
+	}
+	if _, err := NewAgent("a1", "Ana", "", map[TicketCategory]int{"PRINTERS": 3}, 0); !errors.Is(err, ErrInvalidAgentSkill) {
+		t.Errorf("Expected ErrInvalidAgentSkill, got %v", err)
+	}
+	if _, err := NewAgent("a1", "Ana", "", map[TicketCategory]int{TicketCategoryNetwork: 6}, 0); !errors.Is(err, ErrInvalidAgentSkill) {
+		t.Errorf("Expected ErrInvalidAgentSkill, got %v", err)
+	}
+}
+
+func TestDecideAssignment_SkipsUnavailableAndFull(t *testing.T) {
+	away := newTestAgent(t, "a1", nil, 0, 0)
+	away.SetAvailability(false)
+	full := newTestAgent(t, "a2", nil, 2, 2)
+	free := newTestAgent(t, "a3", nil, 2, 1)
+
+	ticket := &Ticket{ID: "ticket-1", Category: TicketCategoryNetwork}
+	decision := DecideAssignment(LeastOpenStrategy{}, AssignmentRequest{Ticket: ticket, Agents: []*Agent{away, full, free}})
+
+	if decision.AgentID != "a3" {
+		t.Errorf("Expected a3, got %q", decision.AgentID)
+	}
+	if len(decision.Skipped) != 2 || len(decision.Candidates) != 1 {
+		t.Errorf("Expected 2 skipped and 1 candidate, got %d and %d", len(decision.Skipped), len(decision.Candidates))
+	}
+
+	free.SetAvailability(false)
+	decision = DecideAssignment(LeastOpenStrategy{}, AssignmentRequest{Ticket: ticket, Agents: []*Agent{away, full, free}})
+	if decision.AgentID != "" || decision.Reason == "" {
+		t.Errorf("Expected no agent with a reason, got %q %q", decision.AgentID, decision.Reason)
+	}
+}
+
+func TestRoundRobinStrategy(t *testing.T) {
+	now := time.Now()
+	recent := newTestAgent(t, "a1", nil, 0, 0)
+	recent.RecordAssignment(now)
+	earlier := newTestAgent(t, "a2", nil, 0, 5)
+	earlier.RecordAssignment(now.Add(-time.Hour))
+	never := newTestAgent(t, "a3", nil, 0, 0)
+
+	ticket := &Ticket{ID: "ticket-1", Category: TicketCategoryOther}
+	candidates := RoundRobinStrategy{}.Rank(AssignmentRequest{Ticket: ticket, Agents: []*Agent{recent, earlier, never}})
+
+	got := []string{candidates[0].AgentID, candidates[1].AgentID, candidates[2].AgentID}
+	want := []string{"a3", "a2", "a1"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Expected order %v, got %v", want, got)
+		}
+	}
+}
+
+func TestLeastOpenStrategy(t *testing.T) {
+	busy := newTestAgent(t, "a1", nil, 0, 4)
+	idle := newTestAgent(t, "a2", nil, 0, 1)
+
+	ticket := &Ticket{ID: "ticket-1", Category: TicketCategoryOther}
+	candidates := LeastOpenStrategy{}.Rank(AssignmentRequest{Ticket: ticket, Agents: []*Agent{busy, idle}})
+
+	if candidates[0].AgentID != "a2" || candidates[0].Score <= candidates[1].Score {
+		t.Errorf("Expected a2 ranked first with the higher score, got %+v", candidates)
+	}
+}
+
+func TestSkillMatchStrategy(t *testing.T) {
+	networker := newTestAgent(t, "a1", map[TicketCategory]int{TicketCategoryNetwork: 5}, 0, 0)
+	accounts := newTestAgent(t, "a2", map[TicketCategory]int{TicketCategoryAccount: 5, TicketCategoryNetwork: 1}, 0, 0)
+
+	ticket := &Ticket{ID: "ticket-1", Category: TicketCategoryNetwork}
+
+	candidates := SkillMatchStrategy{}.Rank(AssignmentRequest{Ticket: ticket, Agents: []*Agent{accounts, networker}})
+	if candidates[0].AgentID != "a1" {
+		t.Errorf("Expected the network specialist without a prediction, got %s", candidates[0].AgentID)
+	}
+
+	// A confident prediction that the ticket is really about accounts shifts the weight
+	candidates = SkillMatchStrategy{}.Rank(AssignmentRequest{
+		Ticket:               ticket,
+		Agents:               []*Agent{networker, accounts},
+		PredictedCategory:    TicketCategoryAccount,
+		PredictionConfidence: 0.8,
+	})
+	if candidates[0].AgentID != "a2" {
+		t.Errorf("Expected the account specialist with the prediction, got %s", candidates[0].AgentID)
+	}
+}
+
+func TestSkillMatchStrategy_TieGoesToFewerOpenTickets(t *testing.T) {
+	busy := newTestAgent(t, "a1", map[TicketCategory]int{TicketCategoryHardware: 3}, 0, 3)
+	idle := newTestAgent(t, "a2", map[TicketCategory]int{TicketCategoryHardware: 3}, 0, 0)
+
+	ticket := &Ticket{ID: "ticket-1", Category: TicketCategoryHardware}
+	candidates := SkillMatchStrategy{}.Rank(AssignmentRequest{Ticket: ticket, Agents: []*Agent{busy, idle}})
+
+	if candidates[0].AgentID != "a2" {
+		t.Errorf("Expected a2, got %s", candidates[0].AgentID)
+	}
+}
+
+func TestCategoryWeights(t *testing.T) {
+	weights := CategoryWeights(TicketCategoryNetwork, "", 0)
+	if len(weights) != 1 || weights[TicketCategoryNetwork] != 1 {
+		t.Errorf("Expected full weight on the ticket's category, got %v", weights)
+	}
+
+	weights = CategoryWeights(TicketCategoryNetwork, TicketCategoryNetwork, 0.9)
+	if len(weights) != 1 || weights[TicketCategoryNetwork] != 1 {
+		t.Errorf("Expected full weight when the prediction agrees, got %v", weights)
+	}
+
+	weights = CategoryWeights(TicketCategoryNetwork, TicketCategorySoftware, 0.25)
+	if weights[TicketCategoryNetwork] != 0.75 || weights[TicketCategorySoftware] != 0.25 {
+		t.Errorf("Expected weights split by confidence, got %v", weights)
+	}
+}
@@ -0,0 +1,447 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AutomationTrigger is what makes the engine evaluate a rule against a ticket
+type AutomationTrigger string
+
+// Event triggers carry the name of the ticket event that fires them
+const (
+	AutomationTriggerTicketCreated  AutomationTrigger = "ticket_created"
+	AutomationTriggerTicketUpdated  AutomationTrigger = "ticket_updated"
+	AutomationTriggerTicketAssigned AutomationTrigger = "ticket_assigned"
+	AutomationTriggerTicketResolved AutomationTrigger = "ticket_resolved"
+	AutomationTriggerTicketQueued   AutomationTrigger = "ticket_queued"
+	// AutomationTriggerScheduled rules are evaluated periodically, for time-based policies such
+	// as closing resolved tickets after a week. They act on each ticket at most once.
+	AutomationTriggerScheduled AutomationTrigger = "scheduled"
+)
+
+// EventTriggers lists the triggers fired by ticket events
+var EventTriggers = []AutomationTrigger{
+	AutomationTriggerTicketCreated,
+	AutomationTriggerTicketUpdated,
+	AutomationTriggerTicketAssigned,
+	AutomationTriggerTicketResolved,
+	AutomationTriggerTicketQueued,
+}
+
+// IsValid checks if the trigger is known
+func (t AutomationTrigger) IsValid() bool {
+	if t == AutomationTriggerScheduled {
+		return true
+	}
+	for _, trigger := range EventTriggers {
+		if t == trigger {
+			return true
+		}
+	}
+	return false
+}
+
+// Ticket fields a condition can test
+const (
+	ConditionFieldStatus            = "status"
+	ConditionFieldCategory          = "category"
+	ConditionFieldPriority          = "priority"
+	ConditionFieldTitle             = "title"
+	ConditionFieldDescription       = "description"
+	ConditionFieldText              = "text" // title and description
+	ConditionFieldCreatedBy         = "created_by"
+	ConditionFieldAssignedTo        = "assigned_to"
+	ConditionFieldQueueID           = "queue_id"
+	ConditionFieldHoursSinceCreated = "hours_since_created"
+	ConditionFieldHoursSinceUpdated = "hours_since_updated"
+)
+
+// Condition operators. List operators take comma-separated values.
+const (
+	ConditionOpEquals      = "equals"
+	ConditionOpNotEquals   = "not_equals"
+	ConditionOpIn          = "in"
+	ConditionOpContains    = "contains"
+	ConditionOpContainsAny = "contains_any"
+	ConditionOpNotContains = "not_contains"
+	ConditionOpEmpty       = "empty"
+	ConditionOpNotEmpty    = "not_empty"
+	ConditionOpAtLeast     = "at_least" // numeric fields only
+	ConditionOpLessThan    = "less_than"
+)
+
+// AutomationCondition tests one ticket field. Text comparisons ignore case.
+type AutomationCondition struct {
+	Field    string `json:"field"`
+	Operator string `json:"operator"`
+	Value    string `json:"value,omitempty"`
+}
+
+// Automation action types
+const (
+	ActionSetPriority = "set_priority" // value: the priority
+	ActionAssign      = "assign"       // value: the agent ID
+	ActionAddComment  = "add_comment"  // value: the comment body
+	ActionNotify      = "notify"       // value: the message; target: requester (default), assignee or a user ID
+	ActionResolve     = "resolve"      // value: the resolution
+	ActionClose       = "close"
+)
+
+// Notification targets
+const (
+	NotifyTargetRequester = "requester"
+	NotifyTargetAssignee  = "assignee"
+)
+
+// AutomationAction is one change a rule makes to the ticket
+type AutomationAction struct {
+	Type   string `json:"type"`
+	Value  string `json:"value,omitempty"`
+	Target string `json:"target,omitempty"`
+}
+
+// AutomationRule applies its actions to tickets matching all of its conditions when its trigger
+// fires. Rules run in position order; a rule with StopProcessing ends evaluation for the ticket.
+type AutomationRule struct {
+	ID             string                `json:"id"`
+	Name           string                `json:"name"`
+	Description    string                `json:"description,omitempty"`
+	Trigger        AutomationTrigger     `json:"trigger"`
+	Conditions     []AutomationCondition `json:"conditions"`
+	Actions        []AutomationAction    `json:"actions"`
+	Position       int                   `json:"position"`
+	Enabled        bool                  `json:"enabled"`
+	StopProcessing bool                  `json:"stop_processing"`
+	CreatedBy      string                `json:"created_by"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+}
+
+// NewAutomationRule creates an enabled rule
+func NewAutomationRule(name, description string, trigger AutomationTrigger, conditions []AutomationCondition, actions []AutomationAction, createdBy string) (*AutomationRule, error) {
+	now := time.Now()
+	rule := &AutomationRule{
+		ID:          generateAutomationRuleID(),
+		Name:        strings.TrimSpace(name),
+		Description: strings.TrimSpace(description),
+		Trigger:     trigger,
+		Conditions:  conditions,
+		Actions:     actions,
+		Enabled:     true,
+		CreatedBy:   createdBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// Validate checks the rule's name, trigger, conditions and actions
+func (r *AutomationRule) Validate() error {
+	if r.Name == "" {
+		return ErrEmptyRuleName
+	}
+	if !r.Trigger.IsValid() {
+		return ErrInvalidRuleTrigger
+	}
+	// A scheduled rule without conditions would act on every ticket
+	if r.Trigger == AutomationTriggerScheduled && len(r.Conditions) == 0 {
+		return fmt.Errorf("%w: scheduled rules need at least one condition", ErrInvalidRuleCondition)
+	}
+	for _, condition := range r.Conditions {
+		if err := condition.validate(); err != nil {
+			return err
+		}
+	}
+	if len(r.Actions) == 0 {
+		return ErrNoRuleActions
+	}
+	for _, action := range r.Actions {
+		if err := action.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Matches reports whether the ticket meets all of the rule's conditions
+func (r *AutomationRule) Matches(ticket *Ticket, now time.Time) bool {
+	for _, condition := range r.Conditions {
+		if !condition.Matches(ticket, now) {
+			return false
+		}
+	}
+	return true
+}
+
+// Matches reports whether the ticket meets the condition
+func (c AutomationCondition) Matches(ticket *Ticket, now time.Time) bool {
+	switch c.Field {
+	case ConditionFieldHoursSinceCreated:
+		return c.compareNumber(now.Sub(ticket.CreatedAt).Hours())
+	case ConditionFieldHoursSinceUpdated:
+		return c.compareNumber(now.Sub(ticket.UpdatedAt).Hours())
+	}
+
+	actual := strings.ToLower(conditionFieldValue(ticket, c.Field))
+	value := strings.ToLower(c.Value)
+
+	switch c.Operator {
+	case ConditionOpEquals:
+		return actual == value
+	case ConditionOpNotEquals:
+		return actual != value
+	case ConditionOpIn:
+		for _, v := range splitConditionValues(value) {
+			if actual == v {
+				return true
+			}
+		}
+		return false
+	case ConditionOpContains:
+		return strings.Contains(actual, value)
+	case ConditionOpNotContains:
+		return !strings.Contains(actual, value)
+	case ConditionOpContainsAny:
+		for _, v := range splitConditionValues(value) {
+			if strings.Contains(actual, v) {
+				return true
+			}
+		}
+		return false
+	case ConditionOpEmpty:
+		return actual == ""
+	case ConditionOpNotEmpty:
+		return actual != ""
+	}
+	return false
+}
+
+func (c AutomationCondition) compareNumber(actual float64) bool {
+	threshold, err := strconv.ParseFloat(c.Value, 64)
+	if err != nil {
+		return false
+	}
+	switch c.Operator {
+	case ConditionOpAtLeast:
+		return actual >= threshold
+	case ConditionOpLessThan:
+		return actual < threshold
+	}
+	return false
+}
+
+func (c AutomationCondition) validate() error {
+	switch c.Field {
+	case ConditionFieldHoursSinceCreated, ConditionFieldHoursSinceUpdated:
+		if c.Operator != ConditionOpAtLeast && c.Operator != ConditionOpLessThan {
+			return fmt.Errorf("%w: %s supports at_least and less_than", ErrInvalidRuleCondition, c.Field)
+		}
+		if threshold, err := strconv.ParseFloat(c.Value, 64); err != nil || threshold < 0 {
+			return fmt.Errorf("%w: %s needs a number of hours", ErrInvalidRuleCondition, c.Field)
+		}
+		return nil
+	case ConditionFieldStatus, ConditionFieldCategory, ConditionFieldPriority, ConditionFieldTitle,
+		ConditionFieldDescription, ConditionFieldText, ConditionFieldCreatedBy, ConditionFieldAssignedTo,
+		ConditionFieldQueueID:
+	default:
+		return fmt.Errorf("%w: unknown field %q", ErrInvalidRuleCondition, c.Field)
+	}
+
+	switch c.Operator {
+	case ConditionOpEmpty, ConditionOpNotEmpty:
+		return nil
+	case ConditionOpEquals, ConditionOpNotEquals, ConditionOpIn, ConditionOpContains,
+		ConditionOpContainsAny, ConditionOpNotContains:
+		if strings.TrimSpace(c.Value) == "" {
+			return fmt.Errorf("%w: %s %s needs a value", ErrInvalidRuleCondition, c.Field, c.Operator)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: unknown operator %q", ErrInvalidRuleCondition, c.Operator)
+}
+
+func (a AutomationAction) validate() error {
+	switch a.Type {
+	case ActionSetPriority:
+		switch TicketPriority(a.Value) {
+		case TicketPriorityLow, TicketPriorityMedium, TicketPriorityHigh, TicketPriorityCritical:
+			return nil
+		}
+		return fmt.Errorf("%w: invalid priority %q", ErrInvalidRuleAction, a.Value)
+	case ActionAssign, ActionAddComment, ActionResolve:
+		if strings.TrimSpace(a.Value) == "" {
+			return fmt.Errorf("%w: %s needs a value", ErrInvalidRuleAction, a.Type)
+		}
+		return nil
+	case ActionNotify:
+		if strings.TrimSpace(a.Value) == "" {
+			return fmt.Errorf("%w: notify needs a message", ErrInvalidRuleAction)
+		}
+		return nil
+	case ActionClose:
+		return nil
+	}
+	return fmt.Errorf("%w: unknown action %q", ErrInvalidRuleAction, a.Type)
+}
+
+func conditionFieldValue(ticket *Ticket, field string) string {
+	switch field {
+	case ConditionFieldStatus:
+		return string(ticket.Status)
+	case ConditionFieldCategory:
+		return string(ticket.Category)
+	case ConditionFieldPriority:
+		return string(ticket.Priority)
+	case ConditionFieldTitle:
+		return ticket.Title
+	case ConditionFieldDescription:
+		return ticket.Description
+	case ConditionFieldText:
+		return ticket.Title + "\n" + ticket.Description
+	case ConditionFieldCreatedBy:
+		return ticket.CreatedBy
+	case ConditionFieldAssignedTo:
+		if ticket.AssignedTo != nil {
+			return *ticket.AssignedTo
+		}
+	case ConditionFieldQueueID:
+		if ticket.QueueID != nil {
+			return *ticket.QueueID
+		}
+	}
+	return ""
+}
+
+func splitConditionValues(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// MaxAutomationDepth is how many rule-triggered changes can cascade from one original change
+const MaxAutomationDepth = 3
+
+// AutomationChain tracks the rules that led to a change, so rules reacting to changes made by
+// other rules cannot loop. Each rule fires at most once per ticket in a chain.
+type AutomationChain struct {
+	Depth int
+	fired map[string]bool // rule ID + ticket ID
+}
+
+// Allows reports whether the rule may act on the ticket in this chain, with the reason when not
+func (c AutomationChain) Allows(ruleID, ticketID string) (bool, string) {
+	if c.Depth >= MaxAutomationDepth {
+		return false, fmt.Sprintf("loop protection: %d rules already cascaded", c.Depth)
+	}
+	if c.fired[ruleID+"/"+ticketID] {
+		return false, "loop protection: rule already fired on this ticket in this chain"
+	}
+	return true, ""
+}
+
+// Next returns the chain for changes made by the rule on the ticket
+func (c AutomationChain) Next(ruleID, ticketID string) AutomationChain {
+	fired := make(map[string]bool, len(c.fired)+1)
+	for key := range c.fired {
+		fired[key] = true
+	}
+	fired[ruleID+"/"+ticketID] = true
+	return AutomationChain{Depth: c.Depth + 1, fired: fired}
+}
+
+// AutomationExecutionStatus represents the outcome of running a rule
+type AutomationExecutionStatus string
+
+const (
+	AutomationExecutionSucceeded AutomationExecutionStatus = "succeeded"
+	AutomationExecutionFailed    AutomationExecutionStatus = "failed"
+	AutomationExecutionSkipped   AutomationExecutionStatus = "skipped" // blocked by loop protection
+)
+
+// AutomationExecution records a rule running on a ticket
+type AutomationExecution struct {
+	ID         string                    `json:"id"`
+	RuleID     string                    `json:"rule_id"`
+	RuleName   string                    `json:"rule_name"`
+	TicketID   string                    `json:"ticket_id"`
+	Trigger    AutomationTrigger         `json:"trigger"`
+	EventID    string                    `json:"event_id,omitempty"`
+	Status     AutomationExecutionStatus `json:"status"`
+	Actions    []string                  `json:"actions"` // what each applied action did
+	Error      string                    `json:"error,omitempty"`
+	Depth      int                       `json:"depth"` // rules cascaded before this one
+	ExecutedAt time.Time                 `json:"executed_at"`
+}
+
+// NewAutomationExecution starts the log record of a rule running on a ticket
+func NewAutomationExecution(rule *AutomationRule, ticketID, eventID string, depth int) *AutomationExecution {
+	return &AutomationExecution{
+		ID:         generateAutomationExecutionID(),
+		RuleID:     rule.ID,
+		RuleName:   rule.Name,
+		TicketID:   ticketID,
+		Trigger:    rule.Trigger,
+		EventID:    eventID,
+		Status:     AutomationExecutionSucceeded,
+		Actions:    []string{},
+		Depth:      depth,
+		ExecutedAt: time.Now(),
+	}
+}
+
+// Fail records the error that stopped the rule's remaining actions
+func (e *AutomationExecution) Fail(err error) {
+	e.Status = AutomationExecutionFailed
+	e.Error = err.Error()
+}
+
+// Skip records why the rule was not run
+func (e *AutomationExecution) Skip(reason string) {
+	e.Status = AutomationExecutionSkipped
+	e.Error = reason
+}
+
+// AutomationRuleFilter represents filters for listing automation rules
+type AutomationRuleFilter struct {
+	Trigger *AutomationTrigger `json:"trigger,omitempty"`
+	Enabled *bool              `json:"enabled,omitempty"`
+}
+
+// AutomationExecutionFilter represents filters for listing the execution log
+type AutomationExecutionFilter struct {
+	RuleID   *string                    `json:"rule_id,omitempty"`
+	TicketID *string                    `json:"ticket_id,omitempty"`
+	Status   *AutomationExecutionStatus `json:"status,omitempty"`
+	Limit    int                        `json:"limit"`
+	Offset   int                        `json:"offset"`
+}
+
+// Automation errors
+var (
+	ErrAutomationRuleNotFound = NewDomainError("automation rule not found")
+	ErrEmptyRuleName          = NewDomainError("automation rule name cannot be empty")
+	ErrInvalidRuleTrigger     = NewDomainError("invalid automation rule trigger")
+	ErrInvalidRuleCondition   = NewDomainError("invalid automation rule condition")
+	ErrInvalidRuleAction      = NewDomainError("invalid automation rule action")
+	ErrNoRuleActions          = NewDomainError("automation rule needs at least one action")
+	ErrInvalidRuleOrder       = NewDomainError("rule order must list every rule exactly once")
+)
+
+func generateAutomationRuleID() string {
+	return "rule_" + strconv.FormatInt(time.Now().UnixNano(), 10)
+}
+
+func generateAutomationExecutionID() string {
+	return "automation_run_" + strconv.FormatInt(time.Now().UnixNano(), 10)
+}
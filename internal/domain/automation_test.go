@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAutomationCondition_Matches(t *testing.T) {
+	now := time.Now()
+	agent := "agent-1"
+	ticket := &Ticket{
+		ID:          "ticket-1",
+		Title:       "VPN keeps disconnecting",
+		Description: "Since this morning the VPN drops every few minutes",
+		Category:    TicketCategoryNetwork,
+		Priority:    TicketPriorityMedium,
+		Status:      TicketStatusOpen,
+		CreatedBy:   "user-1",
+		AssignedTo:  &agent,
+		CreatedAt:   now.Add(-30 * time.Hour),
+		UpdatedAt:   now.Add(-2 * time.Hour),
+	}
+
+	tests := []struct {
+		name      string
+		condition AutomationCondition
+		want      bool
+	}{
+		{"equals ignores case", AutomationCondition{ConditionFieldStatus, ConditionOpEquals, "open"}, true},
+		{"not equals", AutomationCondition{ConditionFieldPriority, ConditionOpNotEquals, "MEDIUM"}, false},
+		{"in", AutomationCondition{ConditionFieldCategory, ConditionOpIn, "HARDWARE, NETWORK"}, true},
+		{"text contains", AutomationCondition{ConditionFieldText, ConditionOpContains, "drops"}, true},
+		{"title contains any", AutomationCondition{ConditionFieldTitle, ConditionOpContainsAny, "outage,vpn"}, true},
+		{"not contains", AutomationCondition{ConditionFieldTitle, ConditionOpNotContains, "vpn"}, false},
+		{"empty queue", AutomationCondition{ConditionFieldQueueID, ConditionOpEmpty, ""}, true},
+		{"assigned", AutomationCondition{ConditionFieldAssignedTo, ConditionOpNotEmpty, ""}, true},
+		{"hours since created", AutomationCondition{ConditionFieldHoursSinceCreated, ConditionOpAtLeast, "24"}, true},
+		{"hours since updated", AutomationCondition{ConditionFieldHoursSinceUpdated, ConditionOpAtLeast, "4"}, false},
+		{"hours since updated below", AutomationCondition{ConditionFieldHoursSinceUpdated, ConditionOpLessThan, "4"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.condition.Matches(ticket, now); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAutomationRule_Validate(t *testing.T) {
+	escalate := []AutomationAction{{Type: ActionSetPriority, Value: string(TicketPriorityHigh)}}
+
+	tests := []struct {
+		name       string
+		ruleName   string
+		trigger    AutomationTrigger
+		conditions []AutomationCondition
+		actions    []AutomationAction
+		want       error
+	}{
+		{"valid", "Escalate outages", AutomationTriggerTicketCreated,
+			[]AutomationCondition{{ConditionFieldText, ConditionOpContains, "outage"}}, escalate, nil},
+		{"empty name", " ", AutomationTriggerTicketCreated, nil, escalate, ErrEmptyRuleName},
+		{"unknown trigger", "Rule", AutomationTrigger("ticket_deleted"), nil, escalate, ErrInvalidRuleTrigger},
+		{"scheduled without conditions", "Remind", AutomationTriggerScheduled, nil, escalate, ErrInvalidRuleCondition},
+		{"unknown field", "Rule", AutomationTriggerTicketCreated,
+			[]AutomationCondition{{"severity", ConditionOpEquals, "1"}}, escalate, ErrInvalidRuleCondition},
+		{"hours need a number", "Rule", AutomationTriggerScheduled,
+			[]AutomationCondition{{ConditionFieldHoursSinceUpdated, ConditionOpAtLeast, "a day"}}, escalate, ErrInvalidRuleCondition},
+		{"no actions", "Rule", AutomationTriggerTicketCreated, nil, nil, ErrNoRuleActions},
+		{"invalid priority", "Rule", AutomationTriggerTicketCreated, nil,
+			[]AutomationAction{{Type: ActionSetPriority, Value: "URGENT"}}, ErrInvalidRuleAction},
+		{"comment needs a body", "Rule", AutomationTriggerTicketCreated, nil,
+			[]AutomationAction{{Type: ActionAddComment}}, ErrInvalidRuleAction},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAutomationRule(tt.ruleName, "", tt.trigger, tt.conditions, tt.actions, "admin-1")
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestAutomationChain_LoopProtection(t *testing.T) {
+	var chain AutomationChain
+	if ok, _ := chain.Allows("rule-1", "ticket-1"); !ok {
+		t.Fatal("Expected an empty chain to allow any rule")
+	}
+
+	next := chain.Next("rule-1", "ticket-1")
+	if ok, _ := next.Allows("rule-1", "ticket-1"); ok {
+		t.Error("Expected a rule not to fire twice on the same ticket in a chain")
+	}
+	if ok, _ := next.Allows("rule-1", "ticket-2"); !ok {
+		t.Error("Expected the rule to be allowed on another ticket")
+	}
+	if ok, _ := chain.Allows("rule-1", "ticket-1"); !ok {
+		t.Error("Expected Next not to modify the original chain")
+	}
+
+	deep := next.Next("rule-2", "ticket-1").Next("rule-3", "ticket-1")
+	if ok, reason := deep.Allows("rule-4", "ticket-1"); ok || reason == "" {
+		t.Errorf("Expected depth %d to stop the cascade", deep.Depth)
+	}
+}
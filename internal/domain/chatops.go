@@ -0,0 +1,29 @@
+package domain
+
+// ChatAction is a ticket action an agent takes from a button on a chat message
+type ChatAction string
+
+const (
+	ChatActionAssignToMe ChatAction = "assign_to_me"
+	ChatActionResolve    ChatAction = "resolve"
+	ChatActionClose      ChatAction = "close"
+)
+
+// ChatActions lists the actions offered on ticket messages, in the order they are shown
+var ChatActions = []ChatAction{ChatActionAssignToMe, ChatActionResolve, ChatActionClose}
+
+// IsValid checks if the chat action is known
+func (a ChatAction) IsValid() bool {
+	for _, action := range ChatActions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// ChatOps errors
+var (
+	ErrUnknownChatAction = NewDomainError("unknown chat action")
+	ErrChatUserNotLinked = NewDomainError("chat user is not linked to a Fixora user")
+)
@@ -0,0 +1,14 @@
+package domain
+
+import "testing"
+
+func TestChatAction_IsValid(t *testing.T) {
+	for _, action := range ChatActions {
+		if !action.IsValid() {
+			t.Errorf("Expected %s to be valid", action)
+		}
+	}
+	if ChatAction("reopen").IsValid() {
+		t.Error("Expected unknown actions to be invalid")
+	}
+}
@@ -0,0 +1,172 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CSAT scores range from 1 (very dissatisfied) to 5 (very satisfied)
+const (
+	MinCSATScore = 1
+	MaxCSATScore = 5
+)
+
+// CSATRating is the requester's satisfaction with how a ticket was resolved. A ticket has at most
+// one rating; rating again replaces the score.
+type CSATRating struct {
+	ID          string         `json:"id"`
+	TicketID    string         `json:"ticket_id"`
+	Score       int            `json:"score"`
+	Comment     string         `json:"comment,omitempty"`
+	SubmittedBy string         `json:"submitted_by"`
+	AgentID     *string        `json:"agent_id,omitempty"` // assignee when the ticket was rated
+	Category    TicketCategory `json:"category"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+// NewCSATRating creates the requester's rating of a resolved or closed ticket
+func NewCSATRating(ticket *Ticket, score int, comment string) (*CSATRating, error) {
+	if ticket.Status != TicketStatusResolved && ticket.Status != TicketStatusClosed {
+		return nil, ErrTicketNotResolved
+	}
+
+	now := time.Now()
+	rating := &CSATRating{
+		ID:          generateCSATRatingID(),
+		TicketID:    ticket.ID,
+		SubmittedBy: ticket.CreatedBy,
+		AgentID:     ticket.AssignedTo,
+		Category:    ticket.Category,
+		CreatedAt:   now,
+	}
+
+	if err := rating.Rate(score, comment); err != nil {
+		return nil, err
+	}
+	return rating, nil
+}
+
+// Rate sets the score, and the comment when one is given
+func (r *CSATRating) Rate(score int, comment string) error {
+	if score < MinCSATScore || score > MaxCSATScore {
+		return ErrInvalidCSATScore
+	}
+	r.Score = score
+	if comment = strings.TrimSpace(comment); comment != "" {
+		r.Comment = comment
+	}
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// CSATSurvey is the set of rating links sent to the requester when a ticket is resolved
+type CSATSurvey struct {
+	TicketID  string     `json:"ticket_id"`
+	Links     []CSATLink `json:"links"`    // one per score, lowest first
+	FormURL   string     `json:"form_url"` // page to pick a score and leave a comment
+	ExpiresAt time.Time  `json:"expires_at"`
+}
+
+// CSATLink opens the survey page with its score selected
+type CSATLink struct {
+	Score int    `json:"score"`
+	URL   string `json:"url"`
+}
+
+// NewCSATSurvey builds the rating links for a ticket from the survey's base URL and signed token
+func NewCSATSurvey(ticketID, baseURL, token string, expiresAt time.Time) *CSATSurvey {
+	formURL := strings.TrimRight(baseURL, "/") + "/" + token
+
+	survey := &CSATSurvey{
+		TicketID:  ticketID,
+		FormURL:   formURL,
+		ExpiresAt: expiresAt,
+	}
+	for score := MinCSATScore; score <= MaxCSATScore; score++ {
+		survey.Links = append(survey.Links, CSATLink{
+			Score: score,
+			URL:   formURL + "?score=" + strconv.Itoa(score),
+		})
+	}
+	return survey
+}
+
+// SignCSATToken creates a URL-safe token allowing the ticket to be rated until expiresAt. The
+// token carries the ticket ID and expiry, signed with HMAC-SHA256.
+func SignCSATToken(secret []byte, ticketID string, expiresAt time.Time) string {
+	payload := ticketID + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+	encoded := base64.RawURLEncoding.EncodeToString([]byte(payload))
+	return encoded + "." + csatSignature(secret, encoded)
+}
+
+// ParseCSATToken verifies a token and returns the ticket it allows to be rated
+func ParseCSATToken(secret []byte, token string, now time.Time) (string, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(csatSignature(secret, encoded))) {
+		return "", ErrInvalidCSATToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", ErrInvalidCSATToken
+	}
+
+	dot := strings.LastIndex(string(payload), ".")
+	if dot <= 0 {
+		return "", ErrInvalidCSATToken
+	}
+	ticketID := string(payload[:dot])
+	expires, err := strconv.ParseInt(string(payload[dot+1:]), 10, 64)
+	if err != nil {
+		return "", ErrInvalidCSATToken
+	}
+
+	if now.After(time.Unix(expires, 0)) {
+		return "", ErrCSATTokenExpired
+	}
+	return ticketID, nil
+}
+
+func csatSignature(secret []byte, encoded string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("csat:" + encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// CSATScore is the average satisfaction score for one group of ratings
+type CSATScore struct {
+	Responses int     `json:"responses"`
+	Total     int     `json:"-"`
+	Average   float64 `json:"average"`
+}
+
+func (s *CSATScore) add(score int) {
+	s.Responses++
+	s.Total += score
+	s.Average = float64(s.Total) / float64(s.Responses)
+}
+
+// CSATFilter represents filters for listing ratings
+type CSATFilter struct {
+	StartDate *time.Time `json:"start_date,omitempty"`
+	EndDate   *time.Time `json:"end_date,omitempty"`
+	Category  string     `json:"category,omitempty"`
+	AgentID   string     `json:"agent_id,omitempty"`
+}
+
+// CSAT errors
+var (
+	ErrCSATRatingNotFound = NewDomainError("satisfaction rating not found")
+	ErrInvalidCSATScore   = NewDomainError("satisfaction score must be between 1 and 5")
+	ErrInvalidCSATToken   = NewDomainError("invalid rating link")
+	ErrCSATTokenExpired   = NewDomainError("rating link has expired")
+)
+
+func generateCSATRatingID() string {
+	return "csat_" + strconv.FormatInt(time.Now().UnixNano(), 10)
+}
@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCSATToken_RoundTrip(t *testing.T) {
+	secret := []byte("secret")
+	now := time.Now()
+	token := SignCSATToken(secret, "ticket-1", now.Add(time.Hour))
+
+	ticketID, err := ParseCSATToken(secret, token, now)
+	if err != nil {
+		t.Fatalf("Expected a valid token, got %v", err)
+	}
+	if ticketID != "ticket-1" {
+		t.Errorf("Expected ticket-1, got %s", ticketID)
+	}
+}
+
+func TestCSATToken_Rejected(t *testing.T) {
+	secret := []byte("secret")
+	now := time.Now()
+	token := SignCSATToken(secret, "ticket-1", now.Add(time.Hour))
+	other := SignCSATToken(secret, "ticket-2", now.Add(time.Hour))
+	encoded, _, _ := strings.Cut(token, ".")
+	_, otherSignature, _ := strings.Cut(other, ".")
+
+	tests := []struct {
+		name   string
+		secret []byte
+		token  string
+		now    time.Time
+		want   error
+	}{
+		{"wrong secret", []byte("other"), token, now, ErrInvalidCSATToken},
+		{"swapped signature", secret, encoded + "." + otherSignature, now, ErrInvalidCSATToken},
+		{"no signature", secret, encoded, now, ErrInvalidCSATToken},
+		{"empty", secret, "", now, ErrInvalidCSATToken},
+		{"expired", secret, token, now.Add(2 * time.Hour), ErrCSATTokenExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseCSATToken(tt.secret, tt.token, tt.now); err != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestNewCSATSurvey(t *testing.T) {
+	survey := NewCSATSurvey("ticket-1", "https://help.example.com/csat/", "tok", time.Now())
+
+	if survey.FormURL != "https://help.example.com/csat/tok" {
+		t.Errorf("Unexpected form URL %s", survey.FormURL)
+	}
+	if len(survey.Links) != MaxCSATScore {
+		t.Fatalf("Expected %d links, got %d", MaxCSATScore, len(survey.Links))
+	}
+	if survey.Links[0].Score != 1 || survey.Links[0].URL != "https://help.example.com/csat/tok?score=1" {
+		t.Errorf("Unexpected first link %+v", survey.Links[0])
+	}
+}
+
+func TestNewCSATRating(t *testing.T) {
+	ticket := NewTicket("VPN down", "Cannot connect", TicketCategoryNetwork, TicketPriorityHigh, "user-1")
+
+	if _, err := NewCSATRating(ticket, 5, ""); err != ErrTicketNotResolved {
+		t.Errorf("Expected ErrTicketNotResolved for an open ticket, got %v", err)
+	}
+
+	ticket.Assign("agent-1")
+	ticket.Resolve()
+
+	for _, score := range []int{0, 6} {
+		if _, err := NewCSATRating(ticket, score, ""); err != ErrInvalidCSATScore {
+			t.Errorf("Expected ErrInvalidCSATScore for %d, got %v", score, err)
+		}
+	}
+
+	rating, err := NewCSATRating(ticket, 4, " Quick fix ")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if rating.SubmittedBy != "user-1" || rating.AgentID == nil || *rating.AgentID != "agent-1" || rating.Comment != "Quick fix" {
+		t.Errorf("Unexpected rating %+v", rating)
+	}
+
+	if err := rating.Rate(2, ""); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if rating.Score != 2 || rating.Comment != "Quick fix" {
+		t.Errorf("Expected the score replaced and the comment kept, got %+v", rating)
+	}
+}
+
+func TestMetric_CalculateCSAT(t *testing.T) {
+	agent := "agent-1"
+	day := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	ratings := []*CSATRating{
+		{Score: 5, AgentID: &agent, Category: TicketCategoryNetwork, CreatedAt: day},
+		{Score: 3, AgentID: &agent, Category: TicketCategorySoftware, CreatedAt: day.Add(24 * time.Hour)},
+		{Score: 1, Category: TicketCategoryNetwork, CreatedAt: day.AddDate(0, 1, 0)},
+	}
+
+	var m Metric
+	m.CalculateCSAT(ratings, MetricPeriodMonthly)
+
+	if m.CSATResponses != 3 || m.CSATAverage != 3 {
+		t.Errorf("Expected 3 responses averaging 3, got %d averaging %v", m.CSATResponses, m.CSATAverage)
+	}
+	if got := m.CSATByAgent["agent-1"]; got == nil || got.Average != 4 {
+		t.Errorf("Expected agent-1 to average 4, got %+v", got)
+	}
+	if got := m.CSATByAgent["unassigned"]; got == nil || got.Responses != 1 {
+		t.Errorf("Expected one unassigned rating, got %+v", got)
+	}
+	if got := m.CSATByCategory[string(TicketCategoryNetwork)]; got == nil || got.Average != 3 {
+		t.Errorf("Expected network to average 3, got %+v", got)
+	}
+	if got := m.CSATByPeriod["2024-03"]; got == nil || got.Responses != 2 {
+		t.Errorf("Expected two ratings in 2024-03, got %+v", got)
+	}
+
+	m.CalculateCSAT(ratings, MetricPeriodWeekly)
+	if got := m.CSATByPeriod["2024-W10"]; got == nil || got.Responses != 2 {
+		t.Errorf("Expected two ratings in 2024-W10, got %+v", m.CSATByPeriod)
+	}
+}
@@ -0,0 +1,188 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DeflectionStatus represents the outcome of a self-service deflection session
+type DeflectionStatus string
+
+const (
+	DeflectionStatusOpen      DeflectionStatus = "open"
+	DeflectionStatusDeflected DeflectionStatus = "deflected" // a suggestion fixed the issue, no ticket was created
+	DeflectionStatusEscalated DeflectionStatus = "escalated" // the employee went on to create a ticket
+)
+
+// DeflectionCandidate is a suggestion offered to the employee during a deflection session
+type DeflectionCandidate struct {
+	Rank       int     `json:"rank"`
+	Score      float64 `json:"score"`
+	Suggestion string  `json:"suggestion"`
+	Category   string  `json:"category,omitempty"`
+	EntryID    string  `json:"entry_id,omitempty"`
+	ChunkIndex int     `json:"chunk_index,omitempty"`
+	Source     string  `json:"source,omitempty"`
+	Model      string  `json:"model,omitempty"`
+}
+
+// DeflectionSession tracks an employee trying AI suggestions before raising a ticket. The query ID
+// of the suggestion stream links the session to feedback on its candidates.
+type DeflectionSession struct {
+	ID           string                `json:"id"`
+	Description  string                `json:"description"`
+	RequestedBy  string                `json:"requested_by"`
+	Status       DeflectionStatus      `json:"status"`
+	QueryID      string                `json:"query_id,omitempty"`
+	Candidates   []DeflectionCandidate `json:"candidates"`
+	SelectedRank int                   `json:"selected_rank,omitempty"` // candidate that fixed the issue or was attached to the ticket
+	TicketID     string                `json:"ticket_id,omitempty"`
+	CreatedAt    time.Time             `json:"created_at"`
+	UpdatedAt    time.Time             `json:"updated_at"`
+	ClosedAt     *time.Time            `json:"closed_at,omitempty"`
+}
+
+// NewDeflectionSession creates a new deflection session for a problem description
+func NewDeflectionSession(description, requestedBy string) (*DeflectionSession, error) {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return nil, ErrEmptyDeflectionDescription
+	}
+	if requestedBy == "" {
+		return nil, ErrEmptyAuthorID
+	}
+
+	now := time.Now()
+	return &DeflectionSession{
+		ID:          generateDeflectionID(),
+		Description: description,
+		RequestedBy: requestedBy,
+		Status:      DeflectionStatusOpen,
+		Candidates:  []DeflectionCandidate{},
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// StartQuery records the query ID of a new suggestion stream, discarding candidates from earlier streams
+func (s *DeflectionSession) StartQuery(queryID string) error {
+	if s.Status != DeflectionStatusOpen {
+		return ErrDeflectionSessionClosed
+	}
+	if queryID == s.QueryID {
+		return nil
+	}
+
+	s.QueryID = queryID
+	s.Candidates = []DeflectionCandidate{}
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// AddCandidate records a suggestion offered by the current stream, replacing one with the same rank
+func (s *DeflectionSession) AddCandidate(candidate DeflectionCandidate) {
+	for i, existing := range s.Candidates {
+		if existing.Rank == candidate.Rank {
+			s.Candidates[i] = candidate
+			s.UpdatedAt = time.Now()
+			return
+		}
+	}
+	s.Candidates = append(s.Candidates, candidate)
+	s.UpdatedAt = time.Now()
+}
+
+// Candidate returns the offered candidate with the given rank
+func (s *DeflectionSession) Candidate(rank int) (*DeflectionCandidate, error) {
+	for i := range s.Candidates {
+		if s.Candidates[i].Rank == rank {
+			return &s.Candidates[i], nil
+		}
+	}
+	return nil, ErrDeflectionCandidateNotFound
+}
+
+// TopCandidate returns the best ranked candidate, or nil when none was offered
+func (s *DeflectionSession) TopCandidate() *DeflectionCandidate {
+	var top *DeflectionCandidate
+	for i := range s.Candidates {
+		if top == nil || s.Candidates[i].Rank < top.Rank {
+			top = &s.Candidates[i]
+		}
+	}
+	return top
+}
+
+// Deflect closes the session as solved by the candidate with the given rank
+func (s *DeflectionSession) Deflect(rank int) error {
+	if s.Status != DeflectionStatusOpen {
+		return ErrDeflectionSessionClosed
+	}
+	if s.QueryID == "" {
+		return ErrNoDeflectionSuggestions
+	}
+	if _, err := s.Candidate(rank); err != nil {
+		return err
+	}
+
+	s.close(DeflectionStatusDeflected)
+	s.SelectedRank = rank
+	return nil
+}
+
+// Escalate closes the session before a ticket is created for it, so the session is claimed by
+// one escalation only. Rank is the candidate attached to the ticket, or zero when none was.
+func (s *DeflectionSession) Escalate(rank int) error {
+	if s.Status != DeflectionStatusOpen {
+		return ErrDeflectionSessionClosed
+	}
+
+	s.close(DeflectionStatusEscalated)
+	s.SelectedRank = rank
+	return nil
+}
+
+// AttachTicket records the ticket created for an escalated session
+func (s *DeflectionSession) AttachTicket(ticketID string) error {
+	if s.Status != DeflectionStatusEscalated {
+		return ErrDeflectionNotEscalated
+	}
+	if ticketID == "" {
+		return ErrEmptyTicketID
+	}
+
+	s.TicketID = ticketID
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// Reopen undoes an escalation whose ticket could not be created
+func (s *DeflectionSession) Reopen() {
+	s.Status = DeflectionStatusOpen
+	s.SelectedRank = 0
+	s.ClosedAt = nil
+	s.UpdatedAt = time.Now()
+}
+
+func (s *DeflectionSession) close(status DeflectionStatus) {
+	now := time.Now()
+	s.Status = status
+	s.ClosedAt = &now
+	s.UpdatedAt = now
+}
+
+// Deflection errors
+var (
+	ErrDeflectionSessionNotFound   = NewDomainError("deflection session not found")
+	ErrDeflectionSessionClosed     = NewDomainError("deflection session is already closed")
+	ErrNotSessionRequester         = NewDomainError("only the requester can use the deflection session")
+	ErrEmptyDeflectionDescription  = NewDomainError("problem description cannot be empty")
+	ErrNoDeflectionSuggestions     = NewDomainError("no suggestions have been streamed for this session")
+	ErrDeflectionCandidateNotFound = NewDomainError("suggestion candidate not found in this session")
+	ErrDeflectionNotEscalated      = NewDomainError("deflection session has not been escalated")
+)
+
+func generateDeflectionID() string {
+	return "deflection_" + strconv.FormatInt(time.Now().UnixNano(), 10)
+}
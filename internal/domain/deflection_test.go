@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func streamedSession(t *testing.T) *DeflectionSession {
+	session, err := NewDeflectionSession("Outlook keeps asking for my password", "user1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := session.StartQuery("query-1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	session.AddCandidate(DeflectionCandidate{Rank: 2, Score: 0.6, Suggestion: "Restart Outlook"})
+	session.AddCandidate(DeflectionCandidate{Rank: 1, Score: 0.9, Suggestion: "Clear cached credentials", EntryID: "kb-1"})
+	return session
+}
+
+func TestDeflectionSession_Deflect(t *testing.T) {
+	session := streamedSession(t)
+
+	if err := session.Deflect(3); !errors.Is(err, ErrDeflectionCandidateNotFound) {
+		t.Errorf("Expected ErrDeflectionCandidateNotFound, got %v", err)
+	}
+
+	if err := session.Deflect(2); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if session.Status != DeflectionStatusDeflected || session.SelectedRank != 2 || session.ClosedAt == nil {
+		t.Errorf("Expected session deflected by rank 2, got %s rank %d", session.Status, session.SelectedRank)
+	}
+
+	if err := session.Escalate(0); !errors.Is(err, ErrDeflectionSessionClosed) {
+		t.Errorf("Expected ErrDeflectionSessionClosed, got %v", err)
+	}
+}
+
+func TestDeflectionSession_DeflectRequiresSuggestions(t *testing.T) {
+	session, err := NewDeflectionSession("VPN drops every hour", "user1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := session.Deflect(1); !errors.Is(err, ErrNoDeflectionSuggestions) {
+		t.Errorf("Expected ErrNoDeflectionSuggestions, got %v", err)
+	}
+
+	if _, err := NewDeflectionSession("  ", "user1"); !errors.Is(err, ErrEmptyDeflectionDescription) {
+		t.Errorf("Expected ErrEmptyDeflectionDescription, got %v", err)
+	}
+}
+
+func TestDeflectionSession_NewQueryReplacesCandidates(t *testing.T) {
+	session := streamedSession(t)
+
+	if top := session.TopCandidate(); top == nil || top.Rank != 1 {
+		t.Fatalf("Expected top candidate rank 1, got %+v", top)
+	}
+
+	if err := session.StartQuery("query-2"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(session.Candidates) != 0 || session.TopCandidate() != nil {
+		t.Errorf("Expected candidates of the previous stream to be discarded, got %d", len(session.Candidates))
+	}
+	if session.QueryID != "query-2" {
+		t.Errorf("Expected query ID query-2, got %s", session.QueryID)
+	}
+}
+
+func TestDeflectionSession_Escalate(t *testing.T) {
+	session := streamedSession(t)
+
+	if err := session.AttachTicket("ticket-1"); !errors.Is(err, ErrDeflectionNotEscalated) {
+		t.Errorf("Expected ErrDeflectionNotEscalated before escalating, got %v", err)
+	}
+
+	if err := session.Escalate(1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := session.Escalate(1); !errors.Is(err, ErrDeflectionSessionClosed) {
+		t.Errorf("Expected a second escalation to fail with ErrDeflectionSessionClosed, got %v", err)
+	}
+	if err := session.AttachTicket("ticket-1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if session.Status != DeflectionStatusEscalated || session.TicketID != "ticket-1" || session.SelectedRank != 1 {
+		t.Errorf("Expected session escalated to ticket-1, got %s %s", session.Status, session.TicketID)
+	}
+
+	if err := session.StartQuery("query-2"); !errors.Is(err, ErrDeflectionSessionClosed) {
+		t.Errorf("Expected ErrDeflectionSessionClosed, got %v", err)
+	}
+}
+
+func TestDeflectionSession_ReopenAfterFailedEscalation(t *testing.T) {
+	session := streamedSession(t)
+	session.Escalate(2)
+
+	session.Reopen()
+	if session.Status != DeflectionStatusOpen || session.SelectedRank != 0 || session.ClosedAt != nil {
+		t.Errorf("Expected an open session, got %s rank %d", session.Status, session.SelectedRank)
+	}
+	if err := session.Deflect(1); err != nil {
+		t.Errorf("Expected the reopened session to be usable, got %v", err)
+	}
+}
@@ -0,0 +1,231 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// InboundEmail is an email received by the helpdesk mailbox, reduced to what ticket intake needs
+type InboundEmail struct {
+	MessageID  string   `json:"message_id"`
+	InReplyTo  string   `json:"in_reply_to,omitempty"`
+	References []string `json:"references,omitempty"` // oldest first, as in the header
+	From       string   `json:"from"`                 // sender address, lowercased
+	FromName   string   `json:"from_name,omitempty"`
+	To         []string `json:"to,omitempty"`
+	Subject    string   `json:"subject"`
+	Body       string   `json:"body"` // plain text, including any quoted reply
+	// AutoSubmitted is set for auto-replies and bulk mail, e.g. out-of-office replies
+	AutoSubmitted bool      `json:"auto_submitted"`
+	ReceivedAt    time.Time `json:"received_at"`
+}
+
+// Validate checks the email can be turned into a ticket or comment
+func (e *InboundEmail) Validate() error {
+	if e.From == "" {
+		return ErrEmptyEmailSender
+	}
+	return nil
+}
+
+// ThreadReferences returns the message IDs the email replies to, most recent first
+func (e *InboundEmail) ThreadReferences() []string {
+	seen := make(map[string]bool)
+	var refs []string
+
+	add := func(id string) {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			refs = append(refs, id)
+		}
+	}
+
+	add(e.InReplyTo)
+	for i := len(e.References) - 1; i >= 0; i-- {
+		add(e.References[i])
+	}
+	return refs
+}
+
+// EmailDirection tells whether a message was received or sent by the helpdesk
+type EmailDirection string
+
+const (
+	EmailDirectionInbound  EmailDirection = "inbound"
+	EmailDirectionOutbound EmailDirection = "outbound"
+)
+
+// EmailMessage records an email that belongs to a ticket's conversation, so replies to it can be
+// threaded back to the ticket
+type EmailMessage struct {
+	ID        string         `json:"id"`
+	MessageID string         `json:"message_id"`
+	TicketID  string         `json:"ticket_id"`
+	CommentID *string        `json:"comment_id,omitempty"` // the comment the email became or was sent for
+	Direction EmailDirection `json:"direction"`
+	Address   string         `json:"address"` // sender of inbound mail, recipient of outbound mail
+	Subject   string         `json:"subject"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// NewEmailMessage creates a record of an email in a ticket's conversation
+func NewEmailMessage(messageID, ticketID string, direction EmailDirection, address, subject string) *EmailMessage {
+	return &EmailMessage{
+		ID:        generateEmailMessageID(),
+		MessageID: messageID,
+		TicketID:  ticketID,
+		Direction: direction,
+		Address:   address,
+		Subject:   subject,
+		CreatedAt: time.Now(),
+	}
+}
+
+var ticketSubjectTag = regexp.MustCompile(`\[#([A-Za-z0-9_.-]+)\]`)
+
+// TicketSubjectTag returns the tag added to email subjects so replies can be matched to the ticket
+// even when the mail client drops the threading headers
+func TicketSubjectTag(ticketID string) string {
+	return "[#" + ticketID + "]"
+}
+
+// TicketIDFromSubject returns the ticket ID tagged in an email subject
+func TicketIDFromSubject(subject string) (string, bool) {
+	match := ticketSubjectTag.FindStringSubmatch(subject)
+	if match == nil {
+		return "", false
+	}
+	return match[1], true
+}
+
+// SignReplyToken creates the token put in the reply-to address of a ticket's emails, so a reply
+// can be matched to the ticket even when its threading headers and subject were changed. The
+// token only uses characters that survive mail servers lowercasing the address.
+func SignReplyToken(secret []byte, ticketID string) string {
+	return strings.ToLower(ticketID) + "." + replySignature(secret, ticketID)
+}
+
+// ParseReplyToken verifies a reply token and returns the ticket it is for
+func ParseReplyToken(secret []byte, token string) (string, error) {
+	dot := strings.LastIndex(token, ".")
+	if dot <= 0 {
+		return "", ErrInvalidReplyToken
+	}
+
+	ticketID := token[:dot]
+	if !hmac.Equal([]byte(strings.ToLower(token[dot+1:])), []byte(replySignature(secret, ticketID))) {
+		return "", ErrInvalidReplyToken
+	}
+	return ticketID, nil
+}
+
+func replySignature(secret []byte, ticketID string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("reply:" + strings.ToLower(ticketID)))
+	// 64 bits keeps the address under the 64 character local part limit
+	return hex.EncodeToString(mac.Sum(nil)[:8])
+}
+
+// ReplyAddress adds a reply token to a mailbox address using plus addressing, e.g.
+// helpdesk@example.com becomes helpdesk+token@example.com
+func ReplyAddress(mailbox, token string) string {
+	local, host, ok := strings.Cut(mailbox, "@")
+	if !ok {
+		return mailbox
+	}
+	return local + "+" + token + "@" + host
+}
+
+// ReplyTokenFromAddress returns the reply token in a plus address
+func ReplyTokenFromAddress(address string) (string, bool) {
+	local, _, ok := strings.Cut(address, "@")
+	if !ok {
+		return "", false
+	}
+	_, token, ok := strings.Cut(local, "+")
+	return token, ok && token != ""
+}
+
+var replyPrefix = regexp.MustCompile(`(?i)^\s*(re|fw|fwd|aw|balas|terusan)\s*:\s*`)
+
+// CleanEmailSubject removes reply and forward prefixes and the ticket tag from a subject
+func CleanEmailSubject(subject string) string {
+	subject = ticketSubjectTag.ReplaceAllString(subject, "")
+	for replyPrefix.MatchString(subject) {
+		subject = replyPrefix.ReplaceAllString(subject, "")
+	}
+	return strings.Join(strings.Fields(subject), " ")
+}
+
+var (
+	// "On Mon, 1 Jan 2024 at 10:00, Jane <jane@example.com> wrote:" and the Indonesian equivalent
+	replyHeaderLine = regexp.MustCompile(`(?i)^(on\s.+\swrote|pada\s.+\smenulis)\s*:\s*$`)
+	replyHeaderFrom = regexp.MustCompile(`(?i)^(on|pada)\s`)
+	replyHeaderTo   = regexp.MustCompile(`(?i)(wrote|menulis)\s*:\s*$`)
+	originalMessage = regexp.MustCompile(`(?i)^-{2,}\s*(original message|forwarded message|pesan asli|pesan yang diteruskan)\s*-{2,}$`)
+	outlookDivider  = regexp.MustCompile(`^_{10,}$`)
+	headerBlock     = regexp.MustCompile(`(?i)^(from|dari)\s*:`)
+	headerBlockNext = regexp.MustCompile(`(?i)^(sent|date|to|subject|dikirim|tanggal|kepada|subjek)\s*:`)
+	mobileSignature = regexp.MustCompile(`(?i)^(sent from my|get outlook for|dikirim dari)\s`)
+)
+
+// StripQuotedReply returns only what the sender wrote: the quoted message they replied to, the
+// reply header introducing it and their signature are removed
+func StripQuotedReply(body string) string {
+	lines := strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+
+	var kept []string
+scan:
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case line == "-- " || trimmed == "--":
+			break scan
+		case replyHeaderLine.MatchString(trimmed):
+			break scan
+		case replyHeaderFrom.MatchString(trimmed) && i+1 < len(lines) && replyHeaderTo.MatchString(strings.TrimSpace(lines[i+1])):
+			// Reply header wrapped over two lines
+			break scan
+		case originalMessage.MatchString(trimmed), outlookDivider.MatchString(trimmed):
+			break scan
+		case headerBlock.MatchString(trimmed) && startsHeaderBlock(lines[i+1:]):
+			break scan
+		case mobileSignature.MatchString(trimmed):
+			break scan
+		case strings.HasPrefix(trimmed, ">"):
+			continue
+		}
+
+		kept = append(kept, strings.TrimRight(line, " \t"))
+	}
+
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
+
+// startsHeaderBlock reports whether the lines after a "From:" line continue a quoted message's
+// headers, as Outlook writes them above the message being replied to
+func startsHeaderBlock(next []string) bool {
+	for i := 0; i < len(next) && i < 4; i++ {
+		if headerBlockNext.MatchString(strings.TrimSpace(next[i])) {
+			return true
+		}
+	}
+	return false
+}
+
+// Email errors
+var (
+	ErrEmailMessageNotFound = NewDomainError("email message not found")
+	ErrEmptyEmailSender     = NewDomainError("email has no sender address")
+	ErrInvalidReplyToken    = NewDomainError("invalid reply address")
+)
+
+func generateEmailMessageID() string {
+	return "email_" + strconv.FormatInt(time.Now().UnixNano(), 10)
+}
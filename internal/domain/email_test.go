@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStripQuotedReply(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "gmail reply",
+			body: "Still broken after the restart.\r\n\r\nOn Mon, 4 Mar 2024 at 10:00, Helpdesk <helpdesk@example.com> wrote:\r\n> Please restart the laptop.\r\n",
+			want: "Still broken after the restart.",
+		},
+		{
+			name: "wrapped reply header",
+			body: "Thanks, that fixed it\n\nOn Mon, 4 Mar 2024 at 10:00, Helpdesk\n<helpdesk@example.com> wrote:\n> Please restart the laptop.",
+			want: "Thanks, that fixed it",
+		},
+		{
+			name: "indonesian reply",
+			body: "Masih tidak bisa login.\n\nPada Sen, 4 Mar 2024 pukul 10.00, Helpdesk <helpdesk@example.com> menulis:\n> Silakan coba lagi.",
+			want: "Masih tidak bisa login.",
+		},
+		{
+			name: "outlook reply",
+			body: "Works now.\n\n________________________________\nFrom: Helpdesk <helpdesk@example.com>\nSent: Monday, March 4, 2024 10:00 AM\nSubject: RE: VPN",
+			want: "Works now.",
+		},
+		{
+			name: "outlook header block",
+			body: "Works now.\n\nFrom: Helpdesk <helpdesk@example.com>\nSent: Monday, March 4, 2024 10:00 AM\nTo: Jane",
+			want: "Works now.",
+		},
+		{
+			name: "original message",
+			body: "See below\n\n-----Original Message-----\nFrom: Helpdesk",
+			want: "See below",
+		},
+		{
+			name: "signature",
+			body: "The printer on floor 3 is jammed.\n\n-- \nJane Doe\nFinance",
+			want: "The printer on floor 3 is jammed.",
+		},
+		{
+			name: "mobile signature",
+			body: "Cannot connect to wifi\n\nSent from my iPhone",
+			want: "Cannot connect to wifi",
+		},
+		{
+			name: "inline quotes",
+			body: "> Which floor?\nThird floor.\n> Which printer?\nThe colour one.",
+			want: "Third floor.\nThe colour one.",
+		},
+		{
+			name: "mentions from in text",
+			body: "From: the office, I cannot reach the VPN.",
+			want: "From: the office, I cannot reach the VPN.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripQuotedReply(tt.body); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTicketIDFromSubject(t *testing.T) {
+	subject := "Re: " + TicketSubjectTag("ticket_20240304100000") + " VPN down"
+
+	id, ok := TicketIDFromSubject(subject)
+	if !ok || id != "ticket_20240304100000" {
+		t.Errorf("Expected ticket_20240304100000, got %q (%v)", id, ok)
+	}
+
+	if _, ok := TicketIDFromSubject("VPN down [urgent]"); ok {
+		t.Error("Expected no ticket ID in an untagged subject")
+	}
+}
+
+func TestCleanEmailSubject(t *testing.T) {
+	got := CleanEmailSubject("RE: Fwd:  Balas: [#ticket_1]  VPN   down")
+	if got != "VPN down" {
+		t.Errorf("Expected %q, got %q", "VPN down", got)
+	}
+}
+
+func TestInboundEmail_ThreadReferences(t *testing.T) {
+	email := &InboundEmail{
+		InReplyTo:  "<c@example.com>",
+		References: []string{"<a@example.com>", "<b@example.com>", "<c@example.com>"},
+	}
+
+	want := []string{"<c@example.com>", "<b@example.com>", "<a@example.com>"}
+	if got := email.ThreadReferences(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestReplyToken(t *testing.T) {
+	secret := []byte("secret")
+	address := ReplyAddress("helpdesk@example.com", SignReplyToken(secret, "ticket_20240304100000"))
+
+	token, ok := ReplyTokenFromAddress(address)
+	if !ok {
+		t.Fatalf("Expected a reply token in %s", address)
+	}
+	if len(address[:len(address)-len("@example.com")]) > 64 {
+		t.Errorf("Expected the local part to fit in 64 characters, got %s", address)
+	}
+
+	ticketID, err := ParseReplyToken(secret, token)
+	if err != nil || ticketID != "ticket_20240304100000" {
+		t.Errorf("Expected ticket_20240304100000, got %q (%v)", ticketID, err)
+	}
+
+	if _, err := ParseReplyToken([]byte("other"), token); err != ErrInvalidReplyToken {
+		t.Errorf("Expected ErrInvalidReplyToken for another secret, got %v", err)
+	}
+	if _, err := ParseReplyToken(secret, "ticket_20240304100001"+token[len("ticket_20240304100000"):]); err != ErrInvalidReplyToken {
+		t.Errorf("Expected ErrInvalidReplyToken for another ticket, got %v", err)
+	}
+	if _, ok := ReplyTokenFromAddress("helpdesk@example.com"); ok {
+		t.Error("Expected no reply token in a plain address")
+	}
+}
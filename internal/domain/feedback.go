@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FeedbackRating represents how useful a requester found an AI suggestion
+type FeedbackRating string
+
+const (
+	FeedbackRatingHelpful    FeedbackRating = "helpful"
+	FeedbackRatingNotHelpful FeedbackRating = "not_helpful"
+	FeedbackRatingSolved     FeedbackRating = "solved" // the suggestion solved the issue
+)
+
+// FeedbackTarget represents what kind of suggestion the feedback is about
+type FeedbackTarget string
+
+const (
+	FeedbackTargetTicketInsight   FeedbackTarget = "ticket_insight"   // the AIInsight attached to a ticket
+	FeedbackTargetStreamCandidate FeedbackTarget = "stream_candidate" // a candidate from the suggestion stream
+)
+
+// SuggestionFeedback records whether an AI suggestion helped, together with what produced it
+type SuggestionFeedback struct {
+	ID            string            `json:"id"`
+	TargetType    FeedbackTarget    `json:"target_type"`
+	TargetID      string            `json:"target_id"` // ticket ID, or query ID and candidate rank
+	TicketID      string            `json:"ticket_id,omitempty"`
+	QueryID       string            `json:"query_id,omitempty"`
+	CandidateRank int               `json:"candidate_rank,omitempty"`
+	Rating        FeedbackRating    `json:"rating"`
+	Comment       string            `json:"comment,omitempty"`
+	Category      string            `json:"category,omitempty"`
+	Provider      string            `json:"provider"`
+	Model         string            `json:"model,omitempty"`
+	Citations     []InsightCitation `json:"citations,omitempty"`
+	Deflected     bool              `json:"deflected"` // the requester's issue was solved without an agent
+	SubmittedBy   string            `json:"submitted_by"`
+	CreatedAt     time.Time         `json:"created_at"`
+}
+
+// NewTicketInsightFeedback creates feedback on the AI insight attached to a ticket. Only the
+// requester can rate it. A "solved" rating on a ticket that is still open means the ticket was
+// self-resolved by the suggestion and counts as deflected.
+func NewTicketInsightFeedback(ticket *Ticket, rating FeedbackRating, comment, submittedBy string) (*SuggestionFeedback, error) {
+	if ticket.AIInsight == nil {
+		return nil, ErrNoAIInsight
+	}
+	if submittedBy != ticket.CreatedBy {
+		return nil, ErrNotTicketRequester
+	}
+
+	feedback := newSuggestionFeedback(FeedbackTargetTicketInsight, ticket.ID, rating, comment, submittedBy)
+	feedback.TicketID = ticket.ID
+	feedback.Category = string(ticket.Category)
+	feedback.Provider = ticket.AIInsight.Source
+	feedback.Model = ticket.AIInsight.Model
+	feedback.Citations = ticket.AIInsight.Citations
+	feedback.Deflected = feedback.Deflected && (ticket.Status == TicketStatusOpen || ticket.Status == TicketStatusInProgress)
+
+	if err := feedback.validate(); err != nil {
+		return nil, err
+	}
+	return feedback, nil
+}
+
+// SuggestionCandidate is a candidate served by the suggestion stream. It is recorded as it is
+// streamed, so feedback on it is attributed to what actually produced it.
+type SuggestionCandidate struct {
+	QueryID    string    `json:"query_id"`
+	Rank       int       `json:"rank"`
+	Score      float64   `json:"score"`
+	Category   string    `json:"category,omitempty"`
+	Provider   string    `json:"provider,omitempty"`
+	Model      string    `json:"model,omitempty"`
+	EntryID    string    `json:"entry_id,omitempty"`
+	ChunkIndex int       `json:"chunk_index,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// NewCandidateFeedback creates feedback on a candidate served by the suggestion stream. Category,
+// provider, model and citation come from the recorded candidate. A "solved" rating means the issue
+// was fixed without opening a ticket and counts as deflected.
+func NewCandidateFeedback(candidate *SuggestionCandidate, rating FeedbackRating, comment, submittedBy string) (*SuggestionFeedback, error) {
+	if candidate.QueryID == "" || candidate.Rank < 1 {
+		return nil, ErrInvalidFeedbackTarget
+	}
+
+	feedback := newSuggestionFeedback(FeedbackTargetStreamCandidate, candidate.QueryID+"#"+strconv.Itoa(candidate.Rank), rating, comment, submittedBy)
+	feedback.QueryID = candidate.QueryID
+	feedback.CandidateRank = candidate.Rank
+	feedback.Category = strings.ToUpper(candidate.Category)
+	feedback.Provider = candidate.Provider
+	feedback.Model = candidate.Model
+	if candidate.EntryID != "" {
+		feedback.Citations = []InsightCitation{{EntryID: candidate.EntryID, ChunkIndex: candidate.ChunkIndex, Score: candidate.Score}}
+	}
+
+	if err := feedback.validate(); err != nil {
+		return nil, err
+	}
+	return feedback, nil
+}
+
+func newSuggestionFeedback(target FeedbackTarget, targetID string, rating FeedbackRating, comment, submittedBy string) *SuggestionFeedback {
+	rating = FeedbackRating(strings.ToLower(string(rating)))
+
+	return &SuggestionFeedback{
+		ID:          generateFeedbackID(),
+		TargetType:  target,
+		TargetID:    targetID,
+		Rating:      rating,
+		Comment:     strings.TrimSpace(comment),
+		Deflected:   rating == FeedbackRatingSolved,
+		SubmittedBy: submittedBy,
+		CreatedAt:   time.Now(),
+	}
+}
+
+func (f *SuggestionFeedback) validate() error {
+	switch f.Rating {
+	case FeedbackRatingHelpful, FeedbackRatingNotHelpful, FeedbackRatingSolved:
+	default:
+		return ErrInvalidFeedbackRating
+	}
+	if f.SubmittedBy == "" {
+		return ErrEmptyAuthorID
+	}
+	if len(f.Comment) > 2000 {
+		return ErrFeedbackCommentTooLong
+	}
+	return nil
+}
+
+// IsSuccessful reports whether the suggestion helped the requester
+func (f *SuggestionFeedback) IsSuccessful() bool {
+	return f.Rating == FeedbackRatingHelpful || f.Rating == FeedbackRatingSolved
+}
+
+// FeedbackFilter represents filters for listing suggestion feedback
+type FeedbackFilter struct {
+	StartDate *time.Time `json:"start_date,omitempty"`
+	EndDate   *time.Time `json:"end_date,omitempty"`
+	Category  string     `json:"category,omitempty"`
+	Provider  string     `json:"provider,omitempty"`
+}
+
+// Feedback errors
+var (
+	ErrNoAIInsight            = NewDomainError("ticket has no AI insight")
+	ErrNotTicketRequester     = NewDomainError("only the requester can rate the ticket's AI insight")
+	ErrInvalidFeedbackRating  = NewDomainError("rating must be helpful, not_helpful or solved")
+	ErrInvalidFeedbackTarget  = NewDomainError("query ID and candidate rank are required")
+	ErrFeedbackCommentTooLong = NewDomainError("feedback comment must not exceed 2000 characters")
+	ErrCandidateNotFound      = NewDomainError("suggestion candidate not found")
+	ErrFeedbackAlreadyGiven   = NewDomainError("feedback on this suggestion was already submitted")
+)
+
+func generateFeedbackID() string {
+	return "feedback_" + strconv.FormatInt(time.Now().UnixNano(), 10)
+}
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func ticketWithInsight() *Ticket {
+	ticket := NewTicket("VPN drops", "VPN disconnects every hour", TicketCategoryNetwork, TicketPriorityMedium, "user1")
+	ticket.AIInsight = &AIInsight{
+		Text:       "Update the VPN client",
+		Confidence: 0.8,
+		Source:     "openai",
+		Model:      "gpt-4o-mini",
+		Citations:  []InsightCitation{{EntryID: "kb-1", ChunkIndex: 0, Score: 0.91}},
+	}
+	return ticket
+}
+
+func TestNewTicketInsightFeedback_SolvedOpenTicketIsDeflected(t *testing.T) {
+	ticket := ticketWithInsight()
+
+	feedback, err := NewTicketInsightFeedback(ticket, "SOLVED", "", "user1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if feedback.Rating != FeedbackRatingSolved {
+		t.Errorf("Expected rating %s, got %s", FeedbackRatingSolved, feedback.Rating)
+	}
+	if !feedback.Deflected {
+		t.Error("Expected feedback on an open ticket to count as deflected")
+	}
+	if feedback.Provider != "openai" || feedback.Model != "gpt-4o-mini" {
+		t.Errorf("Expected provider and model from the insight, got %s %s", feedback.Provider, feedback.Model)
+	}
+	if len(feedback.Citations) != 1 {
+		t.Errorf("Expected citations from the insight, got %d", len(feedback.Citations))
+	}
+
+	if err := ticket.Resolve(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	feedback, err = NewTicketInsightFeedback(ticket, FeedbackRatingSolved, "", "user1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if feedback.Deflected {
+		t.Error("Expected feedback on a resolved ticket not to count as deflected")
+	}
+}
+
+func TestNewTicketInsightFeedback_Validation(t *testing.T) {
+	ticket := ticketWithInsight()
+
+	if _, err := NewTicketInsightFeedback(ticket, FeedbackRatingHelpful, "", "user2"); !errors.Is(err, ErrNotTicketRequester) {
+		t.Errorf("Expected ErrNotTicketRequester, got %v", err)
+	}
+	if _, err := NewTicketInsightFeedback(ticket, "great", "", "user1"); !errors.Is(err, ErrInvalidFeedbackRating) {
+		t.Errorf("Expected ErrInvalidFeedbackRating, got %v", err)
+	}
+
+	ticket.AIInsight = nil
+	if _, err := NewTicketInsightFeedback(ticket, FeedbackRatingHelpful, "", "user1"); !errors.Is(err, ErrNoAIInsight) {
+		t.Errorf("Expected ErrNoAIInsight, got %v", err)
+	}
+}
+
+func TestNewCandidateFeedback(t *testing.T) {
+	candidate := &SuggestionCandidate{QueryID: "query-1", Rank: 2, Score: 0.8, Category: "network", Provider: "openai", Model: "gpt-4o", EntryID: "kb-1", ChunkIndex: 3}
+	feedback, err := NewCandidateFeedback(candidate, FeedbackRatingNotHelpful, "", "user1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if feedback.TargetID != "query-1#2" {
+		t.Errorf("Expected target ID query-1#2, got %s", feedback.TargetID)
+	}
+	if feedback.Category != "NETWORK" || feedback.Provider != "openai" || feedback.Model != "gpt-4o" {
+		t.Errorf("Expected attribution from the candidate, got %s %s %s", feedback.Category, feedback.Provider, feedback.Model)
+	}
+	if len(feedback.Citations) != 1 || feedback.Citations[0].EntryID != "kb-1" || feedback.Citations[0].ChunkIndex != 3 {
+		t.Errorf("Expected the candidate's citation, got %+v", feedback.Citations)
+	}
+	if feedback.Deflected {
+		t.Error("Expected a not helpful rating not to count as deflected")
+	}
+
+	if _, err := NewCandidateFeedback(&SuggestionCandidate{QueryID: "query-1"}, FeedbackRatingHelpful, "", "user1"); !errors.Is(err, ErrInvalidFeedbackTarget) {
+		t.Errorf("Expected ErrInvalidFeedbackTarget, got %v", err)
+	}
+}
+
+func TestMetric_CalculateAIFeedback(t *testing.T) {
+	feedback := []*SuggestionFeedback{
+		{TargetType: FeedbackTargetTicketInsight, Rating: FeedbackRatingSolved, Category: "NETWORK", Provider: "openai", Deflected: true},
+		{TargetType: FeedbackTargetTicketInsight, Rating: FeedbackRatingNotHelpful, Category: "NETWORK", Provider: "zai"},
+		{TargetType: FeedbackTargetStreamCandidate, Rating: FeedbackRatingHelpful, Provider: "openai"},
+		{TargetType: FeedbackTargetStreamCandidate, Rating: FeedbackRatingSolved, Provider: "openai", Deflected: true},
+	}
+
+	metric := NewMetric(string(MetricPeriodWeekly))
+	metric.CalculateAIFeedback(feedback)
+
+	if metric.AIFeedbackCount != 4 {
+		t.Errorf("Expected 4 feedback, got %d", metric.AIFeedbackCount)
+	}
+	if metric.AIAccuracyRate != 0.75 {
+		t.Errorf("Expected accuracy 0.75, got %f", metric.AIAccuracyRate)
+	}
+	if metric.DeflectedTickets != 1 || metric.DeflectedSessions != 1 {
+		t.Errorf("Expected 1 deflected ticket and session, got %d and %d", metric.DeflectedTickets, metric.DeflectedSessions)
+	}
+
+	network := metric.AIAccuracyByCategory["NETWORK"]
+	if network == nil || network.Total != 2 || network.Rate != 0.5 {
+		t.Errorf("Expected NETWORK accuracy 0.5 over 2, got %+v", network)
+	}
+	if unknown := metric.AIAccuracyByCategory["unknown"]; unknown == nil || unknown.Total != 2 {
+		t.Errorf("Expected uncategorised feedback under unknown, got %+v", unknown)
+	}
+
+	openai := metric.AIAccuracyByProvider["openai"]
+	if openai == nil || openai.Total != 3 || openai.Successful != 3 || openai.Deflected != 2 {
+		t.Errorf("Expected openai 3/3 with 2 deflections, got %+v", openai)
+	}
+}
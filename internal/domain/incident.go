@@ -0,0 +1,184 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IncidentStatus represents the stage of an incident
+type IncidentStatus string
+
+const (
+	IncidentStatusInvestigating IncidentStatus = "investigating"
+	IncidentStatusIdentified    IncidentStatus = "identified"
+	IncidentStatusMonitoring    IncidentStatus = "monitoring"
+	IncidentStatusResolved      IncidentStatus = "resolved"
+)
+
+// IsValid checks if the status is a known incident status
+func (s IncidentStatus) IsValid() bool {
+	switch s {
+	case IncidentStatusInvestigating, IncidentStatusIdentified, IncidentStatusMonitoring, IncidentStatusResolved:
+		return true
+	}
+	return false
+}
+
+// IncidentUpdate is a status update broadcast to the requesters of an incident's tickets
+type IncidentUpdate struct {
+	Status   IncidentStatus `json:"status"`
+	Message  string         `json:"message"`
+	PostedBy string         `json:"posted_by"`
+	PostedAt time.Time      `json:"posted_at"`
+}
+
+// Incident groups the tickets raised about one service disruption, such as an outage
+type Incident struct {
+	ID          string           `json:"id"`
+	Title       string           `json:"title"`
+	Description string           `json:"description"`
+	Status      IncidentStatus   `json:"status"`
+	Priority    TicketPriority   `json:"priority"`
+	ProblemID   *string          `json:"problem_id,omitempty"` // problem recording the root cause
+	Resolution  string           `json:"resolution,omitempty"`
+	Updates     []IncidentUpdate `json:"updates"`
+	CreatedBy   string           `json:"created_by"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+	ResolvedAt  *time.Time       `json:"resolved_at,omitempty"`
+}
+
+// NewIncident creates a new incident under investigation
+func NewIncident(title, description string, priority TicketPriority, createdBy string) (*Incident, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return nil, ErrEmptyIncidentTitle
+	}
+	if createdBy == "" {
+		return nil, ErrEmptyAuthorID
+	}
+	if priority == "" {
+		priority = TicketPriorityHigh
+	}
+
+	now := time.Now()
+	return &Incident{
+		ID:          generateIncidentID(),
+		Title:       title,
+		Description: strings.TrimSpace(description),
+		Status:      IncidentStatusInvestigating,
+		Priority:    priority,
+		Updates:     []IncidentUpdate{},
+		CreatedBy:   createdBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// PostUpdate records a status update. An empty status keeps the current one; incidents are
+// resolved with Resolve so the resolution is always recorded.
+func (i *Incident) PostUpdate(status IncidentStatus, message, postedBy string) (*IncidentUpdate, error) {
+	if i.IsResolved() {
+		return nil, ErrIncidentResolved
+	}
+	if status == "" {
+		status = i.Status
+	}
+	if !status.IsValid() || status == IncidentStatusResolved {
+		return nil, ErrInvalidIncidentStatus
+	}
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return nil, ErrEmptyIncidentUpdate
+	}
+
+	return i.addUpdate(status, message, postedBy), nil
+}
+
+// Resolve resolves the incident and records the resolution as its final update
+func (i *Incident) Resolve(resolution, resolvedBy string) (*IncidentUpdate, error) {
+	if i.IsResolved() {
+		return nil, ErrIncidentResolved
+	}
+	resolution = strings.TrimSpace(resolution)
+	if resolution == "" {
+		return nil, ErrEmptyIncidentUpdate
+	}
+
+	update := i.addUpdate(IncidentStatusResolved, resolution, resolvedBy)
+	i.Resolution = resolution
+	i.ResolvedAt = &update.PostedAt
+	return update, nil
+}
+
+// LinkProblem records the problem that holds the incident's root cause
+func (i *Incident) LinkProblem(problemID string) {
+	i.ProblemID = &problemID
+	i.UpdatedAt = time.Now()
+}
+
+// IsResolved checks if the incident is resolved
+func (i *Incident) IsResolved() bool {
+	return i.Status == IncidentStatusResolved
+}
+
+func (i *Incident) addUpdate(status IncidentStatus, message, postedBy string) *IncidentUpdate {
+	update := IncidentUpdate{
+		Status:   status,
+		Message:  message,
+		PostedBy: postedBy,
+		PostedAt: time.Now(),
+	}
+	i.Updates = append(i.Updates, update)
+	i.Status = status
+	i.UpdatedAt = update.PostedAt
+	return &update
+}
+
+// LinkToIncident links the ticket to an incident that is still ongoing
+func (t *Ticket) LinkToIncident(incident *Incident) error {
+	if t.Status == TicketStatusClosed {
+		return ErrTicketClosed
+	}
+	if incident.IsResolved() {
+		return ErrIncidentResolved
+	}
+
+	t.IncidentID = &incident.ID
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// UnlinkIncident removes the ticket from its incident
+func (t *Ticket) UnlinkIncident() error {
+	if t.IncidentID == nil {
+		return ErrTicketNotInIncident
+	}
+	t.IncidentID = nil
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// IncidentFilter represents filters for listing incidents
+type IncidentFilter struct {
+	Status    *IncidentStatus `json:"status,omitempty"`
+	ProblemID *string         `json:"problem_id,omitempty"`
+	Limit     int             `json:"limit"`
+	Offset    int             `json:"offset"`
+}
+
+// Incident errors
+var (
+	ErrIncidentNotFound      = NewDomainError("incident not found")
+	ErrIncidentResolved      = NewDomainError("incident is already resolved")
+	ErrEmptyIncidentTitle    = NewDomainError("incident title cannot be empty")
+	ErrEmptyIncidentUpdate   = NewDomainError("incident update message cannot be empty")
+	ErrInvalidIncidentStatus = NewDomainError("invalid incident status")
+	ErrTicketNotInIncident   = NewDomainError("ticket is not linked to an incident")
+	ErrTooFewTicketsMerge    = NewDomainError("at least two tickets are required to merge")
+)
+
+func generateIncidentID() string {
+	return "incident_" + strconv.FormatInt(time.Now().UnixNano(), 10)
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIncident_PostUpdate(t *testing.T) {
+	incident, err := NewIncident("Email outage", "Exchange is unreachable", "", "admin1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if incident.Priority != TicketPriorityHigh || incident.Status != IncidentStatusInvestigating {
+		t.Fatalf("Expected a HIGH incident under investigation, got %s %s", incident.Priority, incident.Status)
+	}
+
+	if _, err := incident.PostUpdate(IncidentStatusIdentified, "Failed storage node", "admin1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := incident.PostUpdate("", "Replacing the node", "admin1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if incident.Status != IncidentStatusIdentified || len(incident.Updates) != 2 {
+		t.Errorf("Expected status to be kept with 2 updates, got %s with %d", incident.Status, len(incident.Updates))
+	}
+
+	if _, err := incident.PostUpdate(IncidentStatusResolved, "Fixed", "admin1"); !errors.Is(err, ErrInvalidIncidentStatus) {
+		t.Errorf("Expected ErrInvalidIncidentStatus, got %v", err)
+	}
+	if _, err := incident.PostUpdate(IncidentStatusMonitoring, "  ", "admin1"); !errors.Is(err, ErrEmptyIncidentUpdate) {
+		t.Errorf("Expected ErrEmptyIncidentUpdate, got %v", err)
+	}
+}
+
+func TestIncident_Resolve(t *testing.T) {
+	incident, err := NewIncident("VPN down", "", TicketPriorityCritical, "admin1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ticket := &Ticket{ID: "ticket-1", Status: TicketStatusOpen}
+	if err := ticket.LinkToIncident(incident); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := incident.Resolve("Gateway restarted", "admin1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !incident.IsResolved() || incident.ResolvedAt == nil || incident.Resolution != "Gateway restarted" {
+		t.Errorf("Expected incident resolved with its resolution, got %s %q", incident.Status, incident.Resolution)
+	}
+
+	if _, err := incident.PostUpdate("", "Still monitoring", "admin1"); !errors.Is(err, ErrIncidentResolved) {
+		t.Errorf("Expected ErrIncidentResolved, got %v", err)
+	}
+
+	other := &Ticket{ID: "ticket-2", Status: TicketStatusOpen}
+	if err := other.LinkToIncident(incident); !errors.Is(err, ErrIncidentResolved) {
+		t.Errorf("Expected ErrIncidentResolved, got %v", err)
+	}
+
+	if err := ticket.UnlinkIncident(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := ticket.UnlinkIncident(); !errors.Is(err, ErrTicketNotInIncident) {
+		t.Errorf("Expected ErrTicketNotInIncident, got %v", err)
+	}
+}
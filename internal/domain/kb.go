@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"strconv"
 	"time"
 )
 
@@ -23,17 +24,19 @@ const (
 
 // KnowledgeEntry represents a knowledge base entry
 type KnowledgeEntry struct {
-	ID         string                 `json:"id"`
-	Title      string                 `json:"title"`
-	Content    string                 `json:"content"`
-	Status     KnowledgeEntryStatus   `json:"status"`
-	Category   string                 `json:"category,omitempty"`
-	Tags       []string               `json:"tags,omitempty"`
-	SourceType KnowledgeSourceType    `json:"source_type"`
-	Version    int                    `json:"version"`
-	CreatedBy  string                 `json:"created_by"`
-	CreatedAt  time.Time              `json:"created_at"`
-	UpdatedAt  time.Time              `json:"updated_at"`
+	ID               string                 `json:"id"`
+	Title            string                 `json:"title"`
+	Content          string                 `json:"content"`
+	Status           KnowledgeEntryStatus   `json:"status"`
+	Category         string                 `json:"category,omitempty"`
+	Tags             []string               `json:"tags,omitempty"`
+	SourceType       KnowledgeSourceType    `json:"source_type"`
+	SourceTicketID   string                 `json:"source_ticket_id,omitempty"` // ticket a LEARNED entry was drafted from
+	Version          int                    `json:"version"`
+	PublishedVersion int                    `json:"published_version,omitempty"` // 0 when never published
+	CreatedBy        string                 `json:"created_by"`
+	CreatedAt        time.Time              `json:"created_at"`
+	UpdatedAt        time.Time              `json:"updated_at"`
 }
 
 // NewKnowledgeEntry creates a new knowledge base entry
@@ -54,13 +57,27 @@ func NewKnowledgeEntry(title, content, category string, tags []string, createdBy
 	}
 }
 
-// Publish marks the entry as active and increments version
+// NewLearnedKnowledgeEntry creates a draft entry learned from a resolved ticket.
+// Like manual entries it stays a draft until an admin reviews and publishes it.
+func NewLearnedKnowledgeEntry(title, content, category string, tags []string, sourceTicketID, createdBy string) *KnowledgeEntry {
+	entry := NewKnowledgeEntry(title, content, category, tags, createdBy)
+	entry.SourceType = KnowledgeSourceTypeLearned
+	entry.SourceTicketID = sourceTicketID
+	return entry
+}
+
+// IsLearned checks if the entry was drafted from a resolved ticket
+func (k *KnowledgeEntry) IsLearned() bool {
+	return k.SourceType == KnowledgeSourceTypeLearned
+}
+
+// Publish marks the entry as active and records the current version as the published one
 func (k *KnowledgeEntry) Publish() error {
 	if k.Status == KnowledgeEntryStatusArchived {
 		return ErrCannotPublishArchived
 	}
 	k.Status = KnowledgeEntryStatusActive
-	k.Version++
+	k.PublishedVersion = k.Version
 	k.UpdatedAt = time.Now()
 	return nil
 }
@@ -75,33 +92,56 @@ func (k *KnowledgeEntry) Archive() error {
 	return nil
 }
 
-// UpdateContent updates the entry content and sets status to draft
+// UpdateContent updates the entry content, starts a new version and sets status to draft.
+// The previously published version stays searchable until the new draft is published.
 func (k *KnowledgeEntry) UpdateContent(title, content string) {
 	k.Title = title
 	k.Content = content
 	k.Status = KnowledgeEntryStatusDraft
+	k.Version++
 	k.UpdatedAt = time.Now()
 }
 
+// RestoreRevision copies a previous revision into a new draft version
+func (k *KnowledgeEntry) RestoreRevision(rev *KnowledgeRevision) error {
+	if k.Status == KnowledgeEntryStatusArchived {
+		return ErrKBEntryArchived
+	}
+	if rev.EntryID != k.ID {
+		return ErrKBRevisionNotFound
+	}
+	k.UpdateContent(rev.Title, rev.Content)
+	k.Category = rev.Category
+	k.Tags = rev.Tags
+	return nil
+}
+
 // IsActive checks if the entry is active
 func (k *KnowledgeEntry) IsActive() bool {
 	return k.Status == KnowledgeEntryStatusActive
 }
 
+// IsPublished checks if any version of the entry has been published
+func (k *KnowledgeEntry) IsPublished() bool {
+	return k.PublishedVersion > 0
+}
+
 // KBChunk represents a chunk of knowledge base content with embedding
 type KBChunk struct {
-	ID         string    `json:"id"`
-	EntryID    string    `json:"entry_id"`
-	ChunkIndex int       `json:"chunk_index"`
-	Content    string    `json:"content"`
-	Embedding  []float32 `json:"embedding,omitempty"`
-	CreatedAt  time.Time `json:"created_at"`
+	ID             string    `json:"id"`
+	EntryID        string    `json:"entry_id"`
+	ChunkIndex     int       `json:"chunk_index"`
+	Content        string    `json:"content"`
+	Embedding      []float32 `json:"embedding,omitempty"`
+	EmbeddingModel string    `json:"embedding_model,omitempty"` // model that produced Embedding
+	Score          float64   `json:"score,omitempty"`           // similarity to the query, set by search
+	CreatedAt      time.Time `json:"created_at"`
 }
 
 // NewKBChunk creates a new knowledge base chunk
 func NewKBChunk(entryID string, chunkIndex int, content string) *KBChunk {
 	return &KBChunk{
-		ID:         generateChunkID(),
+		ID:         generateChunkID(entryID, chunkIndex),
 		EntryID:    entryID,
 		ChunkIndex: chunkIndex,
 		Content:    content,
@@ -109,17 +149,25 @@ func NewKBChunk(entryID string, chunkIndex int, content string) *KBChunk {
 	}
 }
 
-// SetEmbedding sets the embedding vector for the chunk
-func (k *KBChunk) SetEmbedding(embedding []float32) {
+// SetEmbedding sets the embedding vector for the chunk and the model that produced it
+func (k *KBChunk) SetEmbedding(model string, embedding []float32) {
 	k.Embedding = embedding
+	k.EmbeddingModel = model
+}
+
+// EmbeddingDim returns the dimension of the chunk embedding, 0 when not embedded
+func (k *KBChunk) EmbeddingDim() int {
+	return len(k.Embedding)
 }
 
 // KBChunkFilter represents filters for searching knowledge base chunks
 type KBChunkFilter struct {
-	Tags      []string `json:"tags,omitempty"`
-	Category  string   `json:"category,omitempty"`
-	Status    string   `json:"status,omitempty"`
-	TopK      int      `json:"top_k"`
+	Tags           []string `json:"tags,omitempty"`
+	Category       string   `json:"category,omitempty"`
+	Status         string   `json:"status,omitempty"`
+	SourceType     string   `json:"source_type,omitempty"`
+	SourceTicketID string   `json:"source_ticket_id,omitempty"`
+	TopK           int      `json:"top_k"`
 }
 
 // Knowledge base errors
@@ -130,6 +178,9 @@ var (
 	ErrInvalidEmbedding       = NewDomainError("invalid embedding dimension")
 	ErrEmptyKBContent        = NewDomainError("knowledge base content cannot be empty")
 	ErrDuplicateKBEntry      = NewDomainError("knowledge base entry already exists")
+	ErrKBEntryArchived       = NewDomainError("cannot modify archived entry")
+	ErrKBRevisionNotFound    = NewDomainError("knowledge base revision not found")
+	ErrKBEntryModified       = NewDomainError("knowledge base entry was modified by someone else")
 )
 
 // Helper functions for generating IDs
@@ -137,6 +188,7 @@ func generateKBEntryID() string {
 	return "kb_" + time.Now().Format("20060102150405")
 }
 
-func generateChunkID() string {
-	return "chunk_" + time.Now().Format("20060102150405")
+// Chunk IDs are derived from the entry and position so re-publishing an entry is idempotent
+func generateChunkID(entryID string, chunkIndex int) string {
+	return "chunk_" + entryID + "_" + strconv.Itoa(chunkIndex)
 }
\ No newline at end of file
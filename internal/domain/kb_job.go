@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"strconv"
+	"time"
+)
+
+// IndexJobStatus represents the status of a knowledge base indexing job
+type IndexJobStatus string
+
+const (
+	IndexJobStatusQueued    IndexJobStatus = "queued"
+	IndexJobStatusRunning   IndexJobStatus = "running"
+	IndexJobStatusSucceeded IndexJobStatus = "succeeded"
+	IndexJobStatusFailed    IndexJobStatus = "failed"
+)
+
+// IndexJob represents a background job that chunks, embeds and publishes an entry version
+type IndexJob struct {
+	ID              string         `json:"id"`
+	EntryID         string         `json:"entry_id"`
+	Version         int            `json:"version"`
+	Status          IndexJobStatus `json:"status"`
+	TotalChunks     int            `json:"total_chunks"`
+	ProcessedChunks int            `json:"processed_chunks"`
+	Attempts        int            `json:"attempts"`
+	Error           string         `json:"error,omitempty"`
+	CreatedAt       time.Time      `json:"created_at"`
+	StartedAt       *time.Time     `json:"started_at,omitempty"`
+	FinishedAt      *time.Time     `json:"finished_at,omitempty"`
+	LeaseUntil      *time.Time     `json:"lease_until,omitempty"` // while running, when another worker may take the job over
+	UpdatedAt       time.Time      `json:"updated_at"`
+}
+
+// NewIndexJob creates a queued indexing job for an entry version
+func NewIndexJob(entryID string, version int) *IndexJob {
+	now := time.Now()
+	return &IndexJob{
+		ID:        generateIndexJobID(),
+		EntryID:   entryID,
+		Version:   version,
+		Status:    IndexJobStatusQueued,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Start marks the job as running, held by the caller for lease
+func (j *IndexJob) Start(lease time.Duration) error {
+	if j.IsFinished() {
+		return ErrIndexJobFinished
+	}
+	now := time.Now()
+	leaseUntil := now.Add(lease)
+	j.Status = IndexJobStatusRunning
+	j.Attempts++
+	j.StartedAt = &now
+	j.LeaseUntil = &leaseUntil
+	j.UpdatedAt = now
+	return nil
+}
+
+// Renew extends the lease of a running job
+func (j *IndexJob) Renew(lease time.Duration) {
+	now := time.Now()
+	leaseUntil := now.Add(lease)
+	j.LeaseUntil = &leaseUntil
+	j.UpdatedAt = now
+}
+
+// Claimable reports whether a worker may start the job at now: it is queued, or running under
+// a lease that has expired because its worker stopped
+func (j *IndexJob) Claimable(now time.Time) bool {
+	switch j.Status {
+	case IndexJobStatusQueued:
+		return true
+	case IndexJobStatusRunning:
+		return j.LeaseUntil == nil || j.LeaseUntil.Before(now)
+	default:
+		return false
+	}
+}
+
+// SetTotal records how many chunks the job has to embed
+func (j *IndexJob) SetTotal(total int) {
+	j.TotalChunks = total
+	j.ProcessedChunks = 0
+	j.UpdatedAt = time.Now()
+}
+
+// Advance records that a number of chunks have been embedded
+func (j *IndexJob) Advance(processed int) {
+	j.ProcessedChunks += processed
+	if j.ProcessedChunks > j.TotalChunks {
+		j.ProcessedChunks = j.TotalChunks
+	}
+	j.UpdatedAt = time.Now()
+}
+
+// Succeed marks the job as successfully finished
+func (j *IndexJob) Succeed() {
+	now := time.Now()
+	j.Status = IndexJobStatusSucceeded
+	j.ProcessedChunks = j.TotalChunks
+	j.Error = ""
+	j.FinishedAt = &now
+	j.LeaseUntil = nil
+	j.UpdatedAt = now
+}
+
+// Fail marks the job as failed with the given reason
+func (j *IndexJob) Fail(reason error) {
+	now := time.Now()
+	j.Status = IndexJobStatusFailed
+	j.Error = reason.Error()
+	j.FinishedAt = &now
+	j.LeaseUntil = nil
+	j.UpdatedAt = now
+}
+
+// Progress returns the fraction of chunks processed, between 0 and 1
+func (j *IndexJob) Progress() float64 {
+	if j.Status == IndexJobStatusSucceeded {
+		return 1
+	}
+	if j.TotalChunks == 0 {
+		return 0
+	}
+	return float64(j.ProcessedChunks) / float64(j.TotalChunks)
+}
+
+// IsFinished checks if the job has reached a terminal status
+func (j *IndexJob) IsFinished() bool {
+	return j.Status == IndexJobStatusSucceeded || j.Status == IndexJobStatusFailed
+}
+
+// Indexing job errors
+var (
+	ErrIndexJobNotFound   = NewDomainError("indexing job not found")
+	ErrIndexJobFinished   = NewDomainError("indexing job already finished")
+	ErrIndexJobClaimed    = NewDomainError("indexing job is finished or being processed by another worker")
+	ErrIndexJobSuperseded = NewDomainError("entry changed after the indexing job was queued")
+)
+
+// Helper function for generating indexing job IDs
+func generateIndexJobID() string {
+	return "kbjob_" + strconv.FormatInt(time.Now().UnixNano(), 10)
+}
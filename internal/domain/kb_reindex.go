@@ -0,0 +1,40 @@
+package domain
+
+// EmbeddingSpace identifies the model and dimension that produced a set of vectors.
+// Vectors from different spaces are not comparable and must never be searched together.
+type EmbeddingSpace struct {
+	Model     string `json:"model"`
+	Dimension int    `json:"dimension"`
+}
+
+// Matches checks if an embedding belongs to this space
+func (s EmbeddingSpace) Matches(model string, embedding []float32) bool {
+	return model == s.Model && len(embedding) == s.Dimension
+}
+
+// ReindexProgress reports how far the knowledge base is from being fully embedded in a target space
+type ReindexProgress struct {
+	Target          EmbeddingSpace `json:"target"`
+	TotalChunks     int            `json:"total_chunks"`
+	ReindexedChunks int            `json:"reindexed_chunks"` // shadow vectors ready for cutover
+	CurrentChunks   int            `json:"current_chunks"`   // live vectors already in the target space
+}
+
+// Remaining returns the number of chunks that still need a shadow vector
+func (p *ReindexProgress) Remaining() int {
+	remaining := p.TotalChunks - p.ReindexedChunks
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// IsCurrent checks if every live vector is already in the target space, so no reindex is needed
+func (p *ReindexProgress) IsCurrent() bool {
+	return p.CurrentChunks == p.TotalChunks && p.ReindexedChunks == 0
+}
+
+// Reindex errors
+var (
+	ErrReindexIncomplete = NewDomainError("reindex incomplete: some chunks have no vector in the target embedding space")
+)
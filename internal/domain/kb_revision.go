@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// KnowledgeRevision represents an immutable snapshot of a knowledge base entry version
+type KnowledgeRevision struct {
+	ID        string    `json:"id"`
+	EntryID   string    `json:"entry_id"`
+	Version   int       `json:"version"`
+	Title     string    `json:"title"`
+	Content   string    `json:"content"`
+	Category  string    `json:"category,omitempty"`
+	Tags      []string  `json:"tags,omitempty"`
+	AuthorID  string    `json:"author_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewKnowledgeRevision snapshots the current state of an entry
+func NewKnowledgeRevision(entry *KnowledgeEntry, authorID string) *KnowledgeRevision {
+	return &KnowledgeRevision{
+		ID:        generateRevisionID(),
+		EntryID:   entry.ID,
+		Version:   entry.Version,
+		Title:     entry.Title,
+		Content:   entry.Content,
+		Category:  entry.Category,
+		Tags:      entry.Tags,
+		AuthorID:  authorID,
+		CreatedAt: time.Now(),
+	}
+}
+
+// DiffOp represents the kind of change for a diff line
+type DiffOp string
+
+const (
+	DiffOpEqual  DiffOp = "equal"
+	DiffOpAdd    DiffOp = "add"
+	DiffOpRemove DiffOp = "remove"
+)
+
+// DiffLine represents a single line in a line diff
+type DiffLine struct {
+	Op      DiffOp `json:"op"`
+	Text    string `json:"text"`
+	OldLine int    `json:"old_line,omitempty"` // 1-based, 0 for added lines
+	NewLine int    `json:"new_line,omitempty"` // 1-based, 0 for removed lines
+}
+
+// RevisionDiff represents a line diff between two revisions of an entry
+type RevisionDiff struct {
+	EntryID     string     `json:"entry_id"`
+	FromVersion int        `json:"from_version"`
+	ToVersion   int        `json:"to_version"`
+	FromTitle   string     `json:"from_title"`
+	ToTitle     string     `json:"to_title"`
+	Added       int        `json:"added"`
+	Removed     int        `json:"removed"`
+	Lines       []DiffLine `json:"lines"`
+}
+
+// DiffRevisions computes a line diff of the content of two revisions
+func DiffRevisions(from, to *KnowledgeRevision) *RevisionDiff {
+	diff := &RevisionDiff{
+		EntryID:     from.EntryID,
+		FromVersion: from.Version,
+		ToVersion:   to.Version,
+		FromTitle:   from.Title,
+		ToTitle:     to.Title,
+		Lines:       DiffLines(from.Content, to.Content),
+	}
+
+	for _, line := range diff.Lines {
+		switch line.Op {
+		case DiffOpAdd:
+			diff.Added++
+		case DiffOpRemove:
+			diff.Removed++
+		}
+	}
+
+	return diff
+}
+
+// DiffLines computes a line diff between two texts using the longest common subsequence
+func DiffLines(oldText, newText string) []DiffLine {
+	a := splitLines(oldText)
+	b := splitLines(newText)
+
+	// lcs[i][j] holds the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	lines := make([]DiffLine, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, DiffLine{Op: DiffOpEqual, Text: a[i], OldLine: i + 1, NewLine: j + 1})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, DiffLine{Op: DiffOpRemove, Text: a[i], OldLine: i + 1})
+			i++
+		default:
+			lines = append(lines, DiffLine{Op: DiffOpAdd, Text: b[j], NewLine: j + 1})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, DiffLine{Op: DiffOpRemove, Text: a[i], OldLine: i + 1})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, DiffLine{Op: DiffOpAdd, Text: b[j], NewLine: j + 1})
+	}
+
+	return lines
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+}
+
+// Helper function for generating revision IDs
+func generateRevisionID() string {
+	return "kbrev_" + strconv.FormatInt(time.Now().UnixNano(), 10)
+}
@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestKnowledgeEntry_UpdateKeepsPublishedVersion(t *testing.T) {
+	entry := NewKnowledgeEntry("VPN setup", "Install the client", "NETWORK", nil, "admin1")
+
+	if err := entry.Publish(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if entry.PublishedVersion != 1 {
+		t.Errorf("Expected published version 1, got %d", entry.PublishedVersion)
+	}
+
+	entry.UpdateContent("VPN setup", "Install the client\nImport the profile")
+
+	if entry.Version != 2 {
+		t.Errorf("Expected version 2, got %d", entry.Version)
+	}
+
+	if entry.Status != KnowledgeEntryStatusDraft {
+		t.Errorf("Expected status %s, got %s", KnowledgeEntryStatusDraft, entry.Status)
+	}
+
+	if entry.PublishedVersion != 1 {
+		t.Errorf("Expected published version to stay 1, got %d", entry.PublishedVersion)
+	}
+}
+
+func TestKnowledgeEntry_RestoreRevision(t *testing.T) {
+	entry := NewKnowledgeEntry("Printer", "Restart the spooler", "HARDWARE", []string{"printer"}, "admin1")
+	first := NewKnowledgeRevision(entry, "admin1")
+
+	entry.UpdateContent("Printer jams", "Open tray 2")
+	entry.Category = "OTHER"
+
+	if err := entry.RestoreRevision(first); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if entry.Version != 3 {
+		t.Errorf("Expected rollback to create version 3, got %d", entry.Version)
+	}
+
+	if entry.Content != first.Content || entry.Title != first.Title || entry.Category != first.Category {
+		t.Errorf("Expected entry to match revision %d, got %+v", first.Version, entry)
+	}
+
+	if entry.Status != KnowledgeEntryStatusDraft {
+		t.Errorf("Expected status %s, got %s", KnowledgeEntryStatusDraft, entry.Status)
+	}
+}
+
+func TestKnowledgeEntry_RestoreRevisionArchived(t *testing.T) {
+	entry := NewKnowledgeEntry("Printer", "Restart the spooler", "HARDWARE", nil, "admin1")
+	rev := NewKnowledgeRevision(entry, "admin1")
+	entry.Archive()
+
+	if err := entry.RestoreRevision(rev); err != ErrKBEntryArchived {
+		t.Errorf("Expected ErrKBEntryArchived, got %v", err)
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	oldText := "step one\nstep two\nstep three"
+	newText := "step one\nstep 2\nstep three\nstep four"
+
+	lines := DiffLines(oldText, newText)
+
+	expected := []DiffLine{
+		{Op: DiffOpEqual, Text: "step one", OldLine: 1, NewLine: 1},
+		{Op: DiffOpRemove, Text: "step two", OldLine: 2},
+		{Op: DiffOpAdd, Text: "step 2", NewLine: 2},
+		{Op: DiffOpEqual, Text: "step three", OldLine: 3, NewLine: 3},
+		{Op: DiffOpAdd, Text: "step four", NewLine: 4},
+	}
+
+	if len(lines) != len(expected) {
+		t.Fatalf("Expected %d lines, got %d: %+v", len(expected), len(lines), lines)
+	}
+
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Errorf("Line %d: expected %+v, got %+v", i, expected[i], lines[i])
+		}
+	}
+}
+
+func TestDiffRevisions_Counts(t *testing.T) {
+	from := &KnowledgeRevision{EntryID: "kb_1", Version: 1, Content: "a\nb"}
+	to := &KnowledgeRevision{EntryID: "kb_1", Version: 2, Content: "a\nc\nd"}
+
+	diff := DiffRevisions(from, to)
+
+	if diff.Added != 2 {
+		t.Errorf("Expected 2 added lines, got %d", diff.Added)
+	}
+
+	if diff.Removed != 1 {
+		t.Errorf("Expected 1 removed line, got %d", diff.Removed)
+	}
+}
+
+func TestNewKnowledgeRevision_UniqueIDs(t *testing.T) {
+	entry := NewKnowledgeEntry("Printer", "Restart the spooler", "HARDWARE", nil, "admin1")
+
+	// A create, update and rollback can all happen within the same second
+	seen := make(map[string]bool)
+	for i := 0; i < 3; i++ {
+		rev := NewKnowledgeRevision(entry, "admin1")
+		if seen[rev.ID] {
+			t.Fatalf("Duplicate revision ID %s", rev.ID)
+		}
+		seen[rev.ID] = true
+		entry.UpdateContent("Printer", entry.Content+"\nCheck the queue")
+	}
+}
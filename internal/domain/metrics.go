@@ -1,22 +1,33 @@
 package domain
 
 import (
+	"fmt"
 	"time"
 )
 
 // Metric represents system performance metrics
 type Metric struct {
-	TotalTickets           int           `json:"total_tickets"`
-	OpenTickets            int           `json:"open_tickets"`
-	ResolvedTickets        int           `json:"resolved_tickets"`
-	AverageResolutionTime  time.Duration `json:"average_resolution_time"`
-	FirstResponseTime      time.Duration `json:"first_response_time"`
-	AIAccuracyRate         float64       `json:"ai_accuracy_rate"`
-	SLAComplianceRate      float64       `json:"sla_compliance_rate"`
-	TotalKnowledgeEntries  int           `json:"total_knowledge_entries"`
-	ActiveKnowledgeEntries int           `json:"active_knowledge_entries"`
-	Period                 string        `json:"period"`
-	GeneratedAt            time.Time     `json:"generated_at"`
+	TotalTickets           int                    `json:"total_tickets"`
+	OpenTickets            int                    `json:"open_tickets"`
+	ResolvedTickets        int                    `json:"resolved_tickets"`
+	AverageResolutionTime  time.Duration          `json:"average_resolution_time"`
+	FirstResponseTime      time.Duration          `json:"first_response_time"`
+	AIAccuracyRate         float64                `json:"ai_accuracy_rate"`
+	AIFeedbackCount        int                    `json:"ai_feedback_count"`
+	AIAccuracyByCategory   map[string]*AIAccuracy `json:"ai_accuracy_by_category,omitempty"`
+	AIAccuracyByProvider   map[string]*AIAccuracy `json:"ai_accuracy_by_provider,omitempty"`
+	DeflectedTickets       int                    `json:"deflected_tickets"`  // tickets self-resolved by an AI suggestion
+	DeflectedSessions      int                    `json:"deflected_sessions"` // issues solved by a streamed suggestion without a ticket
+	CSATAverage            float64                `json:"csat_average"`
+	CSATResponses          int                    `json:"csat_responses"`
+	CSATByAgent            map[string]*CSATScore  `json:"csat_by_agent,omitempty"`
+	CSATByCategory         map[string]*CSATScore  `json:"csat_by_category,omitempty"`
+	CSATByPeriod           map[string]*CSATScore  `json:"csat_by_period,omitempty"` // keyed by day, ISO week or month
+	SLAComplianceRate      float64                `json:"sla_compliance_rate"`
+	TotalKnowledgeEntries  int                    `json:"total_knowledge_entries"`
+	ActiveKnowledgeEntries int                    `json:"active_knowledge_entries"`
+	Period                 string                 `json:"period"`
+	GeneratedAt            time.Time              `json:"generated_at"`
 }
 
 // MetricPeriod represents the period for metrics calculation
@@ -65,6 +76,109 @@ func (m *Metric) CalculateAIAccuracy(totalSuggestions, successfulSuggestions int
 	m.AIAccuracyRate = float64(successfulSuggestions) / float64(totalSuggestions)
 }
 
+// AIAccuracy is the share of rated AI suggestions that helped, for one group of feedback
+type AIAccuracy struct {
+	Total      int     `json:"total"`
+	Successful int     `json:"successful"`
+	Deflected  int     `json:"deflected"`
+	Rate       float64 `json:"rate"`
+}
+
+func (a *AIAccuracy) add(feedback *SuggestionFeedback) {
+	a.Total++
+	if feedback.IsSuccessful() {
+		a.Successful++
+	}
+	if feedback.Deflected {
+		a.Deflected++
+	}
+	a.Rate = float64(a.Successful) / float64(a.Total)
+}
+
+// CalculateAIFeedback calculates AI accuracy overall, per ticket category and per provider, and
+// counts deflections. Feedback without a category or provider is grouped under "unknown".
+func (m *Metric) CalculateAIFeedback(feedback []*SuggestionFeedback) {
+	m.AIFeedbackCount = len(feedback)
+	m.AIAccuracyByCategory = make(map[string]*AIAccuracy)
+	m.AIAccuracyByProvider = make(map[string]*AIAccuracy)
+
+	successful := 0
+	for _, f := range feedback {
+		if f.IsSuccessful() {
+			successful++
+		}
+		if f.Deflected {
+			if f.TargetType == FeedbackTargetTicketInsight {
+				m.DeflectedTickets++
+			} else {
+				m.DeflectedSessions++
+			}
+		}
+
+		accuracyGroup(m.AIAccuracyByCategory, f.Category).add(f)
+		accuracyGroup(m.AIAccuracyByProvider, f.Provider).add(f)
+	}
+
+	m.CalculateAIAccuracy(len(feedback), successful)
+}
+
+func accuracyGroup(groups map[string]*AIAccuracy, key string) *AIAccuracy {
+	if key == "" {
+		key = "unknown"
+	}
+	group, ok := groups[key]
+	if !ok {
+		group = &AIAccuracy{}
+		groups[key] = group
+	}
+	return group
+}
+
+// CalculateCSAT calculates the average satisfaction score overall, per agent, per category and per
+// day, ISO week or month depending on the period. Unassigned tickets are grouped under "unassigned".
+func (m *Metric) CalculateCSAT(ratings []*CSATRating, period MetricPeriod) {
+	overall := &CSATScore{}
+	m.CSATByAgent = make(map[string]*CSATScore)
+	m.CSATByCategory = make(map[string]*CSATScore)
+	m.CSATByPeriod = make(map[string]*CSATScore)
+
+	for _, r := range ratings {
+		agent := "unassigned"
+		if r.AgentID != nil {
+			agent = *r.AgentID
+		}
+
+		overall.add(r.Score)
+		csatGroup(m.CSATByAgent, agent).add(r.Score)
+		csatGroup(m.CSATByCategory, string(r.Category)).add(r.Score)
+		csatGroup(m.CSATByPeriod, csatPeriodKey(r.CreatedAt, period)).add(r.Score)
+	}
+
+	m.CSATResponses = overall.Responses
+	m.CSATAverage = overall.Average
+}
+
+func csatGroup(groups map[string]*CSATScore, key string) *CSATScore {
+	group, ok := groups[key]
+	if !ok {
+		group = &CSATScore{}
+		groups[key] = group
+	}
+	return group
+}
+
+func csatPeriodKey(t time.Time, period MetricPeriod) string {
+	t = t.UTC()
+	switch period {
+	case MetricPeriodWeekly:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	case MetricPeriodMonthly:
+		return t.Format("2006-01")
+	}
+	return t.Format("2006-01-02")
+}
+
 // CalculateSLACompliance calculates SLA compliance rate
 func (m *Metric) CalculateSLACompliance(totalTickets, compliantTickets int) {
 	if totalTickets == 0 {
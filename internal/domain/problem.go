@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ProblemStatus represents the stage of a problem investigation
+type ProblemStatus string
+
+const (
+	ProblemStatusOpen       ProblemStatus = "open"
+	ProblemStatusKnownError ProblemStatus = "known_error" // root cause and workaround are documented
+	ProblemStatusResolved   ProblemStatus = "resolved"
+)
+
+// Problem records the underlying cause of one or more incidents and how to work around it
+type Problem struct {
+	ID          string         `json:"id"`
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Status      ProblemStatus  `json:"status"`
+	RootCause   string         `json:"root_cause,omitempty"`
+	Workaround  string         `json:"workaround,omitempty"`
+	KBEntryID   string         `json:"kb_entry_id,omitempty"` // knowledge base entry the workaround is published as
+	Category    TicketCategory `json:"category,omitempty"`
+	CreatedBy   string         `json:"created_by"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	ResolvedAt  *time.Time     `json:"resolved_at,omitempty"`
+}
+
+// NewProblem creates a new open problem
+func NewProblem(title, description string, category TicketCategory, createdBy string) (*Problem, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return nil, ErrEmptyProblemTitle
+	}
+	if createdBy == "" {
+		return nil, ErrEmptyAuthorID
+	}
+
+	now := time.Now()
+	return &Problem{
+		ID:          generateProblemID(),
+		Title:       title,
+		Description: strings.TrimSpace(description),
+		Status:      ProblemStatusOpen,
+		Category:    category,
+		CreatedBy:   createdBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// RecordAnalysis records the root cause and workaround. Empty values keep the current ones.
+// A problem with both documented becomes a known error.
+func (p *Problem) RecordAnalysis(rootCause, workaround string) error {
+	if p.IsResolved() {
+		return ErrProblemResolved
+	}
+
+	if rootCause = strings.TrimSpace(rootCause); rootCause != "" {
+		p.RootCause = rootCause
+	}
+	if workaround = strings.TrimSpace(workaround); workaround != "" {
+		p.Workaround = workaround
+	}
+
+	if p.RootCause != "" && p.Workaround != "" {
+		p.Status = ProblemStatusKnownError
+	}
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// Resolve marks the problem as resolved once its root cause is known
+func (p *Problem) Resolve() error {
+	if p.IsResolved() {
+		return ErrProblemResolved
+	}
+	if p.RootCause == "" {
+		return ErrNoRootCause
+	}
+
+	now := time.Now()
+	p.Status = ProblemStatusResolved
+	p.ResolvedAt = &now
+	p.UpdatedAt = now
+	return nil
+}
+
+// IsResolved checks if the problem is resolved
+func (p *Problem) IsResolved() bool {
+	return p.Status == ProblemStatusResolved
+}
+
+// WorkaroundArticle returns the title and content of the knowledge base entry for the workaround
+func (p *Problem) WorkaroundArticle() (string, string, error) {
+	if p.Workaround == "" {
+		return "", "", ErrNoWorkaround
+	}
+
+	var content strings.Builder
+	if p.Description != "" {
+		fmt.Fprintf(&content, "Symptoms:\n%s\n\n", p.Description)
+	}
+	if p.RootCause != "" {
+		fmt.Fprintf(&content, "Cause:\n%s\n\n", p.RootCause)
+	}
+	fmt.Fprintf(&content, "Workaround:\n%s", p.Workaround)
+
+	// Knowledge base titles are at most 200 characters
+	title := []rune("Workaround: " + p.Title)
+	if len(title) > 200 {
+		title = title[:200]
+	}
+
+	return string(title), content.String(), nil
+}
+
+// SetKBEntry records the knowledge base entry the workaround was published as
+func (p *Problem) SetKBEntry(entryID string) {
+	p.KBEntryID = entryID
+	p.UpdatedAt = time.Now()
+}
+
+// ProblemFilter represents filters for listing problems
+type ProblemFilter struct {
+	Status *ProblemStatus `json:"status,omitempty"`
+	Limit  int            `json:"limit"`
+	Offset int            `json:"offset"`
+}
+
+// Problem errors
+var (
+	ErrProblemNotFound   = NewDomainError("problem not found")
+	ErrProblemResolved   = NewDomainError("problem is already resolved")
+	ErrEmptyProblemTitle = NewDomainError("problem title cannot be empty")
+	ErrNoRootCause       = NewDomainError("root cause must be recorded before resolving the problem")
+	ErrNoWorkaround      = NewDomainError("problem has no workaround to publish")
+)
+
+func generateProblemID() string {
+	return "problem_" + strconv.FormatInt(time.Now().UnixNano(), 10)
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestProblem_RecordAnalysis(t *testing.T) {
+	problem, err := NewProblem("Outlook password prompts", "Outlook asks for the password repeatedly", TicketCategorySoftware, "admin1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := problem.Resolve(); !errors.Is(err, ErrNoRootCause) {
+		t.Errorf("Expected ErrNoRootCause, got %v", err)
+	}
+	if _, _, err := problem.WorkaroundArticle(); !errors.Is(err, ErrNoWorkaround) {
+		t.Errorf("Expected ErrNoWorkaround, got %v", err)
+	}
+
+	if err := problem.RecordAnalysis("Expired token cache", ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if problem.Status != ProblemStatusOpen {
+		t.Errorf("Expected problem to stay open without a workaround, got %s", problem.Status)
+	}
+
+	if err := problem.RecordAnalysis("", "Clear cached credentials in Credential Manager"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if problem.Status != ProblemStatusKnownError || problem.RootCause != "Expired token cache" {
+		t.Errorf("Expected a known error keeping the root cause, got %s %q", problem.Status, problem.RootCause)
+	}
+
+	title, content, err := problem.WorkaroundArticle()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if title != "Workaround: Outlook password prompts" || !strings.Contains(content, "Clear cached credentials") {
+		t.Errorf("Unexpected workaround article %q: %q", title, content)
+	}
+
+	if err := problem.Resolve(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := problem.RecordAnalysis("Other cause", ""); !errors.Is(err, ErrProblemResolved) {
+		t.Errorf("Expected ErrProblemResolved, got %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"strconv"
+	"time"
+)
+
+// JobRunStatus represents the outcome of a scheduled job run
+type JobRunStatus string
+
+const (
+	JobRunSucceeded JobRunStatus = "succeeded"
+	JobRunFailed    JobRunStatus = "failed"
+)
+
+// JobRun records one run of a scheduled background job
+type JobRun struct {
+	ID         string       `json:"id"`
+	Job        string       `json:"job"`
+	Instance   string       `json:"instance"` // instance that ran the job as scheduler leader
+	Status     JobRunStatus `json:"status"`
+	Processed  int          `json:"processed"` // tickets the job acted on
+	Error      string       `json:"error,omitempty"`
+	StartedAt  time.Time    `json:"started_at"`
+	FinishedAt time.Time    `json:"finished_at"`
+}
+
+// NewJobRun starts the record of a job run
+func NewJobRun(job, instance string) *JobRun {
+	return &JobRun{
+		ID:        generateJobRunID(),
+		Job:       job,
+		Instance:  instance,
+		Status:    JobRunSucceeded,
+		StartedAt: time.Now(),
+	}
+}
+
+// Finish records how many tickets the run acted on and the error that stopped it, if any
+func (r *JobRun) Finish(processed int, err error) {
+	r.Processed = processed
+	r.FinishedAt = time.Now()
+	if err != nil {
+		r.Status = JobRunFailed
+		r.Error = err.Error()
+	}
+}
+
+// IsDue reports whether a job that last ran at lastRun should run again at now
+func IsDue(lastRun *JobRun, interval time.Duration, now time.Time) bool {
+	return lastRun == nil || !now.Before(lastRun.StartedAt.Add(interval))
+}
+
+// LastActivity returns when the ticket last changed or was commented on
+func (t *Ticket) LastActivity(comments []*Comment) time.Time {
+	last := t.UpdatedAt
+	for _, comment := range comments {
+		if comment.CreatedAt.After(last) {
+			last = comment.CreatedAt
+		}
+	}
+	return last
+}
+
+// EscalatedPriority returns the next priority up, or false when already CRITICAL
+func EscalatedPriority(priority TicketPriority) (TicketPriority, bool) {
+	switch priority {
+	case TicketPriorityLow:
+		return TicketPriorityMedium, true
+	case TicketPriorityMedium:
+		return TicketPriorityHigh, true
+	case TicketPriorityHigh:
+		return TicketPriorityCritical, true
+	}
+	return priority, false
+}
+
+// Scheduler errors
+var (
+	ErrJobNotFound       = NewDomainError("scheduled job not found")
+	ErrJobAlreadyRunning = NewDomainError("scheduled job is already running")
+)
+
+func generateJobRunID() string {
+	return "job_run_" + strconv.FormatInt(time.Now().UnixNano(), 10)
+}
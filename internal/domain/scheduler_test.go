@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestJobRun_Finish(t *testing.T) {
+	run := NewJobRun("auto_close_resolved", "instance-1")
+	run.Finish(3, nil)
+	if run.Status != JobRunSucceeded || run.Processed != 3 || run.FinishedAt.IsZero() {
+		t.Errorf("Expected a succeeded run with 3 processed, got %+v", run)
+	}
+
+	failed := NewJobRun("auto_close_resolved", "instance-1")
+	failed.Finish(1, errors.New("database unavailable"))
+	if failed.Status != JobRunFailed || failed.Error != "database unavailable" {
+		t.Errorf("Expected a failed run, got %+v", failed)
+	}
+}
+
+func TestIsDue(t *testing.T) {
+	now := time.Now()
+
+	if !IsDue(nil, time.Hour, now) {
+		t.Error("Expected a job that never ran to be due")
+	}
+	if IsDue(&JobRun{StartedAt: now.Add(-30 * time.Minute)}, time.Hour, now) {
+		t.Error("Expected a job that ran 30 minutes ago not to be due hourly")
+	}
+	if !IsDue(&JobRun{StartedAt: now.Add(-time.Hour)}, time.Hour, now) {
+		t.Error("Expected a job that ran an hour ago to be due hourly")
+	}
+}
+
+func TestTicket_LastActivity(t *testing.T) {
+	now := time.Now()
+	ticket := &Ticket{ID: "ticket-1", UpdatedAt: now.Add(-72 * time.Hour)}
+
+	if got := ticket.LastActivity(nil); !got.Equal(ticket.UpdatedAt) {
+		t.Errorf("Expected the last update without comments, got %v", got)
+	}
+
+	comments := []*Comment{
+		{TicketID: "ticket-1", CreatedAt: now.Add(-96 * time.Hour)},
+		{TicketID: "ticket-1", CreatedAt: now.Add(-2 * time.Hour)},
+	}
+	if got := ticket.LastActivity(comments); !got.Equal(comments[1].CreatedAt) {
+		t.Errorf("Expected the latest comment, got %v", got)
+	}
+}
+
+func TestEscalatedPriority(t *testing.T) {
+	tests := []struct {
+		priority TicketPriority
+		want     TicketPriority
+		ok       bool
+	}{
+		{TicketPriorityLow, TicketPriorityMedium, true},
+		{TicketPriorityMedium, TicketPriorityHigh, true},
+		{TicketPriorityHigh, TicketPriorityCritical, true},
+		{TicketPriorityCritical, TicketPriorityCritical, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := EscalatedPriority(tt.priority)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("EscalatedPriority(%s) = %s, %v; want %s, %v", tt.priority, got, ok, tt.want, tt.ok)
+		}
+	}
+}
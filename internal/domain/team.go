@@ -0,0 +1,204 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Team is a group of agents, such as the Network team or Service Desk L1, that owns queues
+type Team struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Members     []string  `json:"members"` // agent IDs
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// NewTeam creates a team without members
+func NewTeam(name, description string) (*Team, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrEmptyTeamName
+	}
+
+	now := time.Now()
+	return &Team{
+		ID:          generateTeamID(),
+		Name:        name,
+		Description: strings.TrimSpace(description),
+		Members:     []string{},
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// Rename changes the team's name and description
+func (t *Team) Rename(name, description string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrEmptyTeamName
+	}
+	t.Name = name
+	t.Description = strings.TrimSpace(description)
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// HasMember reports whether the agent belongs to the team
+func (t *Team) HasMember(agentID string) bool {
+	for _, member := range t.Members {
+		if member == agentID {
+			return true
+		}
+	}
+	return false
+}
+
+// AddMember adds an agent to the team
+func (t *Team) AddMember(agentID string) error {
+	if agentID == "" {
+		return ErrEmptyAgentID
+	}
+	if t.HasMember(agentID) {
+		return ErrAlreadyTeamMember
+	}
+	t.Members = append(t.Members, agentID)
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// RemoveMember removes an agent from the team
+func (t *Team) RemoveMember(agentID string) error {
+	for i, member := range t.Members {
+		if member == agentID {
+			t.Members = append(t.Members[:i], t.Members[i+1:]...)
+			t.UpdatedAt = time.Now()
+			return nil
+		}
+	}
+	return ErrNotTeamMember
+}
+
+// Queue holds tickets waiting for one of its team's agents to claim them
+type Queue struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	TeamID      string    `json:"team_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// NewQueue creates a queue owned by a team
+func NewQueue(name, description, teamID string) (*Queue, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrEmptyQueueName
+	}
+	if teamID == "" {
+		return nil, ErrEmptyTeamID
+	}
+
+	now := time.Now()
+	return &Queue{
+		ID:          generateQueueID(),
+		Name:        name,
+		Description: strings.TrimSpace(description),
+		TeamID:      teamID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// Update changes the queue's name and description, or hands it to another team
+func (q *Queue) Update(name, description, teamID string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrEmptyQueueName
+	}
+	if teamID == "" {
+		return ErrEmptyTeamID
+	}
+	q.Name = name
+	q.Description = strings.TrimSpace(description)
+	q.TeamID = teamID
+	q.UpdatedAt = time.Now()
+	return nil
+}
+
+// MoveToQueue places the ticket in a queue to be claimed. A ticket already being worked on is
+// released back to OPEN, so it can move between queues.
+func (t *Ticket) MoveToQueue(queueID string) error {
+	if t.Status == TicketStatusClosed {
+		return ErrTicketClosed
+	}
+	if t.Status == TicketStatusResolved {
+		return ErrInvalidAssignment
+	}
+	t.QueueID = &queueID
+	t.AssignedTo = nil
+	t.Status = TicketStatusOpen
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// CanBeClaimed checks that the ticket waits in a queue and nobody has claimed it yet
+func (t *Ticket) CanBeClaimed() error {
+	if t.QueueID == nil {
+		return ErrTicketNotQueued
+	}
+	if t.AssignedTo != nil {
+		return ErrTicketAlreadyClaimed
+	}
+	if t.Status != TicketStatusOpen {
+		return ErrInvalidAssignment
+	}
+	return nil
+}
+
+// QueueDashboard summarizes a queue's workload
+type QueueDashboard struct {
+	Queue      *Queue                 `json:"queue"`
+	Team       *Team                  `json:"team"`
+	Unclaimed  int                    `json:"unclaimed"`   // open tickets waiting to be claimed
+	InProgress int                    `json:"in_progress"` // claimed tickets being worked on
+	Resolved   int                    `json:"resolved"`
+	ByPriority map[TicketPriority]int `json:"unclaimed_by_priority"`
+	Members    []QueueMemberLoad      `json:"members"`
+}
+
+// QueueMemberLoad is a team member's share of the queue
+type QueueMemberLoad struct {
+	AgentID    string `json:"agent_id"`
+	Name       string `json:"name"`
+	Available  bool   `json:"available"`
+	InProgress int    `json:"in_progress"` // tickets from this queue the agent is working on
+}
+
+// QueueFilter represents filters for listing queues
+type QueueFilter struct {
+	TeamID *string `json:"team_id,omitempty"`
+}
+
+// Team and queue errors
+var (
+	ErrTeamNotFound         = NewDomainError("team not found")
+	ErrQueueNotFound        = NewDomainError("queue not found")
+	ErrEmptyTeamID          = NewDomainError("team ID cannot be empty")
+	ErrEmptyTeamName        = NewDomainError("team name cannot be empty")
+	ErrEmptyQueueName       = NewDomainError("queue name cannot be empty")
+	ErrAlreadyTeamMember    = NewDomainError("agent is already a member of the team")
+	ErrNotTeamMember        = NewDomainError("agent is not a member of the team")
+	ErrTicketNotQueued      = NewDomainError("ticket is not in a queue")
+	ErrTicketAlreadyClaimed = NewDomainError("ticket has already been claimed")
+)
+
+func generateTeamID() string {
+	return "team_" + strconv.FormatInt(time.Now().UnixNano(), 10)
+}
+
+func generateQueueID() string {
+	return "queue_" + strconv.FormatInt(time.Now().UnixNano(), 10)
+}
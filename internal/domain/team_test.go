@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTeam_Membership(t *testing.T) {
+	if _, err := NewTeam("  ", ""); !errors.Is(err, ErrEmptyTeamName) {
+		t.Fatalf("Expected ErrEmptyTeamName, got %v", err)
+	}
+
+	team, err := NewTeam("Network", "Switches, Wi-Fi and VPN")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := team.AddMember("agent-1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := team.AddMember("agent-1"); !errors.Is(err, ErrAlreadyTeamMember) {
+		t.Errorf("Expected ErrAlreadyTeamMember, got %v", err)
+	}
+	if !team.HasMember("agent-1") || team.HasMember("agent-2") {
+		t.Errorf("Expected only agent-1 as member, got %v", team.Members)
+	}
+
+	if err := team.RemoveMember("agent-2"); !errors.Is(err, ErrNotTeamMember) {
+		t.Errorf("Expected ErrNotTeamMember, got %v", err)
+	}
+	if err := team.RemoveMember("agent-1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(team.Members) != 0 {
+		t.Errorf("Expected no members, got %v", team.Members)
+	}
+}
+
+func TestTicket_QueueAndClaim(t *testing.T) {
+	ticket := &Ticket{ID: "ticket-1", Status: TicketStatusOpen}
+	if err := ticket.CanBeClaimed(); !errors.Is(err, ErrTicketNotQueued) {
+		t.Errorf("Expected ErrTicketNotQueued, got %v", err)
+	}
+
+	agent := "agent-1"
+	ticket.AssignedTo = &agent
+	ticket.Status = TicketStatusInProgress
+
+	// Moving a ticket being worked on releases it back to the queue
+	if err := ticket.MoveToQueue("queue-1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ticket.AssignedTo != nil || ticket.Status != TicketStatusOpen || *ticket.QueueID != "queue-1" {
+		t.Errorf("Expected an unassigned OPEN ticket in queue-1, got %v %s", ticket.AssignedTo, ticket.Status)
+	}
+	if err := ticket.CanBeClaimed(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	if err := ticket.Assign("agent-2"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := ticket.CanBeClaimed(); !errors.Is(err, ErrTicketAlreadyClaimed) {
+		t.Errorf("Expected ErrTicketAlreadyClaimed, got %v", err)
+	}
+	if ticket.QueueID == nil {
+		t.Error("Expected the claimed ticket to stay in its queue")
+	}
+
+	closed := &Ticket{ID: "ticket-2", Status: TicketStatusClosed}
+	if err := closed.MoveToQueue("queue-1"); !errors.Is(err, ErrTicketClosed) {
+		t.Errorf("Expected ErrTicketClosed, got %v", err)
+	}
+}
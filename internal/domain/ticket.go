@@ -10,6 +10,7 @@ type TicketStatus string
 const (
 	TicketStatusOpen       TicketStatus = "OPEN"
 	TicketStatusInProgress TicketStatus = "IN_PROGRESS"
+	TicketStatusPending    TicketStatus = "PENDING" // waiting on the requester
 	TicketStatusResolved   TicketStatus = "RESOLVED"
 	TicketStatusClosed     TicketStatus = "CLOSED"
 )
@@ -25,6 +26,15 @@ const (
 	TicketCategoryOther    TicketCategory = "OTHER"
 )
 
+// IsValid checks if the category is a known ticket category
+func (c TicketCategory) IsValid() bool {
+	switch c {
+	case TicketCategoryNetwork, TicketCategorySoftware, TicketCategoryHardware, TicketCategoryAccount, TicketCategoryOther:
+		return true
+	}
+	return false
+}
+
 // TicketPriority represents the priority of a ticket
 type TicketPriority string
 
@@ -37,8 +47,18 @@ const (
 
 // AIInsight represents AI-generated insights for a ticket
 type AIInsight struct {
-	Text       string  `json:"text"`
-	Confidence float64 `json:"confidence"`
+	Text       string            `json:"text"`
+	Confidence float64           `json:"confidence"`
+	Source     string            `json:"source,omitempty"` // provider that produced the insight
+	Model      string            `json:"model,omitempty"`
+	Citations  []InsightCitation `json:"citations,omitempty"`
+}
+
+// InsightCitation points to the knowledge base chunk an insight is grounded in
+type InsightCitation struct {
+	EntryID    string  `json:"entry_id"`
+	ChunkIndex int     `json:"chunk_index"`
+	Score      float64 `json:"score"`
 }
 
 // Ticket represents an IT support ticket
@@ -52,6 +72,8 @@ type Ticket struct {
 	CreatedBy   string          `json:"created_by"`
 	AssignedTo  *string         `json:"assigned_to,omitempty"`
 	AIInsight   *AIInsight      `json:"ai_insight,omitempty"`
+	IncidentID  *string         `json:"incident_id,omitempty"` // incident this ticket is linked to
+	QueueID     *string         `json:"queue_id,omitempty"` // queue the ticket waits in until an agent claims it
 	CreatedAt   time.Time       `json:"created_at"`
 	UpdatedAt   time.Time       `json:"updated_at"`
 }
@@ -72,13 +94,16 @@ func NewTicket(title, description string, category TicketCategory, priority Tick
 	}
 }
 
-// Assign assigns the ticket to an admin
+// Assign assigns the ticket to an admin. A pending ticket stays on hold until the requester
+// replies, and then resumes with the new assignee.
 func (t *Ticket) Assign(adminID string) error {
 	if t.Status == TicketStatusClosed {
 		return ErrTicketClosed
 	}
 	t.AssignedTo = &adminID
-	t.Status = TicketStatusInProgress
+	if t.Status != TicketStatusPending {
+		t.Status = TicketStatusInProgress
+	}
 	t.UpdatedAt = time.Now()
 	return nil
 }
@@ -93,6 +118,33 @@ func (t *Ticket) Resolve() error {
 	return nil
 }
 
+// MarkPending puts the ticket on hold while waiting on the requester
+func (t *Ticket) MarkPending() error {
+	switch t.Status {
+	case TicketStatusClosed:
+		return ErrTicketClosed
+	case TicketStatusOpen, TicketStatusInProgress:
+	default:
+		return ErrInvalidStatus
+	}
+	t.Status = TicketStatusPending
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// Resume takes a pending ticket off hold, back to its assignee if it has one
+func (t *Ticket) Resume() error {
+	if t.Status != TicketStatusPending {
+		return ErrInvalidStatus
+	}
+	t.Status = TicketStatusOpen
+	if t.AssignedTo != nil {
+		t.Status = TicketStatusInProgress
+	}
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
 // Close closes the ticket
 func (t *Ticket) Close() error {
 	if t.Status != TicketStatusResolved {
@@ -103,6 +155,12 @@ func (t *Ticket) Close() error {
 	return nil
 }
 
+// IsActive reports whether the ticket is still being worked on, including while it waits on the
+// requester
+func (t *Ticket) IsActive() bool {
+	return t.Status == TicketStatusOpen || t.Status == TicketStatusInProgress || t.Status == TicketStatusPending
+}
+
 // SetAIInsight sets the AI insight for the ticket
 func (t *Ticket) SetAIInsight(text string, confidence float64) {
 	t.AIInsight = &AIInsight{
@@ -115,10 +173,15 @@ func (t *Ticket) SetAIInsight(text string, confidence float64) {
 // TicketFilter represents filters for listing tickets
 type TicketFilter struct {
 	Status     *TicketStatus     `json:"status,omitempty"`
+	Statuses   []TicketStatus    `json:"statuses,omitempty"` // any of these statuses
 	Category   *TicketCategory   `json:"category,omitempty"`
 	Priority   *TicketPriority   `json:"priority,omitempty"`
 	CreatedBy  *string           `json:"created_by,omitempty"`
 	AssignedTo *string           `json:"assigned_to,omitempty"`
+	IncidentID *string           `json:"incident_id,omitempty"`
+	QueueID    *string           `json:"queue_id,omitempty"`
+	TeamID     *string           `json:"team_id,omitempty"` // tickets in any of the team's queues
+	UpdatedBefore *time.Time     `json:"updated_before,omitempty"`
 	Limit      int               `json:"limit"`
 	Offset     int               `json:"offset"`
 }
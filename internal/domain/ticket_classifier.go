@@ -0,0 +1,263 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// MinClassifierDocuments is the number of training tickets needed before the classifier predicts
+const MinClassifierDocuments = 20
+
+// NaiveBayes is a multinomial naive Bayes text classifier with Laplace smoothing.
+// It trains incrementally: each document only updates counts.
+type NaiveBayes struct {
+	Documents   int                       `json:"documents"`
+	LabelDocs   map[string]int            `json:"label_docs"`   // label -> training documents
+	TokenCounts map[string]map[string]int `json:"token_counts"` // label -> token -> occurrences
+	LabelTokens map[string]int            `json:"label_tokens"` // label -> total tokens
+	Vocabulary  map[string]int            `json:"vocabulary"`   // token -> occurrences across labels
+}
+
+// NewNaiveBayes creates an untrained classifier
+func NewNaiveBayes() *NaiveBayes {
+	return &NaiveBayes{
+		LabelDocs:   make(map[string]int),
+		TokenCounts: make(map[string]map[string]int),
+		LabelTokens: make(map[string]int),
+		Vocabulary:  make(map[string]int),
+	}
+}
+
+// Learn adds one labelled document to the model
+func (nb *NaiveBayes) Learn(tokens []string, label string) {
+	if label == "" || len(tokens) == 0 {
+		return
+	}
+
+	counts := nb.TokenCounts[label]
+	if counts == nil {
+		counts = make(map[string]int)
+		nb.TokenCounts[label] = counts
+	}
+
+	for _, token := range tokens {
+		counts[token]++
+		nb.Vocabulary[token]++
+	}
+
+	nb.LabelTokens[label] += len(tokens)
+	nb.LabelDocs[label]++
+	nb.Documents++
+}
+
+// Predict returns the most probable label and its posterior probability
+func (nb *NaiveBayes) Predict(tokens []string) (string, float64) {
+	if nb.Documents == 0 || len(nb.LabelDocs) == 0 {
+		return "", 0
+	}
+
+	labels := make([]string, 0, len(nb.LabelDocs))
+	for label := range nb.LabelDocs {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels) // deterministic tie-breaking
+
+	vocabulary := float64(len(nb.Vocabulary))
+	scores := make([]float64, len(labels))
+
+	for i, label := range labels {
+		score := math.Log(float64(nb.LabelDocs[label]) / float64(nb.Documents))
+		denominator := float64(nb.LabelTokens[label]) + vocabulary
+
+		for _, token := range tokens {
+			if _, known := nb.Vocabulary[token]; !known {
+				continue
+			}
+			score += math.Log((float64(nb.TokenCounts[label][token]) + 1) / denominator)
+		}
+
+		scores[i] = score
+	}
+
+	best := 0
+	for i := range scores {
+		if scores[i] > scores[best] {
+			best = i
+		}
+	}
+
+	// Normalise log scores into a posterior probability
+	var total float64
+	for _, score := range scores {
+		total += math.Exp(score - scores[best])
+	}
+
+	return labels[best], 1 / total
+}
+
+// TicketClassifier predicts ticket category and priority from the description
+type TicketClassifier struct {
+	Category  *NaiveBayes `json:"category"`
+	Priority  *NaiveBayes `json:"priority"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// NewTicketClassifier creates an untrained ticket classifier
+func NewTicketClassifier() *TicketClassifier {
+	return &TicketClassifier{
+		Category:  NewNaiveBayes(),
+		Priority:  NewNaiveBayes(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+// Learn trains the classifier on a resolved ticket
+func (c *TicketClassifier) Learn(text string, category TicketCategory, priority TicketPriority) {
+	tokens := ClassifierTokens(text)
+	c.Category.Learn(tokens, string(category))
+	c.Priority.Learn(tokens, string(priority))
+	c.UpdatedAt = time.Now()
+}
+
+// Predict returns the predicted category and priority with their probabilities.
+// Labels are empty until the classifier has seen MinClassifierDocuments tickets.
+func (c *TicketClassifier) Predict(text string) (TicketCategory, float64, TicketPriority, float64) {
+	if !c.Ready() {
+		return "", 0, "", 0
+	}
+
+	tokens := ClassifierTokens(text)
+	category, categoryConfidence := c.Category.Predict(tokens)
+	priority, priorityConfidence := c.Priority.Predict(tokens)
+
+	return TicketCategory(category), categoryConfidence, TicketPriority(priority), priorityConfidence
+}
+
+// Ready reports whether the classifier has enough training data to predict
+func (c *TicketClassifier) Ready() bool {
+	return c.Category.Documents >= MinClassifierDocuments
+}
+
+// Documents returns the number of tickets the classifier was trained on
+func (c *TicketClassifier) Documents() int {
+	return c.Category.Documents
+}
+
+// ClassifierExample is a labelled ticket used for training or evaluation
+type ClassifierExample struct {
+	Text     string
+	Category TicketCategory
+	Priority TicketPriority
+}
+
+// ClassifierEvaluation reports classifier accuracy on a held-out set
+type ClassifierEvaluation struct {
+	TrainSize               int                `json:"train_size"`
+	TestSize                int                `json:"test_size"`
+	CategoryAccuracy        float64            `json:"category_accuracy"`
+	PriorityAccuracy        float64            `json:"priority_accuracy"`
+	CategoryBaseline        float64            `json:"category_baseline"` // accuracy of always predicting the most common label
+	PriorityBaseline        float64            `json:"priority_baseline"`
+	CategoryAccuracyByLabel map[string]float64 `json:"category_accuracy_by_label"`
+	PriorityAccuracyByLabel map[string]float64 `json:"priority_accuracy_by_label"`
+	EvaluatedAt             time.Time          `json:"evaluated_at"`
+}
+
+// EvaluateTicketClassifier trains a fresh classifier on train and measures its accuracy on test
+func EvaluateTicketClassifier(train, test []ClassifierExample) *ClassifierEvaluation {
+	classifier := NewTicketClassifier()
+	for _, example := range train {
+		classifier.Learn(example.Text, example.Category, example.Priority)
+	}
+
+	evaluation := &ClassifierEvaluation{
+		TrainSize:               len(train),
+		TestSize:                len(test),
+		CategoryAccuracyByLabel: make(map[string]float64),
+		PriorityAccuracyByLabel: make(map[string]float64),
+		EvaluatedAt:             time.Now(),
+	}
+
+	if len(test) == 0 {
+		return evaluation
+	}
+
+	categoryTotals, categoryHits := make(map[string]int), make(map[string]int)
+	priorityTotals, priorityHits := make(map[string]int), make(map[string]int)
+	var categoryCorrect, priorityCorrect int
+
+	for _, example := range test {
+		tokens := ClassifierTokens(example.Text)
+		category, _ := classifier.Category.Predict(tokens)
+		priority, _ := classifier.Priority.Predict(tokens)
+
+		categoryTotals[string(example.Category)]++
+		if category == string(example.Category) {
+			categoryCorrect++
+			categoryHits[string(example.Category)]++
+		}
+
+		priorityTotals[string(example.Priority)]++
+		if priority == string(example.Priority) {
+			priorityCorrect++
+			priorityHits[string(example.Priority)]++
+		}
+	}
+
+	total := float64(len(test))
+	evaluation.CategoryAccuracy = float64(categoryCorrect) / total
+	evaluation.PriorityAccuracy = float64(priorityCorrect) / total
+	evaluation.CategoryBaseline = majorityAccuracy(classifier.Category, categoryTotals, total)
+	evaluation.PriorityBaseline = majorityAccuracy(classifier.Priority, priorityTotals, total)
+
+	for label, count := range categoryTotals {
+		evaluation.CategoryAccuracyByLabel[label] = float64(categoryHits[label]) / float64(count)
+	}
+	for label, count := range priorityTotals {
+		evaluation.PriorityAccuracyByLabel[label] = float64(priorityHits[label]) / float64(count)
+	}
+
+	return evaluation
+}
+
+// majorityAccuracy is the test accuracy of always predicting the most common training label
+func majorityAccuracy(model *NaiveBayes, testTotals map[string]int, total float64) float64 {
+	majority, most := "", -1
+	for label, count := range model.LabelDocs {
+		if count > most || (count == most && label < majority) {
+			majority, most = label, count
+		}
+	}
+	return float64(testTotals[majority]) / total
+}
+
+// classifierStopWords are frequent words that carry no signal about category or priority
+var classifierStopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "are": true, "but": true, "you": true,
+	"all": true, "can": true, "was": true, "our": true, "has": true, "have": true, "with": true,
+	"this": true, "that": true, "from": true, "they": true, "when": true, "what": true, "there": true,
+	"been": true, "will": true, "would": true, "could": true, "please": true, "into": true, "its": true,
+	"yang": true, "dan": true, "di": true, "ke": true, "dari": true, "ini": true, "itu": true,
+	"saya": true, "untuk": true, "dengan": true, "ada": true, "bisa": true,
+}
+
+// ClassifierTokens lowercases text and splits it into word tokens, dropping stop words and
+// single characters. Negations such as "not" and "tidak" are kept as they signal broken things.
+func ClassifierTokens(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if len([]rune(field)) < 2 || classifierStopWords[field] {
+			continue
+		}
+		tokens = append(tokens, field)
+	}
+
+	return tokens
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+// classifierExamples generates labelled tickets with vocabulary typical of each category
+func classifierExamples(n int) []ClassifierExample {
+	templates := []ClassifierExample{
+		{Text: "wifi keeps disconnecting on floor %d, network unstable", Category: TicketCategoryNetwork, Priority: TicketPriorityMedium},
+		{Text: "vpn outage, entire office %d cannot reach network", Category: TicketCategoryNetwork, Priority: TicketPriorityCritical},
+		{Text: "excel crashes when opening spreadsheet %d", Category: TicketCategorySoftware, Priority: TicketPriorityHigh},
+		{Text: "please install application update version %d", Category: TicketCategorySoftware, Priority: TicketPriorityLow},
+		{Text: "laptop screen flickering, keyboard broken on device %d", Category: TicketCategoryHardware, Priority: TicketPriorityMedium},
+		{Text: "password reset needed, account %d locked after login attempts", Category: TicketCategoryAccount, Priority: TicketPriorityHigh},
+	}
+
+	examples := make([]ClassifierExample, n)
+	for i := range examples {
+		template := templates[i%len(templates)]
+		examples[i] = ClassifierExample{
+			Text:     fmt.Sprintf(template.Text, i),
+			Category: template.Category,
+			Priority: template.Priority,
+		}
+	}
+	return examples
+}
+
+func TestTicketClassifierPredict(t *testing.T) {
+	classifier := NewTicketClassifier()
+
+	if category, _, _, _ := classifier.Predict("wifi down"); category != "" {
+		t.Errorf("Expected no prediction from an untrained classifier, got %s", category)
+	}
+
+	for _, example := range classifierExamples(MinClassifierDocuments) {
+		classifier.Learn(example.Text, example.Category, example.Priority)
+	}
+
+	category, categoryConfidence, priority, priorityConfidence := classifier.Predict("The WiFi network in the meeting room keeps disconnecting")
+	if category != TicketCategoryNetwork {
+		t.Errorf("Expected category %s, got %s", TicketCategoryNetwork, category)
+	}
+	if priority != TicketPriorityMedium {
+		t.Errorf("Expected priority %s, got %s", TicketPriorityMedium, priority)
+	}
+	if categoryConfidence <= 0.5 || categoryConfidence > 1 || priorityConfidence <= 0 || priorityConfidence > 1 {
+		t.Errorf("Expected probabilities in (0.5, 1], got %.2f and %.2f", categoryConfidence, priorityConfidence)
+	}
+
+	// The model survives a JSON round trip, as it does when persisted
+	data, err := json.Marshal(classifier)
+	if err != nil {
+		t.Fatalf("Failed to encode classifier: %v", err)
+	}
+
+	restored := NewTicketClassifier()
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("Failed to decode classifier: %v", err)
+	}
+
+	if restored.Documents() != classifier.Documents() {
+		t.Errorf("Expected %d documents after round trip, got %d", classifier.Documents(), restored.Documents())
+	}
+	if again, _, _, _ := restored.Predict("excel crashes on startup"); again != TicketCategorySoftware {
+		t.Errorf("Expected restored classifier to predict %s, got %s", TicketCategorySoftware, again)
+	}
+}
+
+func TestEvaluateTicketClassifier(t *testing.T) {
+	examples := classifierExamples(120)
+	evaluation := EvaluateTicketClassifier(examples[:100], examples[100:])
+
+	if evaluation.TrainSize != 100 || evaluation.TestSize != 20 {
+		t.Errorf("Expected 100/20 split, got %d/%d", evaluation.TrainSize, evaluation.TestSize)
+	}
+	if evaluation.CategoryAccuracy < 0.9 {
+		t.Errorf("Expected category accuracy of at least 0.9, got %.2f", evaluation.CategoryAccuracy)
+	}
+	if evaluation.CategoryAccuracy <= evaluation.CategoryBaseline {
+		t.Errorf("Expected accuracy %.2f to beat the majority baseline %.2f", evaluation.CategoryAccuracy, evaluation.CategoryBaseline)
+	}
+	if evaluation.PriorityAccuracy < 0.9 {
+		t.Errorf("Expected priority accuracy of at least 0.9, got %.2f", evaluation.PriorityAccuracy)
+	}
+}
@@ -0,0 +1,15 @@
+package domain
+
+// SimilarTicket is a ticket whose embedding is close to another ticket's
+type SimilarTicket struct {
+	Ticket *Ticket `json:"ticket"`
+	Score  float64 `json:"score"`
+}
+
+// SimilarTicketFilter represents filters for ticket similarity search
+type SimilarTicketFilter struct {
+	Statuses  []TicketStatus `json:"statuses,omitempty"`
+	ExcludeID string         `json:"exclude_id,omitempty"`
+	MinScore  float64        `json:"min_score"`
+	Limit     int            `json:"limit"`
+}
@@ -117,6 +117,74 @@ func TestTicket_CloseUnresolvedTicket(t *testing.T) {
 	}
 }
 
+func TestTicket_PendingAndResume(t *testing.T) {
+	ticket := NewTicket("Test", "Description", TicketCategorySoftware, TicketPriorityMedium, "user1")
+	if err := ticket.Resume(); err != ErrInvalidStatus {
+		t.Errorf("Expected ErrInvalidStatus resuming an open ticket, got %v", err)
+	}
+
+	if err := ticket.MarkPending(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := ticket.Resume(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ticket.Status != TicketStatusOpen {
+		t.Errorf("Expected an unassigned ticket to resume as %s, got %s", TicketStatusOpen, ticket.Status)
+	}
+
+	ticket.Assign("admin1")
+	ticket.MarkPending()
+	if err := ticket.Resume(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ticket.Status != TicketStatusInProgress {
+		t.Errorf("Expected an assigned ticket to resume as %s, got %s", TicketStatusInProgress, ticket.Status)
+	}
+
+	ticket.Resolve()
+	if err := ticket.MarkPending(); err != ErrInvalidStatus {
+		t.Errorf("Expected ErrInvalidStatus for a resolved ticket, got %v", err)
+	}
+}
+
+func TestTicket_AssignPendingTicket(t *testing.T) {
+	ticket := NewTicket("Test", "Description", TicketCategorySoftware, TicketPriorityMedium, "user1")
+	ticket.MarkPending()
+
+	if err := ticket.Assign("admin1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ticket.Status != TicketStatusPending || ticket.AssignedTo == nil || *ticket.AssignedTo != "admin1" {
+		t.Errorf("Expected the ticket to stay pending with the new assignee, got %s", ticket.Status)
+	}
+
+	ticket.Resume()
+	if ticket.Status != TicketStatusInProgress {
+		t.Errorf("Expected the ticket to resume with its assignee as %s, got %s", TicketStatusInProgress, ticket.Status)
+	}
+}
+
+func TestTicket_IsActive(t *testing.T) {
+	tests := []struct {
+		status TicketStatus
+		want   bool
+	}{
+		{TicketStatusOpen, true},
+		{TicketStatusInProgress, true},
+		{TicketStatusPending, true},
+		{TicketStatusResolved, false},
+		{TicketStatusClosed, false},
+	}
+
+	for _, tt := range tests {
+		ticket := &Ticket{Status: tt.status}
+		if got := ticket.IsActive(); got != tt.want {
+			t.Errorf("Expected IsActive %v for %s, got %v", tt.want, tt.status, got)
+		}
+	}
+}
+
 func TestTicket_SetAIInsight(t *testing.T) {
 	ticket := NewTicket("Test", "Description", TicketCategoryNetwork, TicketPriorityHigh, "user1")
 	insightText := "Try restarting your router"
@@ -158,6 +226,7 @@ func TestTicketStatusValues(t *testing.T) {
 	}{
 		{TicketStatusOpen, "OPEN"},
 		{TicketStatusInProgress, "IN_PROGRESS"},
+		{TicketStatusPending, "PENDING"},
 		{TicketStatusResolved, "RESOLVED"},
 		{TicketStatusClosed, "CLOSED"},
 	}
@@ -0,0 +1,360 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers sent with every webhook delivery
+const (
+	WebhookEventHeader     = "X-Fixora-Event"
+	WebhookDeliveryHeader  = "X-Fixora-Delivery"
+	WebhookTimestampHeader = "X-Fixora-Timestamp" // Unix seconds, covered by the signature
+	WebhookSignatureHeader = "X-Fixora-Signature" // "v1=" + hex HMAC-SHA256 of "<timestamp>.<body>"
+)
+
+// MinWebhookSecretLength is the shortest secret accepted for signing deliveries
+const MinWebhookSecretLength = 16
+
+// MaxWebhookResponseBody is how much of an endpoint's response is kept in the delivery log
+const MaxWebhookResponseBody = 2048
+
+// WebhookSubscription sends the events it subscribes to to an external endpoint. Subscriptions
+// are disabled automatically when deliveries keep failing after all their retries.
+type WebhookSubscription struct {
+	ID                  string    `json:"id"`
+	Name                string    `json:"name"`
+	URL                 string    `json:"url"`
+	Secret              string    `json:"-"`
+	EventTypes          []string  `json:"event_types"`
+	Enabled             bool      `json:"enabled"`
+	ConsecutiveFailures int       `json:"consecutive_failures"` // deliveries that failed in a row
+	DisabledReason      string    `json:"disabled_reason,omitempty"`
+	CreatedBy           string    `json:"created_by"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+// NewWebhookSubscription creates an enabled subscription. A random secret is generated when
+// none is given.
+func NewWebhookSubscription(name, endpoint, secret string, eventTypes []string, createdBy string) (*WebhookSubscription, error) {
+	if secret == "" {
+		generated, err := GenerateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+
+	now := time.Now()
+	subscription := &WebhookSubscription{
+		ID:         generateWebhookSubscriptionID(),
+		Name:       strings.TrimSpace(name),
+		URL:        strings.TrimSpace(endpoint),
+		Secret:     secret,
+		EventTypes: eventTypes,
+		Enabled:    true,
+		CreatedBy:  createdBy,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if err := subscription.Validate(); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// Validate checks the subscription's endpoint, secret and events. Whether the event types exist
+// is checked by the caller, which knows the published events.
+func (s *WebhookSubscription) Validate() error {
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidWebhookURL
+	}
+	if len(s.Secret) < MinWebhookSecretLength {
+		return ErrWebhookSecretTooShort
+	}
+	if len(s.EventTypes) == 0 {
+		return ErrNoWebhookEvents
+	}
+	return nil
+}
+
+// CheckAddress rejects an endpoint naming localhost or an internal address outside the allowed
+// networks. Hostnames are checked again when each delivery connects, since they can resolve to
+// anything.
+func (s *WebhookSubscription) CheckAddress(allowed []*net.IPNet) error {
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		return ErrInvalidWebhookURL
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		host = "127.0.0.1"
+	}
+	if ip := net.ParseIP(host); ip != nil && !WebhookAddressAllowed(ip, allowed) {
+		return ErrWebhookAddressNotAllowed
+	}
+	return nil
+}
+
+// WebhookAddressAllowed reports whether deliveries may connect to ip. Loopback, private,
+// link-local (including cloud metadata at 169.254.169.254), unspecified and multicast addresses
+// are internal and allowed only inside one of the allowed networks.
+func WebhookAddressAllowed(ip net.IP, allowed []*net.IPNet) bool {
+	internal := ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified()
+	if !internal {
+		return true
+	}
+
+	for _, network := range allowed {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseWebhookNetworks parses the internal networks webhooks may reach, given as CIDRs or single
+// addresses
+func ParseWebhookNetworks(values []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(values))
+	for _, value := range values {
+		if !strings.Contains(value, "/") {
+			ip := net.ParseIP(value)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid webhook network: %s", value)
+			}
+			bits := 8 * len(ip.To16())
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid webhook network: %s", value)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// Subscribes reports whether the subscription wants events of the type
+func (s *WebhookSubscription) Subscribes(eventType string) bool {
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Enable re-enables the subscription and forgets its failures
+func (s *WebhookSubscription) Enable() {
+	s.Enabled = true
+	s.ConsecutiveFailures = 0
+	s.DisabledReason = ""
+	s.UpdatedAt = time.Now()
+}
+
+// Disable stops deliveries to the subscription
+func (s *WebhookSubscription) Disable(reason string) {
+	s.Enabled = false
+	s.DisabledReason = reason
+	s.UpdatedAt = time.Now()
+}
+
+// GenerateWebhookSecret creates a random signing secret
+func GenerateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+// SignWebhookPayload signs a delivery body sent at timestamp. Receivers recompute the signature
+// from the timestamp header and the raw body, and reject old timestamps to stop replays.
+func SignWebhookPayload(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature checks a delivery's signature and that its timestamp is within
+// tolerance of now
+func VerifyWebhookSignature(secret, signature, timestamp string, body []byte, now time.Time, tolerance time.Duration) error {
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidWebhookSignature
+	}
+
+	sent := time.Unix(unix, 0)
+	if now.Sub(sent) > tolerance || sent.Sub(now) > tolerance {
+		return ErrInvalidWebhookSignature
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(SignWebhookPayload(secret, sent, body))) {
+		return ErrInvalidWebhookSignature
+	}
+	return nil
+}
+
+// WebhookDeliveryStatus represents the state of a delivery
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending" // waiting for its first attempt or a retry
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed" // out of retries
+)
+
+// WebhookDelivery is one event sent to one subscription, with the outcome of its latest attempt
+type WebhookDelivery struct {
+	ID             string                `json:"id"`
+	SubscriptionID string                `json:"subscription_id"`
+	EventID        string                `json:"event_id"`
+	EventType      string                `json:"event_type"`
+	Payload        json.RawMessage       `json:"payload"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	ResponseStatus int                   `json:"response_status,omitempty"` // HTTP status of the last attempt
+	ResponseBody   string                `json:"response_body,omitempty"`   // truncated to MaxWebhookResponseBody
+	Error          string                `json:"error,omitempty"`
+	DurationMS     int64                 `json:"duration_ms"`
+	ReplayOf       *string               `json:"replay_of,omitempty"` // the delivery this one replays
+	NextAttemptAt  *time.Time            `json:"next_attempt_at,omitempty"`
+	LastAttemptAt  *time.Time            `json:"last_attempt_at,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+}
+
+// NewWebhookDelivery creates a pending delivery of an event to a subscription
+func NewWebhookDelivery(subscriptionID, eventID, eventType string, payload []byte) *WebhookDelivery {
+	now := time.Now()
+	return &WebhookDelivery{
+		ID:             generateWebhookDeliveryID(),
+		SubscriptionID: subscriptionID,
+		EventID:        eventID,
+		EventType:      eventType,
+		Payload:        payload,
+		Status:         WebhookDeliveryPending,
+		NextAttemptAt:  &now,
+		CreatedAt:      now,
+	}
+}
+
+// Replay creates a new delivery of the same payload to the same subscription
+func (d *WebhookDelivery) Replay() *WebhookDelivery {
+	replay := NewWebhookDelivery(d.SubscriptionID, d.EventID, d.EventType, d.Payload)
+	replayOf := d.ID
+	replay.ReplayOf = &replayOf
+	return replay
+}
+
+// RecordSuccess records an attempt the endpoint accepted
+func (d *WebhookDelivery) RecordSuccess(statusCode int, body string, duration time.Duration, at time.Time) {
+	d.recordAttempt(statusCode, body, "", duration, at)
+	d.Status = WebhookDeliverySucceeded
+	d.NextAttemptAt = nil
+}
+
+// RecordFailure records a failed attempt and schedules a retry, or fails the delivery after
+// maxAttempts attempts
+func (d *WebhookDelivery) RecordFailure(statusCode int, body, errMsg string, duration time.Duration, at time.Time, maxAttempts int) {
+	d.recordAttempt(statusCode, body, errMsg, duration, at)
+
+	if d.Attempts >= maxAttempts {
+		d.Status = WebhookDeliveryFailed
+		d.NextAttemptAt = nil
+		return
+	}
+
+	next := at.Add(WebhookRetryDelay(d.Attempts))
+	d.Status = WebhookDeliveryPending
+	d.NextAttemptAt = &next
+}
+
+// Abandon fails the delivery without attempting it again
+func (d *WebhookDelivery) Abandon(reason string) {
+	d.Status = WebhookDeliveryFailed
+	d.Error = reason
+	d.NextAttemptAt = nil
+}
+
+func (d *WebhookDelivery) recordAttempt(statusCode int, body, errMsg string, duration time.Duration, at time.Time) {
+	if len(body) > MaxWebhookResponseBody {
+		body = body[:MaxWebhookResponseBody]
+	}
+
+	d.Attempts++
+	d.ResponseStatus = statusCode
+	d.ResponseBody = body
+	d.Error = errMsg
+	d.DurationMS = duration.Milliseconds()
+	d.LastAttemptAt = &at
+}
+
+// WebhookRetryDelay returns how long to wait after the given number of failed attempts: 30
+// seconds, doubling each time, at most an hour
+func WebhookRetryDelay(attempts int) time.Duration {
+	delay := 30 * time.Second
+	for i := 1; i < attempts && delay < time.Hour; i++ {
+		delay *= 2
+	}
+	if delay > time.Hour {
+		delay = time.Hour
+	}
+	return delay
+}
+
+// WebhookSubscriptionFilter represents filters for listing subscriptions
+type WebhookSubscriptionFilter struct {
+	Enabled   *bool   `json:"enabled,omitempty"`
+	EventType *string `json:"event_type,omitempty"`
+}
+
+// WebhookDeliveryFilter represents filters for listing the delivery log
+type WebhookDeliveryFilter struct {
+	SubscriptionID *string                `json:"subscription_id,omitempty"`
+	EventID        *string                `json:"event_id,omitempty"`
+	Status         *WebhookDeliveryStatus `json:"status,omitempty"`
+	Limit          int                    `json:"limit"`
+	Offset         int                    `json:"offset"`
+}
+
+// Webhook errors
+var (
+	ErrWebhookSubscriptionNotFound = NewDomainError("webhook subscription not found")
+	ErrWebhookDeliveryNotFound     = NewDomainError("webhook delivery not found")
+	ErrInvalidWebhookURL           = NewDomainError("webhook URL must be an absolute http or https URL")
+	ErrWebhookAddressNotAllowed    = NewDomainError("webhook URL must not point at an internal address")
+	ErrWebhookSecretTooShort       = NewDomainError("webhook secret must be at least 16 characters")
+	ErrNoWebhookEvents             = NewDomainError("webhook subscription needs at least one event type")
+	ErrInvalidWebhookEvent         = NewDomainError("unknown webhook event type")
+	ErrInvalidWebhookSignature     = NewDomainError("invalid webhook signature")
+)
+
+func generateWebhookSubscriptionID() string {
+	return "webhook_" + strconv.FormatInt(time.Now().UnixNano(), 10)
+}
+
+func generateWebhookDeliveryID() string {
+	return "webhook_delivery_" + strconv.FormatInt(time.Now().UnixNano(), 10)
+}
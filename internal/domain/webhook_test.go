@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewWebhookSubscription(t *testing.T) {
+	events := []string{"ticket_created"}
+
+	tests := []struct {
+		name     string
+		endpoint string
+		secret   string
+		events   []string
+		wantErr  error
+	}{
+		{"valid", "https://cmdb.example.com/hooks/fixora", "0123456789abcdef", events, nil},
+		{"generated secret", "http://bot.internal:8080/events", "", events, nil},
+		{"relative URL", "/hooks/fixora", "", events, ErrInvalidWebhookURL},
+		{"unsupported scheme", "ftp://cmdb.example.com/hooks", "", events, ErrInvalidWebhookURL},
+		{"short secret", "https://cmdb.example.com/hooks", "secret", events, ErrWebhookSecretTooShort},
+		{"no events", "https://cmdb.example.com/hooks", "", nil, ErrNoWebhookEvents},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscription, err := NewWebhookSubscription("CMDB", tt.endpoint, tt.secret, tt.events, "admin-1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Expected %v, got %v", tt.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if !subscription.Enabled || len(subscription.Secret) < MinWebhookSecretLength {
+				t.Errorf("Expected an enabled subscription with a secret, got %+v", subscription)
+			}
+			if !subscription.Subscribes("ticket_created") || subscription.Subscribes("ticket_resolved") {
+				t.Errorf("Unexpected event subscriptions %v", subscription.EventTypes)
+			}
+		})
+	}
+}
+
+func TestWebhookSubscription_CheckAddress(t *testing.T) {
+	allowed, err := ParseWebhookNetworks([]string{"10.20.0.0/16", "10.0.5.7"})
+	if err != nil {
+		t.Fatalf("Failed to parse networks: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		endpoint string
+		wantErr  error
+	}{
+		{"public hostname", "https://cmdb.example.com/hooks", nil},
+		{"public address", "https://93.184.216.34/hooks", nil},
+		{"allowed network", "http://10.20.3.4:8080/hooks", nil},
+		{"allowed address", "http://10.0.5.7/hooks", nil},
+		{"other private address", "http://10.0.5.8/hooks", ErrWebhookAddressNotAllowed},
+		{"loopback", "http://127.0.0.1:8080/hooks", ErrWebhookAddressNotAllowed},
+		{"localhost", "http://localhost:8080/hooks", ErrWebhookAddressNotAllowed},
+		{"metadata service", "http://169.254.169.254/latest/meta-data", ErrWebhookAddressNotAllowed},
+		{"IPv6 loopback", "http://[::1]/hooks", ErrWebhookAddressNotAllowed},
+		{"unspecified", "http://0.0.0.0/hooks", ErrWebhookAddressNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscription := &WebhookSubscription{URL: tt.endpoint}
+			if err := subscription.CheckAddress(allowed); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	if _, err := ParseWebhookNetworks([]string{"cmdb.internal"}); err == nil {
+		t.Error("Expected an error for a hostname")
+	}
+}
+
+func TestWebhookSignature(t *testing.T) {
+	secret := "0123456789abcdef"
+	body := []byte(`{"type":"ticket_created"}`)
+	sentAt := time.Unix(1709546400, 0)
+	timestamp := strconv.FormatInt(sentAt.Unix(), 10)
+
+	signature := SignWebhookPayload(secret, sentAt, body)
+	if !strings.HasPrefix(signature, "v1=") {
+		t.Fatalf("Expected a versioned signature, got %s", signature)
+	}
+
+	if err := VerifyWebhookSignature(secret, signature, timestamp, body, sentAt.Add(time.Minute), 5*time.Minute); err != nil {
+		t.Errorf("Expected a valid signature, got %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		body      []byte
+		now       time.Time
+	}{
+		{"other secret", "fedcba9876543210", timestamp, body, sentAt},
+		{"tampered body", secret, timestamp, []byte(`{"type":"ticket_closed"}`), sentAt},
+		{"other timestamp", secret, strconv.FormatInt(sentAt.Unix()+1, 10), body, sentAt},
+		{"expired", secret, timestamp, body, sentAt.Add(10 * time.Minute)},
+		{"bad timestamp", secret, "yesterday", body, sentAt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyWebhookSignature(tt.secret, signature, tt.timestamp, tt.body, tt.now, 5*time.Minute)
+			if err != ErrInvalidWebhookSignature {
+				t.Errorf("Expected ErrInvalidWebhookSignature, got %v", err)
+			}
+		})
+	}
+}
+
+func TestWebhookDelivery_Retries(t *testing.T) {
+	delivery := NewWebhookDelivery("webhook_1", "event_1", "ticket_created", []byte(`{}`))
+	now := time.Now()
+
+	delivery.RecordFailure(0, "", "connection refused", time.Second, now, 3)
+	if delivery.Status != WebhookDeliveryPending || !delivery.NextAttemptAt.Equal(now.Add(30*time.Second)) {
+		t.Fatalf("Expected a retry in 30s, got %s at %v", delivery.Status, delivery.NextAttemptAt)
+	}
+
+	delivery.RecordFailure(503, strings.Repeat("x", MaxWebhookResponseBody+10), "endpoint returned 503", time.Second, now, 3)
+	if !delivery.NextAttemptAt.Equal(now.Add(time.Minute)) {
+		t.Errorf("Expected the delay to double, got %v", delivery.NextAttemptAt)
+	}
+	if len(delivery.ResponseBody) != MaxWebhookResponseBody {
+		t.Errorf("Expected the response body to be truncated, got %d bytes", len(delivery.ResponseBody))
+	}
+
+	delivery.RecordFailure(503, "", "endpoint returned 503", time.Second, now, 3)
+	if delivery.Status != WebhookDeliveryFailed || delivery.NextAttemptAt != nil || delivery.Attempts != 3 {
+		t.Errorf("Expected the delivery to fail after 3 attempts, got %s after %d", delivery.Status, delivery.Attempts)
+	}
+
+	replay := delivery.Replay()
+	if replay.Status != WebhookDeliveryPending || replay.Attempts != 0 || replay.ReplayOf == nil || *replay.ReplayOf != delivery.ID {
+		t.Errorf("Expected a fresh delivery replaying %s, got %+v", delivery.ID, replay)
+	}
+
+	replay.RecordSuccess(200, "ok", time.Second, now)
+	if replay.Status != WebhookDeliverySucceeded || replay.Error != "" || replay.NextAttemptAt != nil {
+		t.Errorf("Expected a succeeded delivery, got %+v", replay)
+	}
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{4, 4 * time.Minute},
+		{12, time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := WebhookRetryDelay(tt.attempts); got != tt.want {
+			t.Errorf("After %d attempts expected %v, got %v", tt.attempts, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package events
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"fixora/internal/ports"
+)
+
+// Bus is an in-process EventPublisher. Handlers run asynchronously so slow subscribers never
+// delay the request that published the event; a handler error is logged and does not affect others.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]ports.EventHandler
+	wg       sync.WaitGroup
+}
+
+// NewBus creates a new in-process event bus
+func NewBus() *Bus {
+	return &Bus{
+		handlers: make(map[string][]ports.EventHandler),
+	}
+}
+
+// Publish delivers the event to every handler subscribed to its type
+func (b *Bus) Publish(ctx context.Context, event ports.Event) error {
+	b.mu.RLock()
+	handlers := append([]ports.EventHandler(nil), b.handlers[event.Type]...)
+	b.mu.RUnlock()
+
+	// Handlers outlive the publishing request
+	ctx = context.WithoutCancel(ctx)
+
+	for _, handler := range handlers {
+		b.wg.Add(1)
+		go func(handler ports.EventHandler) {
+			defer b.wg.Done()
+			if err := handler.Handle(ctx, event); err != nil {
+				log.Printf("Event handler for %s failed on %s %s: %v", event.Type, event.Aggregate, event.AggregateID, err)
+			}
+		}(handler)
+	}
+
+	return nil
+}
+
+// Subscribe registers a handler for an event type
+func (b *Bus) Subscribe(eventType string, handler ports.EventHandler) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+	return nil
+}
+
+// Unsubscribe removes a handler for an event type
+func (b *Bus) Unsubscribe(eventType string, handler ports.EventHandler) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	handlers := b.handlers[eventType]
+	for i, h := range handlers {
+		if h == handler {
+			b.handlers[eventType] = append(handlers[:i:i], handlers[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// Wait blocks until all handlers started so far have finished
+func (b *Bus) Wait() {
+	b.wg.Wait()
+}
@@ -2,6 +2,11 @@ package ports
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
 )
 
 // AISuggestionService defines the interface for AI suggestion services
@@ -42,10 +47,56 @@ type EmbeddingProvider interface {
 	// Dimension returns the dimension of the embedding vectors
 	Dimension() int
 
+	// Model returns the name of the model that produces the vectors
+	Model() string
+
 	// ValidateEmbedding checks if embedding dimension is correct
 	ValidateEmbedding(embedding []float32) bool
 }
 
+// EmbeddingCacheStore defines a durable tier for cached embeddings, keyed by model and content hash
+type EmbeddingCacheStore interface {
+	// GetMany retrieves cached embeddings for the given content hashes; missing hashes are omitted
+	GetMany(ctx context.Context, model string, hashes []string) (map[string][]float32, error)
+
+	// PutMany stores embeddings by content hash
+	PutMany(ctx context.Context, model string, embeddings map[string][]float32) error
+}
+
+// CachedEmbeddingProvider is an EmbeddingProvider that reports cache effectiveness
+type CachedEmbeddingProvider interface {
+	EmbeddingProvider
+
+	// CacheStats returns hit and miss counters since startup
+	CacheStats() EmbeddingCacheStats
+}
+
+// EmbeddingCacheStats reports embedding cache effectiveness
+type EmbeddingCacheStats struct {
+	MemoryHits int64   `json:"memory_hits"`
+	StoreHits  int64   `json:"store_hits"`
+	Misses     int64   `json:"misses"`
+	HitRate    float64 `json:"hit_rate"`
+	Entries    int     `json:"entries"`
+	Capacity   int     `json:"capacity"`
+}
+
+// TicketClassifier predicts ticket attributes with a model trained locally on resolved tickets.
+// It needs no external service, so it keeps working when AI providers are unavailable.
+type TicketClassifier interface {
+	// PredictAttributes predicts category and priority; fields are empty until the model has enough training data
+	PredictAttributes(ctx context.Context, description string) (PredictedAttributes, error)
+
+	// Learn trains the model on one resolved ticket, once per ticket
+	Learn(ctx context.Context, ticket *TicketTrainingData) error
+
+	// Retrain replaces the model with one trained on the given tickets
+	Retrain(ctx context.Context, tickets []*TicketTrainingData) error
+
+	// Documents returns the number of tickets the model was trained on
+	Documents() int
+}
+
 // AIProviderFactory creates AI service instances based on provider type
 type AIProviderFactory interface {
 	// Suggestion returns an AI suggestion service
@@ -72,15 +123,72 @@ type SuggestionResult struct {
 	Confidence float64 `json:"confidence"`
 	Category   string  `json:"category,omitempty"`
 	Source     string  `json:"source"`
+	Model      string  `json:"model,omitempty"`
 	UsedCache  bool    `json:"used_cache"`
+	Grounded   bool       `json:"grounded"`
+	Citations  []Citation `json:"citations,omitempty"`
+}
+
+// Citation points to a knowledge base chunk a suggestion is grounded in
+type Citation struct {
+	EntryID    string  `json:"entry_id"`
+	ChunkIndex int     `json:"chunk_index"`
+	Score      float64 `json:"score"`
+}
+
+// NoGroundedAnswer is returned as the suggestion when the knowledge base has nothing relevant
+const NoGroundedAnswer = "no grounded answer"
+
+// KnowledgeGroundable is implemented by AI providers that can ground suggestions in the knowledge base
+type KnowledgeGroundable interface {
+	// SetKnowledgeBase sets the repository used to retrieve grounding chunks
+	SetKnowledgeBase(repo KnowledgeRepository)
+}
+
+// ResolutionSummary describes the problem a resolved ticket reported and how it was fixed
+type ResolutionSummary struct {
+	Title   string `json:"title"`
+	Problem string `json:"problem"`
+	Fix     string `json:"fix"`
+	Source  string `json:"source"`
+}
+
+// ResolutionSummarizer is implemented by AI services that can summarise how a ticket was resolved
+type ResolutionSummarizer interface {
+	// SummarizeResolution summarises problem and fix from a resolved ticket and its comments
+	SummarizeResolution(ctx context.Context, ticket *TicketTrainingData) (*ResolutionSummary, error)
+}
+
+// Circuit breaker states of providers behind an AI router
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "half_open"
+)
+
+// ProviderStatus reports the circuit breaker state of one provider behind an AI router
+type ProviderStatus struct {
+	Provider            string     `json:"provider"`
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	LastError           string     `json:"last_error,omitempty"`
+	OpenedAt            *time.Time `json:"opened_at,omitempty"`
+	RetryAt             *time.Time `json:"retry_at,omitempty"`
+}
+
+// ProviderRouter is implemented by AI services that fall back across several providers
+type ProviderRouter interface {
+	// ProviderStatuses returns the breaker state of each provider in priority order
+	ProviderStatuses() []ProviderStatus
 }
 
 // SuggestionEvent represents a streaming suggestion event
 type SuggestionEvent struct {
-    Type    string      `json:"type"`    // init, candidate, progress, end, error
+    Type    string      `json:"type"`    // init, candidate, token, progress, end, error
     QueryID string      `json:"query_id"`
     Data    interface{} `json:"data"`
     Error   string      `json:"error,omitempty"`
+    Err     error       `json:"-"` // typed cause of an error event, for breaker classification
 }
 
 // CandidateData represents a suggestion candidate
@@ -91,6 +199,14 @@ type CandidateData struct {
 	Category    string  `json:"category,omitempty"`
 	EntryID     string  `json:"entry_id,omitempty"`
 	ChunkIndex  int     `json:"chunk_index,omitempty"`
+	Source      string  `json:"source,omitempty"` // provider that produced the candidate
+	Model       string  `json:"model,omitempty"`
+}
+
+// TokenData represents a piece of generated text streamed from the model
+type TokenData struct {
+	Index int    `json:"index"`
+	Text  string `json:"text"`
 }
 
 // ProgressData represents search progress
@@ -125,6 +241,7 @@ type TicketTrainingData struct {
 	Title       string   `json:"title"`
 	Description string   `json:"description"`
 	Category    string   `json:"category"`
+	Priority    string   `json:"priority"`
 	Solution    string   `json:"solution"`
 	Comments    []string `json:"comments"`
 	Resolution  string   `json:"resolution"`
@@ -180,4 +297,39 @@ const (
 	ErrRateLimitExceeded = "rate limit exceeded"
 	ErrInvalidEmbedding  = "invalid embedding dimension"
 	ErrTrainingFailed    = "training failed"
-)
\ No newline at end of file
+)
+
+// ProviderError represents a non-success response from an AI provider API
+type ProviderError struct {
+	Provider   string `json:"provider"`
+	StatusCode int    `json:"status_code"`
+	Message    string `json:"message"`
+}
+
+func (e *ProviderError) Error() string {
+	return fmt.Sprintf("%s API error: %d - %s", e.Provider, e.StatusCode, e.Message)
+}
+
+// Retryable reports whether the request may succeed when retried (rate limits and server errors)
+func (e *ProviderError) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
+// IsRetryableAIError reports whether err is a transient AI provider failure worth retrying
+func IsRetryableAIError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var providerErr *ProviderError
+	if errors.As(err, &providerErr) {
+		return providerErr.Retryable()
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, context.DeadlineExceeded)
+}
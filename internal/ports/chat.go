@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+
+	"fixora/internal/domain"
+)
+
+// ChatOpsHandler runs ticket commands and actions issued from a team chat. Users are identified
+// by their chat user ID; the handler maps them to Fixora users.
+type ChatOpsHandler interface {
+	// CreateTicket opens a ticket from a free-text description through AI intake
+	CreateTicket(ctx context.Context, chatUserID, text string) (*domain.Ticket, error)
+
+	// AssignedTickets lists the open, in progress and pending tickets assigned to the user
+	AssignedTickets(ctx context.Context, chatUserID string) ([]*domain.Ticket, error)
+
+	// PerformAction applies a ticket action on behalf of the user
+	PerformAction(ctx context.Context, chatUserID string, action domain.ChatAction, ticketID string) (*domain.Ticket, error)
+}
+
+// ChatNotifier posts ticket messages with action buttons to a team chat channel
+type ChatNotifier interface {
+	PostTicket(ctx context.Context, ticket *domain.Ticket, headline string) error
+}
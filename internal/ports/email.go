@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"fixora/internal/domain"
+)
+
+// InboundEmailHandler handles email received by a mail listener
+type InboundEmailHandler interface {
+	// HandleInboundEmail processes one email. An error tells the listener the email was not
+	// processed and should be delivered again later.
+	HandleInboundEmail(ctx context.Context, email *domain.InboundEmail) error
+}
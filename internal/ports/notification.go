@@ -19,8 +19,9 @@ type NotificationService interface {
 	// NotifyCommentAdded sends notification when a comment is added
 	NotifyCommentAdded(ctx context.Context, comment *domain.Comment, ticket *domain.Ticket) error
 
-	// NotifyTicketResolved sends notification when a ticket is resolved
-	NotifyTicketResolved(ctx context.Context, ticket *domain.Ticket) error
+	// NotifyTicketResolved sends notification when a ticket is resolved, with the requester's
+	// satisfaction rating links when surveys are enabled (survey may be nil)
+	NotifyTicketResolved(ctx context.Context, ticket *domain.Ticket, survey *domain.CSATSurvey) error
 
 	// NotifySLABreached sends notification when SLA is breached
 	NotifySLABreached(ctx context.Context, ticket *domain.Ticket, slaType string) error
@@ -70,6 +71,7 @@ const (
 	NotificationTypeCommentAdded    NotificationType = "comment_added"
 	NotificationTypeTicketResolved  NotificationType = "ticket_resolved"
 	NotificationTypeSLABreached     NotificationType = "sla_breached"
+	NotificationTypeIncidentUpdate  NotificationType = "incident_update"
 	NotificationTypeSystemMaintenance NotificationType = "system_maintenance"
 	NotificationTypeCustom          NotificationType = "custom"
 )
@@ -119,11 +121,43 @@ const (
 	EventTypeTicketUpdated   = "ticket_updated"
 	EventTypeTicketResolved  = "ticket_resolved"
 	EventTypeCommentAdded    = "comment_added"
+	EventTypeSuggestionFeedback = "suggestion_feedback"
+	EventTypeDeflectionResolved = "deflection_resolved"
+	EventTypeDeflectionEscalated = "deflection_escalated"
+	EventTypeIncidentCreated = "incident_created"
+	EventTypeIncidentUpdated = "incident_updated"
+	EventTypeIncidentResolved = "incident_resolved"
+	EventTypeProblemUpdated  = "problem_updated"
+	EventTypeTicketQueued    = "ticket_queued"
+	EventTypeCSATSubmitted   = "csat_submitted"
 	EventTypeKBEntryCreated  = "kb_entry_created"
 	EventTypeKBEntryUpdated  = "kb_entry_updated"
 	EventTypeKBEntryPublished = "kb_entry_published"
+	EventTypeKBIndexJobFailed = "kb_index_job_failed"
 )
 
+// EventTypes lists every event type published, for subscribers that pick events by name
+var EventTypes = []string{
+	EventTypeTicketCreated,
+	EventTypeTicketAssigned,
+	EventTypeTicketUpdated,
+	EventTypeTicketResolved,
+	EventTypeCommentAdded,
+	EventTypeSuggestionFeedback,
+	EventTypeDeflectionResolved,
+	EventTypeDeflectionEscalated,
+	EventTypeIncidentCreated,
+	EventTypeIncidentUpdated,
+	EventTypeIncidentResolved,
+	EventTypeProblemUpdated,
+	EventTypeTicketQueued,
+	EventTypeCSATSubmitted,
+	EventTypeKBEntryCreated,
+	EventTypeKBEntryUpdated,
+	EventTypeKBEntryPublished,
+	EventTypeKBIndexJobFailed,
+}
+
 // NewNotification creates a new notification
 func NewNotification(ntype NotificationType, recipient, subject, message string, priority NotificationPriority, channels []NotificationChannel) *Notification {
 	return &Notification{
@@ -2,6 +2,8 @@ package ports
 
 import (
 	"context"
+	"time"
+
 	"fixora/internal/domain"
 )
 
@@ -24,6 +26,19 @@ type TicketRepository interface {
 
 	// Count returns the number of tickets matching the filter
 	Count(ctx context.Context, filter domain.TicketFilter) (int, error)
+
+	// Claim assigns a queued ticket to an agent unless it has been claimed already,
+	// returning ErrTicketAlreadyClaimed if so
+	Claim(ctx context.Context, ticketID, agentID string) error
+}
+
+// TicketEmbeddingRepository defines the interface for ticket embedding persistence and similarity search
+type TicketEmbeddingRepository interface {
+	// SaveEmbedding stores the embedding of a ticket, replacing an earlier one
+	SaveEmbedding(ctx context.Context, ticketID, model string, embedding []float32) error
+
+	// FindSimilar returns tickets whose embedding from the same model is most similar, best first
+	FindSimilar(ctx context.Context, model string, embedding []float32, filter domain.SimilarTicketFilter) ([]*domain.SimilarTicket, error)
 }
 
 // CommentRepository defines the interface for comment persistence
@@ -75,6 +90,326 @@ type KnowledgeRepository interface {
 
 	// DeleteChunksByEntry removes all chunks for an entry
 	DeleteChunksByEntry(ctx context.Context, entryID string) error
+
+	// ReplaceChunks atomically swaps all chunks of an entry and publishes the entry's version in one
+	// transaction, failing with ErrIndexJobSuperseded if the entry moved to another version
+	ReplaceChunks(ctx context.Context, entry *domain.KnowledgeEntry, chunks []*domain.KBChunk) error
+
+	// CreateRevision saves an immutable revision of an entry
+	CreateRevision(ctx context.Context, revision *domain.KnowledgeRevision) error
+
+	// SaveEntryRevision saves an edited entry and its new revision in one transaction. The entry is
+	// only written while it is still at expectedVersion, failing with ErrKBEntryModified otherwise.
+	SaveEntryRevision(ctx context.Context, entry *domain.KnowledgeEntry, revision *domain.KnowledgeRevision, expectedVersion int) error
+
+	// FindRevision retrieves a specific revision of an entry
+	FindRevision(ctx context.Context, entryID string, version int) (*domain.KnowledgeRevision, error)
+
+	// ListRevisions retrieves all revisions of an entry, newest first
+	ListRevisions(ctx context.Context, entryID string) ([]*domain.KnowledgeRevision, error)
+}
+
+// IndexJobRepository defines the interface for knowledge base indexing job persistence
+type IndexJobRepository interface {
+	// Create saves a new indexing job
+	Create(ctx context.Context, job *domain.IndexJob) error
+
+	// FindByID retrieves an indexing job by its ID
+	FindByID(ctx context.Context, id string) (*domain.IndexJob, error)
+
+	// Update updates the status and progress of an indexing job
+	Update(ctx context.Context, job *domain.IndexJob) error
+
+	// ListByStatus retrieves indexing jobs with the given statuses, oldest first
+	ListByStatus(ctx context.Context, statuses []domain.IndexJobStatus, limit int) ([]*domain.IndexJob, error)
+
+	// Claim atomically starts a queued job, or a running one whose lease expired, for lease. It
+	// fails with ErrIndexJobClaimed when the job is finished or another worker holds it.
+	Claim(ctx context.Context, id string, lease time.Duration) (*domain.IndexJob, error)
+}
+
+// KBReindexRepository defines the interface for migrating chunk embeddings to a new embedding space
+type KBReindexRepository interface {
+	// ReindexProgress reports how many chunks already have vectors in the target space
+	ReindexProgress(ctx context.Context, target domain.EmbeddingSpace) (*domain.ReindexProgress, error)
+
+	// ListChunksForReindex retrieves chunks that have no shadow vector in the target space yet
+	ListChunksForReindex(ctx context.Context, target domain.EmbeddingSpace, limit int) ([]*domain.KBChunk, error)
+
+	// SaveShadowEmbeddings stores target-space vectors next to the live ones without affecting search
+	SaveShadowEmbeddings(ctx context.Context, target domain.EmbeddingSpace, chunks []*domain.KBChunk) error
+
+	// CutOver atomically replaces all live vectors with the shadow vectors of the target space
+	CutOver(ctx context.Context, target domain.EmbeddingSpace) error
+}
+
+// ClassifierRepository defines the interface for persisting locally trained classifier models
+type ClassifierRepository interface {
+	// Load returns the stored model, or nil if none has been saved
+	Load(ctx context.Context, name string) (*domain.TicketClassifier, error)
+
+	// Save stores the model, replacing any previous version
+	Save(ctx context.Context, name string, model *domain.TicketClassifier) error
+
+	// MarkLearned records that the model was trained on a ticket; it reports false if it already was
+	MarkLearned(ctx context.Context, name, ticketID string) (bool, error)
+
+	// Replace stores a retrained model and replaces the tickets recorded as learned with ticketIDs
+	Replace(ctx context.Context, name string, model *domain.TicketClassifier, ticketIDs []string) error
+}
+
+// FeedbackRepository defines the interface for AI suggestion feedback persistence
+type FeedbackRepository interface {
+	// Save stores feedback, replacing earlier feedback from the same requester on the same suggestion
+	Save(ctx context.Context, feedback *domain.SuggestionFeedback) error
+
+	// Create stores feedback unless the requester already rated the same suggestion, failing with
+	// ErrFeedbackAlreadyGiven then
+	Create(ctx context.Context, feedback *domain.SuggestionFeedback) error
+
+	// SaveCandidate records a candidate served by the suggestion stream
+	SaveCandidate(ctx context.Context, candidate *domain.SuggestionCandidate) error
+
+	// FindCandidate retrieves a served candidate by query ID and rank
+	FindCandidate(ctx context.Context, queryID string, rank int) (*domain.SuggestionCandidate, error)
+
+	// List retrieves feedback matching the filter, newest first
+	List(ctx context.Context, filter domain.FeedbackFilter) ([]*domain.SuggestionFeedback, error)
+}
+
+// DeflectionRepository defines the interface for self-service deflection session persistence
+type DeflectionRepository interface {
+	// Create saves a new deflection session
+	Create(ctx context.Context, session *domain.DeflectionSession) error
+
+	// FindByID retrieves a deflection session by ID
+	FindByID(ctx context.Context, id string) (*domain.DeflectionSession, error)
+
+	// Update updates an existing deflection session
+	Update(ctx context.Context, session *domain.DeflectionSession) error
+
+	// SaveCandidates stores the query ID and candidates of a session that is still open
+	SaveCandidates(ctx context.Context, session *domain.DeflectionSession) error
+
+	// Close stores the outcome of a session that is still open, failing with
+	// ErrDeflectionSessionClosed when another request closed it first
+	Close(ctx context.Context, session *domain.DeflectionSession) error
+}
+
+// IncidentRepository defines the interface for incident persistence
+type IncidentRepository interface {
+	// Create saves a new incident
+	Create(ctx context.Context, incident *domain.Incident) error
+
+	// FindByID retrieves an incident by ID
+	FindByID(ctx context.Context, id string) (*domain.Incident, error)
+
+	// Update updates an existing incident
+	Update(ctx context.Context, incident *domain.Incident) error
+
+	// List retrieves incidents matching the filter, newest first
+	List(ctx context.Context, filter domain.IncidentFilter) ([]*domain.Incident, error)
+}
+
+// ProblemRepository defines the interface for problem persistence
+type ProblemRepository interface {
+	// Create saves a new problem
+	Create(ctx context.Context, problem *domain.Problem) error
+
+	// FindByID retrieves a problem by ID
+	FindByID(ctx context.Context, id string) (*domain.Problem, error)
+
+	// Update updates an existing problem
+	Update(ctx context.Context, problem *domain.Problem) error
+
+	// List retrieves problems matching the filter, newest first
+	List(ctx context.Context, filter domain.ProblemFilter) ([]*domain.Problem, error)
+}
+
+// AgentRepository defines the interface for support agent persistence
+type AgentRepository interface {
+	// Create registers a new agent
+	Create(ctx context.Context, agent *domain.Agent) error
+
+	// FindByID retrieves an agent by ID
+	FindByID(ctx context.Context, id string) (*domain.Agent, error)
+
+	// Update updates an existing agent
+	Update(ctx context.Context, agent *domain.Agent) error
+
+	// Delete removes an agent
+	Delete(ctx context.Context, id string) error
+
+	// List retrieves agents matching the filter, ordered by name
+	List(ctx context.Context, filter domain.AgentFilter) ([]*domain.Agent, error)
+
+	// RecordAssignment sets when the agent was last routed a ticket
+	RecordAssignment(ctx context.Context, agentID string, at time.Time) error
+
+	// WithAssignmentLock runs fn while no other automatic assignment is picking an agent
+	WithAssignmentLock(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// TeamRepository defines the interface for team persistence
+type TeamRepository interface {
+	// Create saves a new team
+	Create(ctx context.Context, team *domain.Team) error
+
+	// FindByID retrieves a team with its members
+	FindByID(ctx context.Context, id string) (*domain.Team, error)
+
+	// Update updates a team's name and description
+	Update(ctx context.Context, team *domain.Team) error
+
+	// List retrieves all teams with their members, ordered by name
+	List(ctx context.Context) ([]*domain.Team, error)
+
+	// AddMember adds an agent to a team
+	AddMember(ctx context.Context, teamID, agentID string) error
+
+	// RemoveMember removes an agent from a team
+	RemoveMember(ctx context.Context, teamID, agentID string) error
+}
+
+// QueueRepository defines the interface for ticket queue persistence
+type QueueRepository interface {
+	// Create saves a new queue
+	Create(ctx context.Context, queue *domain.Queue) error
+
+	// FindByID retrieves a queue by ID
+	FindByID(ctx context.Context, id string) (*domain.Queue, error)
+
+	// Update updates an existing queue
+	Update(ctx context.Context, queue *domain.Queue) error
+
+	// List retrieves queues matching the filter, ordered by name
+	List(ctx context.Context, filter domain.QueueFilter) ([]*domain.Queue, error)
+}
+
+// AutomationRuleRepository defines the interface for automation rule persistence
+type AutomationRuleRepository interface {
+	// Create saves a new rule
+	Create(ctx context.Context, rule *domain.AutomationRule) error
+
+	// FindByID retrieves a rule by ID
+	FindByID(ctx context.Context, id string) (*domain.AutomationRule, error)
+
+	// Update updates an existing rule
+	Update(ctx context.Context, rule *domain.AutomationRule) error
+
+	// Delete removes a rule and its execution log
+	Delete(ctx context.Context, id string) error
+
+	// List retrieves rules matching the filter in position order
+	List(ctx context.Context, filter domain.AutomationRuleFilter) ([]*domain.AutomationRule, error)
+
+	// Reorder sets each rule's position to its index in ruleIDs
+	Reorder(ctx context.Context, ruleIDs []string) error
+}
+
+// AutomationExecutionRepository defines the interface for the automation execution log
+type AutomationExecutionRepository interface {
+	// Create records an execution
+	Create(ctx context.Context, execution *domain.AutomationExecution) error
+
+	// List retrieves executions matching the filter, newest first
+	List(ctx context.Context, filter domain.AutomationExecutionFilter) ([]*domain.AutomationExecution, error)
+
+	// HasSucceeded reports whether the rule has already run successfully on the ticket
+	HasSucceeded(ctx context.Context, ruleID, ticketID string) (bool, error)
+}
+
+// CSATRepository defines the interface for requester satisfaction rating persistence
+type CSATRepository interface {
+	// Save creates or replaces the ticket's rating
+	Save(ctx context.Context, rating *domain.CSATRating) error
+
+	// FindByTicket retrieves the ticket's rating
+	FindByTicket(ctx context.Context, ticketID string) (*domain.CSATRating, error)
+
+	// List retrieves ratings created in the filter's date range, newest first
+	List(ctx context.Context, filter domain.CSATFilter) ([]*domain.CSATRating, error)
+}
+
+// EmailMessageRepository defines the interface for the emails in ticket conversations
+type EmailMessageRepository interface {
+	// Create records an email received or sent for a ticket
+	Create(ctx context.Context, message *domain.EmailMessage) error
+
+	// FindByMessageID retrieves the email with the given Message-ID
+	FindByMessageID(ctx context.Context, messageID string) (*domain.EmailMessage, error)
+
+	// ListByTicket retrieves the emails in a ticket's conversation, oldest first
+	ListByTicket(ctx context.Context, ticketID string) ([]*domain.EmailMessage, error)
+}
+
+// WebhookSubscriptionRepository defines the interface for webhook subscription persistence
+type WebhookSubscriptionRepository interface {
+	// Create saves a new subscription
+	Create(ctx context.Context, subscription *domain.WebhookSubscription) error
+
+	// FindByID retrieves a subscription by ID
+	FindByID(ctx context.Context, id string) (*domain.WebhookSubscription, error)
+
+	// Update updates an existing subscription
+	Update(ctx context.Context, subscription *domain.WebhookSubscription) error
+
+	// Delete removes a subscription and its delivery log
+	Delete(ctx context.Context, id string) error
+
+	// List retrieves subscriptions matching the filter, oldest first
+	List(ctx context.Context, filter domain.WebhookSubscriptionFilter) ([]*domain.WebhookSubscription, error)
+
+	// RecordDeliveryResult resets the subscription's consecutive failures after a successful
+	// delivery, or counts a failed one and disables the subscription when it reaches disableAfter
+	// failures in a row. It returns the updated subscription.
+	RecordDeliveryResult(ctx context.Context, id string, succeeded bool, disableAfter int, reason string) (*domain.WebhookSubscription, error)
+}
+
+// WebhookDeliveryRepository defines the interface for the webhook delivery log
+type WebhookDeliveryRepository interface {
+	// Create records a new delivery
+	Create(ctx context.Context, delivery *domain.WebhookDelivery) error
+
+	// FindByID retrieves a delivery by ID
+	FindByID(ctx context.Context, id string) (*domain.WebhookDelivery, error)
+
+	// Update saves the outcome of a delivery attempt
+	Update(ctx context.Context, delivery *domain.WebhookDelivery) error
+
+	// List retrieves deliveries matching the filter, newest first
+	List(ctx context.Context, filter domain.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error)
+
+	// ClaimDue takes up to limit pending deliveries due at now, oldest first, and moves their next
+	// attempt to now plus lease, so no other run takes them while they are attempted
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.WebhookDelivery, error)
+}
+
+// JobRunRepository defines the interface for the scheduled job run log
+type JobRunRepository interface {
+	// Create records a finished run
+	Create(ctx context.Context, run *domain.JobRun) error
+
+	// LatestByJob retrieves the most recent run of each job, keyed by job name
+	LatestByJob(ctx context.Context) (map[string]*domain.JobRun, error)
+
+	// ListByJob retrieves a job's runs, newest first
+	ListByJob(ctx context.Context, job string, limit int) ([]*domain.JobRun, error)
+}
+
+// LeaderLock elects a single leader among instances sharing the database
+type LeaderLock interface {
+	// TryAcquire takes or keeps leadership without blocking, reporting whether this instance leads
+	TryAcquire(ctx context.Context) (bool, error)
+
+	// Release gives up leadership
+	Release(ctx context.Context) error
+}
+
+// JobLock keeps a job from running on more than one instance at a time
+type JobLock interface {
+	// TryRun runs fn while holding the job's lock, reporting false without running fn if another run holds it
+	TryRun(ctx context.Context, job string, fn func(ctx context.Context) error) (bool, error)
 }
 
 // MetricRepository defines the interface for metrics persistence
@@ -0,0 +1,15 @@
+package ports
+
+import "context"
+
+// WebhookResponse is an endpoint's answer to a webhook delivery
+type WebhookResponse struct {
+	StatusCode int
+	Body       string // truncated
+}
+
+// WebhookClient posts webhook deliveries to subscriber endpoints
+type WebhookClient interface {
+	// Post sends body to url with the given headers. An error means no response was received.
+	Post(ctx context.Context, url string, headers map[string]string, body []byte) (*WebhookResponse, error)
+}
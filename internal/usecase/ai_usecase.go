@@ -2,7 +2,11 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"hash/fnv"
+	"log"
+	"strings"
 	"time"
 
 	"fixora/internal/domain"
@@ -15,7 +19,13 @@ type AIUseCase struct {
     embeddings    ports.EmbeddingProvider
     knowledgeRepo ports.KnowledgeRepository
     ticketRepo    ports.TicketRepository
+    commentRepo   ports.CommentRepository
+    csatRepo      ports.CSATRepository
+    feedbackRepo  ports.FeedbackRepository // records streamed candidates for feedback
     training      ports.AITrainingService
+    classifier    ports.TicketClassifier
+    similarity    *TicketSimilarity
+    minConfidence float64 // ungrounded suggestions below this are rejected
 }
 
 // NewAIUseCase creates a new AI use case
@@ -24,14 +34,26 @@ func NewAIUseCase(
 	embeddings ports.EmbeddingProvider,
 	knowledgeRepo ports.KnowledgeRepository,
 	ticketRepo ports.TicketRepository,
+	commentRepo ports.CommentRepository,
+	csatRepo ports.CSATRepository,
+	feedbackRepo ports.FeedbackRepository,
 	training ports.AITrainingService,
+	classifier ports.TicketClassifier,
+	similarity *TicketSimilarity,
+	minConfidence float64,
 ) *AIUseCase {
 	return &AIUseCase{
 		aiService:     aiService,
 		embeddings:    embeddings,
 		knowledgeRepo: knowledgeRepo,
 		ticketRepo:    ticketRepo,
+		commentRepo:   commentRepo,
+		csatRepo:      csatRepo,
+		feedbackRepo:  feedbackRepo,
 		training:      training,
+		classifier:    classifier,
+		similarity:    similarity,
+		minConfidence: minConfidence,
 	}
 }
 
@@ -50,15 +72,22 @@ func (uc *AIUseCase) GetSuggestion(ctx context.Context, description string) (*po
 		return nil, fmt.Errorf("failed to get AI suggestion: %w", err)
 	}
 
-	// Filter suggestions with low confidence
-	if suggestion.Confidence < 0.4 {
+	// Nothing relevant in the knowledge base; report it rather than failing
+	if suggestion.Suggestion == ports.NoGroundedAnswer {
+		return &suggestion, nil
+	}
+
+	// Grounded answers only cite sources that already passed the minimum score at retrieval;
+	// filter the rest by confidence
+	if !suggestion.Grounded && suggestion.Confidence < uc.minConfidence {
 		return nil, fmt.Errorf("AI confidence too low: %.2f", suggestion.Confidence)
 	}
 
 	return &suggestion, nil
 }
 
-// StreamSuggestion provides streaming AI suggestions
+// StreamSuggestion provides streaming AI suggestions. Each candidate is recorded before it reaches
+// the client, so feedback on it is attributed to what was actually served.
 func (uc *AIUseCase) StreamSuggestion(ctx context.Context, description string) (<-chan ports.SuggestionEvent, error) {
 	if description == "" {
 		return nil, fmt.Errorf("description is required")
@@ -68,7 +97,67 @@ func (uc *AIUseCase) StreamSuggestion(ctx context.Context, description string) (
 		return nil, fmt.Errorf("AI service not available")
 	}
 
-	return uc.aiService.StreamSuggestionMitigation(ctx, description)
+	upstream, err := uc.aiService.StreamSuggestionMitigation(ctx, description)
+	if err != nil || uc.feedbackRepo == nil {
+		return upstream, err
+	}
+
+	events := make(chan ports.SuggestionEvent, 10)
+
+	go func() {
+		defer close(events)
+
+		for event := range upstream {
+			if event.Type == "candidate" {
+				uc.recordCandidate(ctx, event)
+			}
+
+			select {
+			case events <- event:
+			case <-ctx.Done():
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// recordCandidate stores a streamed candidate under its query ID
+func (uc *AIUseCase) recordCandidate(ctx context.Context, event ports.SuggestionEvent) {
+	data, ok := candidateData(event.Data)
+	if !ok || event.QueryID == "" {
+		return
+	}
+
+	candidate := &domain.SuggestionCandidate{
+		QueryID:    event.QueryID,
+		Rank:       data.Rank,
+		Score:      data.Score,
+		Category:   data.Category,
+		Provider:   data.Source,
+		Model:      data.Model,
+		EntryID:    data.EntryID,
+		ChunkIndex: data.ChunkIndex,
+		CreatedAt:  time.Now(),
+	}
+
+	// The client may go away after seeing the candidate; it can still be rated later
+	if err := uc.feedbackRepo.SaveCandidate(context.WithoutCancel(ctx), candidate); err != nil {
+		log.Printf("Failed to record suggestion candidate %s#%d: %v", event.QueryID, data.Rank, err)
+	}
+}
+
+// candidateData extracts the candidate from the data of a candidate event
+func candidateData(data interface{}) (ports.CandidateData, bool) {
+	switch c := data.(type) {
+	case ports.CandidateData:
+		return c, true
+	case *ports.CandidateData:
+		if c != nil {
+			return *c, true
+		}
+	}
+	return ports.CandidateData{}, false
 }
 
 // SearchKnowledgeBase performs semantic search in the knowledge base
@@ -95,33 +184,76 @@ func (uc *AIUseCase) TrainFromResolvedTicket(ctx context.Context, ticketID strin
 		return fmt.Errorf("ticket ID is required")
 	}
 
-	if uc.training == nil {
+	if uc.training == nil && uc.classifier == nil {
 		return fmt.Errorf("AI training service not available")
 	}
 
+	trainingData, err := uc.resolvedTrainingData(ctx, ticketID)
+	if err != nil {
+		return err
+	}
+
+	// Train the local classifier and the AI model
+	if uc.classifier != nil {
+		if err := uc.classifier.Learn(ctx, trainingData); err != nil {
+			return fmt.Errorf("failed to train classifier from resolved ticket: %w", err)
+		}
+	}
+
+	if uc.training != nil {
+		if err := uc.training.LearnFromResolved(ctx, trainingData); err != nil {
+			return fmt.Errorf("failed to train from resolved ticket: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// TrainFromRatedTicket trains the AI model again once the requester has rated the resolution.
+// Requesters usually rate after the ticket was first trained on, without a rating. The classifier
+// is not retrained, as the rating does not change the ticket's category.
+func (uc *AIUseCase) TrainFromRatedTicket(ctx context.Context, ticketID string) error {
+	if uc.training == nil {
+		return nil
+	}
+
+	trainingData, err := uc.resolvedTrainingData(ctx, ticketID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.training.LearnFromResolved(ctx, trainingData); err != nil {
+		return fmt.Errorf("failed to train from rated ticket: %w", err)
+	}
+
+	return nil
+}
+
+// resolvedTrainingData builds the training data for a resolved ticket, with its comments and the
+// requester's satisfaction rating when there is one
+func (uc *AIUseCase) resolvedTrainingData(ctx context.Context, ticketID string) (*ports.TicketTrainingData, error) {
 	// Get ticket details
 	ticket, err := uc.ticketRepo.FindByID(ctx, ticketID)
 	if err != nil {
-		return fmt.Errorf("failed to get ticket: %w", err)
+		return nil, fmt.Errorf("failed to get ticket: %w", err)
 	}
 
-	if ticket.Status != domain.TicketStatusResolved {
-		return fmt.Errorf("ticket must be resolved before training")
+	if ticket.Status != domain.TicketStatusResolved && ticket.Status != domain.TicketStatusClosed {
+		return nil, fmt.Errorf("ticket must be resolved before training")
 	}
 
 	// Get comments for additional context
 	var comments []string
-	if uc.ticketRepo != nil {
-		// Note: In a real implementation, you would inject CommentRepository
-		// commentRepo := someCommentRepo
-		// commentList, err := commentRepo.ListByTicket(ctx, ticketID)
-		// if err == nil {
-		//     for _, comment := range commentList {
-		//         if comment.Role != domain.CommentRoleAI {
-		//             comments = append(comments, comment.Body)
-		//         }
-		//     }
-		// }
+	if uc.commentRepo != nil {
+		commentList, err := uc.commentRepo.ListByTicket(ctx, ticketID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ticket comments: %w", err)
+		}
+		for _, comment := range commentList {
+			if comment.Role != domain.CommentRoleAI {
+				comments = append(comments, comment.Body)
+			}
+		}
 	}
 
 	// Create training data
@@ -130,16 +262,106 @@ func (uc *AIUseCase) TrainFromResolvedTicket(ctx context.Context, ticketID strin
 		Title:       ticket.Title,
 		Description: ticket.Description,
 		Category:    string(ticket.Category),
+		Priority:    string(ticket.Priority),
 		Solution:    extractSolutionFromComments(comments), // Simplified
 		Comments:    comments,
 	}
 
-	// Train the model
-	if err := uc.training.LearnFromResolved(ctx, trainingData); err != nil {
-		return fmt.Errorf("failed to train from resolved ticket: %w", err)
+	if uc.csatRepo != nil {
+		rating, err := uc.csatRepo.FindByTicket(ctx, ticketID)
+		switch {
+		case err == nil:
+			trainingData.Rating = &rating.Score
+		case !errors.Is(err, domain.ErrCSATRatingNotFound):
+			return nil, fmt.Errorf("failed to get satisfaction rating: %w", err)
+		}
 	}
 
-	return nil
+	return trainingData, nil
+}
+
+// RetrainClassifier rebuilds the local classifier from all resolved and closed tickets
+func (uc *AIUseCase) RetrainClassifier(ctx context.Context) (int, error) {
+	if uc.classifier == nil {
+		return 0, fmt.Errorf("ticket classifier not available")
+	}
+
+	tickets, err := uc.listResolvedTickets(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	data := make([]*ports.TicketTrainingData, len(tickets))
+	for i, ticket := range tickets {
+		data[i] = &ports.TicketTrainingData{
+			TicketID:    ticket.ID,
+			Title:       ticket.Title,
+			Description: ticket.Description,
+			Category:    string(ticket.Category),
+			Priority:    string(ticket.Priority),
+		}
+	}
+
+	if err := uc.classifier.Retrain(ctx, data); err != nil {
+		return 0, fmt.Errorf("failed to retrain classifier: %w", err)
+	}
+
+	return len(data), nil
+}
+
+// EvaluateClassifier trains a classifier on resolved tickets and reports its accuracy on a held-out
+// share of them. Tickets are assigned to the held-out set by a hash of their ID, so repeated
+// evaluations use the same split.
+func (uc *AIUseCase) EvaluateClassifier(ctx context.Context, holdoutPercent int) (*domain.ClassifierEvaluation, error) {
+	if holdoutPercent <= 0 || holdoutPercent >= 100 {
+		return nil, fmt.Errorf("holdout percent must be between 1 and 99")
+	}
+
+	tickets, err := uc.listResolvedTickets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var train, test []domain.ClassifierExample
+	for _, ticket := range tickets {
+		example := domain.ClassifierExample{
+			Text:     ticket.Description,
+			Category: ticket.Category,
+			Priority: ticket.Priority,
+		}
+
+		hash := fnv.New32a()
+		hash.Write([]byte(ticket.ID))
+		if int(hash.Sum32()%100) < holdoutPercent {
+			test = append(test, example)
+		} else {
+			train = append(train, example)
+		}
+	}
+
+	return domain.EvaluateTicketClassifier(train, test), nil
+}
+
+// listResolvedTickets returns every resolved or closed ticket
+func (uc *AIUseCase) listResolvedTickets(ctx context.Context) ([]*domain.Ticket, error) {
+	const pageSize = 500
+
+	var tickets []*domain.Ticket
+	for _, status := range []domain.TicketStatus{domain.TicketStatusResolved, domain.TicketStatusClosed} {
+		status := status
+		for offset := 0; ; offset += pageSize {
+			page, err := uc.ticketRepo.List(ctx, domain.TicketFilter{Status: &status, Limit: pageSize, Offset: offset})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list resolved tickets: %w", err)
+			}
+			tickets = append(tickets, page...)
+			if len(page) < pageSize {
+				break
+			}
+		}
+	}
+
+	return tickets, nil
 }
 
 // TrainFromKnowledgeEntry trains the AI model using knowledge base entry
@@ -251,6 +473,15 @@ func (uc *AIUseCase) ValidateAIProvider(ctx context.Context) error {
 	return nil
 }
 
+// GetProviderStatuses returns the circuit breaker state of each routed AI provider, or nil
+// when suggestions are served by a single provider
+func (uc *AIUseCase) GetProviderStatuses() []ports.ProviderStatus {
+	if router, ok := uc.aiService.(ports.ProviderRouter); ok {
+		return router.ProviderStatuses()
+	}
+	return nil
+}
+
 // GetAIProviderInfo returns information about the AI provider
 func (uc *AIUseCase) GetAIProviderInfo(ctx context.Context) map[string]interface{} {
 	info := make(map[string]interface{})
@@ -264,6 +495,10 @@ func (uc *AIUseCase) GetAIProviderInfo(ctx context.Context) map[string]interface
 	if uc.embeddings != nil {
 		info["embedding_service"] = "available"
 		info["embedding_dimension"] = uc.embeddings.Dimension()
+		info["embedding_model"] = uc.embeddings.Model()
+		if cached, ok := uc.embeddings.(ports.CachedEmbeddingProvider); ok {
+			info["embedding_cache"] = cached.CacheStats()
+		}
 	} else {
 		info["embedding_service"] = "not_available"
 	}
@@ -280,6 +515,14 @@ func (uc *AIUseCase) GetAIProviderInfo(ctx context.Context) map[string]interface
 		info["knowledge_repository"] = "not_available"
 	}
 
+	if statuses := uc.GetProviderStatuses(); statuses != nil {
+		info["providers"] = statuses
+	}
+
+	if uc.classifier != nil {
+		info["classifier_documents"] = uc.classifier.Documents()
+	}
+
 	info["last_validation"] = time.Now().Format(time.RFC3339)
 
 	return info
@@ -327,6 +570,14 @@ func (uc *AIUseCase) AnalyzeTicketContent(ctx context.Context, title, descriptio
 		}
 	}
 
+	// Search for related tickets with the same embedding
+	if uc.similarity != nil && analysis.Embedding != nil {
+		similarTickets, err := uc.similarity.Search(ctx, analysis.Embedding, domain.SimilarTicketFilter{})
+		if err == nil {
+			analysis.SimilarTickets = similarTickets
+		}
+	}
+
 	return analysis, nil
 }
 
@@ -337,6 +588,7 @@ type TicketAnalysis struct {
 	AISuggestion  *ports.SuggestionResult   `json:"ai_suggestion,omitempty"`
 	Embedding     []float32                 `json:"embedding,omitempty"`
 	SimilarIssues []*domain.KBChunk         `json:"similar_issues,omitempty"`
+	SimilarTickets []*domain.SimilarTicket  `json:"similar_tickets,omitempty"`
 	Category      string                    `json:"predicted_category,omitempty"`
 	Priority      string                    `json:"predicted_priority,omitempty"`
 	AnalyzedAt    time.Time                 `json:"analyzed_at"`
@@ -358,9 +610,9 @@ func extractSolutionFromComments(comments []string) string {
 	}
 
 	for _, comment := range comments {
-		lowerComment := lower(comment)
+		lowerComment := strings.ToLower(comment)
 		for _, keyword := range resolutionKeywords {
-			if contains(lowerComment, keyword) {
+			if strings.Contains(lowerComment, keyword) {
 				return comment
 			}
 		}
@@ -370,17 +622,6 @@ func extractSolutionFromComments(comments []string) string {
 	return comments[len(comments)-1]
 }
 
-// Simple string helpers (in a real implementation, use strings package)
-func lower(s string) string {
-	// Implementation would use strings.ToLower()
-	return s
-}
-
-func contains(s, substr string) bool {
-    // Implementation would use strings.Contains()
-    return true
-}
-
 // AI Intake DTOs and logic
 
 // AITicketIntakeRequest represents the request payload for AI-driven ticket intake
@@ -420,13 +661,7 @@ func (uc *AIUseCase) IntakeCreateTicket(ctx context.Context, req AITicketIntakeR
     }
 
     // Fetch AI predictions
-    var preds ports.PredictedAttributes
-    if uc.aiService != nil {
-        p, err := uc.aiService.PredictAttributes(ctx, req.Description)
-        if err == nil {
-            preds = p
-        }
-    }
+    preds := uc.predictAttributes(ctx, req.Description)
 
     // Confidence thresholds
     const titleThreshold = 0.60
@@ -529,7 +764,7 @@ func (uc *AIUseCase) IntakeCreateTicket(ctx context.Context, req AITicketIntakeR
     var insight *ports.SuggestionResult
     if uc.aiService != nil {
         if s, err := uc.aiService.SuggestMitigation(ctx, req.Description); err == nil && s.Confidence >= 0.4 {
-            ticket.SetAIInsight(s.Suggestion, s.Confidence)
+            attachAIInsight(ticket, s)
             insight = &s
         }
     }
@@ -549,6 +784,45 @@ func (uc *AIUseCase) IntakeCreateTicket(ctx context.Context, req AITicketIntakeR
     }, nil
 }
 
+// PredictCategory predicts the category of a ticket from its text, with the prediction's confidence.
+// The category is empty when neither the AI provider nor the local classifier can predict one.
+func (uc *AIUseCase) PredictCategory(ctx context.Context, text string) (domain.TicketCategory, float64) {
+	preds := uc.predictAttributes(ctx, text)
+	if preds.Category.Value == "" {
+		return "", 0
+	}
+	return normalizeCategory(preds.Category.Value), preds.Category.Confidence
+}
+
+// predictAttributes combines the AI provider's predictions with the local classifier's, keeping the
+// more confident prediction for category and priority. Either source may be unavailable.
+func (uc *AIUseCase) predictAttributes(ctx context.Context, description string) ports.PredictedAttributes {
+    var preds ports.PredictedAttributes
+    if uc.aiService != nil {
+        if p, err := uc.aiService.PredictAttributes(ctx, description); err == nil {
+            preds = p
+        }
+    }
+
+    if uc.classifier == nil {
+        return preds
+    }
+
+    local, err := uc.classifier.PredictAttributes(ctx, description)
+    if err != nil {
+        return preds
+    }
+
+    if local.Category.Value != "" && local.Category.Confidence > preds.Category.Confidence {
+        preds.Category = local.Category
+    }
+    if local.Priority.Value != "" && local.Priority.Confidence > preds.Priority.Confidence {
+        preds.Priority = local.Priority
+    }
+
+    return preds
+}
+
 // Helpers for normalization
 func defaultTitleFromDescription(desc string) string {
     if len(desc) == 0 {
@@ -561,7 +835,7 @@ func defaultTitleFromDescription(desc string) string {
 }
 
 func normalizeCategory(s string) domain.TicketCategory {
-    switch lower(s) {
+    switch strings.ToLower(s) {
     case "network":
         return domain.TicketCategoryNetwork
     case "software":
@@ -576,7 +850,7 @@ func normalizeCategory(s string) domain.TicketCategory {
 }
 
 func normalizePriority(s string) domain.TicketPriority {
-    switch lower(s) {
+    switch strings.ToLower(s) {
     case "low":
         return domain.TicketPriorityLow
     case "medium":
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"fixora/internal/ports"
+)
+
+// stubSuggestionService returns a fixed suggestion
+type stubSuggestionService struct {
+	result ports.SuggestionResult
+}
+
+func (s *stubSuggestionService) SuggestMitigation(ctx context.Context, description string) (ports.SuggestionResult, error) {
+	return s.result, nil
+}
+
+func (s *stubSuggestionService) StreamSuggestionMitigation(ctx context.Context, description string) (<-chan ports.SuggestionEvent, error) {
+	return nil, nil
+}
+
+func (s *stubSuggestionService) ValidateProvider(ctx context.Context) error {
+	return nil
+}
+
+func (s *stubSuggestionService) PredictAttributes(ctx context.Context, description string) (ports.PredictedAttributes, error) {
+	return ports.PredictedAttributes{}, nil
+}
+
+func TestAIUseCase_GetSuggestionConfidence(t *testing.T) {
+	tests := []struct {
+		name          string
+		result        ports.SuggestionResult
+		minConfidence float64
+		wantErr       bool
+	}{
+		{"confident", ports.SuggestionResult{Suggestion: "Restart the router", Confidence: 0.7}, 0.6, false},
+		{"below configured minimum", ports.SuggestionResult{Suggestion: "Restart the router", Confidence: 0.5}, 0.6, true},
+		{"below default but above configured minimum", ports.SuggestionResult{Suggestion: "Restart the router", Confidence: 0.3}, 0.2, false},
+		{"grounded below minimum", ports.SuggestionResult{Suggestion: "Reinstall the profile [1]", Confidence: 0.3, Grounded: true}, 0.6, false},
+		{"no grounded answer", ports.SuggestionResult{Suggestion: ports.NoGroundedAnswer}, 0.6, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewAIUseCase(&stubSuggestionService{result: tt.result}, nil, nil, nil, nil, nil, nil, nil, nil, nil, tt.minConfidence)
+
+			suggestion, err := uc.GetSuggestion(context.Background(), "VPN keeps dropping")
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected the suggestion to be rejected, got %+v", suggestion)
+				}
+				return
+			}
+			if err != nil || suggestion.Suggestion != tt.result.Suggestion {
+				t.Errorf("Expected %q, got %+v, %v", tt.result.Suggestion, suggestion, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,319 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// AssignmentConfig configures automatic ticket assignment
+type AssignmentConfig struct {
+	Strategy string // default strategy, one of the registered strategy names
+}
+
+// AssignmentUseCase keeps the agent registry and routes tickets to agents with pluggable strategies.
+// Assignments go through TicketUseCase.AssignTicket, so they are recorded and notified like manual ones.
+type AssignmentUseCase struct {
+	agentRepo  ports.AgentRepository
+	ticketRepo ports.TicketRepository
+	tickets    *TicketUseCase
+	aiUseCase  *AIUseCase
+	strategies map[string]domain.AssignmentStrategy
+	config     AssignmentConfig
+}
+
+// NewAssignmentUseCase creates a new assignment use case with the built-in strategies registered
+func NewAssignmentUseCase(
+	agentRepo ports.AgentRepository,
+	ticketRepo ports.TicketRepository,
+	tickets *TicketUseCase,
+	aiUseCase *AIUseCase,
+	config AssignmentConfig,
+) *AssignmentUseCase {
+	if config.Strategy == "" {
+		config.Strategy = domain.AssignmentStrategySkillMatch
+	}
+
+	uc := &AssignmentUseCase{
+		agentRepo:  agentRepo,
+		ticketRepo: ticketRepo,
+		tickets:    tickets,
+		aiUseCase:  aiUseCase,
+		strategies: make(map[string]domain.AssignmentStrategy),
+		config:     config,
+	}
+
+	uc.RegisterStrategy(domain.RoundRobinStrategy{})
+	uc.RegisterStrategy(domain.LeastOpenStrategy{})
+	uc.RegisterStrategy(domain.SkillMatchStrategy{})
+
+	return uc
+}
+
+// RegisterStrategy adds a strategy, replacing one with the same name
+func (uc *AssignmentUseCase) RegisterStrategy(strategy domain.AssignmentStrategy) {
+	uc.strategies[strategy.Name()] = strategy
+}
+
+// AgentRequest represents an agent's profile
+type AgentRequest struct {
+	ID       string                        `json:"id"`
+	Name     string                        `json:"name"`
+	Email    string                        `json:"email,omitempty"`
+	Skills   map[domain.TicketCategory]int `json:"skills"`
+	Capacity int                           `json:"capacity"`
+}
+
+// AutoAssignResponse represents a routing decision and the assigned ticket
+type AutoAssignResponse struct {
+	Decision *domain.AssignmentDecision `json:"decision"`
+	Ticket   *domain.Ticket             `json:"ticket,omitempty"`
+}
+
+// CreateAgent registers an agent
+func (uc *AssignmentUseCase) CreateAgent(ctx context.Context, req AgentRequest) (*domain.Agent, error) {
+	agent, err := domain.NewAgent(req.ID, req.Name, req.Email, req.Skills, req.Capacity)
+	if err != nil {
+		return nil, fmt.Errorf("invalid agent: %w", err)
+	}
+
+	if err := uc.agentRepo.Create(ctx, agent); err != nil {
+		return nil, fmt.Errorf("failed to create agent: %w", err)
+	}
+
+	return agent, nil
+}
+
+// GetAgent retrieves an agent with its current number of open tickets
+func (uc *AssignmentUseCase) GetAgent(ctx context.Context, agentID string) (*domain.Agent, error) {
+	agent, err := uc.agentRepo.FindByID(ctx, agentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get agent: %w", err)
+	}
+
+	if err := uc.countOpenTickets(ctx, []*domain.Agent{agent}); err != nil {
+		return nil, err
+	}
+
+	return agent, nil
+}
+
+// ListAgents lists agents with their current number of open tickets
+func (uc *AssignmentUseCase) ListAgents(ctx context.Context, filter domain.AgentFilter) ([]*domain.Agent, error) {
+	agents, err := uc.agentRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agents: %w", err)
+	}
+
+	if err := uc.countOpenTickets(ctx, agents); err != nil {
+		return nil, err
+	}
+
+	return agents, nil
+}
+
+// UpdateAgent replaces an agent's profile
+func (uc *AssignmentUseCase) UpdateAgent(ctx context.Context, agentID string, req AgentRequest) (*domain.Agent, error) {
+	agent, err := uc.agentRepo.FindByID(ctx, agentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get agent: %w", err)
+	}
+
+	if err := agent.UpdateProfile(req.Name, req.Email, req.Skills, req.Capacity); err != nil {
+		return nil, fmt.Errorf("invalid agent: %w", err)
+	}
+
+	if err := uc.agentRepo.Update(ctx, agent); err != nil {
+		return nil, fmt.Errorf("failed to update agent: %w", err)
+	}
+
+	return agent, nil
+}
+
+// SetAgentAvailability marks an agent as available or unavailable for new tickets
+func (uc *AssignmentUseCase) SetAgentAvailability(ctx context.Context, agentID string, available bool) (*domain.Agent, error) {
+	agent, err := uc.agentRepo.FindByID(ctx, agentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get agent: %w", err)
+	}
+
+	agent.SetAvailability(available)
+
+	if err := uc.agentRepo.Update(ctx, agent); err != nil {
+		return nil, fmt.Errorf("failed to update agent: %w", err)
+	}
+
+	return agent, nil
+}
+
+// DeleteAgent removes an agent from the registry; tickets assigned to it keep their assignee
+func (uc *AssignmentUseCase) DeleteAgent(ctx context.Context, agentID string) error {
+	if err := uc.agentRepo.Delete(ctx, agentID); err != nil {
+		return fmt.Errorf("failed to delete agent: %w", err)
+	}
+	return nil
+}
+
+// PreviewAssignment explains which agent the strategy would route the ticket to, without assigning it.
+// An empty strategy uses the configured default.
+func (uc *AssignmentUseCase) PreviewAssignment(ctx context.Context, ticketID, strategy string) (*domain.AssignmentDecision, error) {
+	ticket, err := uc.ticketRepo.FindByID(ctx, ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket: %w", err)
+	}
+
+	selected, req, err := uc.prepare(ctx, ticket, strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	decision, err := uc.decide(ctx, selected, req)
+	if err != nil {
+		return nil, err
+	}
+
+	decision.DryRun = true
+	return decision, nil
+}
+
+// AutoAssign routes the ticket to the best agent by the strategy and assigns it. Agents are
+// picked under the assignment lock so concurrent assignments see each other's open tickets.
+// The ticket is read again under the lock; if it was assigned or queued since it was first read,
+// AutoAssign fails with ErrAssignmentChanged instead of overriding that.
+func (uc *AssignmentUseCase) AutoAssign(ctx context.Context, ticketID, strategy string) (*AutoAssignResponse, error) {
+	ticket, err := uc.ticketRepo.FindByID(ctx, ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket: %w", err)
+	}
+	if ticket.Status == domain.TicketStatusResolved || ticket.Status == domain.TicketStatusClosed {
+		return nil, domain.ErrInvalidAssignment
+	}
+
+	// Prepared outside the lock so a slow category prediction does not hold up other assignments
+	selected, req, err := uc.prepare(ctx, ticket, strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	var response *AutoAssignResponse
+	err = uc.agentRepo.WithAssignmentLock(ctx, func(ctx context.Context) error {
+		// The ticket may have been assigned or queued while the request was prepared
+		current, err := uc.ticketRepo.FindByID(ctx, ticket.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get ticket: %w", err)
+		}
+		if current.Status == domain.TicketStatusResolved || current.Status == domain.TicketStatusClosed {
+			return domain.ErrInvalidAssignment
+		}
+		if !sameID(current.AssignedTo, ticket.AssignedTo) || !sameID(current.QueueID, ticket.QueueID) {
+			return domain.ErrAssignmentChanged
+		}
+
+		decision, err := uc.decide(ctx, selected, req)
+		if err != nil {
+			return err
+		}
+		if decision.AgentID == "" {
+			response = &AutoAssignResponse{Decision: decision}
+			return domain.ErrNoEligibleAgent
+		}
+
+		assigned, err := uc.tickets.AssignTicket(ctx, ticket.ID, decision.AgentID)
+		if err != nil {
+			return err
+		}
+
+		// Record the assignment for round-robin ordering
+		if err := uc.agentRepo.RecordAssignment(ctx, decision.AgentID, time.Now()); err != nil {
+			return fmt.Errorf("failed to update agent: %w", err)
+		}
+
+		response = &AutoAssignResponse{Decision: decision, Ticket: assigned}
+		return nil
+	})
+
+	return response, err
+}
+
+// AutoAssignNewTicket assigns a ticket with the default strategy unless someone already picked it up
+// or it waits in a queue to be claimed. It returns nil without error when the ticket was skipped.
+func (uc *AssignmentUseCase) AutoAssignNewTicket(ctx context.Context, ticketID string) (*AutoAssignResponse, error) {
+	ticket, err := uc.ticketRepo.FindByID(ctx, ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket: %w", err)
+	}
+	if ticket.AssignedTo != nil || ticket.QueueID != nil || ticket.Status != domain.TicketStatusOpen {
+		return nil, nil
+	}
+
+	response, err := uc.AutoAssign(ctx, ticketID, "")
+	if errors.Is(err, domain.ErrAssignmentChanged) {
+		return nil, nil
+	}
+	return response, err
+}
+
+// sameID reports whether two optional IDs are equal
+func sameID(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// prepare looks up the strategy and builds the assignment request for the ticket, without agents
+func (uc *AssignmentUseCase) prepare(ctx context.Context, ticket *domain.Ticket, strategyName string) (domain.AssignmentStrategy, domain.AssignmentRequest, error) {
+	if strategyName == "" {
+		strategyName = uc.config.Strategy
+	}
+	strategy, ok := uc.strategies[strategyName]
+	if !ok {
+		return nil, domain.AssignmentRequest{}, fmt.Errorf("%w: %s", domain.ErrUnknownAssignmentStrategy, strategyName)
+	}
+
+	req := domain.AssignmentRequest{Ticket: ticket}
+
+	// Only skill matching uses the predicted category, so other strategies skip the AI call
+	if strategy.Name() == domain.AssignmentStrategySkillMatch && uc.aiUseCase != nil {
+		req.PredictedCategory, req.PredictionConfidence = uc.aiUseCase.PredictCategory(ctx, ticketText(ticket))
+	}
+
+	return strategy, req, nil
+}
+
+// decide ranks the registered agents with their current open tickets for the prepared request
+func (uc *AssignmentUseCase) decide(ctx context.Context, strategy domain.AssignmentStrategy, req domain.AssignmentRequest) (*domain.AssignmentDecision, error) {
+	agents, err := uc.agentRepo.List(ctx, domain.AgentFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agents: %w", err)
+	}
+
+	if err := uc.countOpenTickets(ctx, agents); err != nil {
+		return nil, err
+	}
+
+	req.Agents = agents
+	return domain.DecideAssignment(strategy, req), nil
+}
+
+// countOpenTickets sets each agent's number of open and in-progress tickets
+func (uc *AssignmentUseCase) countOpenTickets(ctx context.Context, agents []*domain.Agent) error {
+	statuses := []domain.TicketStatus{domain.TicketStatusOpen, domain.TicketStatusInProgress}
+
+	for _, agent := range agents {
+		agent.OpenTickets = 0
+		for i := range statuses {
+			count, err := uc.ticketRepo.Count(ctx, domain.TicketFilter{AssignedTo: &agent.ID, Status: &statuses[i]})
+			if err != nil {
+				return fmt.Errorf("failed to count open tickets for agent %s: %w", agent.ID, err)
+			}
+			agent.OpenTickets += count
+		}
+	}
+
+	return nil
+}
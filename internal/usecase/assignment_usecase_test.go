@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// memoryTicketRepo keeps tickets in memory as copies, like a database
+type memoryTicketRepo struct {
+	ports.TicketRepository
+	mu      sync.Mutex
+	tickets map[string]*domain.Ticket
+}
+
+func newMemoryTicketRepo(tickets ...*domain.Ticket) *memoryTicketRepo {
+	r := &memoryTicketRepo{tickets: make(map[string]*domain.Ticket)}
+	for _, ticket := range tickets {
+		r.Update(context.Background(), ticket)
+	}
+	return r
+}
+
+func (r *memoryTicketRepo) FindByID(ctx context.Context, id string) (*domain.Ticket, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ticket, ok := r.tickets[id]
+	if !ok {
+		return nil, domain.ErrTicketNotFound
+	}
+	c := *ticket
+	return &c, nil
+}
+
+func (r *memoryTicketRepo) Update(ctx context.Context, ticket *domain.Ticket) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := *ticket
+	r.tickets[ticket.ID] = &c
+	return nil
+}
+
+func (r *memoryTicketRepo) Count(ctx context.Context, filter domain.TicketFilter) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	count := 0
+	for _, ticket := range r.tickets {
+		if sameID(ticket.AssignedTo, filter.AssignedTo) && (filter.Status == nil || ticket.Status == *filter.Status) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// memoryAgentRepo lists fixed agents and runs beforeLock when an assignment takes the lock
+type memoryAgentRepo struct {
+	ports.AgentRepository
+	agents     []*domain.Agent
+	beforeLock func()
+}
+
+func (r *memoryAgentRepo) List(ctx context.Context, filter domain.AgentFilter) ([]*domain.Agent, error) {
+	return r.agents, nil
+}
+
+func (r *memoryAgentRepo) RecordAssignment(ctx context.Context, agentID string, at time.Time) error {
+	return nil
+}
+
+func (r *memoryAgentRepo) WithAssignmentLock(ctx context.Context, fn func(ctx context.Context) error) error {
+	if r.beforeLock != nil {
+		r.beforeLock()
+	}
+	return fn(ctx)
+}
+
+func TestAssignmentUseCase_AutoAssignNewTicketSkipsTicketAssignedMeanwhile(t *testing.T) {
+	ctx := context.Background()
+	agent, err := domain.NewAgent("agent-1", "Alex", "", map[domain.TicketCategory]int{domain.TicketCategoryNetwork: 3}, 5)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	assigned := domain.NewTicket("VPN down", "Cannot reach the VPN", domain.TicketCategoryNetwork, domain.TicketPriorityHigh, "user-1")
+	raced := domain.NewTicket("Wi-Fi drops", "Wi-Fi keeps dropping", domain.TicketCategoryNetwork, domain.TicketPriorityMedium, "user-2")
+	raced.ID = assigned.ID + "_raced"
+	tickets := newMemoryTicketRepo(assigned, raced)
+	agents := &memoryAgentRepo{agents: []*domain.Agent{agent}}
+	uc := NewAssignmentUseCase(agents, tickets, NewTicketUseCase(tickets, nil, nil, nil, nil, nil, nil), nil, AssignmentConfig{})
+
+	result, err := uc.AutoAssignNewTicket(ctx, assigned.ID)
+	if err != nil || result == nil || result.Decision.AgentID != "agent-1" {
+		t.Fatalf("Expected the ticket to be assigned to agent-1, got %+v, %v", result, err)
+	}
+
+	// An agent picks the ticket up after it was read but before the assignment lock is taken
+	agents.beforeLock = func() {
+		ticket, _ := tickets.FindByID(ctx, raced.ID)
+		ticket.Assign("agent-2")
+		tickets.Update(ctx, ticket)
+	}
+
+	result, err = uc.AutoAssignNewTicket(ctx, raced.ID)
+	if err != nil || result != nil {
+		t.Fatalf("Expected the ticket to be skipped, got %+v, %v", result, err)
+	}
+	if ticket, _ := tickets.FindByID(ctx, raced.ID); ticket.AssignedTo == nil || *ticket.AssignedTo != "agent-2" {
+		t.Errorf("Expected the ticket to stay with agent-2, got %v", ticket.AssignedTo)
+	}
+
+	agents.beforeLock = nil
+	if _, err := uc.AutoAssign(ctx, raced.ID, ""); err != nil {
+		t.Errorf("Expected an explicit auto-assign of an unchanged ticket to reassign it, got %v", err)
+	}
+}
@@ -0,0 +1,425 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// AutomationAuthorID is the author of comments added by automation rules
+const AutomationAuthorID = "automation"
+
+// AutomationConfig configures the automation engine
+type AutomationConfig struct {
+	BatchSize int // tickets loaded per page when evaluating scheduled rules
+}
+
+// AutomationUseCase manages automation rules and runs them. Event rules run when TicketUseCase
+// publishes a matching ticket event; scheduled rules run as a Scheduler job. Actions go through
+// TicketUseCase, so their own events can trigger further rules, up to domain.MaxAutomationDepth.
+type AutomationUseCase struct {
+	ruleRepo      ports.AutomationRuleRepository
+	executionRepo ports.AutomationExecutionRepository
+	ticketRepo    ports.TicketRepository
+	commentRepo   ports.CommentRepository
+	tickets       *TicketUseCase
+	notifyService ports.NotificationService
+	config        AutomationConfig
+}
+
+// NewAutomationUseCase creates a new automation use case
+func NewAutomationUseCase(
+	ruleRepo ports.AutomationRuleRepository,
+	executionRepo ports.AutomationExecutionRepository,
+	ticketRepo ports.TicketRepository,
+	commentRepo ports.CommentRepository,
+	tickets *TicketUseCase,
+	notifyService ports.NotificationService,
+	config AutomationConfig,
+) *AutomationUseCase {
+	if config.BatchSize <= 0 {
+		config.BatchSize = 200
+	}
+
+	return &AutomationUseCase{
+		ruleRepo:      ruleRepo,
+		executionRepo: executionRepo,
+		ticketRepo:    ticketRepo,
+		commentRepo:   commentRepo,
+		tickets:       tickets,
+		notifyService: notifyService,
+		config:        config,
+	}
+}
+
+// AutomationRuleRequest represents an automation rule's definition
+type AutomationRuleRequest struct {
+	Name           string                       `json:"name"`
+	Description    string                       `json:"description,omitempty"`
+	Trigger        domain.AutomationTrigger     `json:"trigger"`
+	Conditions     []domain.AutomationCondition `json:"conditions"`
+	Actions        []domain.AutomationAction    `json:"actions"`
+	Enabled        *bool                        `json:"enabled,omitempty"` // defaults to true
+	StopProcessing bool                         `json:"stop_processing"`
+	CreatedBy      string                       `json:"-"`
+}
+
+// CreateRule creates a rule, placed after the existing rules
+func (uc *AutomationUseCase) CreateRule(ctx context.Context, req AutomationRuleRequest) (*domain.AutomationRule, error) {
+	rule, err := domain.NewAutomationRule(req.Name, req.Description, req.Trigger, req.Conditions, req.Actions, req.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid automation rule: %w", err)
+	}
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+	rule.StopProcessing = req.StopProcessing
+
+	rules, err := uc.ruleRepo.List(ctx, domain.AutomationRuleFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list automation rules: %w", err)
+	}
+	for _, existing := range rules {
+		if existing.Position >= rule.Position {
+			rule.Position = existing.Position + 1
+		}
+	}
+
+	if err := uc.ruleRepo.Create(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to create automation rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+// GetRule retrieves a rule
+func (uc *AutomationUseCase) GetRule(ctx context.Context, ruleID string) (*domain.AutomationRule, error) {
+	rule, err := uc.ruleRepo.FindByID(ctx, ruleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get automation rule: %w", err)
+	}
+	return rule, nil
+}
+
+// ListRules lists rules in evaluation order
+func (uc *AutomationUseCase) ListRules(ctx context.Context, filter domain.AutomationRuleFilter) ([]*domain.AutomationRule, error) {
+	rules, err := uc.ruleRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list automation rules: %w", err)
+	}
+	return rules, nil
+}
+
+// UpdateRule replaces a rule's definition, keeping its position
+func (uc *AutomationUseCase) UpdateRule(ctx context.Context, ruleID string, req AutomationRuleRequest) (*domain.AutomationRule, error) {
+	rule, err := uc.ruleRepo.FindByID(ctx, ruleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get automation rule: %w", err)
+	}
+
+	updated, err := domain.NewAutomationRule(req.Name, req.Description, req.Trigger, req.Conditions, req.Actions, rule.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid automation rule: %w", err)
+	}
+
+	rule.Name = updated.Name
+	rule.Description = updated.Description
+	rule.Trigger = updated.Trigger
+	rule.Conditions = updated.Conditions
+	rule.Actions = updated.Actions
+	rule.StopProcessing = req.StopProcessing
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+	rule.UpdatedAt = time.Now()
+
+	if err := uc.ruleRepo.Update(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to update automation rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+// DeleteRule removes a rule and its execution log
+func (uc *AutomationUseCase) DeleteRule(ctx context.Context, ruleID string) error {
+	if err := uc.ruleRepo.Delete(ctx, ruleID); err != nil {
+		return fmt.Errorf("failed to delete automation rule: %w", err)
+	}
+	return nil
+}
+
+// ReorderRules sets the evaluation order. Every rule must be listed exactly once.
+func (uc *AutomationUseCase) ReorderRules(ctx context.Context, ruleIDs []string) ([]*domain.AutomationRule, error) {
+	rules, err := uc.ruleRepo.List(ctx, domain.AutomationRuleFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list automation rules: %w", err)
+	}
+
+	if len(ruleIDs) != len(rules) {
+		return nil, domain.ErrInvalidRuleOrder
+	}
+	known := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		known[rule.ID] = true
+	}
+	for _, id := range ruleIDs {
+		if !known[id] {
+			return nil, domain.ErrInvalidRuleOrder
+		}
+		delete(known, id) // a repeated ID is no longer known
+	}
+
+	if err := uc.ruleRepo.Reorder(ctx, ruleIDs); err != nil {
+		return nil, fmt.Errorf("failed to reorder automation rules: %w", err)
+	}
+
+	return uc.ListRules(ctx, domain.AutomationRuleFilter{})
+}
+
+// ListExecutions lists the execution log, newest first
+func (uc *AutomationUseCase) ListExecutions(ctx context.Context, filter domain.AutomationExecutionFilter) ([]*domain.AutomationExecution, error) {
+	executions, err := uc.executionRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list automation executions: %w", err)
+	}
+	return executions, nil
+}
+
+// HandleEvent runs the enabled rules triggered by a ticket event
+func (uc *AutomationUseCase) HandleEvent(ctx context.Context, event ports.Event) error {
+	if event.Aggregate != "ticket" {
+		return nil
+	}
+
+	trigger := domain.AutomationTrigger(event.Type)
+	enabled := true
+	rules, err := uc.ruleRepo.List(ctx, domain.AutomationRuleFilter{Trigger: &trigger, Enabled: &enabled})
+	if err != nil {
+		return fmt.Errorf("failed to list automation rules: %w", err)
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	ticket, err := uc.ticketRepo.FindByID(ctx, event.AggregateID)
+	if err != nil {
+		return fmt.Errorf("failed to get ticket: %w", err)
+	}
+
+	now := time.Now()
+	for _, rule := range rules {
+		if !rule.Matches(ticket, now) {
+			continue
+		}
+
+		ticket = uc.runRule(ctx, rule, ticket, event.ID)
+		if rule.StopProcessing {
+			break
+		}
+	}
+
+	return nil
+}
+
+// RunScheduledRules runs each enabled scheduled rule on the tickets it matches at now. A scheduled
+// rule acts on a ticket at most once, so reminders are not repeated on every run.
+// It returns how many times rules ran.
+func (uc *AutomationUseCase) RunScheduledRules(ctx context.Context, now time.Time) (int, error) {
+	trigger := domain.AutomationTriggerScheduled
+	enabled := true
+	rules, err := uc.ruleRepo.List(ctx, domain.AutomationRuleFilter{Trigger: &trigger, Enabled: &enabled})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list automation rules: %w", err)
+	}
+
+	runs := 0
+	stopped := make(map[string]bool) // tickets a stop_processing rule already ran on
+
+	for _, rule := range rules {
+		tickets, err := uc.scheduledCandidates(ctx, rule)
+		if err != nil {
+			return runs, err
+		}
+
+		for _, ticket := range tickets {
+			if stopped[ticket.ID] || !rule.Matches(ticket, now) {
+				continue
+			}
+
+			done, err := uc.executionRepo.HasSucceeded(ctx, rule.ID, ticket.ID)
+			if err != nil {
+				return runs, err
+			}
+			if done {
+				continue
+			}
+
+			uc.runRule(ctx, rule, ticket, "")
+			runs++
+			if rule.StopProcessing {
+				stopped[ticket.ID] = true
+			}
+		}
+	}
+
+	return runs, nil
+}
+
+// scheduledCandidates loads the tickets a scheduled rule could match, narrowed by its equality
+// conditions. All pages are loaded before any action runs, so tickets the rule changes do not
+// shift the pages.
+func (uc *AutomationUseCase) scheduledCandidates(ctx context.Context, rule *domain.AutomationRule) ([]*domain.Ticket, error) {
+	filter := domain.TicketFilter{Limit: uc.config.BatchSize}
+
+	for _, condition := range rule.Conditions {
+		if condition.Operator != domain.ConditionOpEquals {
+			continue
+		}
+		value := condition.Value
+		switch condition.Field {
+		case domain.ConditionFieldStatus:
+			status := domain.TicketStatus(value)
+			filter.Status = &status
+		case domain.ConditionFieldCategory:
+			category := domain.TicketCategory(value)
+			filter.Category = &category
+		case domain.ConditionFieldPriority:
+			priority := domain.TicketPriority(value)
+			filter.Priority = &priority
+		case domain.ConditionFieldQueueID:
+			filter.QueueID = &value
+		}
+	}
+
+	var tickets []*domain.Ticket
+	for {
+		page, err := uc.ticketRepo.List(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tickets: %w", err)
+		}
+		tickets = append(tickets, page...)
+		if len(page) < filter.Limit {
+			return tickets, nil
+		}
+		filter.Offset += filter.Limit
+	}
+}
+
+// runRule applies the rule's actions to the ticket unless loop protection stops it, and logs the
+// execution. It returns the ticket as the actions left it.
+func (uc *AutomationUseCase) runRule(ctx context.Context, rule *domain.AutomationRule, ticket *domain.Ticket, eventID string) *domain.Ticket {
+	chain := automationChainFrom(ctx)
+	execution := domain.NewAutomationExecution(rule, ticket.ID, eventID, chain.Depth)
+
+	if ok, reason := chain.Allows(rule.ID, ticket.ID); !ok {
+		execution.Skip(reason)
+		uc.recordExecution(ctx, execution)
+		return ticket
+	}
+
+	// Events published by the actions carry the chain to the rules they trigger
+	ruleCtx := context.WithValue(ctx, automationChainKey{}, chain.Next(rule.ID, ticket.ID))
+
+	for _, action := range rule.Actions {
+		result, updated, err := uc.applyAction(ruleCtx, ticket, action)
+		if err != nil {
+			execution.Fail(fmt.Errorf("%s: %w", action.Type, err))
+			break
+		}
+		execution.Actions = append(execution.Actions, result)
+		if updated != nil {
+			ticket = updated
+		}
+	}
+
+	uc.recordExecution(ctx, execution)
+	return ticket
+}
+
+// applyAction performs one action, describing what it did
+func (uc *AutomationUseCase) applyAction(ctx context.Context, ticket *domain.Ticket, action domain.AutomationAction) (string, *domain.Ticket, error) {
+	switch action.Type {
+	case domain.ActionSetPriority:
+		priority := domain.TicketPriority(action.Value)
+		if ticket.Priority == priority {
+			return fmt.Sprintf("priority already %s", priority), nil, nil
+		}
+		updated, err := uc.tickets.UpdateTicket(ctx, ticket.ID, map[string]interface{}{"priority": priority})
+		return fmt.Sprintf("set priority to %s", priority), updated, err
+
+	case domain.ActionAssign:
+		updated, err := uc.tickets.AssignTicket(ctx, ticket.ID, action.Value)
+		return fmt.Sprintf("assigned to %s", action.Value), updated, err
+
+	case domain.ActionAddComment:
+		if uc.commentRepo == nil {
+			return "", nil, fmt.Errorf("comments are not configured")
+		}
+		comment := domain.NewComment(ticket.ID, AutomationAuthorID, domain.CommentRoleAdmin, action.Value)
+		if err := uc.commentRepo.Create(ctx, comment); err != nil {
+			return "", nil, fmt.Errorf("failed to add comment: %w", err)
+		}
+		return "added comment", nil, nil
+
+	case domain.ActionNotify:
+		recipient := ticket.CreatedBy
+		switch action.Target {
+		case "", domain.NotifyTargetRequester:
+		case domain.NotifyTargetAssignee:
+			if ticket.AssignedTo == nil {
+				return "", nil, fmt.Errorf("ticket has no assignee to notify")
+			}
+			recipient = *ticket.AssignedTo
+		default:
+			recipient = action.Target
+		}
+
+		if uc.notifyService == nil {
+			return "", nil, fmt.Errorf("notifications are not configured")
+		}
+		notification := ports.NewNotification(
+			ports.NotificationTypeCustom,
+			recipient,
+			fmt.Sprintf("Ticket update: %s", ticket.Title),
+			action.Value,
+			ports.NotificationPriorityMedium,
+			ports.DefaultNotificationConfig().DefaultChannels,
+		)
+		notification.AddData("ticket_id", ticket.ID)
+		if err := uc.notifyService.SendCustomNotification(ctx, notification); err != nil {
+			return "", nil, fmt.Errorf("failed to notify %s: %w", recipient, err)
+		}
+		return fmt.Sprintf("notified %s", recipient), nil, nil
+
+	case domain.ActionResolve:
+		updated, err := uc.tickets.ResolveTicket(ctx, ticket.ID, action.Value)
+		return "resolved", updated, err
+
+	case domain.ActionClose:
+		updated, err := uc.tickets.CloseTicket(ctx, ticket.ID)
+		return "closed", updated, err
+	}
+
+	return "", nil, fmt.Errorf("%w: unknown action %q", domain.ErrInvalidRuleAction, action.Type)
+}
+
+func (uc *AutomationUseCase) recordExecution(ctx context.Context, execution *domain.AutomationExecution) {
+	if err := uc.executionRepo.Create(ctx, execution); err != nil {
+		log.Printf("Failed to log automation rule %s on ticket %s: %v", execution.RuleID, execution.TicketID, err)
+	}
+	if execution.Status != domain.AutomationExecutionSucceeded {
+		log.Printf("Automation rule %q on ticket %s %s: %s", execution.RuleName, execution.TicketID, execution.Status, execution.Error)
+	}
+}
+
+// automationChainKey carries the domain.AutomationChain of rule-triggered changes in a context
+type automationChainKey struct{}
+
+func automationChainFrom(ctx context.Context) domain.AutomationChain {
+	chain, _ := ctx.Value(automationChainKey{}).(domain.AutomationChain)
+	return chain
+}
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// ChatOpsConfig configures working tickets from a team chat
+type ChatOpsConfig struct {
+	UserMap   map[string]string // chat user ID to Fixora user ID; unmapped users cannot run commands
+	MaxListed int               // tickets listed for the "mine" command
+}
+
+// ChatOpsUseCase lets agents work tickets from a team chat: open tickets through AI intake, list
+// the tickets assigned to them, and assign, resolve or close tickets from message buttons. New
+// tickets are also posted to the chat channel when a chat notifier is configured.
+type ChatOpsUseCase struct {
+	ticketUseCase *TicketUseCase
+	aiUseCase     *AIUseCase
+	notifier      ports.ChatNotifier
+	config        ChatOpsConfig
+}
+
+// NewChatOpsUseCase creates a new chat ops use case. The notifier may be nil.
+func NewChatOpsUseCase(ticketUseCase *TicketUseCase, aiUseCase *AIUseCase, notifier ports.ChatNotifier, config ChatOpsConfig) *ChatOpsUseCase {
+	if config.UserMap == nil {
+		config.UserMap = map[string]string{}
+	}
+	if config.MaxListed <= 0 {
+		config.MaxListed = 10
+	}
+
+	return &ChatOpsUseCase{
+		ticketUseCase: ticketUseCase,
+		aiUseCase:     aiUseCase,
+		notifier:      notifier,
+		config:        config,
+	}
+}
+
+// LinkedUser returns the Fixora user a chat user is linked to
+func (uc *ChatOpsUseCase) LinkedUser(chatUserID string) (string, error) {
+	userID, ok := uc.config.UserMap[chatUserID]
+	if !ok || userID == "" {
+		return "", domain.ErrChatUserNotLinked
+	}
+	return userID, nil
+}
+
+// CreateTicket opens a ticket described in a chat message. Title, category and priority are
+// filled in by AI intake.
+func (uc *ChatOpsUseCase) CreateTicket(ctx context.Context, chatUserID, text string) (*domain.Ticket, error) {
+	userID, err := uc.LinkedUser(chatUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, fmt.Errorf("description is required")
+	}
+
+	result, err := uc.aiUseCase.IntakeCreateTicket(ctx, AITicketIntakeRequest{
+		Description:     text,
+		AutoCategorize:  true,
+		AutoPrioritize:  true,
+		AutoTitleFromAI: true,
+	}, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ticket: %w", err)
+	}
+	return result.Ticket, nil
+}
+
+// AssignedTickets lists the user's open, in progress and pending tickets, at most MaxListed of them
+func (uc *ChatOpsUseCase) AssignedTickets(ctx context.Context, chatUserID string) ([]*domain.Ticket, error) {
+	userID, err := uc.LinkedUser(chatUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	tickets, _, err := uc.ticketUseCase.ListTickets(ctx, domain.TicketFilter{
+		AssignedTo: &userID,
+		Statuses:   []domain.TicketStatus{domain.TicketStatusOpen, domain.TicketStatusInProgress, domain.TicketStatusPending},
+		Limit:      uc.config.MaxListed,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tickets, nil
+}
+
+// PerformAction assigns the ticket to the user, resolves it or closes it
+func (uc *ChatOpsUseCase) PerformAction(ctx context.Context, chatUserID string, action domain.ChatAction, ticketID string) (*domain.Ticket, error) {
+	userID, err := uc.LinkedUser(chatUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch action {
+	case domain.ChatActionAssignToMe:
+		return uc.ticketUseCase.AssignTicket(ctx, ticketID, userID)
+	case domain.ChatActionResolve:
+		return uc.ticketUseCase.ResolveTicket(ctx, ticketID, "Resolved from chat by "+userID)
+	case domain.ChatActionClose:
+		return uc.ticketUseCase.CloseTicket(ctx, ticketID)
+	default:
+		return nil, domain.ErrUnknownChatAction
+	}
+}
+
+// HandleTicketCreated posts a new ticket to the chat channel
+func (uc *ChatOpsUseCase) HandleTicketCreated(ctx context.Context, event ports.Event) error {
+	if uc.notifier == nil {
+		return nil
+	}
+
+	ticket, err := uc.ticketUseCase.GetTicket(ctx, event.AggregateID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.notifier.PostTicket(ctx, ticket, "New ticket"); err != nil {
+		return fmt.Errorf("failed to post ticket to chat: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// CSATConfig configures requester satisfaction surveys
+type CSATConfig struct {
+	LinkSecret []byte        // signs rating links
+	LinkTTL    time.Duration // how long rating links stay valid
+	BaseURL    string        // public URL the rating links point to, e.g. https://help.example.com/csat
+}
+
+// CSATUseCase issues signed rating links for resolved tickets and records requester satisfaction
+type CSATUseCase struct {
+	csatRepo       ports.CSATRepository
+	ticketRepo     ports.TicketRepository
+	eventPublisher ports.EventPublisher
+	config         CSATConfig
+}
+
+// NewCSATUseCase creates a new CSAT use case
+func NewCSATUseCase(
+	csatRepo ports.CSATRepository,
+	ticketRepo ports.TicketRepository,
+	eventPublisher ports.EventPublisher,
+	config CSATConfig,
+) *CSATUseCase {
+	if config.LinkTTL <= 0 {
+		config.LinkTTL = 30 * 24 * time.Hour
+	}
+
+	return &CSATUseCase{
+		csatRepo:       csatRepo,
+		ticketRepo:     ticketRepo,
+		eventPublisher: eventPublisher,
+		config:         config,
+	}
+}
+
+// CSATSurveyView is what the requester sees when opening a rating link
+type CSATSurveyView struct {
+	TicketID string             `json:"ticket_id"`
+	Title    string             `json:"title"`
+	Rating   *domain.CSATRating `json:"rating,omitempty"` // the current rating, if already rated
+}
+
+// NewSurvey issues the signed rating links for a ticket
+func (uc *CSATUseCase) NewSurvey(ticket *domain.Ticket) *domain.CSATSurvey {
+	expiresAt := time.Now().Add(uc.config.LinkTTL)
+	token := domain.SignCSATToken(uc.config.LinkSecret, ticket.ID, expiresAt)
+	return domain.NewCSATSurvey(ticket.ID, uc.config.BaseURL, token, expiresAt)
+}
+
+// IssueSurvey issues rating links for a resolved or closed ticket, e.g. to send them again
+func (uc *CSATUseCase) IssueSurvey(ctx context.Context, ticketID string) (*domain.CSATSurvey, error) {
+	ticket, err := uc.ticketRepo.FindByID(ctx, ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket: %w", err)
+	}
+
+	if ticket.Status != domain.TicketStatusResolved && ticket.Status != domain.TicketStatusClosed {
+		return nil, domain.ErrTicketNotResolved
+	}
+
+	return uc.NewSurvey(ticket), nil
+}
+
+// GetSurvey returns the ticket a rating link is for, with its current rating
+func (uc *CSATUseCase) GetSurvey(ctx context.Context, token string) (*CSATSurveyView, error) {
+	ticket, err := uc.ticketForToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	view := &CSATSurveyView{TicketID: ticket.ID, Title: ticket.Title}
+
+	rating, err := uc.csatRepo.FindByTicket(ctx, ticket.ID)
+	if err != nil && !errors.Is(err, domain.ErrCSATRatingNotFound) {
+		return nil, fmt.Errorf("failed to get satisfaction rating: %w", err)
+	}
+	view.Rating = rating
+
+	return view, nil
+}
+
+// SubmitRating records the score and optional comment for the ticket a rating link is for.
+// Rating again replaces the score; an empty comment keeps the earlier one.
+func (uc *CSATUseCase) SubmitRating(ctx context.Context, token string, score int, comment string) (*domain.CSATRating, error) {
+	ticket, err := uc.ticketForToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	rating, err := uc.csatRepo.FindByTicket(ctx, ticket.ID)
+	switch {
+	case errors.Is(err, domain.ErrCSATRatingNotFound):
+		rating, err = domain.NewCSATRating(ticket, score, comment)
+	case err == nil:
+		err = rating.Rate(score, comment)
+	default:
+		return nil, fmt.Errorf("failed to get satisfaction rating: %w", err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid satisfaction rating: %w", err)
+	}
+
+	if err := uc.csatRepo.Save(ctx, rating); err != nil {
+		return nil, fmt.Errorf("failed to save satisfaction rating: %w", err)
+	}
+
+	if uc.eventPublisher != nil {
+		event := ports.NewEvent(
+			ports.EventTypeCSATSubmitted,
+			"ticket",
+			ticket.ID,
+			map[string]interface{}{
+				"score":    rating.Score,
+				"agent_id": rating.AgentID,
+				"category": rating.Category,
+			},
+			1,
+		)
+		_ = uc.eventPublisher.Publish(ctx, *event)
+	}
+
+	return rating, nil
+}
+
+// GetTicketRating retrieves a ticket's rating
+func (uc *CSATUseCase) GetTicketRating(ctx context.Context, ticketID string) (*domain.CSATRating, error) {
+	rating, err := uc.csatRepo.FindByTicket(ctx, ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get satisfaction rating: %w", err)
+	}
+	return rating, nil
+}
+
+// ListRatings lists ratings, newest first
+func (uc *CSATUseCase) ListRatings(ctx context.Context, filter domain.CSATFilter) ([]*domain.CSATRating, error) {
+	ratings, err := uc.csatRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list satisfaction ratings: %w", err)
+	}
+	return ratings, nil
+}
+
+func (uc *CSATUseCase) ticketForToken(ctx context.Context, token string) (*domain.Ticket, error) {
+	ticketID, err := domain.ParseCSATToken(uc.config.LinkSecret, token, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	ticket, err := uc.ticketRepo.FindByID(ctx, ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket: %w", err)
+	}
+	return ticket, nil
+}
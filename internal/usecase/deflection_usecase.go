@@ -0,0 +1,312 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// DeflectionUseCase lets employees try AI suggestions before raising a ticket. A session either
+// ends with "this fixed it", recorded as a deflection, or with a ticket carrying the suggestion.
+type DeflectionUseCase struct {
+	deflectionRepo ports.DeflectionRepository
+	aiUseCase      *AIUseCase
+	tickets        *TicketUseCase
+	feedback       *FeedbackUseCase
+	eventPublisher ports.EventPublisher
+}
+
+// NewDeflectionUseCase creates a new deflection use case
+func NewDeflectionUseCase(
+	deflectionRepo ports.DeflectionRepository,
+	aiUseCase *AIUseCase,
+	tickets *TicketUseCase,
+	feedback *FeedbackUseCase,
+	eventPublisher ports.EventPublisher,
+) *DeflectionUseCase {
+	return &DeflectionUseCase{
+		deflectionRepo: deflectionRepo,
+		aiUseCase:      aiUseCase,
+		tickets:        tickets,
+		feedback:       feedback,
+		eventPublisher: eventPublisher,
+	}
+}
+
+// StartDeflectionRequest describes the employee's problem
+type StartDeflectionRequest struct {
+	Description string `json:"description"`
+	RequestedBy string `json:"requested_by"`
+}
+
+// ResolveDeflectionRequest confirms that a suggestion fixed the problem
+type ResolveDeflectionRequest struct {
+	Rank        int    `json:"rank"`
+	Comment     string `json:"comment,omitempty"`
+	RequestedBy string `json:"requested_by"`
+}
+
+// EscalateDeflectionRequest creates a ticket from the session. Omitted fields default to a title
+// from the description, category OTHER and priority MEDIUM; rank zero attaches the top candidate.
+type EscalateDeflectionRequest struct {
+	Title       string                `json:"title,omitempty"`
+	Category    domain.TicketCategory `json:"category,omitempty"`
+	Priority    domain.TicketPriority `json:"priority,omitempty"`
+	Rank        int                   `json:"rank,omitempty"`
+	RequestedBy string                `json:"requested_by"`
+}
+
+// EscalateDeflectionResponse represents the session and the ticket created from it
+type EscalateDeflectionResponse struct {
+	Session *domain.DeflectionSession `json:"session"`
+	Ticket  *domain.Ticket            `json:"ticket"`
+}
+
+// StartSession opens a deflection session for a problem description
+func (uc *DeflectionUseCase) StartSession(ctx context.Context, req StartDeflectionRequest) (*domain.DeflectionSession, error) {
+	session, err := domain.NewDeflectionSession(req.Description, req.RequestedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid deflection session: %w", err)
+	}
+
+	if err := uc.deflectionRepo.Create(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to create deflection session: %w", err)
+	}
+
+	return session, nil
+}
+
+// GetSession retrieves a deflection session of the requester
+func (uc *DeflectionUseCase) GetSession(ctx context.Context, sessionID, requestedBy string) (*domain.DeflectionSession, error) {
+	session, err := uc.deflectionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deflection session: %w", err)
+	}
+
+	if session.RequestedBy != requestedBy {
+		return nil, domain.ErrNotSessionRequester
+	}
+
+	return session, nil
+}
+
+// StreamSuggestions streams KB-grounded suggestions for the session's description. Events are
+// passed through unchanged; the query ID and each candidate are recorded on the session before the
+// candidate reaches the client, so either outcome can be traced back to what was offered.
+func (uc *DeflectionUseCase) StreamSuggestions(ctx context.Context, sessionID, requestedBy string) (<-chan ports.SuggestionEvent, error) {
+	session, err := uc.GetSession(ctx, sessionID, requestedBy)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status != domain.DeflectionStatusOpen {
+		return nil, domain.ErrDeflectionSessionClosed
+	}
+
+	upstream, err := uc.aiUseCase.StreamSuggestion(ctx, session.Description)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan ports.SuggestionEvent, 10)
+
+	go func() {
+		defer close(events)
+
+		for event := range upstream {
+			if event.QueryID != "" && event.QueryID != session.QueryID {
+				_ = session.StartQuery(event.QueryID)
+			}
+
+			if event.Type == "candidate" {
+				if candidate, ok := deflectionCandidate(event.Data); ok {
+					session.AddCandidate(candidate)
+					// The client may go away after seeing the candidate; it is still worth keeping
+					if err := uc.deflectionRepo.SaveCandidates(context.WithoutCancel(ctx), session); err != nil {
+						log.Printf("Failed to record suggestions of deflection session %s: %v", session.ID, err)
+					}
+				}
+			}
+
+			select {
+			case events <- event:
+			case <-ctx.Done():
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// ResolveSession records that a suggestion fixed the problem. No ticket is created; the candidate
+// is rated as solved so the deflection counts towards AI accuracy metrics.
+func (uc *DeflectionUseCase) ResolveSession(ctx context.Context, sessionID string, req ResolveDeflectionRequest) (*domain.DeflectionSession, error) {
+	session, err := uc.GetSession(ctx, sessionID, req.RequestedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := session.Deflect(req.Rank); err != nil {
+		return nil, err
+	}
+
+	if err := uc.deflectionRepo.Close(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to close deflection session: %w", err)
+	}
+
+	if uc.feedback != nil {
+		candidate, _ := session.Candidate(req.Rank)
+		_, err := uc.feedback.SubmitCandidateFeedback(ctx, SubmitCandidateFeedbackRequest{
+			QueryID:     session.QueryID,
+			Rank:        candidate.Rank,
+			Rating:      domain.FeedbackRatingSolved,
+			Comment:     req.Comment,
+			SubmittedBy: session.RequestedBy,
+		})
+		if err != nil {
+			log.Printf("Failed to record feedback for deflection session %s: %v", session.ID, err)
+		}
+	}
+
+	uc.publish(ctx, ports.EventTypeDeflectionResolved, session)
+
+	return session, nil
+}
+
+// EscalateSession creates a ticket for a problem the suggestions did not fix, with the chosen
+// suggestion attached as its AI insight. The session is closed before the ticket is created, so
+// a double submit or a concurrent resolve cannot create a second ticket.
+func (uc *DeflectionUseCase) EscalateSession(ctx context.Context, sessionID string, req EscalateDeflectionRequest) (*EscalateDeflectionResponse, error) {
+	session, err := uc.GetSession(ctx, sessionID, req.RequestedBy)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status != domain.DeflectionStatusOpen {
+		return nil, domain.ErrDeflectionSessionClosed
+	}
+
+	candidate := session.TopCandidate()
+	if req.Rank > 0 {
+		if candidate, err = session.Candidate(req.Rank); err != nil {
+			return nil, err
+		}
+	}
+
+	createReq := CreateTicketRequest{
+		Title:       req.Title,
+		Description: session.Description,
+		Category:    req.Category,
+		Priority:    req.Priority,
+		CreatedBy:   session.RequestedBy,
+	}
+	if createReq.Title == "" {
+		createReq.Title = defaultTitleFromDescription(session.Description)
+	}
+	if createReq.Category == "" {
+		createReq.Category = domain.TicketCategoryOther
+	}
+	if createReq.Priority == "" {
+		createReq.Priority = domain.TicketPriorityMedium
+	}
+
+	rank := 0
+	if candidate != nil && candidate.Suggestion != ports.NoGroundedAnswer {
+		createReq.Suggestion = suggestionFromCandidate(candidate)
+		rank = candidate.Rank
+	}
+
+	if err := session.Escalate(rank); err != nil {
+		return nil, err
+	}
+	if err := uc.deflectionRepo.Close(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to close deflection session: %w", err)
+	}
+
+	created, err := uc.tickets.CreateTicket(ctx, createReq)
+	if err != nil {
+		// Give the session back so the employee can try again
+		session.Reopen()
+		if reopenErr := uc.deflectionRepo.Update(ctx, session); reopenErr != nil {
+			log.Printf("Failed to reopen deflection session %s: %v", session.ID, reopenErr)
+		}
+		return nil, fmt.Errorf("failed to create ticket: %w", err)
+	}
+
+	if err := session.AttachTicket(created.Ticket.ID); err != nil {
+		return nil, err
+	}
+	if err := uc.deflectionRepo.Update(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to update deflection session: %w", err)
+	}
+
+	uc.publish(ctx, ports.EventTypeDeflectionEscalated, session)
+
+	return &EscalateDeflectionResponse{
+		Session: session,
+		Ticket:  created.Ticket,
+	}, nil
+}
+
+func (uc *DeflectionUseCase) publish(ctx context.Context, eventType string, session *domain.DeflectionSession) {
+	if uc.eventPublisher == nil {
+		return
+	}
+
+	event := ports.NewEvent(
+		eventType,
+		"deflection_session",
+		session.ID,
+		map[string]interface{}{
+			"query_id":      session.QueryID,
+			"status":        session.Status,
+			"selected_rank": session.SelectedRank,
+			"ticket_id":     session.TicketID,
+			"requested_by":  session.RequestedBy,
+		},
+		1,
+	)
+	_ = uc.eventPublisher.Publish(ctx, *event)
+}
+
+// deflectionCandidate converts the data of a candidate event
+func deflectionCandidate(data interface{}) (domain.DeflectionCandidate, bool) {
+	candidate, ok := candidateData(data)
+	if !ok {
+		return domain.DeflectionCandidate{}, false
+	}
+
+	return domain.DeflectionCandidate{
+		Rank:       candidate.Rank,
+		Score:      candidate.Score,
+		Suggestion: candidate.Suggestion,
+		Category:   candidate.Category,
+		EntryID:    candidate.EntryID,
+		ChunkIndex: candidate.ChunkIndex,
+		Source:     candidate.Source,
+		Model:      candidate.Model,
+	}, true
+}
+
+// suggestionFromCandidate rebuilds the suggestion the employee saw, to attach it to their ticket
+func suggestionFromCandidate(candidate *domain.DeflectionCandidate) *ports.SuggestionResult {
+	suggestion := &ports.SuggestionResult{
+		Suggestion: candidate.Suggestion,
+		Confidence: candidate.Score,
+		Category:   candidate.Category,
+		Source:     candidate.Source,
+		Model:      candidate.Model,
+	}
+
+	if candidate.EntryID != "" {
+		suggestion.Grounded = true
+		suggestion.Citations = []ports.Citation{{
+			EntryID:    candidate.EntryID,
+			ChunkIndex: candidate.ChunkIndex,
+			Score:      candidate.Score,
+		}}
+	}
+
+	return suggestion
+}
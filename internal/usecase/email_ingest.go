@@ -0,0 +1,280 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"unicode/utf8"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// EmailIngestConfig configures how inbound email becomes tickets
+type EmailIngestConfig struct {
+	UseAI bool // predict category and priority and attach an AI suggestion to new tickets
+	// ReplySecret verifies the ticket token in the reply-to address of outbound notifications
+	ReplySecret []byte
+}
+
+// EmailIngestAction tells what ingesting an email did
+type EmailIngestAction string
+
+const (
+	EmailIngestCreated   EmailIngestAction = "created"   // opened a new ticket
+	EmailIngestCommented EmailIngestAction = "commented" // added a comment to the ticket it replies to
+	EmailIngestDuplicate EmailIngestAction = "duplicate" // the email was already ingested
+	EmailIngestIgnored   EmailIngestAction = "ignored"   // auto-reply or empty reply
+)
+
+// EmailIngestResult is the outcome of ingesting one email
+type EmailIngestResult struct {
+	Action    EmailIngestAction `json:"action"`
+	TicketID  string            `json:"ticket_id,omitempty"`
+	CommentID string            `json:"comment_id,omitempty"`
+}
+
+// Predictions below these confidences leave the email's ticket at the default category or priority
+const (
+	emailCategoryThreshold = 0.70
+	emailPriorityThreshold = 0.70
+)
+
+// EmailIngestUseCase turns inbound email into tickets. Replies to a ticket's emails become comments
+// on it; other email opens a new ticket with the sender as requester.
+type EmailIngestUseCase struct {
+	messageRepo ports.EmailMessageRepository
+	ticketRepo  ports.TicketRepository
+	tickets     *TicketUseCase
+	ai          *AIUseCase
+	config      EmailIngestConfig
+}
+
+// NewEmailIngestUseCase creates a new email ingest use case. AI is optional; without it new
+// tickets get the default category and priority.
+func NewEmailIngestUseCase(
+	messageRepo ports.EmailMessageRepository,
+	ticketRepo ports.TicketRepository,
+	tickets *TicketUseCase,
+	ai *AIUseCase,
+	config EmailIngestConfig,
+) *EmailIngestUseCase {
+	return &EmailIngestUseCase{
+		messageRepo: messageRepo,
+		ticketRepo:  ticketRepo,
+		tickets:     tickets,
+		ai:          ai,
+		config:      config,
+	}
+}
+
+// HandleInboundEmail ingests an email delivered by a mail listener
+func (uc *EmailIngestUseCase) HandleInboundEmail(ctx context.Context, email *domain.InboundEmail) error {
+	result, err := uc.Ingest(ctx, email)
+	if err != nil {
+		return err
+	}
+	log.Printf("Inbound email %s from %s %s ticket %s", email.MessageID, email.From, result.Action, result.TicketID)
+	return nil
+}
+
+// Ingest threads an email into the ticket it replies to, or opens a ticket for it. A reply is
+// matched by its In-Reply-To and References headers, then by the ticket tag in its subject, and
+// is only added to a ticket the sender requested; otherwise it opens a new ticket.
+func (uc *EmailIngestUseCase) Ingest(ctx context.Context, email *domain.InboundEmail) (*EmailIngestResult, error) {
+	if err := email.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Never answer auto-replies, which could loop with our own notifications
+	if email.AutoSubmitted {
+		return &EmailIngestResult{Action: EmailIngestIgnored}, nil
+	}
+
+	if email.MessageID != "" {
+		existing, err := uc.messageRepo.FindByMessageID(ctx, email.MessageID)
+		switch {
+		case err == nil:
+			return &EmailIngestResult{Action: EmailIngestDuplicate, TicketID: existing.TicketID}, nil
+		case !errors.Is(err, domain.ErrEmailMessageNotFound):
+			return nil, fmt.Errorf("failed to get email message: %w", err)
+		}
+	}
+
+	body := domain.StripQuotedReply(email.Body)
+
+	ticket, err := uc.threadTicket(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	if ticket != nil {
+		return uc.addReply(ctx, email, ticket, body)
+	}
+	return uc.createTicket(ctx, email, body)
+}
+
+// threadTicket finds the open ticket the email replies to, if the sender requested it
+func (uc *EmailIngestUseCase) threadTicket(ctx context.Context, email *domain.InboundEmail) (*domain.Ticket, error) {
+	var ticketID string
+	for _, ref := range email.ThreadReferences() {
+		message, err := uc.messageRepo.FindByMessageID(ctx, ref)
+		if errors.Is(err, domain.ErrEmailMessageNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get email message: %w", err)
+		}
+		ticketID = message.TicketID
+		break
+	}
+
+	if ticketID == "" {
+		ticketID = uc.replyTokenTicket(email)
+	}
+
+	if ticketID == "" {
+		id, ok := domain.TicketIDFromSubject(email.Subject)
+		if !ok {
+			return nil, nil
+		}
+		ticketID = id
+	}
+
+	ticket, err := uc.ticketRepo.FindByID(ctx, ticketID)
+	if errors.Is(err, domain.ErrTicketNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket: %w", err)
+	}
+
+	// A reply to a closed ticket, or from someone other than the requester, is a new request
+	if ticket.Status == domain.TicketStatusClosed || !strings.EqualFold(ticket.CreatedBy, email.From) {
+		return nil, nil
+	}
+	return ticket, nil
+}
+
+// replyTokenTicket returns the ticket named by a valid reply token in the recipient addresses
+func (uc *EmailIngestUseCase) replyTokenTicket(email *domain.InboundEmail) string {
+	if len(uc.config.ReplySecret) == 0 {
+		return ""
+	}
+	for _, to := range email.To {
+		token, ok := domain.ReplyTokenFromAddress(to)
+		if !ok {
+			continue
+		}
+		if ticketID, err := domain.ParseReplyToken(uc.config.ReplySecret, token); err == nil {
+			return ticketID
+		}
+	}
+	return ""
+}
+
+func (uc *EmailIngestUseCase) addReply(ctx context.Context, email *domain.InboundEmail, ticket *domain.Ticket, body string) (*EmailIngestResult, error) {
+	if body == "" {
+		return &EmailIngestResult{Action: EmailIngestIgnored, TicketID: ticket.ID}, nil
+	}
+
+	comment, err := uc.tickets.AddComment(ctx, ticket.ID, ticket.CreatedBy, domain.CommentRoleEmployee, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add email reply: %w", err)
+	}
+
+	// The requester answered, so the ticket is no longer waiting on them
+	if ticket.Status == domain.TicketStatusPending {
+		if _, err := uc.tickets.ResumeTicket(ctx, ticket.ID); err != nil {
+			log.Printf("Failed to resume ticket %s after email reply: %v", ticket.ID, err)
+		}
+	}
+
+	message := domain.NewEmailMessage(email.MessageID, ticket.ID, domain.EmailDirectionInbound, email.From, email.Subject)
+	message.CommentID = &comment.ID
+	if err := uc.record(ctx, message); err != nil {
+		return nil, err
+	}
+
+	return &EmailIngestResult{Action: EmailIngestCommented, TicketID: ticket.ID, CommentID: comment.ID}, nil
+}
+
+func (uc *EmailIngestUseCase) createTicket(ctx context.Context, email *domain.InboundEmail, body string) (*EmailIngestResult, error) {
+	title := domain.CleanEmailSubject(email.Subject)
+	if utf8.RuneCountInString(title) < 3 {
+		title = defaultTitleFromDescription(firstLine(body))
+	}
+	if utf8.RuneCountInString(title) < 3 {
+		title = "Email from " + email.From
+	}
+
+	description := body
+	if len(description) < 10 {
+		description = strings.TrimSpace(title + "\n\n" + body)
+	}
+	if len(description) < 10 {
+		description = "Email from " + email.From + ": " + description
+	}
+
+	req := CreateTicketRequest{
+		Title:       truncateUTF8(title, 200),
+		Description: truncateUTF8(description, 2000),
+		Category:    domain.TicketCategoryOther,
+		Priority:    domain.TicketPriorityMedium,
+		CreatedBy:   email.From,
+		UseAI:       uc.config.UseAI,
+	}
+
+	if uc.config.UseAI && uc.ai != nil {
+		preds := uc.ai.predictAttributes(ctx, req.Title+"\n\n"+req.Description)
+		if preds.Category.Value != "" && preds.Category.Confidence >= emailCategoryThreshold {
+			req.Category = normalizeCategory(preds.Category.Value)
+		}
+		if preds.Priority.Value != "" && preds.Priority.Confidence >= emailPriorityThreshold {
+			req.Priority = normalizePriority(preds.Priority.Value)
+		}
+	}
+
+	response, err := uc.tickets.CreateTicket(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ticket from email: %w", err)
+	}
+
+	message := domain.NewEmailMessage(email.MessageID, response.Ticket.ID, domain.EmailDirectionInbound, email.From, email.Subject)
+	if err := uc.record(ctx, message); err != nil {
+		return nil, err
+	}
+
+	return &EmailIngestResult{Action: EmailIngestCreated, TicketID: response.Ticket.ID}, nil
+}
+
+// record stores the email so replies to it are threaded to its ticket. Emails without a
+// Message-ID cannot be replied to by reference.
+func (uc *EmailIngestUseCase) record(ctx context.Context, message *domain.EmailMessage) error {
+	if message.MessageID == "" {
+		return nil
+	}
+	if err := uc.messageRepo.Create(ctx, message); err != nil {
+		return fmt.Errorf("failed to record email message: %w", err)
+	}
+	return nil
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return strings.TrimSpace(line)
+}
+
+// truncateUTF8 shortens s to at most max bytes without splitting a character
+func truncateUTF8(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	s = s[:max]
+	for !utf8.ValidString(s) {
+		s = s[:len(s)-1]
+	}
+	return s
+}
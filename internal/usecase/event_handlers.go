@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// ResolvedTicketTrainer trains the AI models on each ticket as it is resolved
+type ResolvedTicketTrainer struct {
+	aiUseCase *AIUseCase
+}
+
+// NewResolvedTicketTrainer creates a handler for ticket_resolved events
+func NewResolvedTicketTrainer(aiUseCase *AIUseCase) *ResolvedTicketTrainer {
+	return &ResolvedTicketTrainer{aiUseCase: aiUseCase}
+}
+
+// Handle trains on the resolved ticket
+func (h *ResolvedTicketTrainer) Handle(ctx context.Context, event ports.Event) error {
+	return h.aiUseCase.TrainFromResolvedTicket(ctx, event.AggregateID)
+}
+
+// EventType returns the event type the handler subscribes to
+func (h *ResolvedTicketTrainer) EventType() string {
+	return ports.EventTypeTicketResolved
+}
+
+// RatedTicketTrainer trains the AI model again on each ticket the requester rates
+type RatedTicketTrainer struct {
+	aiUseCase *AIUseCase
+}
+
+// NewRatedTicketTrainer creates a handler for csat_submitted events
+func NewRatedTicketTrainer(aiUseCase *AIUseCase) *RatedTicketTrainer {
+	return &RatedTicketTrainer{aiUseCase: aiUseCase}
+}
+
+// Handle trains on the rated ticket
+func (h *RatedTicketTrainer) Handle(ctx context.Context, event ports.Event) error {
+	return h.aiUseCase.TrainFromRatedTicket(ctx, event.AggregateID)
+}
+
+// EventType returns the event type the handler subscribes to
+func (h *RatedTicketTrainer) EventType() string {
+	return ports.EventTypeCSATSubmitted
+}
+
+// LearnedEntryDrafter drafts a LEARNED knowledge base entry from each resolved ticket
+type LearnedEntryDrafter struct {
+	learner *KBLearner
+}
+
+// NewLearnedEntryDrafter creates a handler for ticket_resolved events
+func NewLearnedEntryDrafter(learner *KBLearner) *LearnedEntryDrafter {
+	return &LearnedEntryDrafter{learner: learner}
+}
+
+// Handle drafts an entry from the resolved ticket, attributed to whoever resolved it
+func (h *LearnedEntryDrafter) Handle(ctx context.Context, event ports.Event) error {
+	resolvedBy, _ := event.Data["resolved_by"].(string)
+
+	result, err := h.learner.DraftFromResolvedTicket(ctx, event.AggregateID, resolvedBy)
+	if err != nil {
+		return err
+	}
+
+	if result.Entry != nil {
+		log.Printf("Drafted learned knowledge base entry %s from ticket %s", result.Entry.ID, event.AggregateID)
+	}
+	return nil
+}
+
+// EventType returns the event type the handler subscribes to
+func (h *LearnedEntryDrafter) EventType() string {
+	return ports.EventTypeTicketResolved
+}
+
+// TicketAutoAssigner routes each new ticket to an agent with the default assignment strategy
+type TicketAutoAssigner struct {
+	assignments *AssignmentUseCase
+}
+
+// NewTicketAutoAssigner creates a handler for ticket_created events
+func NewTicketAutoAssigner(assignments *AssignmentUseCase) *TicketAutoAssigner {
+	return &TicketAutoAssigner{assignments: assignments}
+}
+
+// Handle assigns the new ticket unless it was already assigned
+func (h *TicketAutoAssigner) Handle(ctx context.Context, event ports.Event) error {
+	result, err := h.assignments.AutoAssignNewTicket(ctx, event.AggregateID)
+	if errors.Is(err, domain.ErrNoEligibleAgent) {
+		log.Printf("No agent available for ticket %s: %s", event.AggregateID, result.Decision.Reason)
+		return nil
+	}
+	if err != nil || result == nil {
+		return err
+	}
+
+	log.Printf("Assigned ticket %s to agent %s (%s)", event.AggregateID, result.Decision.AgentID, result.Decision.Strategy)
+	return nil
+}
+
+// EventType returns the event type the handler subscribes to
+func (h *TicketAutoAssigner) EventType() string {
+	return ports.EventTypeTicketCreated
+}
+
+// AutomationTriggerHandler runs the automation rules for one ticket event type
+type AutomationTriggerHandler struct {
+	automation *AutomationUseCase
+	trigger    domain.AutomationTrigger
+}
+
+// NewAutomationTriggerHandlers creates a handler for each event type automation rules can trigger on
+func NewAutomationTriggerHandlers(automation *AutomationUseCase) []*AutomationTriggerHandler {
+	handlers := make([]*AutomationTriggerHandler, 0, len(domain.EventTriggers))
+	for _, trigger := range domain.EventTriggers {
+		handlers = append(handlers, &AutomationTriggerHandler{automation: automation, trigger: trigger})
+	}
+	return handlers
+}
+
+// Handle runs the rules triggered by the event
+func (h *AutomationTriggerHandler) Handle(ctx context.Context, event ports.Event) error {
+	return h.automation.HandleEvent(ctx, event)
+}
+
+// EventType returns the event type the handler subscribes to
+func (h *AutomationTriggerHandler) EventType() string {
+	return string(h.trigger)
+}
+
+// WebhookEventHandler delivers events of one type to webhook subscriptions
+type WebhookEventHandler struct {
+	webhooks  *WebhookUseCase
+	eventType string
+}
+
+// NewWebhookEventHandlers creates a handler for each event type webhooks can subscribe to
+func NewWebhookEventHandlers(webhooks *WebhookUseCase) []*WebhookEventHandler {
+	handlers := make([]*WebhookEventHandler, 0, len(ports.EventTypes))
+	for _, eventType := range ports.EventTypes {
+		handlers = append(handlers, &WebhookEventHandler{webhooks: webhooks, eventType: eventType})
+	}
+	return handlers
+}
+
+// Handle delivers the event to its subscribers
+func (h *WebhookEventHandler) Handle(ctx context.Context, event ports.Event) error {
+	return h.webhooks.HandleEvent(ctx, event)
+}
+
+// EventType returns the event type the handler subscribes to
+func (h *WebhookEventHandler) EventType() string {
+	return h.eventType
+}
+
+// ChatTicketNotifier posts new tickets to the team chat
+type ChatTicketNotifier struct {
+	chatOps *ChatOpsUseCase
+}
+
+// NewChatTicketNotifier creates a handler for ticket_created events
+func NewChatTicketNotifier(chatOps *ChatOpsUseCase) *ChatTicketNotifier {
+	return &ChatTicketNotifier{chatOps: chatOps}
+}
+
+// Handle posts the created ticket
+func (h *ChatTicketNotifier) Handle(ctx context.Context, event ports.Event) error {
+	return h.chatOps.HandleTicketCreated(ctx, event)
+}
+
+// EventType returns the event type the handler subscribes to
+func (h *ChatTicketNotifier) EventType() string {
+	return ports.EventTypeTicketCreated
+}
@@ -0,0 +1,228 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// selfResolvedResolution is recorded on tickets the requester marked as solved by the AI insight
+const selfResolvedResolution = "Solved by the AI suggestion (confirmed by the requester)"
+
+// FeedbackUseCase records whether AI suggestions helped and reports AI accuracy
+type FeedbackUseCase struct {
+	feedbackRepo   ports.FeedbackRepository
+	ticketRepo     ports.TicketRepository
+	csatRepo       ports.CSATRepository
+	tickets        *TicketUseCase
+	eventPublisher ports.EventPublisher
+}
+
+// NewFeedbackUseCase creates a new feedback use case. Tickets are resolved through the ticket use
+// case so a self-resolved ticket gets the same comment, event and notification as any other.
+// Without a CSAT repository, metrics carry no satisfaction scores.
+func NewFeedbackUseCase(
+	feedbackRepo ports.FeedbackRepository,
+	ticketRepo ports.TicketRepository,
+	csatRepo ports.CSATRepository,
+	tickets *TicketUseCase,
+	eventPublisher ports.EventPublisher,
+) *FeedbackUseCase {
+	return &FeedbackUseCase{
+		feedbackRepo:   feedbackRepo,
+		ticketRepo:     ticketRepo,
+		csatRepo:       csatRepo,
+		tickets:        tickets,
+		eventPublisher: eventPublisher,
+	}
+}
+
+// SubmitTicketFeedbackRequest rates the AI insight attached to a ticket
+type SubmitTicketFeedbackRequest struct {
+	Rating      domain.FeedbackRating `json:"rating"`
+	Comment     string                `json:"comment,omitempty"`
+	SubmittedBy string                `json:"submitted_by"`
+}
+
+// SubmitCandidateFeedbackRequest rates a candidate from the suggestion stream, identified by the
+// query ID and rank of its candidate event
+type SubmitCandidateFeedbackRequest struct {
+	QueryID     string                `json:"query_id"`
+	Rank        int                   `json:"rank"`
+	Rating      domain.FeedbackRating `json:"rating"`
+	Comment     string                `json:"comment,omitempty"`
+	SubmittedBy string                `json:"submitted_by"`
+}
+
+// SubmitTicketFeedback records the requester's rating of a ticket's AI insight. Marking it as
+// solved resolves the ticket and counts it as deflected.
+func (uc *FeedbackUseCase) SubmitTicketFeedback(ctx context.Context, ticketID string, req SubmitTicketFeedbackRequest) (*domain.SuggestionFeedback, error) {
+	if ticketID == "" {
+		return nil, fmt.Errorf("ticket ID is required")
+	}
+
+	ticket, err := uc.ticketRepo.FindByID(ctx, ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket: %w", err)
+	}
+
+	feedback, err := domain.NewTicketInsightFeedback(ticket, req.Rating, req.Comment, req.SubmittedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid feedback: %w", err)
+	}
+
+	// Only a ticket that is still open is resolved by its insight. It is resolved before the
+	// feedback is saved so a failed resolve never leaves a deflection behind for an open ticket.
+	if feedback.Deflected && uc.tickets != nil {
+		if _, err := uc.tickets.ResolveTicket(ctx, ticket.ID, selfResolvedResolution); err != nil {
+			return nil, fmt.Errorf("failed to resolve ticket: %w", err)
+		}
+	}
+
+	if err := uc.feedbackRepo.Save(ctx, feedback); err != nil {
+		return nil, fmt.Errorf("failed to save feedback: %w", err)
+	}
+
+	uc.publish(ctx, feedback)
+
+	return feedback, nil
+}
+
+// SubmitCandidateFeedback records a rating of a streamed suggestion candidate. The candidate is
+// looked up from what the stream recorded, so feedback is attributed to the provider, model and
+// entry that produced it. Each person rates a candidate once; a repeat fails with
+// ErrFeedbackAlreadyGiven.
+func (uc *FeedbackUseCase) SubmitCandidateFeedback(ctx context.Context, req SubmitCandidateFeedbackRequest) (*domain.SuggestionFeedback, error) {
+	if req.QueryID == "" || req.Rank < 1 {
+		return nil, fmt.Errorf("invalid feedback: %w", domain.ErrInvalidFeedbackTarget)
+	}
+
+	candidate, err := uc.feedbackRepo.FindCandidate(ctx, req.QueryID, req.Rank)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get suggestion candidate: %w", err)
+	}
+
+	feedback, err := domain.NewCandidateFeedback(candidate, req.Rating, req.Comment, req.SubmittedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid feedback: %w", err)
+	}
+
+	if err := uc.feedbackRepo.Create(ctx, feedback); err != nil {
+		return nil, fmt.Errorf("failed to save feedback: %w", err)
+	}
+
+	uc.publish(ctx, feedback)
+
+	return feedback, nil
+}
+
+// GetMetrics calculates ticket counts, AI accuracy per category and provider, and requester
+// satisfaction per agent, category and period
+func (uc *FeedbackUseCase) GetMetrics(ctx context.Context, filter domain.MetricFilter) (*domain.Metric, error) {
+	if filter.Period == "" {
+		filter.Period = domain.MetricPeriodWeekly
+	}
+
+	start, end, err := metricWindow(filter, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	feedbackFilter := domain.FeedbackFilter{StartDate: start, EndDate: end}
+	if filter.Category != nil {
+		feedbackFilter.Category = *filter.Category
+	}
+
+	feedback, err := uc.feedbackRepo.List(ctx, feedbackFilter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feedback: %w", err)
+	}
+
+	metric := domain.NewMetric(string(filter.Period))
+	metric.CalculateAIFeedback(feedback)
+
+	if uc.csatRepo != nil {
+		csatFilter := domain.CSATFilter{StartDate: start, EndDate: end, Category: feedbackFilter.Category}
+		ratings, err := uc.csatRepo.List(ctx, csatFilter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list satisfaction ratings: %w", err)
+		}
+		metric.CalculateCSAT(ratings, filter.Period)
+	}
+
+	// Ticket counts are current totals, not limited to the period
+	ticketFilter := domain.TicketFilter{}
+	if filter.Category != nil {
+		category := domain.TicketCategory(*filter.Category)
+		ticketFilter.Category = &category
+	}
+
+	if metric.TotalTickets, err = uc.ticketRepo.Count(ctx, ticketFilter); err != nil {
+		return nil, fmt.Errorf("failed to count tickets: %w", err)
+	}
+	if metric.OpenTickets, err = uc.countByStatus(ctx, ticketFilter, domain.TicketStatusOpen); err != nil {
+		return nil, err
+	}
+	if metric.ResolvedTickets, err = uc.countByStatus(ctx, ticketFilter, domain.TicketStatusResolved); err != nil {
+		return nil, err
+	}
+
+	return metric, nil
+}
+
+func (uc *FeedbackUseCase) countByStatus(ctx context.Context, filter domain.TicketFilter, status domain.TicketStatus) (int, error) {
+	filter.Status = &status
+	count, err := uc.ticketRepo.Count(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count %s tickets: %w", status, err)
+	}
+	return count, nil
+}
+
+func (uc *FeedbackUseCase) publish(ctx context.Context, feedback *domain.SuggestionFeedback) {
+	if uc.eventPublisher == nil {
+		return
+	}
+
+	event := ports.NewEvent(
+		ports.EventTypeSuggestionFeedback,
+		"suggestion_feedback",
+		feedback.ID,
+		map[string]interface{}{
+			"target_type": feedback.TargetType,
+			"target_id":   feedback.TargetID,
+			"rating":      feedback.Rating,
+			"provider":    feedback.Provider,
+			"deflected":   feedback.Deflected,
+		},
+		1,
+	)
+	_ = uc.eventPublisher.Publish(ctx, *event)
+}
+
+// metricWindow returns the explicit date range, or the period ending now
+func metricWindow(filter domain.MetricFilter, now time.Time) (*time.Time, *time.Time, error) {
+	if filter.StartDate != nil || filter.EndDate != nil {
+		if filter.StartDate != nil && filter.EndDate != nil && !filter.EndDate.After(*filter.StartDate) {
+			return nil, nil, domain.ErrInvalidDateRange
+		}
+		return filter.StartDate, filter.EndDate, nil
+	}
+
+	var start time.Time
+	switch filter.Period {
+	case domain.MetricPeriodDaily:
+		start = now.AddDate(0, 0, -1)
+	case domain.MetricPeriodWeekly:
+		start = now.AddDate(0, 0, -7)
+	case domain.MetricPeriodMonthly:
+		start = now.AddDate(0, -1, 0)
+	default:
+		return nil, nil, domain.ErrInvalidMetricPeriod
+	}
+
+	return &start, nil, nil
+}
@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// memoryFeedbackRepo keeps feedback and served candidates in memory
+type memoryFeedbackRepo struct {
+	mu         sync.Mutex
+	feedback   map[string]*domain.SuggestionFeedback
+	candidates map[string]*domain.SuggestionCandidate
+}
+
+func newMemoryFeedbackRepo() *memoryFeedbackRepo {
+	return &memoryFeedbackRepo{
+		feedback:   make(map[string]*domain.SuggestionFeedback),
+		candidates: make(map[string]*domain.SuggestionCandidate),
+	}
+}
+
+func feedbackKey(f *domain.SuggestionFeedback) string {
+	return string(f.TargetType) + "|" + f.TargetID + "|" + f.SubmittedBy
+}
+
+func (r *memoryFeedbackRepo) Save(ctx context.Context, feedback *domain.SuggestionFeedback) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.feedback[feedbackKey(feedback)] = feedback
+	return nil
+}
+
+func (r *memoryFeedbackRepo) Create(ctx context.Context, feedback *domain.SuggestionFeedback) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.feedback[feedbackKey(feedback)]; ok {
+		return domain.ErrFeedbackAlreadyGiven
+	}
+	r.feedback[feedbackKey(feedback)] = feedback
+	return nil
+}
+
+func (r *memoryFeedbackRepo) List(ctx context.Context, filter domain.FeedbackFilter) ([]*domain.SuggestionFeedback, error) {
+	return nil, nil
+}
+
+func (r *memoryFeedbackRepo) SaveCandidate(ctx context.Context, candidate *domain.SuggestionCandidate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.candidates[fmt.Sprintf("%s#%d", candidate.QueryID, candidate.Rank)] = candidate
+	return nil
+}
+
+func (r *memoryFeedbackRepo) FindCandidate(ctx context.Context, queryID string, rank int) (*domain.SuggestionCandidate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	candidate, ok := r.candidates[fmt.Sprintf("%s#%d", queryID, rank)]
+	if !ok {
+		return nil, domain.ErrCandidateNotFound
+	}
+	return candidate, nil
+}
+
+// streamingSuggestionService streams fixed events
+type streamingSuggestionService struct {
+	stubSuggestionService
+	events []ports.SuggestionEvent
+}
+
+func (s *streamingSuggestionService) StreamSuggestionMitigation(ctx context.Context, description string) (<-chan ports.SuggestionEvent, error) {
+	events := make(chan ports.SuggestionEvent, len(s.events))
+	for _, event := range s.events {
+		events <- event
+	}
+	close(events)
+	return events, nil
+}
+
+func TestFeedbackUseCase_CandidateFeedbackUsesServedCandidate(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryFeedbackRepo()
+	service := &streamingSuggestionService{events: []ports.SuggestionEvent{
+		{Type: "init", QueryID: "router_1"},
+		{Type: "candidate", QueryID: "router_1", Data: ports.CandidateData{Rank: 1, Score: 0.9, Category: "network", EntryID: "kb-1", ChunkIndex: 2, Source: "openai", Model: "gpt-4o"}},
+		{Type: "end", QueryID: "router_1"},
+	}}
+	ai := NewAIUseCase(service, nil, nil, nil, nil, nil, repo, nil, nil, nil, 0)
+	uc := NewFeedbackUseCase(repo, nil, nil, nil, nil)
+
+	events, err := ai.StreamSuggestion(ctx, "VPN keeps dropping")
+	if err != nil {
+		t.Fatalf("Failed to stream: %v", err)
+	}
+	for range events {
+	}
+
+	feedback, err := uc.SubmitCandidateFeedback(ctx, SubmitCandidateFeedbackRequest{QueryID: "router_1", Rank: 1, Rating: domain.FeedbackRatingHelpful, SubmittedBy: "user-1"})
+	if err != nil {
+		t.Fatalf("Failed to submit feedback: %v", err)
+	}
+	if feedback.Provider != "openai" || feedback.Model != "gpt-4o" || feedback.Category != "NETWORK" {
+		t.Errorf("Expected attribution from the served candidate, got %s %s %s", feedback.Provider, feedback.Model, feedback.Category)
+	}
+	if len(feedback.Citations) != 1 || feedback.Citations[0].EntryID != "kb-1" {
+		t.Errorf("Expected the served candidate's citation, got %+v", feedback.Citations)
+	}
+
+	_, err = uc.SubmitCandidateFeedback(ctx, SubmitCandidateFeedbackRequest{QueryID: "router_1", Rank: 1, Rating: domain.FeedbackRatingSolved, SubmittedBy: "user-1"})
+	if !errors.Is(err, domain.ErrFeedbackAlreadyGiven) {
+		t.Errorf("Expected a repeat rating to be rejected, got %v", err)
+	}
+
+	_, err = uc.SubmitCandidateFeedback(ctx, SubmitCandidateFeedbackRequest{QueryID: "router_1", Rank: 2, Rating: domain.FeedbackRatingHelpful, SubmittedBy: "user-1"})
+	if !errors.Is(err, domain.ErrCandidateNotFound) {
+		t.Errorf("Expected a candidate that was never served to be rejected, got %v", err)
+	}
+}
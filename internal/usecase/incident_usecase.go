@@ -0,0 +1,438 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// IncidentUseCase manages incidents: many tickets about one disruption that are kept informed
+// through the incident's status updates and can be resolved together with it
+type IncidentUseCase struct {
+	incidentRepo   ports.IncidentRepository
+	ticketRepo     ports.TicketRepository
+	commentRepo    ports.CommentRepository
+	tickets        *TicketUseCase
+	notifyService  ports.NotificationService
+	eventPublisher ports.EventPublisher
+}
+
+// NewIncidentUseCase creates a new incident use case
+func NewIncidentUseCase(
+	incidentRepo ports.IncidentRepository,
+	ticketRepo ports.TicketRepository,
+	commentRepo ports.CommentRepository,
+	tickets *TicketUseCase,
+	notifyService ports.NotificationService,
+	eventPublisher ports.EventPublisher,
+) *IncidentUseCase {
+	return &IncidentUseCase{
+		incidentRepo:   incidentRepo,
+		ticketRepo:     ticketRepo,
+		commentRepo:    commentRepo,
+		tickets:        tickets,
+		notifyService:  notifyService,
+		eventPublisher: eventPublisher,
+	}
+}
+
+// CreateIncidentRequest represents the request to declare an incident
+type CreateIncidentRequest struct {
+	Title       string                `json:"title"`
+	Description string                `json:"description"`
+	Priority    domain.TicketPriority `json:"priority,omitempty"` // defaults to HIGH
+	TicketIDs   []string              `json:"ticket_ids,omitempty"`
+	CreatedBy   string                `json:"created_by"`
+}
+
+// MergeTicketsRequest represents the request to merge tickets about the same issue into a new incident
+type MergeTicketsRequest struct {
+	TicketIDs []string `json:"ticket_ids"`
+	Title     string   `json:"title,omitempty"` // defaults to the title of the earliest ticket
+	CreatedBy string   `json:"created_by"`
+}
+
+// PostIncidentUpdateRequest represents a status update for an incident
+type PostIncidentUpdateRequest struct {
+	Status   domain.IncidentStatus `json:"status,omitempty"` // keeps the current status when empty
+	Message  string                `json:"message"`
+	PostedBy string                `json:"posted_by"`
+}
+
+// ResolveIncidentRequest represents the request to resolve an incident. With ResolveTickets set,
+// every open linked ticket is resolved too, with its entry in TicketResolutions or a resolution
+// referring to the incident.
+type ResolveIncidentRequest struct {
+	Resolution        string            `json:"resolution"`
+	ResolveTickets    bool              `json:"resolve_tickets"`
+	TicketResolutions map[string]string `json:"ticket_resolutions,omitempty"`
+	ResolvedBy        string            `json:"resolved_by"`
+}
+
+// IncidentResponse represents an incident and its linked tickets
+type IncidentResponse struct {
+	Incident *domain.Incident `json:"incident"`
+	Tickets  []*domain.Ticket `json:"tickets"`
+}
+
+// CreateIncident declares an incident, linking the given tickets to it
+func (uc *IncidentUseCase) CreateIncident(ctx context.Context, req CreateIncidentRequest) (*IncidentResponse, error) {
+	incident, err := domain.NewIncident(req.Title, req.Description, req.Priority, req.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid incident: %w", err)
+	}
+
+	// Check tickets before the incident exists so a bad ticket leaves nothing behind
+	tickets, err := uc.findLinkableTickets(ctx, incident, req.TicketIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.incidentRepo.Create(ctx, incident); err != nil {
+		return nil, fmt.Errorf("failed to create incident: %w", err)
+	}
+
+	if err := uc.saveTicketLinks(ctx, incident, tickets); err != nil {
+		return nil, err
+	}
+
+	uc.publish(ctx, ports.EventTypeIncidentCreated, incident, map[string]interface{}{
+		"title":      incident.Title,
+		"priority":   incident.Priority,
+		"tickets":    len(tickets),
+		"created_by": incident.CreatedBy,
+	})
+
+	return &IncidentResponse{Incident: incident, Tickets: tickets}, nil
+}
+
+// MergeTickets declares an incident for tickets about the same issue and links them to it. The
+// incident takes the description of the earliest ticket and the highest priority among them.
+func (uc *IncidentUseCase) MergeTickets(ctx context.Context, req MergeTicketsRequest) (*IncidentResponse, error) {
+	seen := make(map[string]bool)
+	var tickets []*domain.Ticket
+
+	for _, id := range req.TicketIDs {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		ticket, err := uc.ticketRepo.FindByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ticket %s: %w", id, err)
+		}
+		tickets = append(tickets, ticket)
+	}
+	if len(tickets) < 2 {
+		return nil, domain.ErrTooFewTicketsMerge
+	}
+
+	earliest := tickets[0]
+	priority := earliest.Priority
+	for _, ticket := range tickets {
+		if ticket.CreatedAt.Before(earliest.CreatedAt) {
+			earliest = ticket
+		}
+		if priorityRank(ticket.Priority) > priorityRank(priority) {
+			priority = ticket.Priority
+		}
+	}
+
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		title = earliest.Title
+	}
+
+	return uc.CreateIncident(ctx, CreateIncidentRequest{
+		Title:       title,
+		Description: earliest.Description,
+		Priority:    priority,
+		TicketIDs:   req.TicketIDs,
+		CreatedBy:   req.CreatedBy,
+	})
+}
+
+// GetIncident retrieves an incident with its linked tickets
+func (uc *IncidentUseCase) GetIncident(ctx context.Context, incidentID string) (*IncidentResponse, error) {
+	incident, err := uc.incidentRepo.FindByID(ctx, incidentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incident: %w", err)
+	}
+
+	tickets, err := uc.linkedTickets(ctx, incident.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IncidentResponse{Incident: incident, Tickets: tickets}, nil
+}
+
+// ListIncidents lists incidents matching the filter
+func (uc *IncidentUseCase) ListIncidents(ctx context.Context, filter domain.IncidentFilter) ([]*domain.Incident, error) {
+	if filter.Limit <= 0 || filter.Limit > 100 {
+		filter.Limit = 20
+	}
+
+	incidents, err := uc.incidentRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incidents: %w", err)
+	}
+
+	return incidents, nil
+}
+
+// LinkTickets links tickets to an ongoing incident. All tickets are checked before any is linked.
+func (uc *IncidentUseCase) LinkTickets(ctx context.Context, incidentID string, ticketIDs []string) (*IncidentResponse, error) {
+	if len(ticketIDs) == 0 {
+		return nil, fmt.Errorf("at least one ticket to link is required")
+	}
+
+	incident, err := uc.incidentRepo.FindByID(ctx, incidentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incident: %w", err)
+	}
+
+	tickets, err := uc.findLinkableTickets(ctx, incident, ticketIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.saveTicketLinks(ctx, incident, tickets); err != nil {
+		return nil, err
+	}
+
+	return uc.GetIncident(ctx, incident.ID)
+}
+
+// UnlinkTicket removes a ticket from its incident
+func (uc *IncidentUseCase) UnlinkTicket(ctx context.Context, incidentID, ticketID string) (*domain.Ticket, error) {
+	ticket, err := uc.ticketRepo.FindByID(ctx, ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket: %w", err)
+	}
+	if ticket.IncidentID == nil || *ticket.IncidentID != incidentID {
+		return nil, domain.ErrTicketNotInIncident
+	}
+
+	if err := ticket.UnlinkIncident(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.ticketRepo.Update(ctx, ticket); err != nil {
+		return nil, fmt.Errorf("failed to update ticket: %w", err)
+	}
+
+	uc.addComment(ctx, ticket, "system", fmt.Sprintf("Unlinked from incident %s", incidentID))
+
+	return ticket, nil
+}
+
+// PostUpdate records a status update and broadcasts it to the requesters of all linked tickets
+func (uc *IncidentUseCase) PostUpdate(ctx context.Context, incidentID string, req PostIncidentUpdateRequest) (*domain.Incident, error) {
+	incident, err := uc.incidentRepo.FindByID(ctx, incidentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incident: %w", err)
+	}
+
+	update, err := incident.PostUpdate(req.Status, req.Message, req.PostedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.incidentRepo.Update(ctx, incident); err != nil {
+		return nil, fmt.Errorf("failed to update incident: %w", err)
+	}
+
+	tickets, err := uc.linkedTickets(ctx, incident.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.broadcast(ctx, incident, update, tickets)
+
+	return incident, nil
+}
+
+// ResolveIncident resolves the incident, broadcasts the resolution and optionally resolves its open tickets
+func (uc *IncidentUseCase) ResolveIncident(ctx context.Context, incidentID string, req ResolveIncidentRequest) (*IncidentResponse, error) {
+	incident, err := uc.incidentRepo.FindByID(ctx, incidentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incident: %w", err)
+	}
+
+	update, err := incident.Resolve(req.Resolution, req.ResolvedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.incidentRepo.Update(ctx, incident); err != nil {
+		return nil, fmt.Errorf("failed to update incident: %w", err)
+	}
+
+	tickets, err := uc.linkedTickets(ctx, incident.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.broadcast(ctx, incident, update, tickets)
+
+	resolved := 0
+	if req.ResolveTickets {
+		for i, ticket := range tickets {
+			if !ticket.IsActive() {
+				continue
+			}
+
+			resolution := req.TicketResolutions[ticket.ID]
+			if resolution == "" {
+				resolution = fmt.Sprintf("Resolved with incident %s: %s", incident.ID, incident.Resolution)
+			}
+
+			updated, err := uc.tickets.ResolveTicket(ctx, ticket.ID, resolution)
+			if err != nil {
+				// Keep going so one failing ticket does not leave the rest open
+				log.Printf("Failed to resolve ticket %s with incident %s: %v", ticket.ID, incident.ID, err)
+				continue
+			}
+			tickets[i] = updated
+			resolved++
+		}
+	}
+
+	uc.publish(ctx, ports.EventTypeIncidentResolved, incident, map[string]interface{}{
+		"resolution":       incident.Resolution,
+		"tickets":          len(tickets),
+		"tickets_resolved": resolved,
+		"resolved_by":      req.ResolvedBy,
+	})
+
+	return &IncidentResponse{Incident: incident, Tickets: tickets}, nil
+}
+
+// findLinkableTickets loads the tickets to be linked and checks each can join the incident
+func (uc *IncidentUseCase) findLinkableTickets(ctx context.Context, incident *domain.Incident, ticketIDs []string) ([]*domain.Ticket, error) {
+	seen := make(map[string]bool)
+	var tickets []*domain.Ticket
+
+	for _, id := range ticketIDs {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		ticket, err := uc.ticketRepo.FindByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ticket %s: %w", id, err)
+		}
+
+		if err := ticket.LinkToIncident(incident); err != nil {
+			return nil, fmt.Errorf("failed to link ticket %s: %w", ticket.ID, err)
+		}
+
+		tickets = append(tickets, ticket)
+	}
+
+	return tickets, nil
+}
+
+func (uc *IncidentUseCase) saveTicketLinks(ctx context.Context, incident *domain.Incident, tickets []*domain.Ticket) error {
+	for _, ticket := range tickets {
+		if err := uc.ticketRepo.Update(ctx, ticket); err != nil {
+			return fmt.Errorf("failed to update ticket %s: %w", ticket.ID, err)
+		}
+		uc.addComment(ctx, ticket, "system", fmt.Sprintf("Linked to incident %s: %s", incident.ID, incident.Title))
+	}
+	return nil
+}
+
+func (uc *IncidentUseCase) linkedTickets(ctx context.Context, incidentID string) ([]*domain.Ticket, error) {
+	tickets, err := uc.ticketRepo.List(ctx, domain.TicketFilter{IncidentID: &incidentID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incident tickets: %w", err)
+	}
+	return tickets, nil
+}
+
+// broadcast posts the update on every linked ticket and notifies each requester once
+func (uc *IncidentUseCase) broadcast(ctx context.Context, incident *domain.Incident, update *domain.IncidentUpdate, tickets []*domain.Ticket) {
+	message := fmt.Sprintf("Incident update (%s): %s", update.Status, update.Message)
+	notified := make(map[string]bool)
+
+	for _, ticket := range tickets {
+		if ticket.Status == domain.TicketStatusClosed {
+			continue
+		}
+
+		uc.addComment(ctx, ticket, update.PostedBy, message)
+
+		if uc.notifyService == nil || notified[ticket.CreatedBy] {
+			continue
+		}
+		notified[ticket.CreatedBy] = true
+
+		notification := ports.NewNotification(
+			ports.NotificationTypeIncidentUpdate,
+			ticket.CreatedBy,
+			fmt.Sprintf("Update on %s", incident.Title),
+			update.Message,
+			ports.NotificationPriorityHigh,
+			ports.DefaultNotificationConfig().DefaultChannels,
+		)
+		notification.AddData("incident_id", incident.ID)
+		notification.AddData("ticket_id", ticket.ID)
+		notification.AddData("status", update.Status)
+		if err := uc.notifyService.SendCustomNotification(ctx, notification); err != nil {
+			log.Printf("Failed to notify %s of incident %s: %v", ticket.CreatedBy, incident.ID, err)
+		}
+	}
+
+	uc.publish(ctx, ports.EventTypeIncidentUpdated, incident, map[string]interface{}{
+		"status":     update.Status,
+		"message":    update.Message,
+		"posted_by":  update.PostedBy,
+		"tickets":    len(tickets),
+		"requesters": len(notified),
+	})
+}
+
+func (uc *IncidentUseCase) addComment(ctx context.Context, ticket *domain.Ticket, authorID, content string) {
+	if uc.commentRepo == nil {
+		return
+	}
+	if authorID == "" {
+		authorID = "system"
+	}
+
+	comment := domain.NewComment(ticket.ID, authorID, domain.CommentRoleAdmin, content)
+	_ = uc.commentRepo.Create(ctx, comment)
+}
+
+func (uc *IncidentUseCase) publish(ctx context.Context, eventType string, incident *domain.Incident, data map[string]interface{}) {
+	if uc.eventPublisher == nil {
+		return
+	}
+
+	event := ports.NewEvent(eventType, "incident", incident.ID, data, 1)
+	_ = uc.eventPublisher.Publish(ctx, *event)
+}
+
+// priorityRank orders priorities from low to critical
+func priorityRank(priority domain.TicketPriority) int {
+	switch priority {
+	case domain.TicketPriorityCritical:
+		return 4
+	case domain.TicketPriorityHigh:
+		return 3
+	case domain.TicketPriorityMedium:
+		return 2
+	case domain.TicketPriorityLow:
+		return 1
+	default:
+		return 0
+	}
+}
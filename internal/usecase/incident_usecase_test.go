@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// memoryIncidentRepo keeps created incidents in memory
+type memoryIncidentRepo struct {
+	ports.IncidentRepository
+	incidents map[string]*domain.Incident
+}
+
+func (r *memoryIncidentRepo) Create(ctx context.Context, incident *domain.Incident) error {
+	c := *incident
+	r.incidents[incident.ID] = &c
+	return nil
+}
+
+func TestIncidentUseCase_MergeTickets(t *testing.T) {
+	now := time.Now()
+	tickets := newMemoryTicketRepo(
+		&domain.Ticket{ID: "t1", Title: "VPN drops", Description: "Disconnects hourly", Status: domain.TicketStatusOpen, Priority: domain.TicketPriorityMedium, CreatedAt: now.Add(-time.Hour)},
+		&domain.Ticket{ID: "t2", Title: "Cannot reach intranet", Status: domain.TicketStatusInProgress, Priority: domain.TicketPriorityCritical, CreatedAt: now},
+	)
+	incidents := &memoryIncidentRepo{incidents: make(map[string]*domain.Incident)}
+	uc := NewIncidentUseCase(incidents, tickets, nil, nil, nil, nil)
+
+	response, err := uc.MergeTickets(context.Background(), MergeTicketsRequest{
+		TicketIDs: []string{"t2", "t1", "t2"},
+		CreatedBy: "admin1",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	incident := response.Incident
+	if incident.Title != "VPN drops" || incident.Description != "Disconnects hourly" {
+		t.Errorf("Expected the earliest ticket's title and description, got %q / %q", incident.Title, incident.Description)
+	}
+	if incident.Priority != domain.TicketPriorityCritical {
+		t.Errorf("Expected the highest priority, got %s", incident.Priority)
+	}
+	if _, ok := incidents.incidents[incident.ID]; !ok {
+		t.Errorf("Expected incident %s to be saved", incident.ID)
+	}
+
+	for _, id := range []string{"t1", "t2"} {
+		ticket, _ := tickets.FindByID(context.Background(), id)
+		if ticket.IncidentID == nil || *ticket.IncidentID != incident.ID {
+			t.Errorf("Expected ticket %s linked to incident %s, got %v", id, incident.ID, ticket.IncidentID)
+		}
+	}
+}
+
+func TestIncidentUseCase_MergeTicketsNeedsTwoTickets(t *testing.T) {
+	tickets := newMemoryTicketRepo(&domain.Ticket{ID: "t1", Title: "VPN drops", Status: domain.TicketStatusOpen})
+	incidents := &memoryIncidentRepo{incidents: make(map[string]*domain.Incident)}
+	uc := NewIncidentUseCase(incidents, tickets, nil, nil, nil, nil)
+
+	_, err := uc.MergeTickets(context.Background(), MergeTicketsRequest{TicketIDs: []string{"t1", "t1"}})
+	if !errors.Is(err, domain.ErrTooFewTicketsMerge) {
+		t.Errorf("Expected ErrTooFewTicketsMerge, got %v", err)
+	}
+	if len(incidents.incidents) != 0 {
+		t.Errorf("Expected no incident to be created, got %d", len(incidents.incidents))
+	}
+}
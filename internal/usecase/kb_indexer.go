@@ -0,0 +1,482 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// KBIndexerConfig configures the background knowledge base indexer
+type KBIndexerConfig struct {
+	Workers        int           // number of jobs processed in parallel
+	QueueSize      int           // capacity of the in-memory job queue
+	BatchSize      int           // chunks per EmbedBatch call
+	MaxConcurrency int           // EmbedBatch calls in flight across all jobs
+	MaxRetries     int           // retries per batch on transient provider errors
+	RetryBackoff   time.Duration // initial backoff, doubled on each retry
+	PollInterval   time.Duration // how often queued jobs that did not fit in the queue are picked up
+	Lease          time.Duration // how long a running job is held without progress before another worker may take it over
+	ChunkSize      int
+	ChunkOverlap   int
+}
+
+// DefaultKBIndexerConfig returns sensible indexer defaults
+func DefaultKBIndexerConfig() KBIndexerConfig {
+	return KBIndexerConfig{
+		Workers:        2,
+		QueueSize:      100,
+		BatchSize:      32,
+		MaxConcurrency: 5,
+		MaxRetries:     3,
+		RetryBackoff:   500 * time.Millisecond,
+		PollInterval:   30 * time.Second,
+		Lease:          5 * time.Minute,
+		ChunkSize:      800,
+		ChunkOverlap:   150,
+	}
+}
+
+// KBIndexer chunks, embeds and publishes knowledge base entries in the background
+type KBIndexer struct {
+	knowledgeRepo  ports.KnowledgeRepository
+	jobRepo        ports.IndexJobRepository
+	embeddings     ports.EmbeddingProvider
+	eventPublisher ports.EventPublisher
+	config         KBIndexerConfig
+
+	queue     chan string
+	embedSlot chan struct{}
+	startOnce sync.Once
+
+	mu     sync.Mutex
+	active map[string]bool // jobs in the queue or being processed
+}
+
+// NewKBIndexer creates a new knowledge base indexer
+func NewKBIndexer(
+	knowledgeRepo ports.KnowledgeRepository,
+	jobRepo ports.IndexJobRepository,
+	embeddings ports.EmbeddingProvider,
+	eventPublisher ports.EventPublisher,
+	config KBIndexerConfig,
+) *KBIndexer {
+	defaults := DefaultKBIndexerConfig()
+	if config.Workers <= 0 {
+		config.Workers = defaults.Workers
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = defaults.QueueSize
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaults.BatchSize
+	}
+	if config.MaxConcurrency <= 0 {
+		config.MaxConcurrency = defaults.MaxConcurrency
+	}
+	if config.MaxRetries < 0 {
+		config.MaxRetries = 0
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = defaults.RetryBackoff
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaults.PollInterval
+	}
+	if config.Lease <= 0 {
+		config.Lease = defaults.Lease
+	}
+	if config.ChunkSize <= 0 {
+		config.ChunkSize = defaults.ChunkSize
+	}
+	if config.ChunkOverlap < 0 || config.ChunkOverlap >= config.ChunkSize {
+		config.ChunkOverlap = 0
+	}
+
+	return &KBIndexer{
+		knowledgeRepo:  knowledgeRepo,
+		jobRepo:        jobRepo,
+		embeddings:     embeddings,
+		eventPublisher: eventPublisher,
+		config:         config,
+		queue:          make(chan string, config.QueueSize),
+		embedSlot:      make(chan struct{}, config.MaxConcurrency),
+		active:         make(map[string]bool),
+	}
+}
+
+// Start launches the workers and keeps picking up queued jobs that did not fit in the queue and
+// running jobs whose worker stopped
+func (ix *KBIndexer) Start(ctx context.Context) {
+	ix.startOnce.Do(func() {
+		for i := 0; i < ix.config.Workers; i++ {
+			go ix.worker(ctx)
+		}
+
+		go ix.poll(ctx)
+	})
+}
+
+// Enqueue creates an indexing job for the current version of an entry and queues it
+func (ix *KBIndexer) Enqueue(ctx context.Context, entry *domain.KnowledgeEntry) (*domain.IndexJob, error) {
+	job := domain.NewIndexJob(entry.ID, entry.Version)
+
+	if err := ix.jobRepo.Create(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to create indexing job: %w", err)
+	}
+
+	// The job is persisted as queued, so the poller picks it up once the queue has room
+	if !ix.submit(job.ID) {
+		log.Printf("KB indexing queue is full, job %s will be picked up by the next poll", job.ID)
+	}
+
+	return job, nil
+}
+
+// GetJob retrieves an indexing job
+func (ix *KBIndexer) GetJob(ctx context.Context, jobID string) (*domain.IndexJob, error) {
+	if jobID == "" {
+		return nil, fmt.Errorf("job ID is required")
+	}
+
+	job, err := ix.jobRepo.FindByID(ctx, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get indexing job: %w", err)
+	}
+
+	return job, nil
+}
+
+// submit hands a job to the workers unless it is already queued or being processed. It
+// reports false when the queue is full.
+func (ix *KBIndexer) submit(jobID string) bool {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	if ix.active[jobID] {
+		return true
+	}
+
+	select {
+	case ix.queue <- jobID:
+		ix.active[jobID] = true
+		return true
+	default:
+		return false
+	}
+}
+
+// poll queues the claimable jobs now and then every PollInterval. Running jobs are queued only
+// once their lease has expired, since another instance may still be working on them.
+func (ix *KBIndexer) poll(ctx context.Context) {
+	ix.submitPending(ctx)
+
+	ticker := time.NewTicker(ix.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			ix.submitPending(ctx)
+		}
+	}
+}
+
+// submitPending queues stored jobs that can be claimed, oldest first, until the queue is full
+func (ix *KBIndexer) submitPending(ctx context.Context) {
+	jobs, err := ix.jobRepo.ListByStatus(ctx, []domain.IndexJobStatus{domain.IndexJobStatusQueued, domain.IndexJobStatusRunning}, 0)
+	if err != nil {
+		log.Printf("Failed to load pending KB indexing jobs: %v", err)
+		return
+	}
+
+	now := time.Now()
+	for _, job := range jobs {
+		if !job.Claimable(now) {
+			continue
+		}
+		if !ix.submit(job.ID) {
+			return
+		}
+	}
+}
+
+func (ix *KBIndexer) worker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case jobID := <-ix.queue:
+			if err := ix.process(ctx, jobID); err != nil {
+				log.Printf("KB indexing job %s: %v", jobID, err)
+			}
+
+			ix.mu.Lock()
+			delete(ix.active, jobID)
+			ix.mu.Unlock()
+		}
+	}
+}
+
+// process claims a job, runs it to completion and records the outcome on the job. A job that
+// is finished or held by another worker is skipped.
+func (ix *KBIndexer) process(ctx context.Context, jobID string) error {
+	job, err := ix.jobRepo.Claim(ctx, jobID, ix.config.Lease)
+	if errors.Is(err, domain.ErrIndexJobClaimed) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to claim job: %w", err)
+	}
+
+	entry, chunks, err := ix.index(ctx, job)
+	if err != nil {
+		job.Fail(err)
+		if updateErr := ix.jobRepo.Update(ctx, job); updateErr != nil {
+			return fmt.Errorf("failed to update job: %w", updateErr)
+		}
+		ix.publishFailure(ctx, job)
+		return err
+	}
+
+	job.Succeed()
+	if err := ix.jobRepo.Update(ctx, job); err != nil {
+		return fmt.Errorf("failed to update job: %w", err)
+	}
+
+	if entry != nil && ix.eventPublisher != nil {
+		event := ports.NewEvent(
+			ports.EventTypeKBEntryPublished,
+			"knowledge_entry",
+			entry.ID,
+			map[string]interface{}{
+				"title":    entry.Title,
+				"category": entry.Category,
+				"version":  entry.Version,
+				"chunks":   len(chunks),
+				"job_id":   job.ID,
+			},
+			1,
+		)
+		_ = ix.eventPublisher.Publish(ctx, *event)
+	}
+
+	return nil
+}
+
+// index builds, embeds and swaps in the chunks of the job's entry version.
+// It returns a nil entry when the version was already published.
+func (ix *KBIndexer) index(ctx context.Context, job *domain.IndexJob) (*domain.KnowledgeEntry, []*domain.KBChunk, error) {
+	entry, err := ix.knowledgeRepo.FindEntryByID(ctx, job.EntryID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get knowledge entry: %w", err)
+	}
+
+	if entry.Version != job.Version {
+		return nil, nil, domain.ErrIndexJobSuperseded
+	}
+
+	if entry.Status == domain.KnowledgeEntryStatusArchived {
+		return nil, nil, domain.ErrCannotPublishArchived
+	}
+
+	if entry.IsActive() && entry.PublishedVersion == entry.Version {
+		return nil, nil, nil
+	}
+
+	chunks := chunkContent(entry.ID, normalizeContent(entry.Content), ix.config.ChunkSize, ix.config.ChunkOverlap)
+	if len(chunks) == 0 {
+		return nil, nil, domain.ErrEmptyKBContent
+	}
+
+	job.SetTotal(len(chunks))
+	job.Renew(ix.config.Lease)
+	if err := ix.jobRepo.Update(ctx, job); err != nil {
+		return nil, nil, fmt.Errorf("failed to update job: %w", err)
+	}
+
+	if err := ix.embedChunks(ctx, job, chunks); err != nil {
+		return nil, nil, err
+	}
+
+	if err := entry.Publish(); err != nil {
+		return nil, nil, fmt.Errorf("failed to publish entry: %w", err)
+	}
+
+	// Swap chunks and update entry in one transaction
+	if err := ix.knowledgeRepo.ReplaceChunks(ctx, entry, chunks); err != nil {
+		return nil, nil, fmt.Errorf("failed to replace knowledge chunks: %w", err)
+	}
+
+	return entry, chunks, nil
+}
+
+// embedChunks embeds chunks in BatchSize batches, running batches in parallel
+// while never exceeding MaxConcurrency EmbedBatch calls across the indexer
+func (ix *KBIndexer) embedChunks(ctx context.Context, job *domain.IndexJob, chunks []*domain.KBChunk) error {
+	if ix.embeddings == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+
+	for start := 0; start < len(chunks); start += ix.config.BatchSize {
+		end := start + ix.config.BatchSize
+		if end > len(chunks) {
+			end = len(chunks)
+		}
+		batch := chunks[start:end]
+
+		select {
+		case ix.embedSlot <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			if firstErr != nil {
+				return firstErr
+			}
+			return ctx.Err()
+		}
+
+		wg.Add(1)
+		go func(batch []*domain.KBChunk) {
+			defer wg.Done()
+			defer func() { <-ix.embedSlot }()
+
+			err := ix.embedBatch(ctx, batch)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				return
+			}
+
+			job.Advance(len(batch))
+			job.Renew(ix.config.Lease)
+			if err := ix.jobRepo.Update(ctx, job); err != nil {
+				log.Printf("Failed to record progress for KB indexing job %s: %v", job.ID, err)
+			}
+		}(batch)
+	}
+
+	wg.Wait()
+
+	return firstErr
+}
+
+// embedBatch embeds a single batch and attaches the vectors to its chunks
+func (ix *KBIndexer) embedBatch(ctx context.Context, batch []*domain.KBChunk) error {
+	texts := make([]string, len(batch))
+	for i, chunk := range batch {
+		texts[i] = chunk.Content
+	}
+
+	embeddings, err := embedWithRetry(ctx, ix.embeddings, texts, ix.config.MaxRetries, ix.config.RetryBackoff)
+	if err != nil {
+		return err
+	}
+
+	for i, embedding := range embeddings {
+		batch[i].SetEmbedding(ix.embeddings.Model(), embedding)
+	}
+
+	return nil
+}
+
+// embedWithRetry calls EmbedBatch, retrying transient provider errors with exponential backoff
+func embedWithRetry(ctx context.Context, provider ports.EmbeddingProvider, texts []string, maxRetries int, backoff time.Duration) ([][]float32, error) {
+	for attempt := 0; ; attempt++ {
+		embeddings, err := provider.EmbedBatch(ctx, texts)
+		if err == nil {
+			if len(embeddings) != len(texts) {
+				return nil, fmt.Errorf("failed to generate embeddings: got %d, want %d", len(embeddings), len(texts))
+			}
+			return embeddings, nil
+		}
+
+		if attempt >= maxRetries || !ports.IsRetryableAIError(err) {
+			return nil, fmt.Errorf("failed to generate embeddings: %w", err)
+		}
+
+		select {
+		case <-time.After(backoff):
+			backoff *= 2
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to generate embeddings: %w", ctx.Err())
+		}
+	}
+}
+
+func (ix *KBIndexer) publishFailure(ctx context.Context, job *domain.IndexJob) {
+	if ix.eventPublisher == nil {
+		return
+	}
+
+	event := ports.NewEvent(
+		ports.EventTypeKBIndexJobFailed,
+		"knowledge_entry",
+		job.EntryID,
+		map[string]interface{}{
+			"job_id":   job.ID,
+			"version":  job.Version,
+			"attempts": job.Attempts,
+			"error":    job.Error,
+		},
+		1,
+	)
+	_ = ix.eventPublisher.Publish(ctx, *event)
+}
+
+func normalizeContent(content string) string {
+	// Normalize whitespace
+	content = strings.ReplaceAll(content, "\t", " ")
+	content = strings.ReplaceAll(content, "\n\n", "\n")
+	content = strings.Join(strings.Fields(content), " ")
+
+	// Simple markdown stripping (in production, use a proper markdown parser)
+	content = strings.ReplaceAll(content, "**", "")
+	content = strings.ReplaceAll(content, "*", "")
+	content = strings.ReplaceAll(content, "#", "")
+
+	return strings.TrimSpace(content)
+}
+
+func chunkContent(entryID, content string, chunkSize, overlap int) []*domain.KBChunk {
+	var chunks []*domain.KBChunk
+
+	// Simple chunking algorithm
+	for i := 0; i < len(content); i += chunkSize - overlap {
+		end := i + chunkSize
+		if end > len(content) {
+			end = len(content)
+		}
+
+		chunk := domain.NewKBChunk(entryID, len(chunks), content[i:end])
+		chunks = append(chunks, chunk)
+
+		if end == len(content) {
+			break
+		}
+	}
+
+	return chunks
+}
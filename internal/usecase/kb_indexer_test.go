@@ -0,0 +1,337 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// recordingPublisher records the events it is given
+type recordingPublisher struct {
+	mu     sync.Mutex
+	events []ports.Event
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, event ports.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+	return nil
+}
+
+func (p *recordingPublisher) Subscribe(eventType string, handler ports.EventHandler) error {
+	return nil
+}
+
+func (p *recordingPublisher) Unsubscribe(eventType string, handler ports.EventHandler) error {
+	return nil
+}
+
+func (p *recordingPublisher) ofType(eventType string) []ports.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var events []ports.Event
+	for _, event := range p.events {
+		if event.Type == eventType {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// newQueuedJob stores a draft entry and a queued job for it
+func newQueuedJob(t *testing.T, repo *memoryKnowledgeRepo, jobs *memoryIndexJobRepo, entryID, content string) *domain.IndexJob {
+	t.Helper()
+	entry := domain.NewKnowledgeEntry("VPN setup", content, "network", nil, "admin-1")
+	entry.ID = entryID
+	if err := repo.CreateEntry(context.Background(), entry); err != nil {
+		t.Fatalf("Failed to create entry: %v", err)
+	}
+
+	job := domain.NewIndexJob(entry.ID, entry.Version)
+	job.ID = "kbjob_" + entryID
+	if err := jobs.Create(context.Background(), job); err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+	return job
+}
+
+func TestKBIndexer_EmbedsInBatches(t *testing.T) {
+	ctx := context.Background()
+	repo, jobs, embeddings := newMemoryKnowledgeRepo(), newMemoryIndexJobRepo(), &fakeEmbeddings{}
+	indexer := NewKBIndexer(repo, jobs, embeddings, nil, KBIndexerConfig{
+		BatchSize:      2,
+		MaxConcurrency: 1,
+		ChunkSize:      40,
+	})
+
+	job := newQueuedJob(t, repo, jobs, "kb_batches", testKBContent)
+	if err := indexer.process(ctx, job.ID); err != nil {
+		t.Fatalf("Failed to process job: %v", err)
+	}
+
+	chunks, _ := repo.FindChunksByEntry(ctx, job.EntryID)
+	if len(chunks) < 3 {
+		t.Fatalf("Expected at least 3 chunks, got %d", len(chunks))
+	}
+
+	total := 0
+	for _, size := range embeddings.batchSizes {
+		if size > 2 {
+			t.Errorf("Expected batches of at most 2, got %v", embeddings.batchSizes)
+		}
+		total += size
+	}
+	if total != len(chunks) || len(embeddings.batchSizes) != (len(chunks)+1)/2 {
+		t.Errorf("Expected %d chunks in %d batches, got %v", len(chunks), (len(chunks)+1)/2, embeddings.batchSizes)
+	}
+
+	done, _ := jobs.FindByID(ctx, job.ID)
+	if done.Status != domain.IndexJobStatusSucceeded || done.ProcessedChunks != len(chunks) || done.Progress() != 1 {
+		t.Errorf("Expected a succeeded job with all chunks processed, got %s %d/%d", done.Status, done.ProcessedChunks, done.TotalChunks)
+	}
+}
+
+func TestKBIndexer_LimitsConcurrency(t *testing.T) {
+	ctx := context.Background()
+	repo, jobs := newMemoryKnowledgeRepo(), newMemoryIndexJobRepo()
+	embeddings := &fakeEmbeddings{delay: 10 * time.Millisecond}
+	indexer := NewKBIndexer(repo, jobs, embeddings, nil, KBIndexerConfig{
+		BatchSize:      1,
+		MaxConcurrency: 2,
+		ChunkSize:      40,
+	})
+
+	// Two jobs share the indexer's concurrency limit
+	first := newQueuedJob(t, repo, jobs, "kb_first", testKBContent)
+	second := newQueuedJob(t, repo, jobs, "kb_second", testKBContent)
+
+	var wg sync.WaitGroup
+	for _, job := range []*domain.IndexJob{first, second} {
+		wg.Add(1)
+		go func(jobID string) {
+			defer wg.Done()
+			if err := indexer.process(ctx, jobID); err != nil {
+				t.Errorf("Failed to process job %s: %v", jobID, err)
+			}
+		}(job.ID)
+	}
+	wg.Wait()
+
+	if embeddings.maxInFlight > 2 {
+		t.Errorf("Expected at most 2 calls in flight, got %d", embeddings.maxInFlight)
+	}
+	if embeddings.maxInFlight < 2 {
+		t.Errorf("Expected batches to run in parallel, got %d in flight", embeddings.maxInFlight)
+	}
+}
+
+func TestKBIndexer_RetriesTransientErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		errs       []error
+		wantStatus domain.IndexJobStatus
+		wantCalls  int
+	}{
+		{
+			name: "rate limit then server error",
+			errs: []error{
+				&ports.ProviderError{Provider: "fake", StatusCode: 429, Message: "slow down"},
+				&ports.ProviderError{Provider: "fake", StatusCode: 503, Message: "unavailable"},
+			},
+			wantStatus: domain.IndexJobStatusSucceeded,
+			wantCalls:  3,
+		},
+		{
+			name:       "bad request",
+			errs:       []error{&ports.ProviderError{Provider: "fake", StatusCode: 400, Message: "input too long"}},
+			wantStatus: domain.IndexJobStatusFailed,
+			wantCalls:  1,
+		},
+		{
+			name: "retries exhausted",
+			errs: []error{
+				&ports.ProviderError{Provider: "fake", StatusCode: 500, Message: "boom"},
+				&ports.ProviderError{Provider: "fake", StatusCode: 500, Message: "boom"},
+				&ports.ProviderError{Provider: "fake", StatusCode: 500, Message: "boom"},
+			},
+			wantStatus: domain.IndexJobStatusFailed,
+			wantCalls:  3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo, jobs := newMemoryKnowledgeRepo(), newMemoryIndexJobRepo()
+			embeddings := &fakeEmbeddings{errs: tt.errs}
+			indexer := NewKBIndexer(repo, jobs, embeddings, nil, KBIndexerConfig{
+				BatchSize:    10,
+				MaxRetries:   2,
+				RetryBackoff: time.Millisecond,
+				ChunkSize:    400,
+			})
+
+			job := newQueuedJob(t, repo, jobs, "kb_retry", testKBContent)
+			indexer.process(ctx, job.ID)
+
+			done, _ := jobs.FindByID(ctx, job.ID)
+			if done.Status != tt.wantStatus {
+				t.Errorf("Expected status %s, got %s (%s)", tt.wantStatus, done.Status, done.Error)
+			}
+			if embeddings.calls() != tt.wantCalls {
+				t.Errorf("Expected %d embedding calls, got %d", tt.wantCalls, embeddings.calls())
+			}
+		})
+	}
+}
+
+func TestKBIndexer_ReportsFailures(t *testing.T) {
+	ctx := context.Background()
+	repo, jobs, publisher := newMemoryKnowledgeRepo(), newMemoryIndexJobRepo(), &recordingPublisher{}
+	embeddings := &fakeEmbeddings{errs: []error{fmt.Errorf("connection reset")}}
+	indexer := NewKBIndexer(repo, jobs, embeddings, publisher, KBIndexerConfig{ChunkSize: 400})
+
+	job := newQueuedJob(t, repo, jobs, "kb_failure", testKBContent)
+	if err := indexer.process(ctx, job.ID); err == nil {
+		t.Fatal("Expected the job to fail")
+	}
+
+	failed, _ := jobs.FindByID(ctx, job.ID)
+	if failed.Status != domain.IndexJobStatusFailed || !strings.Contains(failed.Error, "connection reset") || failed.FinishedAt == nil {
+		t.Errorf("Expected a finished failed job with the error, got %s: %q", failed.Status, failed.Error)
+	}
+
+	events := publisher.ofType(ports.EventTypeKBIndexJobFailed)
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 failure event, got %d", len(events))
+	}
+	if events[0].Aggregate != "knowledge_entry" || events[0].Data["job_id"] != job.ID || events[0].Data["attempts"] != 1 {
+		t.Errorf("Unexpected failure event %+v", events[0])
+	}
+	if len(publisher.ofType(ports.EventTypeKBEntryPublished)) != 0 {
+		t.Error("Expected no publish event for a failed job")
+	}
+
+	entry, _ := repo.FindEntryByID(ctx, job.EntryID)
+	if entry.IsActive() {
+		t.Error("Expected the entry to stay unpublished")
+	}
+}
+
+func TestKBIndexer_KeepsDraftSavedWhileEmbedding(t *testing.T) {
+	ctx := context.Background()
+	repo, jobs := newMemoryKnowledgeRepo(), newMemoryIndexJobRepo()
+	job := newQueuedJob(t, repo, jobs, "kb_edited", testKBContent)
+
+	// An editor saves a new draft while the job's version is being embedded
+	var edit sync.Once
+	embeddings := &fakeEmbeddings{onEmbed: func() {
+		edit.Do(func() {
+			entry, _ := repo.FindEntryByID(ctx, job.EntryID)
+			entry.UpdateContent("VPN setup (revised)", "Use the new VPN client.")
+			repo.UpdateEntry(ctx, entry)
+		})
+	}}
+	indexer := NewKBIndexer(repo, jobs, embeddings, nil, KBIndexerConfig{ChunkSize: 400})
+
+	if err := indexer.process(ctx, job.ID); !errors.Is(err, domain.ErrIndexJobSuperseded) {
+		t.Fatalf("Expected ErrIndexJobSuperseded, got %v", err)
+	}
+
+	entry, _ := repo.FindEntryByID(ctx, job.EntryID)
+	if entry.Title != "VPN setup (revised)" || entry.Version != job.Version+1 || entry.IsActive() {
+		t.Errorf("Expected the new draft to be kept, got %q v%d (%s)", entry.Title, entry.Version, entry.Status)
+	}
+	if chunks, _ := repo.FindChunksByEntry(ctx, job.EntryID); len(chunks) != 0 {
+		t.Errorf("Expected no chunks for the superseded version, got %d", len(chunks))
+	}
+}
+
+func TestKBIndexer_SkipsJobsHeldByAnotherWorker(t *testing.T) {
+	ctx := context.Background()
+	repo, jobs, embeddings := newMemoryKnowledgeRepo(), newMemoryIndexJobRepo(), &fakeEmbeddings{}
+	indexer := NewKBIndexer(repo, jobs, embeddings, nil, KBIndexerConfig{ChunkSize: 400})
+
+	// Another instance claimed the job and is still working on it
+	job := newQueuedJob(t, repo, jobs, "kb_held", testKBContent)
+	if _, err := jobs.Claim(ctx, job.ID, time.Minute); err != nil {
+		t.Fatalf("Failed to claim job: %v", err)
+	}
+
+	if err := indexer.process(ctx, job.ID); err != nil {
+		t.Fatalf("Expected the held job to be skipped, got %v", err)
+	}
+	if embeddings.calls() != 0 {
+		t.Errorf("Expected no embedding calls for a held job, got %d", embeddings.calls())
+	}
+
+	// The other instance stopped and its lease ran out
+	held, _ := jobs.FindByID(ctx, job.ID)
+	expired := time.Now().Add(-time.Second)
+	held.LeaseUntil = &expired
+	jobs.Update(ctx, held)
+
+	if err := indexer.process(ctx, job.ID); err != nil {
+		t.Fatalf("Failed to process job: %v", err)
+	}
+	done, _ := jobs.FindByID(ctx, job.ID)
+	if done.Status != domain.IndexJobStatusSucceeded || done.Attempts != 2 || done.LeaseUntil != nil {
+		t.Errorf("Expected the expired job to be taken over and finished, got %s after %d attempts", done.Status, done.Attempts)
+	}
+}
+
+func TestKBIndexer_PollsJobsLeftOutOfFullQueue(t *testing.T) {
+	repo, jobs, embeddings := newMemoryKnowledgeRepo(), newMemoryIndexJobRepo(), &fakeEmbeddings{}
+	indexer := NewKBIndexer(repo, jobs, embeddings, nil, KBIndexerConfig{
+		Workers:      1,
+		QueueSize:    1,
+		PollInterval: 5 * time.Millisecond,
+		ChunkSize:    400,
+	})
+
+	ctx := context.Background()
+	var queued []*domain.IndexJob
+	for i := 0; i < 4; i++ {
+		entry := domain.NewKnowledgeEntry("VPN setup", testKBContent, "network", nil, "admin-1")
+		entry.ID = fmt.Sprintf("kb_poll_%d", i)
+		if err := repo.CreateEntry(ctx, entry); err != nil {
+			t.Fatalf("Failed to create entry: %v", err)
+		}
+
+		// Only the first job fits in the queue before the workers start
+		job, err := indexer.Enqueue(ctx, entry)
+		if err != nil {
+			t.Fatalf("Failed to enqueue job: %v", err)
+		}
+		queued = append(queued, job)
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	indexer.Start(runCtx)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for _, job := range queued {
+		for {
+			current, _ := jobs.FindByID(ctx, job.ID)
+			if current.Status == domain.IndexJobStatusSucceeded {
+				if current.Attempts != 1 {
+					t.Errorf("Expected job %s to run once, got %d attempts", job.ID, current.Attempts)
+				}
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("Expected job %s to be picked up, got %s", job.ID, current.Status)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+}
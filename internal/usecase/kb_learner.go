@@ -0,0 +1,261 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// learnedEntryTag marks knowledge base entries drafted from resolved tickets
+const learnedEntryTag = "learned"
+
+// resolvedCommentPrefix starts the comment ResolveTicket adds with the resolution text
+const resolvedCommentPrefix = "Ticket resolved: "
+
+// KBLearnerConfig configures how resolved tickets are turned into knowledge base drafts
+type KBLearnerConfig struct {
+	DuplicateScore float64 // similarity to a published chunk at which the fix counts as already documented
+	DraftOverlap   float64 // word overlap with a pending learned draft at which the draft is a duplicate
+}
+
+// DefaultKBLearnerConfig returns sensible learner defaults
+func DefaultKBLearnerConfig() KBLearnerConfig {
+	return KBLearnerConfig{
+		DuplicateScore: 0.9,
+		DraftOverlap:   0.6,
+	}
+}
+
+// LearnedDraftResult reports the outcome of drafting a knowledge base entry from a ticket
+type LearnedDraftResult struct {
+	Entry       *domain.KnowledgeEntry `json:"entry,omitempty"`        // the new draft, nil when skipped
+	DuplicateOf string                 `json:"duplicate_of,omitempty"` // existing entry that already covers the fix
+	Similarity  float64                `json:"similarity,omitempty"`
+	Skipped     string                 `json:"skipped,omitempty"` // why no draft was created
+}
+
+// KBLearner drafts LEARNED knowledge base entries from resolved tickets. Drafts are never
+// published automatically; an admin reviews and publishes them like any other entry.
+type KBLearner struct {
+	ticketRepo     ports.TicketRepository
+	commentRepo    ports.CommentRepository
+	knowledgeRepo  ports.KnowledgeRepository
+	summarizer     ports.ResolutionSummarizer
+	eventPublisher ports.EventPublisher
+	config         KBLearnerConfig
+}
+
+// NewKBLearner creates a new knowledge base learner. Without a summarizer, or when it fails, the
+// draft is assembled from the ticket description and the comment that describes the fix.
+func NewKBLearner(
+	ticketRepo ports.TicketRepository,
+	commentRepo ports.CommentRepository,
+	knowledgeRepo ports.KnowledgeRepository,
+	summarizer ports.ResolutionSummarizer,
+	eventPublisher ports.EventPublisher,
+	config KBLearnerConfig,
+) *KBLearner {
+	defaults := DefaultKBLearnerConfig()
+	if config.DuplicateScore <= 0 {
+		config.DuplicateScore = defaults.DuplicateScore
+	}
+	if config.DraftOverlap <= 0 {
+		config.DraftOverlap = defaults.DraftOverlap
+	}
+
+	return &KBLearner{
+		ticketRepo:     ticketRepo,
+		commentRepo:    commentRepo,
+		knowledgeRepo:  knowledgeRepo,
+		summarizer:     summarizer,
+		eventPublisher: eventPublisher,
+		config:         config,
+	}
+}
+
+// DraftFromResolvedTicket summarises a resolved ticket into a draft LEARNED entry linked to the
+// ticket. No draft is created when the ticket was already learned, when no fix can be found, or
+// when an existing entry covers the same problem.
+func (l *KBLearner) DraftFromResolvedTicket(ctx context.Context, ticketID, actorID string) (*LearnedDraftResult, error) {
+	if ticketID == "" {
+		return nil, fmt.Errorf("ticket ID is required")
+	}
+
+	ticket, err := l.ticketRepo.FindByID(ctx, ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket: %w", err)
+	}
+
+	if ticket.Status != domain.TicketStatusResolved && ticket.Status != domain.TicketStatusClosed {
+		return nil, fmt.Errorf("ticket must be resolved before drafting a knowledge base entry")
+	}
+
+	// A ticket is learned at most once, even if it is reopened and resolved again
+	existing, err := l.knowledgeRepo.ListEntries(ctx, domain.KBChunkFilter{SourceTicketID: ticket.ID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check learned entries: %w", err)
+	}
+	if len(existing) > 0 {
+		return &LearnedDraftResult{DuplicateOf: existing[0].ID, Similarity: 1, Skipped: "ticket already learned"}, nil
+	}
+
+	comments, err := l.commentRepo.ListByTicket(ctx, ticket.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket comments: %w", err)
+	}
+
+	summary := l.summarize(ctx, ticket, comments)
+	if summary.Fix == "" {
+		return &LearnedDraftResult{Skipped: "no fix found in ticket"}, nil
+	}
+
+	content := learnedEntryContent(summary)
+
+	if duplicate := l.findDuplicate(ctx, content); duplicate != nil {
+		return duplicate, nil
+	}
+
+	if actorID == "" {
+		actorID = "system"
+	}
+
+	entry := domain.NewLearnedKnowledgeEntry(
+		summary.Title,
+		content,
+		string(ticket.Category),
+		[]string{strings.ToLower(string(ticket.Category)), learnedEntryTag},
+		ticket.ID,
+		actorID,
+	)
+
+	if err := createKnowledgeEntry(ctx, l.knowledgeRepo, l.eventPublisher, entry); err != nil {
+		return nil, err
+	}
+
+	return &LearnedDraftResult{Entry: entry}, nil
+}
+
+// summarize asks the summarizer for problem and fix, falling back to the ticket text
+func (l *KBLearner) summarize(ctx context.Context, ticket *domain.Ticket, comments []*domain.Comment) *ports.ResolutionSummary {
+	var texts []string
+	resolution := ""
+	for _, comment := range comments {
+		if comment.Role == domain.CommentRoleAI || strings.TrimSpace(comment.Body) == "" {
+			continue
+		}
+		if strings.HasPrefix(comment.Body, resolvedCommentPrefix) {
+			resolution = strings.TrimSpace(strings.TrimPrefix(comment.Body, resolvedCommentPrefix))
+			continue
+		}
+		texts = append(texts, comment.Body)
+	}
+
+	fallback := &ports.ResolutionSummary{
+		Title:   ticket.Title,
+		Problem: ticket.Description,
+		Fix:     resolution,
+		Source:  "ticket",
+	}
+	if fallback.Fix == "" {
+		fallback.Fix = extractSolutionFromComments(texts)
+	}
+
+	if l.summarizer == nil {
+		return fallback
+	}
+
+	summary, err := l.summarizer.SummarizeResolution(ctx, &ports.TicketTrainingData{
+		TicketID:    ticket.ID,
+		Title:       ticket.Title,
+		Description: ticket.Description,
+		Category:    string(ticket.Category),
+		Priority:    string(ticket.Priority),
+		Comments:    texts,
+		Resolution:  resolution,
+	})
+	if err != nil || summary == nil {
+		if err != nil {
+			log.Printf("Falling back to ticket text for learned entry from ticket %s: %v", ticket.ID, err)
+		}
+		return fallback
+	}
+
+	// The model may decline to name a fix; keep the one recorded on the ticket
+	if summary.Fix == "" {
+		summary.Fix = fallback.Fix
+	}
+	if len(summary.Title) < 3 || len(summary.Title) > 200 {
+		summary.Title = fallback.Title
+	}
+
+	return summary
+}
+
+// findDuplicate returns a result naming an existing entry that covers the same problem, if any.
+// Published entries are compared by embedding similarity; learned drafts have no embeddings
+// until published, so they are compared by word overlap.
+func (l *KBLearner) findDuplicate(ctx context.Context, content string) *LearnedDraftResult {
+	chunks, err := l.knowledgeRepo.SearchChunks(ctx, content, domain.KBChunkFilter{TopK: 1})
+	if err != nil {
+		// The draft is reviewed by an admin anyway; a failed search should not lose it
+		log.Printf("Failed to search knowledge base for duplicates of learned entry: %v", err)
+	} else if len(chunks) > 0 && chunks[0].Score >= l.config.DuplicateScore {
+		return &LearnedDraftResult{DuplicateOf: chunks[0].EntryID, Similarity: chunks[0].Score, Skipped: "similar entry already published"}
+	}
+
+	drafts, err := l.knowledgeRepo.ListEntries(ctx, domain.KBChunkFilter{
+		SourceType: string(domain.KnowledgeSourceTypeLearned),
+		Status:     string(domain.KnowledgeEntryStatusDraft),
+	})
+	if err != nil {
+		log.Printf("Failed to list learned drafts for duplicate check: %v", err)
+		return nil
+	}
+
+	tokens := domain.ClassifierTokens(content)
+	for _, draft := range drafts {
+		if overlap := wordOverlap(tokens, domain.ClassifierTokens(draft.Content)); overlap >= l.config.DraftOverlap {
+			return &LearnedDraftResult{DuplicateOf: draft.ID, Similarity: overlap, Skipped: "similar learned draft awaiting review"}
+		}
+	}
+
+	return nil
+}
+
+// learnedEntryContent formats a resolution summary as an article
+func learnedEntryContent(summary *ports.ResolutionSummary) string {
+	return fmt.Sprintf("## Problem\n\n%s\n\n## Fix\n\n%s\n", strings.TrimSpace(summary.Problem), strings.TrimSpace(summary.Fix))
+}
+
+// wordOverlap is the Jaccard similarity of two token sets
+func wordOverlap(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	set := make(map[string]bool, len(a))
+	for _, token := range a {
+		set[token] = true
+	}
+
+	union := len(set)
+	intersection := 0
+	seen := make(map[string]bool, len(b))
+	for _, token := range b {
+		if seen[token] {
+			continue
+		}
+		seen[token] = true
+		if set[token] {
+			intersection++
+		} else {
+			union++
+		}
+	}
+
+	return float64(intersection) / float64(union)
+}
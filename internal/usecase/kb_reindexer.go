@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// maxCutOverAttempts bounds how often cutover is retried when chunks are published mid-reindex
+const maxCutOverAttempts = 3
+
+// KBReindexer re-embeds the whole knowledge base when the embedding model or dimension changes.
+// Vectors are written to a shadow column in resumable batches and swapped in atomically once
+// every chunk has one, so search never compares vectors from different models.
+type KBReindexer struct {
+	reindexRepo  ports.KBReindexRepository
+	embeddings   ports.EmbeddingProvider
+	batchSize    int
+	maxRetries   int
+	retryBackoff time.Duration
+}
+
+// NewKBReindexer creates a new knowledge base reindexer
+func NewKBReindexer(
+	reindexRepo ports.KBReindexRepository,
+	embeddings ports.EmbeddingProvider,
+	batchSize int,
+	maxRetries int,
+) *KBReindexer {
+	defaults := DefaultKBIndexerConfig()
+	if batchSize <= 0 {
+		batchSize = defaults.BatchSize
+	}
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+
+	return &KBReindexer{
+		reindexRepo:  reindexRepo,
+		embeddings:   embeddings,
+		batchSize:    batchSize,
+		maxRetries:   maxRetries,
+		retryBackoff: defaults.RetryBackoff,
+	}
+}
+
+// Target returns the embedding space of the configured provider
+func (r *KBReindexer) Target() domain.EmbeddingSpace {
+	return domain.EmbeddingSpace{
+		Model:     r.embeddings.Model(),
+		Dimension: r.embeddings.Dimension(),
+	}
+}
+
+// Run re-embeds every chunk into the target space and cuts over. It is safe to interrupt and
+// run again: chunks that already have a shadow vector in the target space are skipped.
+func (r *KBReindexer) Run(ctx context.Context) (*domain.ReindexProgress, error) {
+	target := r.Target()
+
+	progress, err := r.reindexRepo.ReindexProgress(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+
+	if progress.IsCurrent() {
+		log.Printf("Knowledge base already embedded with %s (%d dims), nothing to reindex", target.Model, target.Dimension)
+		return progress, nil
+	}
+
+	log.Printf("Reindexing %d chunks with %s (%d dims), %d already done",
+		progress.TotalChunks, target.Model, target.Dimension, progress.ReindexedChunks)
+
+	for attempt := 1; ; attempt++ {
+		if err := r.embedPending(ctx, target, progress); err != nil {
+			return progress, err
+		}
+
+		err := r.reindexRepo.CutOver(ctx, target)
+		if err == nil {
+			break
+		}
+
+		// Entries published while we were embedding leave new chunks behind
+		if !errors.Is(err, domain.ErrReindexIncomplete) || attempt >= maxCutOverAttempts {
+			return progress, fmt.Errorf("failed to cut over embeddings: %w", err)
+		}
+	}
+
+	progress, err = r.reindexRepo.ReindexProgress(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Cut over %d chunks to %s (%d dims)", progress.TotalChunks, target.Model, target.Dimension)
+
+	return progress, nil
+}
+
+// embedPending writes shadow vectors batch by batch until no chunk is missing one
+func (r *KBReindexer) embedPending(ctx context.Context, target domain.EmbeddingSpace, progress *domain.ReindexProgress) error {
+	for {
+		chunks, err := r.reindexRepo.ListChunksForReindex(ctx, target, r.batchSize)
+		if err != nil {
+			return err
+		}
+
+		if len(chunks) == 0 {
+			return nil
+		}
+
+		texts := make([]string, len(chunks))
+		for i, chunk := range chunks {
+			texts[i] = chunk.Content
+		}
+
+		embeddings, err := embedWithRetry(ctx, r.embeddings, texts, r.maxRetries, r.retryBackoff)
+		if err != nil {
+			return err
+		}
+
+		for i, embedding := range embeddings {
+			if !r.embeddings.ValidateEmbedding(embedding) {
+				return fmt.Errorf("chunk %s: %w", chunks[i].ID, domain.ErrInvalidEmbedding)
+			}
+			chunks[i].SetEmbedding(target.Model, embedding)
+		}
+
+		if err := r.reindexRepo.SaveShadowEmbeddings(ctx, target, chunks); err != nil {
+			return err
+		}
+
+		progress.ReindexedChunks += len(chunks)
+		log.Printf("Reindexed %d/%d chunks", progress.ReindexedChunks, progress.TotalChunks)
+	}
+}
@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+
+	"fixora/internal/domain"
+)
+
+// memoryReindexRepo keeps live and shadow vectors per chunk and cuts over like the database does
+type memoryReindexRepo struct {
+	mu       sync.Mutex
+	contents map[string]string
+	live     map[string]string // chunk ID -> embedding model
+	shadow   map[string]string
+	cutOvers int
+
+	// published is how many cut-overs find a chunk published since the last shadow batch
+	published int
+}
+
+func newMemoryReindexRepo(chunks map[string]string) *memoryReindexRepo {
+	repo := &memoryReindexRepo{contents: make(map[string]string), live: make(map[string]string), shadow: make(map[string]string)}
+	for id, content := range chunks {
+		repo.addChunk(id, content, "old-embedding")
+	}
+	return repo
+}
+
+func (r *memoryReindexRepo) addChunk(id, content, model string) {
+	r.contents[id] = content
+	r.live[id] = model
+}
+
+func (r *memoryReindexRepo) ReindexProgress(ctx context.Context, target domain.EmbeddingSpace) (*domain.ReindexProgress, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	progress := &domain.ReindexProgress{Target: target, TotalChunks: len(r.contents)}
+	for id := range r.contents {
+		if r.shadow[id] == target.Model {
+			progress.ReindexedChunks++
+		} else if r.live[id] == target.Model && r.shadow[id] == "" {
+			progress.CurrentChunks++
+		}
+	}
+	return progress, nil
+}
+
+func (r *memoryReindexRepo) ListChunksForReindex(ctx context.Context, target domain.EmbeddingSpace, limit int) ([]*domain.KBChunk, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var ids []string
+	for id := range r.contents {
+		if r.shadow[id] != target.Model {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	chunks := make([]*domain.KBChunk, len(ids))
+	for i, id := range ids {
+		chunks[i] = &domain.KBChunk{ID: id, Content: r.contents[id]}
+	}
+	return chunks, nil
+}
+
+func (r *memoryReindexRepo) SaveShadowEmbeddings(ctx context.Context, target domain.EmbeddingSpace, chunks []*domain.KBChunk) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, chunk := range chunks {
+		if !target.Matches(chunk.EmbeddingModel, chunk.Embedding) {
+			return domain.ErrInvalidEmbedding
+		}
+		r.shadow[chunk.ID] = target.Model
+	}
+	return nil
+}
+
+func (r *memoryReindexRepo) CutOver(ctx context.Context, target domain.EmbeddingSpace) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cutOvers++
+	if r.cutOvers <= r.published {
+		r.addChunk(fmt.Sprintf("chunk_published_%d", r.cutOvers), "Request a new laptop", "old-embedding")
+	}
+
+	for id := range r.contents {
+		if r.shadow[id] != target.Model {
+			return domain.ErrReindexIncomplete
+		}
+	}
+	for id := range r.contents {
+		r.live[id] = r.shadow[id]
+		delete(r.shadow, id)
+	}
+	return nil
+}
+
+func TestKBReindexer_CutOver(t *testing.T) {
+	chunks := map[string]string{"chunk_1": "Reset your password", "chunk_2": "Reinstall the VPN profile", "chunk_3": "Restart the printer"}
+	target := domain.EmbeddingSpace{Model: "fake-embedding", Dimension: 3}
+
+	tests := []struct {
+		name         string
+		published    int
+		wantCutOvers int
+	}{
+		{
+			name:         "all chunks reindexed",
+			wantCutOvers: 1,
+		},
+		{
+			name:         "chunk published mid-reindex",
+			published:    1,
+			wantCutOvers: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMemoryReindexRepo(chunks)
+			repo.published = tt.published
+			reindexer := NewKBReindexer(repo, &fakeEmbeddings{}, 2, 0)
+
+			progress, err := reindexer.Run(context.Background())
+			if err != nil {
+				t.Fatalf("Failed to reindex: %v", err)
+			}
+
+			if repo.cutOvers != tt.wantCutOvers {
+				t.Errorf("Expected %d cut-over attempts, got %d", tt.wantCutOvers, repo.cutOvers)
+			}
+			if !progress.IsCurrent() || progress.CurrentChunks != len(repo.contents) {
+				t.Errorf("Expected every chunk in the target space, got %+v", progress)
+			}
+			for id, model := range repo.live {
+				if model != target.Model {
+					t.Errorf("Expected chunk %s to use %s, got %s", id, target.Model, model)
+				}
+			}
+			if len(repo.shadow) != 0 {
+				t.Errorf("Expected shadow vectors to be cleared, got %v", repo.shadow)
+			}
+		})
+	}
+}
+
+func TestKBReindexer_GivesUpWhenCutOverKeepsFailing(t *testing.T) {
+	repo := newMemoryReindexRepo(map[string]string{"chunk_1": "Reset your password"})
+	reindexer := NewKBReindexer(repo, &fakeEmbeddings{}, 2, 0)
+
+	// Every cut-over finds a chunk published after the last shadow batch
+	repo.published = maxCutOverAttempts
+
+	_, err := reindexer.Run(context.Background())
+	if !errors.Is(err, domain.ErrReindexIncomplete) {
+		t.Fatalf("Expected ErrReindexIncomplete, got %v", err)
+	}
+	if repo.cutOvers != maxCutOverAttempts {
+		t.Errorf("Expected %d cut-over attempts, got %d", maxCutOverAttempts, repo.cutOvers)
+	}
+	for id, model := range repo.live {
+		if model != "old-embedding" {
+			t.Errorf("Expected chunk %s to keep its live vector, got %s", id, model)
+		}
+	}
+}
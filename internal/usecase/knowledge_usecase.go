@@ -12,19 +12,19 @@ import (
 // KnowledgeUseCase handles knowledge base business logic
 type KnowledgeUseCase struct {
 	knowledgeRepo ports.KnowledgeRepository
-	embeddings    ports.EmbeddingProvider
+	indexer       *KBIndexer
 	eventPublisher ports.EventPublisher
 }
 
 // NewKnowledgeUseCase creates a new knowledge use case
 func NewKnowledgeUseCase(
 	knowledgeRepo ports.KnowledgeRepository,
-	embeddings ports.EmbeddingProvider,
+	indexer *KBIndexer,
 	eventPublisher ports.EventPublisher,
 ) *KnowledgeUseCase {
 	return &KnowledgeUseCase{
 		knowledgeRepo: knowledgeRepo,
-		embeddings:    embeddings,
+		indexer:       indexer,
 		eventPublisher: eventPublisher,
 	}
 }
@@ -43,102 +43,81 @@ func (uc *KnowledgeUseCase) CreateEntry(ctx context.Context, req CreateKnowledge
 		req.CreatedBy,
 	)
 
-	if err := uc.knowledgeRepo.CreateEntry(ctx, entry); err != nil {
-		return nil, fmt.Errorf("failed to create knowledge entry: %w", err)
+	if err := createKnowledgeEntry(ctx, uc.knowledgeRepo, uc.eventPublisher, entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// createKnowledgeEntry saves a new entry with its initial revision and announces it
+func createKnowledgeEntry(ctx context.Context, repo ports.KnowledgeRepository, publisher ports.EventPublisher, entry *domain.KnowledgeEntry) error {
+	if err := repo.CreateEntry(ctx, entry); err != nil {
+		return fmt.Errorf("failed to create knowledge entry: %w", err)
+	}
+
+	// Record the initial revision
+	if err := repo.CreateRevision(ctx, domain.NewKnowledgeRevision(entry, entry.CreatedBy)); err != nil {
+		return fmt.Errorf("failed to create knowledge revision: %w", err)
 	}
 
 	// Publish event
-	if uc.eventPublisher != nil {
-		event := ports.NewEvent(
-			ports.EventTypeKBEntryCreated,
-			"knowledge_entry",
-			entry.ID,
-			map[string]interface{}{
-				"title":      entry.Title,
-				"category":   entry.Category,
-				"created_by": entry.CreatedBy,
-			},
-			1,
-		)
-		_ = uc.eventPublisher.Publish(ctx, *event)
+	if publisher != nil {
+		data := map[string]interface{}{
+			"title":       entry.Title,
+			"category":    entry.Category,
+			"created_by":  entry.CreatedBy,
+			"source_type": entry.SourceType,
+		}
+		if entry.SourceTicketID != "" {
+			data["source_ticket_id"] = entry.SourceTicketID
+		}
+
+		event := ports.NewEvent(ports.EventTypeKBEntryCreated, "knowledge_entry", entry.ID, data, 1)
+		_ = publisher.Publish(ctx, *event)
 	}
 
-	return entry, nil
+	return nil
 }
 
-// PublishEntry processes and publishes a knowledge base entry
-func (uc *KnowledgeUseCase) PublishEntry(ctx context.Context, entryID string) error {
+// PublishEntry queues a knowledge base entry for background indexing and publishing.
+// Publishing is idempotent: re-publishing an already published version is a no-op and
+// returns a nil job. The previously published version stays searchable until the job
+// has embedded the new chunks and swapped them in.
+func (uc *KnowledgeUseCase) PublishEntry(ctx context.Context, entryID string) (*domain.IndexJob, error) {
 	if entryID == "" {
-		return fmt.Errorf("entry ID is required")
+		return nil, fmt.Errorf("entry ID is required")
 	}
 
 	// Get entry
 	entry, err := uc.knowledgeRepo.FindEntryByID(ctx, entryID)
 	if err != nil {
-		return fmt.Errorf("failed to get knowledge entry: %w", err)
+		return nil, fmt.Errorf("failed to get knowledge entry: %w", err)
 	}
 
-	if entry.Status != domain.KnowledgeEntryStatusDraft {
-		return fmt.Errorf("only draft entries can be published")
+	if entry.IsActive() && entry.PublishedVersion == entry.Version {
+		return nil, nil
 	}
 
-	// Process content: normalize and chunk
-	normalizedContent := uc.normalizeContent(entry.Content)
-	chunks := uc.chunkContent(normalizedContent)
-
-	// Generate embeddings for chunks
-	if uc.embeddings != nil {
-		chunkTexts := make([]string, len(chunks))
-		for i, chunk := range chunks {
-			chunkTexts[i] = chunk.Content
-		}
-
-		embeddings, err := uc.embeddings.EmbedBatch(ctx, chunkTexts)
-		if err != nil {
-			return fmt.Errorf("failed to generate embeddings: %w", err)
-		}
-
-		// Set embeddings for chunks
-		for i, embedding := range embeddings {
-			chunks[i].SetEmbedding(embedding)
-		}
+	if entry.Status == domain.KnowledgeEntryStatusArchived {
+		return nil, domain.ErrCannotPublishArchived
 	}
 
-	// Save chunks
-	for _, chunk := range chunks {
-		if err := uc.knowledgeRepo.CreateChunk(ctx, &chunk); err != nil {
-			return fmt.Errorf("failed to create knowledge chunk: %w", err)
-		}
-	}
-
-	// Publish entry
-	if err := entry.Publish(); err != nil {
-		return fmt.Errorf("failed to publish entry: %w", err)
+	if strings.TrimSpace(entry.Content) == "" {
+		return nil, domain.ErrEmptyKBContent
 	}
 
-	// Update entry
-	if err := uc.knowledgeRepo.UpdateEntry(ctx, entry); err != nil {
-		return fmt.Errorf("failed to update knowledge entry: %w", err)
+	job, err := uc.indexer.Enqueue(ctx, entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to queue knowledge entry for indexing: %w", err)
 	}
 
-	// Publish event
-	if uc.eventPublisher != nil {
-		event := ports.NewEvent(
-			ports.EventTypeKBEntryPublished,
-			"knowledge_entry",
-			entry.ID,
-			map[string]interface{}{
-				"title":    entry.Title,
-				"category": entry.Category,
-				"version":  entry.Version,
-				"chunks":   len(chunks),
-			},
-			1,
-		)
-		_ = uc.eventPublisher.Publish(ctx, *event)
-	}
+	return job, nil
+}
 
-	return nil
+// GetIndexJob retrieves a background indexing job
+func (uc *KnowledgeUseCase) GetIndexJob(ctx context.Context, jobID string) (*domain.IndexJob, error) {
+	return uc.indexer.GetJob(ctx, jobID)
 }
 
 // GetEntry retrieves a knowledge base entry
@@ -181,13 +160,18 @@ func (uc *KnowledgeUseCase) UpdateEntry(ctx context.Context, entryID string, req
 		return nil, fmt.Errorf("failed to get knowledge entry: %w", err)
 	}
 
-	// Update content
+	if entry.Status == domain.KnowledgeEntryStatusArchived {
+		return nil, domain.ErrKBEntryArchived
+	}
+
+	// Update content (starts a new draft version)
+	expectedVersion := entry.Version
 	entry.UpdateContent(req.Title, req.Content)
 	entry.Category = req.Category
 	entry.Tags = req.Tags
 
-	// Save updated entry
-	if err := uc.knowledgeRepo.UpdateEntry(ctx, entry); err != nil {
+	// Save the entry and its new revision together, unless someone else saved it meanwhile
+	if err := uc.knowledgeRepo.SaveEntryRevision(ctx, entry, domain.NewKnowledgeRevision(entry, req.UpdatedBy), expectedVersion); err != nil {
 		return nil, fmt.Errorf("failed to update knowledge entry: %w", err)
 	}
 
@@ -235,6 +219,104 @@ func (uc *KnowledgeUseCase) DeleteEntry(ctx context.Context, entryID string) err
 	return nil
 }
 
+// ListRevisions retrieves the revision history of a knowledge base entry
+func (uc *KnowledgeUseCase) ListRevisions(ctx context.Context, entryID string) ([]*domain.KnowledgeRevision, error) {
+	if entryID == "" {
+		return nil, fmt.Errorf("entry ID is required")
+	}
+
+	// Ensure entry exists
+	if _, err := uc.knowledgeRepo.FindEntryByID(ctx, entryID); err != nil {
+		return nil, fmt.Errorf("failed to get knowledge entry: %w", err)
+	}
+
+	revisions, err := uc.knowledgeRepo.ListRevisions(ctx, entryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list knowledge revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// GetRevision retrieves a single revision of a knowledge base entry
+func (uc *KnowledgeUseCase) GetRevision(ctx context.Context, entryID string, version int) (*domain.KnowledgeRevision, error) {
+	if entryID == "" {
+		return nil, fmt.Errorf("entry ID is required")
+	}
+
+	revision, err := uc.knowledgeRepo.FindRevision(ctx, entryID, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get knowledge revision: %w", err)
+	}
+
+	return revision, nil
+}
+
+// DiffRevisions computes a line diff between two revisions of a knowledge base entry
+func (uc *KnowledgeUseCase) DiffRevisions(ctx context.Context, entryID string, fromVersion, toVersion int) (*domain.RevisionDiff, error) {
+	if entryID == "" {
+		return nil, fmt.Errorf("entry ID is required")
+	}
+
+	from, err := uc.knowledgeRepo.FindRevision(ctx, entryID, fromVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revision %d: %w", fromVersion, err)
+	}
+
+	to, err := uc.knowledgeRepo.FindRevision(ctx, entryID, toVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revision %d: %w", toVersion, err)
+	}
+
+	return domain.DiffRevisions(from, to), nil
+}
+
+// RollbackEntry restores a previous revision as a new draft version.
+// The currently published version remains searchable until the draft is published.
+func (uc *KnowledgeUseCase) RollbackEntry(ctx context.Context, entryID string, version int, actorID string) (*domain.KnowledgeEntry, error) {
+	if entryID == "" {
+		return nil, fmt.Errorf("entry ID is required")
+	}
+
+	entry, err := uc.knowledgeRepo.FindEntryByID(ctx, entryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get knowledge entry: %w", err)
+	}
+
+	revision, err := uc.knowledgeRepo.FindRevision(ctx, entryID, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get knowledge revision: %w", err)
+	}
+
+	expectedVersion := entry.Version
+	if err := entry.RestoreRevision(revision); err != nil {
+		return nil, fmt.Errorf("failed to restore revision: %w", err)
+	}
+
+	if err := uc.knowledgeRepo.SaveEntryRevision(ctx, entry, domain.NewKnowledgeRevision(entry, actorID), expectedVersion); err != nil {
+		return nil, fmt.Errorf("failed to update knowledge entry: %w", err)
+	}
+
+	// Publish event
+	if uc.eventPublisher != nil {
+		event := ports.NewEvent(
+			ports.EventTypeKBEntryUpdated,
+			"knowledge_entry",
+			entry.ID,
+			map[string]interface{}{
+				"title":         entry.Title,
+				"version":       entry.Version,
+				"restored_from": version,
+				"updated_by":    actorID,
+			},
+			1,
+		)
+		_ = uc.eventPublisher.Publish(ctx, *event)
+	}
+
+	return entry, nil
+}
+
 // SearchEntries performs semantic search in the knowledge base
 func (uc *KnowledgeUseCase) SearchEntries(ctx context.Context, query string, filter domain.KBChunkFilter) ([]*domain.KBChunk, error) {
 	if query == "" {
@@ -260,10 +342,11 @@ type CreateKnowledgeEntryRequest struct {
 }
 
 type UpdateKnowledgeEntryRequest struct {
-	Title    string   `json:"title" validate:"required,min=3,max=200"`
-	Content  string   `json:"content" validate:"required,min=10"`
-	Category string   `json:"category"`
-	Tags     []string `json:"tags"`
+	Title     string   `json:"title" validate:"required,min=3,max=200"`
+	Content   string   `json:"content" validate:"required,min=10"`
+	Category  string   `json:"category"`
+	Tags      []string `json:"tags"`
+	UpdatedBy string   `json:"updated_by"`
 }
 
 // Helper methods
@@ -313,38 +396,3 @@ func (uc *KnowledgeUseCase) validateUpdateEntryRequest(req UpdateKnowledgeEntryR
 
 	return nil
 }
-
-func (uc *KnowledgeUseCase) normalizeContent(content string) string {
-	// Normalize whitespace
-	content = strings.ReplaceAll(content, "\t", " ")
-	content = strings.ReplaceAll(content, "\n\n", "\n")
-	content = strings.Join(strings.Fields(content), " ")
-
-	// Simple markdown stripping (in production, use a proper markdown parser)
-	content = strings.ReplaceAll(content, "**", "")
-	content = strings.ReplaceAll(content, "*", "")
-	content = strings.ReplaceAll(content, "#", "")
-
-	return strings.TrimSpace(content)
-}
-
-func (uc *KnowledgeUseCase) chunkContent(content string) []domain.KBChunk {
-	chunkSize := 800
-	overlap := 150
-
-	var chunks []domain.KBChunk
-
-	// Simple chunking algorithm
-	for i := 0; i < len(content); i += chunkSize - overlap {
-		end := i + chunkSize
-		if end > len(content) {
-			end = len(content)
-		}
-
-		chunkContent := content[i:end]
-		chunk := domain.NewKBChunk("temp_id", len(chunks), chunkContent)
-		chunks = append(chunks, *chunk)
-	}
-
-	return chunks
-}
\ No newline at end of file
@@ -0,0 +1,482 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// memoryKnowledgeRepo keeps entries and chunks in memory. Entries are stored as copies so only
+// saved changes are visible, like a database.
+type memoryKnowledgeRepo struct {
+	mu        sync.Mutex
+	entries   map[string]*domain.KnowledgeEntry
+	chunks    map[string][]*domain.KBChunk
+	revisions []*domain.KnowledgeRevision
+}
+
+func newMemoryKnowledgeRepo() *memoryKnowledgeRepo {
+	return &memoryKnowledgeRepo{
+		entries: make(map[string]*domain.KnowledgeEntry),
+		chunks:  make(map[string][]*domain.KBChunk),
+	}
+}
+
+func copyEntry(entry *domain.KnowledgeEntry) *domain.KnowledgeEntry {
+	c := *entry
+	return &c
+}
+
+func (r *memoryKnowledgeRepo) CreateEntry(ctx context.Context, entry *domain.KnowledgeEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries[entry.ID] = copyEntry(entry)
+	return nil
+}
+
+func (r *memoryKnowledgeRepo) FindEntryByID(ctx context.Context, id string) (*domain.KnowledgeEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entry, ok := r.entries[id]
+	if !ok {
+		return nil, domain.ErrKBEntryNotFound
+	}
+	return copyEntry(entry), nil
+}
+
+func (r *memoryKnowledgeRepo) UpdateEntry(ctx context.Context, entry *domain.KnowledgeEntry) error {
+	return r.CreateEntry(ctx, entry)
+}
+
+func (r *memoryKnowledgeRepo) ListEntries(ctx context.Context, filter domain.KBChunkFilter) ([]*domain.KnowledgeEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var entries []*domain.KnowledgeEntry
+	for _, entry := range r.entries {
+		entries = append(entries, copyEntry(entry))
+	}
+	return entries, nil
+}
+
+func (r *memoryKnowledgeRepo) DeleteEntry(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.entries, id)
+	delete(r.chunks, id)
+	return nil
+}
+
+func (r *memoryKnowledgeRepo) CreateChunk(ctx context.Context, chunk *domain.KBChunk) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.chunks[chunk.EntryID] = append(r.chunks[chunk.EntryID], chunk)
+	return nil
+}
+
+func (r *memoryKnowledgeRepo) FindChunksByEntry(ctx context.Context, entryID string) ([]*domain.KBChunk, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*domain.KBChunk(nil), r.chunks[entryID]...), nil
+}
+
+func (r *memoryKnowledgeRepo) SearchChunks(ctx context.Context, query string, filter domain.KBChunkFilter) ([]*domain.KBChunk, error) {
+	return nil, nil
+}
+
+func (r *memoryKnowledgeRepo) UpdateChunk(ctx context.Context, chunk *domain.KBChunk) error {
+	return nil
+}
+
+func (r *memoryKnowledgeRepo) DeleteChunksByEntry(ctx context.Context, entryID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.chunks, entryID)
+	return nil
+}
+
+// ReplaceChunks swaps the chunks and publishes the entry's version together, like the
+// transactional repository
+func (r *memoryKnowledgeRepo) ReplaceChunks(ctx context.Context, entry *domain.KnowledgeEntry, chunks []*domain.KBChunk) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.entries[entry.ID]
+	if !ok {
+		return domain.ErrKBEntryNotFound
+	}
+	if stored.Version != entry.Version {
+		return domain.ErrIndexJobSuperseded
+	}
+	r.chunks[entry.ID] = append([]*domain.KBChunk(nil), chunks...)
+	stored.Status = entry.Status
+	stored.PublishedVersion = entry.PublishedVersion
+	stored.UpdatedAt = entry.UpdatedAt
+	return nil
+}
+
+func (r *memoryKnowledgeRepo) CreateRevision(ctx context.Context, revision *domain.KnowledgeRevision) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.revisions = append(r.revisions, revision)
+	return nil
+}
+
+// SaveEntryRevision saves the entry and revision together while the entry is at expectedVersion,
+// like the transactional repository
+func (r *memoryKnowledgeRepo) SaveEntryRevision(ctx context.Context, entry *domain.KnowledgeEntry, revision *domain.KnowledgeRevision, expectedVersion int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.entries[entry.ID]
+	if !ok {
+		return domain.ErrKBEntryNotFound
+	}
+	if stored.Version != expectedVersion {
+		return domain.ErrKBEntryModified
+	}
+	r.entries[entry.ID] = copyEntry(entry)
+	r.revisions = append(r.revisions, revision)
+	return nil
+}
+
+func (r *memoryKnowledgeRepo) FindRevision(ctx context.Context, entryID string, version int) (*domain.KnowledgeRevision, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, revision := range r.revisions {
+		if revision.EntryID == entryID && revision.Version == version {
+			return revision, nil
+		}
+	}
+	return nil, domain.ErrKBRevisionNotFound
+}
+
+func (r *memoryKnowledgeRepo) ListRevisions(ctx context.Context, entryID string) ([]*domain.KnowledgeRevision, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var revisions []*domain.KnowledgeRevision
+	for _, revision := range r.revisions {
+		if revision.EntryID == entryID {
+			revisions = append(revisions, revision)
+		}
+	}
+	return revisions, nil
+}
+
+// memoryIndexJobRepo keeps indexing jobs in memory
+type memoryIndexJobRepo struct {
+	mu   sync.Mutex
+	jobs map[string]*domain.IndexJob
+	// order keeps jobs oldest first for ListByStatus
+	order []string
+}
+
+func newMemoryIndexJobRepo() *memoryIndexJobRepo {
+	return &memoryIndexJobRepo{jobs: make(map[string]*domain.IndexJob)}
+}
+
+func (r *memoryIndexJobRepo) Create(ctx context.Context, job *domain.IndexJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := *job
+	r.jobs[job.ID] = &c
+	r.order = append(r.order, job.ID)
+	return nil
+}
+
+func (r *memoryIndexJobRepo) FindByID(ctx context.Context, id string) (*domain.IndexJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, domain.ErrIndexJobNotFound
+	}
+	c := *job
+	return &c, nil
+}
+
+func (r *memoryIndexJobRepo) Update(ctx context.Context, job *domain.IndexJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := *job
+	r.jobs[job.ID] = &c
+	return nil
+}
+
+func (r *memoryIndexJobRepo) Claim(ctx context.Context, id string, lease time.Duration) (*domain.IndexJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, domain.ErrIndexJobNotFound
+	}
+	if !job.Claimable(time.Now()) {
+		return nil, domain.ErrIndexJobClaimed
+	}
+	job.Start(lease)
+	c := *job
+	return &c, nil
+}
+
+func (r *memoryIndexJobRepo) ListByStatus(ctx context.Context, statuses []domain.IndexJobStatus, limit int) ([]*domain.IndexJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var jobs []*domain.IndexJob
+	for _, id := range r.order {
+		job := r.jobs[id]
+		for _, status := range statuses {
+			if job.Status == status {
+				c := *job
+				jobs = append(jobs, &c)
+				break
+			}
+		}
+		if limit > 0 && len(jobs) == limit {
+			break
+		}
+	}
+	return jobs, nil
+}
+
+// fakeEmbeddings embeds each text as its length. Calls fail with the queued errors first, and
+// each call is held for delay so concurrent calls overlap.
+type fakeEmbeddings struct {
+	mu          sync.Mutex
+	errs        []error
+	delay       time.Duration
+	onEmbed     func() // called before each batch is embedded
+	batchSizes  []int
+	inFlight    int
+	maxInFlight int
+}
+
+func (f *fakeEmbeddings) Embed(ctx context.Context, text string) ([]float32, error) {
+	embeddings, err := f.EmbedBatch(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+	return embeddings[0], nil
+}
+
+func (f *fakeEmbeddings) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	f.mu.Lock()
+	f.batchSizes = append(f.batchSizes, len(texts))
+	f.inFlight++
+	if f.inFlight > f.maxInFlight {
+		f.maxInFlight = f.inFlight
+	}
+	var err error
+	if len(f.errs) > 0 {
+		err, f.errs = f.errs[0], f.errs[1:]
+	}
+	f.mu.Unlock()
+
+	if f.onEmbed != nil {
+		f.onEmbed()
+	}
+	time.Sleep(f.delay)
+
+	f.mu.Lock()
+	f.inFlight--
+	f.mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	embeddings := make([][]float32, len(texts))
+	for i, text := range texts {
+		embeddings[i] = []float32{float32(len(text)), 1, 0}
+	}
+	return embeddings, nil
+}
+
+func (f *fakeEmbeddings) Dimension() int { return 3 }
+
+func (f *fakeEmbeddings) Model() string { return "fake-embedding" }
+
+func (f *fakeEmbeddings) ValidateEmbedding(embedding []float32) bool { return len(embedding) == 3 }
+
+func (f *fakeEmbeddings) calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.batchSizes)
+}
+
+func newTestIndexer(repo *memoryKnowledgeRepo, jobs *memoryIndexJobRepo, embeddings ports.EmbeddingProvider) *KBIndexer {
+	return NewKBIndexer(repo, jobs, embeddings, nil, KBIndexerConfig{
+		BatchSize:    2,
+		RetryBackoff: time.Millisecond,
+		ChunkSize:    40,
+	})
+}
+
+const testKBContent = "Open the VPN client and sign in with your SSO account. " +
+	"If the connection drops, reinstall the profile from the self-service portal."
+
+// publish publishes an entry and runs its indexing job to completion
+func publish(t *testing.T, uc *KnowledgeUseCase, indexer *KBIndexer, entryID string) (*domain.IndexJob, error) {
+	t.Helper()
+	job, err := uc.PublishEntry(context.Background(), entryID)
+	if err != nil || job == nil {
+		return job, err
+	}
+	return job, indexer.process(context.Background(), job.ID)
+}
+
+func chunkContents(chunks []*domain.KBChunk) string {
+	contents := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		contents[i] = chunk.Content
+	}
+	return strings.Join(contents, "|")
+}
+
+func TestKnowledgeUseCase_RepublishIsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	repo, jobs, embeddings := newMemoryKnowledgeRepo(), newMemoryIndexJobRepo(), &fakeEmbeddings{}
+	indexer := newTestIndexer(repo, jobs, embeddings)
+	uc := NewKnowledgeUseCase(repo, indexer, nil)
+
+	entry, err := uc.CreateEntry(ctx, CreateKnowledgeEntryRequest{Title: "VPN setup", Content: testKBContent, CreatedBy: "admin-1"})
+	if err != nil {
+		t.Fatalf("Failed to create entry: %v", err)
+	}
+
+	job, err := publish(t, uc, indexer, entry.ID)
+	if err != nil {
+		t.Fatalf("Failed to publish entry: %v", err)
+	}
+
+	published, _ := repo.FindChunksByEntry(ctx, entry.ID)
+	if len(published) == 0 {
+		t.Fatal("Expected the entry to be chunked")
+	}
+	for _, chunk := range published {
+		if chunk.EmbeddingModel != "fake-embedding" || len(chunk.Embedding) != 3 {
+			t.Errorf("Expected chunk %s to be embedded, got %+v", chunk.ID, chunk)
+		}
+	}
+	calls := embeddings.calls()
+
+	// Publishing the published version again queues nothing
+	again, err := uc.PublishEntry(ctx, entry.ID)
+	if err != nil || again != nil {
+		t.Fatalf("Expected re-publishing to be a no-op, got %v, %v", again, err)
+	}
+
+	// Running the finished job again changes nothing either
+	if err := indexer.process(ctx, job.ID); err != nil {
+		t.Fatalf("Expected re-running a finished job to be a no-op, got %v", err)
+	}
+
+	if embeddings.calls() != calls {
+		t.Errorf("Expected no more embedding calls, got %d after %d", embeddings.calls(), calls)
+	}
+	chunks, _ := repo.FindChunksByEntry(ctx, entry.ID)
+	if len(chunks) != len(published) {
+		t.Fatalf("Expected %d chunks, got %d", len(published), len(chunks))
+	}
+	for i := range chunks {
+		if chunks[i].ID != published[i].ID || chunks[i].Content != published[i].Content {
+			t.Errorf("Expected chunk %d to be unchanged, got %s", i, chunks[i].ID)
+		}
+	}
+}
+
+func TestKnowledgeUseCase_FailedPublishKeepsPreviousChunks(t *testing.T) {
+	ctx := context.Background()
+	repo, jobs, embeddings := newMemoryKnowledgeRepo(), newMemoryIndexJobRepo(), &fakeEmbeddings{}
+	indexer := newTestIndexer(repo, jobs, embeddings)
+	uc := NewKnowledgeUseCase(repo, indexer, nil)
+
+	entry, err := uc.CreateEntry(ctx, CreateKnowledgeEntryRequest{Title: "VPN setup", Content: testKBContent, CreatedBy: "admin-1"})
+	if err != nil {
+		t.Fatalf("Failed to create entry: %v", err)
+	}
+	if _, err := publish(t, uc, indexer, entry.ID); err != nil {
+		t.Fatalf("Failed to publish entry: %v", err)
+	}
+	before, _ := repo.FindChunksByEntry(ctx, entry.ID)
+
+	if _, err := uc.UpdateEntry(ctx, entry.ID, UpdateKnowledgeEntryRequest{
+		Title:     "VPN setup",
+		Content:   "Use the new WireGuard client instead of the old VPN client.",
+		UpdatedBy: "admin-1",
+	}); err != nil {
+		t.Fatalf("Failed to update entry: %v", err)
+	}
+
+	embeddings.errs = []error{&ports.ProviderError{Provider: "fake", StatusCode: 400, Message: "input too long"}}
+	job, err := publish(t, uc, indexer, entry.ID)
+	if err == nil {
+		t.Fatal("Expected the publish to fail")
+	}
+
+	failed, _ := jobs.FindByID(ctx, job.ID)
+	if failed.Status != domain.IndexJobStatusFailed || !strings.Contains(failed.Error, "input too long") {
+		t.Errorf("Expected a failed job with the provider error, got %s: %s", failed.Status, failed.Error)
+	}
+
+	after, _ := repo.FindChunksByEntry(ctx, entry.ID)
+	if chunkContents(after) != chunkContents(before) {
+		t.Errorf("Expected the published chunks to stay, got %q", chunkContents(after))
+	}
+
+	stored, _ := repo.FindEntryByID(ctx, entry.ID)
+	if stored.PublishedVersion != 1 || stored.Version != 2 {
+		t.Errorf("Expected version 1 to stay published under draft 2, got %d and %d", stored.PublishedVersion, stored.Version)
+	}
+}
+
+// racingKnowledgeRepo saves another edit of an entry right after the first time it is read
+type racingKnowledgeRepo struct {
+	*memoryKnowledgeRepo
+	edited bool
+}
+
+func (r *racingKnowledgeRepo) FindEntryByID(ctx context.Context, id string) (*domain.KnowledgeEntry, error) {
+	entry, err := r.memoryKnowledgeRepo.FindEntryByID(ctx, id)
+	if err != nil || r.edited {
+		return entry, err
+	}
+	r.edited = true
+
+	other := copyEntry(entry)
+	other.UpdateContent("VPN setup (edited elsewhere)", testKBContent)
+	if err := r.SaveEntryRevision(ctx, other, domain.NewKnowledgeRevision(other, "admin-2"), entry.Version); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func TestKnowledgeUseCase_UpdateRejectsConcurrentEdit(t *testing.T) {
+	ctx := context.Background()
+	memory := newMemoryKnowledgeRepo()
+	repo := &racingKnowledgeRepo{memoryKnowledgeRepo: memory, edited: true}
+	uc := NewKnowledgeUseCase(repo, nil, nil)
+
+	entry, err := uc.CreateEntry(ctx, CreateKnowledgeEntryRequest{Title: "VPN setup", Content: testKBContent, CreatedBy: "admin-1"})
+	if err != nil {
+		t.Fatalf("Failed to create entry: %v", err)
+	}
+	revisions := len(memory.revisions)
+
+	// Another editor saves between reading the entry and saving this edit
+	repo.edited = false
+	_, err = uc.UpdateEntry(ctx, entry.ID, UpdateKnowledgeEntryRequest{Title: "VPN setup v2", Content: testKBContent, UpdatedBy: "admin-1"})
+	if !errors.Is(err, domain.ErrKBEntryModified) {
+		t.Fatalf("Expected ErrKBEntryModified, got %v", err)
+	}
+
+	stored, _ := memory.FindEntryByID(ctx, entry.ID)
+	if stored.Title != "VPN setup (edited elsewhere)" || stored.Version != entry.Version+1 {
+		t.Errorf("Expected the other edit to be kept, got %q at version %d", stored.Title, stored.Version)
+	}
+	if len(memory.revisions) != revisions+1 {
+		t.Errorf("Expected only the other edit's revision, got %d new revisions", len(memory.revisions)-revisions)
+	}
+}
@@ -0,0 +1,305 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// ProblemUseCase manages problems: the root cause behind incidents and the workaround employees
+// can apply until it is fixed
+type ProblemUseCase struct {
+	problemRepo    ports.ProblemRepository
+	incidentRepo   ports.IncidentRepository
+	knowledge      *KnowledgeUseCase
+	eventPublisher ports.EventPublisher
+}
+
+// NewProblemUseCase creates a new problem use case
+func NewProblemUseCase(
+	problemRepo ports.ProblemRepository,
+	incidentRepo ports.IncidentRepository,
+	knowledge *KnowledgeUseCase,
+	eventPublisher ports.EventPublisher,
+) *ProblemUseCase {
+	return &ProblemUseCase{
+		problemRepo:    problemRepo,
+		incidentRepo:   incidentRepo,
+		knowledge:      knowledge,
+		eventPublisher: eventPublisher,
+	}
+}
+
+// CreateProblemRequest represents the request to open a problem
+type CreateProblemRequest struct {
+	Title       string                `json:"title"`
+	Description string                `json:"description"`
+	Category    domain.TicketCategory `json:"category,omitempty"`
+	RootCause   string                `json:"root_cause,omitempty"`
+	Workaround  string                `json:"workaround,omitempty"`
+	IncidentIDs []string              `json:"incident_ids,omitempty"`
+	CreatedBy   string                `json:"created_by"`
+}
+
+// UpdateProblemRequest records the analysis of a problem. Empty fields keep their current value.
+type UpdateProblemRequest struct {
+	RootCause  string `json:"root_cause,omitempty"`
+	Workaround string `json:"workaround,omitempty"`
+	UpdatedBy  string `json:"updated_by"`
+}
+
+// ProblemResponse represents a problem and the incidents linked to it
+type ProblemResponse struct {
+	Problem   *domain.Problem    `json:"problem"`
+	Incidents []*domain.Incident `json:"incidents"`
+}
+
+// PublishWorkaroundResponse represents the knowledge base entry a workaround was published as
+type PublishWorkaroundResponse struct {
+	Problem *domain.Problem        `json:"problem"`
+	Entry   *domain.KnowledgeEntry `json:"entry"`
+	Job     *domain.IndexJob       `json:"job,omitempty"` // nil when the entry was already published
+}
+
+// CreateProblem opens a problem, linking the given incidents to it
+func (uc *ProblemUseCase) CreateProblem(ctx context.Context, req CreateProblemRequest) (*ProblemResponse, error) {
+	problem, err := domain.NewProblem(req.Title, req.Description, req.Category, req.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid problem: %w", err)
+	}
+
+	if err := problem.RecordAnalysis(req.RootCause, req.Workaround); err != nil {
+		return nil, err
+	}
+
+	incidents, err := uc.findIncidents(ctx, req.IncidentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.problemRepo.Create(ctx, problem); err != nil {
+		return nil, fmt.Errorf("failed to create problem: %w", err)
+	}
+
+	if err := uc.saveIncidentLinks(ctx, problem, incidents); err != nil {
+		return nil, err
+	}
+
+	uc.publish(ctx, problem, map[string]interface{}{
+		"status":     problem.Status,
+		"incidents":  len(incidents),
+		"created_by": problem.CreatedBy,
+	})
+
+	return &ProblemResponse{Problem: problem, Incidents: incidents}, nil
+}
+
+// GetProblem retrieves a problem with its linked incidents
+func (uc *ProblemUseCase) GetProblem(ctx context.Context, problemID string) (*ProblemResponse, error) {
+	problem, err := uc.problemRepo.FindByID(ctx, problemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get problem: %w", err)
+	}
+
+	incidents, err := uc.incidentRepo.List(ctx, domain.IncidentFilter{ProblemID: &problem.ID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list problem incidents: %w", err)
+	}
+
+	return &ProblemResponse{Problem: problem, Incidents: incidents}, nil
+}
+
+// ListProblems lists problems matching the filter
+func (uc *ProblemUseCase) ListProblems(ctx context.Context, filter domain.ProblemFilter) ([]*domain.Problem, error) {
+	if filter.Limit <= 0 || filter.Limit > 100 {
+		filter.Limit = 20
+	}
+
+	problems, err := uc.problemRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list problems: %w", err)
+	}
+
+	return problems, nil
+}
+
+// UpdateProblem records the root cause and workaround of a problem
+func (uc *ProblemUseCase) UpdateProblem(ctx context.Context, problemID string, req UpdateProblemRequest) (*domain.Problem, error) {
+	problem, err := uc.problemRepo.FindByID(ctx, problemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get problem: %w", err)
+	}
+
+	if err := problem.RecordAnalysis(req.RootCause, req.Workaround); err != nil {
+		return nil, err
+	}
+
+	if err := uc.problemRepo.Update(ctx, problem); err != nil {
+		return nil, fmt.Errorf("failed to update problem: %w", err)
+	}
+
+	uc.publish(ctx, problem, map[string]interface{}{
+		"status":     problem.Status,
+		"updated_by": req.UpdatedBy,
+	})
+
+	return problem, nil
+}
+
+// LinkIncidents links incidents to a problem
+func (uc *ProblemUseCase) LinkIncidents(ctx context.Context, problemID string, incidentIDs []string) (*ProblemResponse, error) {
+	if len(incidentIDs) == 0 {
+		return nil, fmt.Errorf("at least one incident to link is required")
+	}
+
+	problem, err := uc.problemRepo.FindByID(ctx, problemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get problem: %w", err)
+	}
+
+	incidents, err := uc.findIncidents(ctx, incidentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.saveIncidentLinks(ctx, problem, incidents); err != nil {
+		return nil, err
+	}
+
+	return uc.GetProblem(ctx, problem.ID)
+}
+
+// ResolveProblem marks a problem as resolved once its root cause has been fixed
+func (uc *ProblemUseCase) ResolveProblem(ctx context.Context, problemID, resolvedBy string) (*domain.Problem, error) {
+	problem, err := uc.problemRepo.FindByID(ctx, problemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get problem: %w", err)
+	}
+
+	if err := problem.Resolve(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.problemRepo.Update(ctx, problem); err != nil {
+		return nil, fmt.Errorf("failed to update problem: %w", err)
+	}
+
+	uc.publish(ctx, problem, map[string]interface{}{
+		"status":      problem.Status,
+		"resolved_by": resolvedBy,
+	})
+
+	return problem, nil
+}
+
+// PublishWorkaround publishes the problem's workaround as a knowledge base entry so suggestions
+// can offer it. The first call creates the entry; later calls update it with the current
+// analysis as a new version.
+func (uc *ProblemUseCase) PublishWorkaround(ctx context.Context, problemID, publishedBy string) (*PublishWorkaroundResponse, error) {
+	if uc.knowledge == nil {
+		return nil, fmt.Errorf("knowledge base not available")
+	}
+
+	problem, err := uc.problemRepo.FindByID(ctx, problemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get problem: %w", err)
+	}
+
+	title, content, err := problem.WorkaroundArticle()
+	if err != nil {
+		return nil, err
+	}
+
+	category := string(problem.Category)
+	tags := []string{"workaround", "problem"}
+
+	var entry *domain.KnowledgeEntry
+	if problem.KBEntryID == "" {
+		entry, err = uc.knowledge.CreateEntry(ctx, CreateKnowledgeEntryRequest{
+			Title:     title,
+			Content:   content,
+			Category:  category,
+			Tags:      tags,
+			CreatedBy: publishedBy,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create workaround entry: %w", err)
+		}
+
+		problem.SetKBEntry(entry.ID)
+		if err := uc.problemRepo.Update(ctx, problem); err != nil {
+			return nil, fmt.Errorf("failed to update problem: %w", err)
+		}
+	} else {
+		entry, err = uc.knowledge.GetEntry(ctx, problem.KBEntryID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get workaround entry: %w", err)
+		}
+
+		if entry.Title != title || entry.Content != content {
+			entry, err = uc.knowledge.UpdateEntry(ctx, entry.ID, UpdateKnowledgeEntryRequest{
+				Title:     title,
+				Content:   content,
+				Category:  category,
+				Tags:      tags,
+				UpdatedBy: publishedBy,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to update workaround entry: %w", err)
+			}
+		}
+	}
+
+	job, err := uc.knowledge.PublishEntry(ctx, entry.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish workaround entry: %w", err)
+	}
+
+	uc.publish(ctx, problem, map[string]interface{}{
+		"kb_entry_id":  entry.ID,
+		"published_by": publishedBy,
+	})
+
+	return &PublishWorkaroundResponse{Problem: problem, Entry: entry, Job: job}, nil
+}
+
+func (uc *ProblemUseCase) findIncidents(ctx context.Context, incidentIDs []string) ([]*domain.Incident, error) {
+	seen := make(map[string]bool)
+	var incidents []*domain.Incident
+
+	for _, id := range incidentIDs {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		incident, err := uc.incidentRepo.FindByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get incident %s: %w", id, err)
+		}
+		incidents = append(incidents, incident)
+	}
+
+	return incidents, nil
+}
+
+func (uc *ProblemUseCase) saveIncidentLinks(ctx context.Context, problem *domain.Problem, incidents []*domain.Incident) error {
+	for _, incident := range incidents {
+		incident.LinkProblem(problem.ID)
+		if err := uc.incidentRepo.Update(ctx, incident); err != nil {
+			return fmt.Errorf("failed to update incident %s: %w", incident.ID, err)
+		}
+	}
+	return nil
+}
+
+func (uc *ProblemUseCase) publish(ctx context.Context, problem *domain.Problem, data map[string]interface{}) {
+	if uc.eventPublisher == nil {
+		return
+	}
+
+	event := ports.NewEvent(ports.EventTypeProblemUpdated, "problem", problem.ID, data, 1)
+	_ = uc.eventPublisher.Publish(ctx, *event)
+}
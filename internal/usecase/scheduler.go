@@ -0,0 +1,254 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// ScheduledJob is a background job the scheduler runs every Interval
+type ScheduledJob struct {
+	Name        string
+	Description string
+	Interval    time.Duration
+	// Run performs the job and reports how many tickets it acted on
+	Run func(ctx context.Context, now time.Time) (int, error)
+}
+
+// SchedulerConfig configures the background scheduler
+type SchedulerConfig struct {
+	Instance     string        // identifies this instance in job runs
+	TickInterval time.Duration // how often to check leadership and due jobs
+}
+
+// Scheduler runs background jobs on one instance at a time. Every tick, each instance tries to
+// take the leader lock; only the leader runs due jobs, so running several instances is safe.
+// Job runs are stored, so a new leader continues the schedule where the previous one stopped.
+// Each run also holds its job's lock, so a manual run or a run left over from a previous leader
+// never overlaps another run of the same job.
+type Scheduler struct {
+	lock      ports.LeaderLock
+	jobLock   ports.JobLock
+	runRepo   ports.JobRunRepository
+	config    SchedulerConfig
+	jobs      []ScheduledJob
+	mu        sync.Mutex
+	leader    bool
+	running   map[string]bool
+	startOnce sync.Once
+}
+
+// NewScheduler creates a new scheduler
+func NewScheduler(lock ports.LeaderLock, jobLock ports.JobLock, runRepo ports.JobRunRepository, config SchedulerConfig) *Scheduler {
+	if config.TickInterval <= 0 {
+		config.TickInterval = 30 * time.Second
+	}
+
+	return &Scheduler{
+		lock:    lock,
+		jobLock: jobLock,
+		runRepo: runRepo,
+		config:  config,
+		running: make(map[string]bool),
+	}
+}
+
+// Register adds a job. Jobs must be registered before Start.
+func (s *Scheduler) Register(job ScheduledJob) {
+	s.jobs = append(s.jobs, job)
+}
+
+// Start runs due jobs while this instance is leader, until the context is cancelled
+func (s *Scheduler) Start(ctx context.Context) {
+	s.startOnce.Do(func() {
+		go func() {
+			ticker := time.NewTicker(s.config.TickInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					releaseCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+					if err := s.lock.Release(releaseCtx); err != nil {
+						log.Printf("Failed to release scheduler leadership: %v", err)
+					}
+					cancel()
+					return
+				case now := <-ticker.C:
+					s.tick(ctx, now)
+				}
+			}
+		}()
+	})
+}
+
+// tick takes or keeps leadership and, as leader, runs the jobs that are due
+func (s *Scheduler) tick(ctx context.Context, now time.Time) {
+	leader, err := s.lock.TryAcquire(ctx)
+	if err != nil {
+		log.Printf("Scheduler leader election failed: %v", err)
+		leader = false
+	}
+
+	s.mu.Lock()
+	if leader != s.leader {
+		if leader {
+			log.Printf("Scheduler instance %s is now leader", s.config.Instance)
+		} else {
+			log.Printf("Scheduler instance %s is no longer leader", s.config.Instance)
+		}
+	}
+	s.leader = leader
+	s.mu.Unlock()
+
+	if !leader {
+		return
+	}
+
+	latest, err := s.runRepo.LatestByJob(ctx)
+	if err != nil {
+		log.Printf("Failed to load scheduled job runs: %v", err)
+		return
+	}
+
+	for _, job := range s.jobs {
+		if domain.IsDue(latest[job.Name], job.Interval, now) {
+			if _, err := s.runJob(ctx, job); err != nil {
+				log.Printf("Scheduled job %s: %v", job.Name, err)
+			}
+		}
+	}
+}
+
+// RunJob runs a job now on this instance, whether or not it is leader. It fails with
+// ErrJobAlreadyRunning while the job runs on any instance.
+func (s *Scheduler) RunJob(ctx context.Context, name string) (*domain.JobRun, error) {
+	for _, job := range s.jobs {
+		if job.Name == name {
+			return s.runJob(ctx, job)
+		}
+	}
+	return nil, domain.ErrJobNotFound
+}
+
+func (s *Scheduler) runJob(ctx context.Context, job ScheduledJob) (*domain.JobRun, error) {
+	s.mu.Lock()
+	if s.running[job.Name] {
+		s.mu.Unlock()
+		return nil, domain.ErrJobAlreadyRunning
+	}
+	s.running[job.Name] = true
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.running, job.Name)
+		s.mu.Unlock()
+	}()
+
+	var run *domain.JobRun
+	var processed int
+	acquired, err := s.jobLock.TryRun(ctx, job.Name, func(ctx context.Context) error {
+		run = domain.NewJobRun(job.Name, s.config.Instance)
+		var runErr error
+		processed, runErr = job.Run(ctx, run.StartedAt)
+		run.Finish(processed, runErr)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock job: %w", err)
+	}
+	if !acquired {
+		return nil, domain.ErrJobAlreadyRunning
+	}
+
+	if run.Status == domain.JobRunFailed {
+		log.Printf("Scheduled job %s failed after %d tickets: %s", job.Name, processed, run.Error)
+	} else if processed > 0 {
+		log.Printf("Scheduled job %s acted on %d tickets", job.Name, processed)
+	}
+
+	if err := s.runRepo.Create(ctx, run); err != nil {
+		return run, fmt.Errorf("failed to record job run: %w", err)
+	}
+
+	return run, nil
+}
+
+// SchedulerStatus describes the scheduler as seen from this instance
+type SchedulerStatus struct {
+	Instance string      `json:"instance"`
+	Leader   bool        `json:"leader"`
+	Jobs     []JobStatus `json:"jobs"`
+}
+
+// JobStatus describes a scheduled job and its last run
+type JobStatus struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Interval    string         `json:"interval"`
+	Running     bool           `json:"running"` // running on this instance
+	LastRun     *domain.JobRun `json:"last_run,omitempty"`
+	NextRunAt   time.Time      `json:"next_run_at"`
+}
+
+// Status reports leadership and each job's last run and outcome
+func (s *Scheduler) Status(ctx context.Context) (*SchedulerStatus, error) {
+	latest, err := s.runRepo.LatestByJob(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load job runs: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := &SchedulerStatus{
+		Instance: s.config.Instance,
+		Leader:   s.leader,
+		Jobs:     make([]JobStatus, 0, len(s.jobs)),
+	}
+
+	now := time.Now()
+	for _, job := range s.jobs {
+		jobStatus := JobStatus{
+			Name:        job.Name,
+			Description: job.Description,
+			Interval:    job.Interval.String(),
+			Running:     s.running[job.Name],
+			LastRun:     latest[job.Name],
+			NextRunAt:   now,
+		}
+		if jobStatus.LastRun != nil && !domain.IsDue(jobStatus.LastRun, job.Interval, now) {
+			jobStatus.NextRunAt = jobStatus.LastRun.StartedAt.Add(job.Interval)
+		}
+		status.Jobs = append(status.Jobs, jobStatus)
+	}
+
+	return status, nil
+}
+
+// ListJobRuns lists a job's recent runs, newest first
+func (s *Scheduler) ListJobRuns(ctx context.Context, name string, limit int) ([]*domain.JobRun, error) {
+	found := false
+	for _, job := range s.jobs {
+		found = found || job.Name == name
+	}
+	if !found {
+		return nil, domain.ErrJobNotFound
+	}
+
+	if limit <= 0 {
+		limit = 20
+	}
+
+	runs, err := s.runRepo.ListByJob(ctx, name, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list job runs: %w", err)
+	}
+	return runs, nil
+}
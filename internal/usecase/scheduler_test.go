@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"fixora/internal/domain"
+)
+
+// memoryJobLock holds job locks shared by the schedulers of several instances
+type memoryJobLock struct {
+	mu     sync.Mutex
+	locked map[string]bool
+}
+
+func (l *memoryJobLock) TryRun(ctx context.Context, job string, fn func(ctx context.Context) error) (bool, error) {
+	l.mu.Lock()
+	if l.locked[job] {
+		l.mu.Unlock()
+		return false, nil
+	}
+	l.locked[job] = true
+	l.mu.Unlock()
+
+	defer func() {
+		l.mu.Lock()
+		delete(l.locked, job)
+		l.mu.Unlock()
+	}()
+	return true, fn(ctx)
+}
+
+// memoryJobRunRepo stores job runs in memory
+type memoryJobRunRepo struct {
+	mu   sync.Mutex
+	runs []*domain.JobRun
+}
+
+func (r *memoryJobRunRepo) Create(ctx context.Context, run *domain.JobRun) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.runs = append(r.runs, run)
+	return nil
+}
+
+func (r *memoryJobRunRepo) LatestByJob(ctx context.Context) (map[string]*domain.JobRun, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	latest := make(map[string]*domain.JobRun)
+	for _, run := range r.runs {
+		latest[run.Job] = run
+	}
+	return latest, nil
+}
+
+func (r *memoryJobRunRepo) ListByJob(ctx context.Context, job string, limit int) ([]*domain.JobRun, error) {
+	return nil, nil
+}
+
+func TestScheduler_RunJobRefusesJobRunningElsewhere(t *testing.T) {
+	ctx := context.Background()
+	jobLock, runs := &memoryJobLock{locked: make(map[string]bool)}, &memoryJobRunRepo{}
+
+	started, release := make(chan struct{}), make(chan struct{})
+	job := ScheduledJob{
+		Name:     "auto_close_resolved",
+		Interval: time.Hour,
+		Run: func(ctx context.Context, now time.Time) (int, error) {
+			close(started)
+			<-release
+			return 2, nil
+		},
+	}
+
+	// The leader and another instance share the job lock
+	leader := NewScheduler(nil, jobLock, runs, SchedulerConfig{Instance: "leader"})
+	other := NewScheduler(nil, jobLock, runs, SchedulerConfig{Instance: "other"})
+	leader.Register(job)
+	other.Register(job)
+
+	done := make(chan error)
+	go func() {
+		_, err := leader.RunJob(ctx, job.Name)
+		done <- err
+	}()
+	<-started
+
+	if _, err := other.RunJob(ctx, job.Name); !errors.Is(err, domain.ErrJobAlreadyRunning) {
+		t.Errorf("Expected ErrJobAlreadyRunning, got %v", err)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("Failed to run job: %v", err)
+	}
+	if len(runs.runs) != 1 || runs.runs[0].Instance != "leader" || runs.runs[0].Processed != 2 {
+		t.Errorf("Expected one run recorded by the leader, got %+v", runs.runs)
+	}
+}
@@ -0,0 +1,371 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// TeamUseCase manages teams of agents and the ticket queues they own. Tickets are placed in a
+// queue first and claimed from it by a member of the queue's team.
+type TeamUseCase struct {
+	teamRepo   ports.TeamRepository
+	queueRepo  ports.QueueRepository
+	agentRepo  ports.AgentRepository
+	ticketRepo ports.TicketRepository
+	tickets    *TicketUseCase
+	publisher  ports.EventPublisher
+}
+
+// NewTeamUseCase creates a new team use case
+func NewTeamUseCase(
+	teamRepo ports.TeamRepository,
+	queueRepo ports.QueueRepository,
+	agentRepo ports.AgentRepository,
+	ticketRepo ports.TicketRepository,
+	tickets *TicketUseCase,
+	publisher ports.EventPublisher,
+) *TeamUseCase {
+	return &TeamUseCase{
+		teamRepo:   teamRepo,
+		queueRepo:  queueRepo,
+		agentRepo:  agentRepo,
+		ticketRepo: ticketRepo,
+		tickets:    tickets,
+		publisher:  publisher,
+	}
+}
+
+// TeamRequest represents a team's name and description
+type TeamRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// QueueRequest represents a queue's name, description and owning team
+type QueueRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	TeamID      string `json:"team_id"`
+}
+
+// CreateTeam creates a team without members
+func (uc *TeamUseCase) CreateTeam(ctx context.Context, req TeamRequest) (*domain.Team, error) {
+	team, err := domain.NewTeam(req.Name, req.Description)
+	if err != nil {
+		return nil, fmt.Errorf("invalid team: %w", err)
+	}
+
+	if err := uc.teamRepo.Create(ctx, team); err != nil {
+		return nil, fmt.Errorf("failed to create team: %w", err)
+	}
+
+	return team, nil
+}
+
+// GetTeam retrieves a team with its members
+func (uc *TeamUseCase) GetTeam(ctx context.Context, teamID string) (*domain.Team, error) {
+	team, err := uc.teamRepo.FindByID(ctx, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team: %w", err)
+	}
+	return team, nil
+}
+
+// ListTeams lists all teams with their members
+func (uc *TeamUseCase) ListTeams(ctx context.Context) ([]*domain.Team, error) {
+	teams, err := uc.teamRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+	return teams, nil
+}
+
+// UpdateTeam renames a team or changes its description
+func (uc *TeamUseCase) UpdateTeam(ctx context.Context, teamID string, req TeamRequest) (*domain.Team, error) {
+	team, err := uc.teamRepo.FindByID(ctx, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team: %w", err)
+	}
+
+	if err := team.Rename(req.Name, req.Description); err != nil {
+		return nil, fmt.Errorf("invalid team: %w", err)
+	}
+
+	if err := uc.teamRepo.Update(ctx, team); err != nil {
+		return nil, fmt.Errorf("failed to update team: %w", err)
+	}
+
+	return team, nil
+}
+
+// AddTeamMember adds a registered agent to a team
+func (uc *TeamUseCase) AddTeamMember(ctx context.Context, teamID, agentID string) (*domain.Team, error) {
+	team, err := uc.teamRepo.FindByID(ctx, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team: %w", err)
+	}
+
+	if _, err := uc.agentRepo.FindByID(ctx, agentID); err != nil {
+		return nil, fmt.Errorf("failed to get agent: %w", err)
+	}
+
+	if err := team.AddMember(agentID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.teamRepo.AddMember(ctx, teamID, agentID); err != nil {
+		return nil, fmt.Errorf("failed to add team member: %w", err)
+	}
+
+	return team, nil
+}
+
+// RemoveTeamMember removes an agent from a team. Tickets the agent already claimed stay with them.
+func (uc *TeamUseCase) RemoveTeamMember(ctx context.Context, teamID, agentID string) (*domain.Team, error) {
+	team, err := uc.teamRepo.FindByID(ctx, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team: %w", err)
+	}
+
+	if err := team.RemoveMember(agentID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.teamRepo.RemoveMember(ctx, teamID, agentID); err != nil {
+		return nil, fmt.Errorf("failed to remove team member: %w", err)
+	}
+
+	return team, nil
+}
+
+// CreateQueue creates a queue owned by an existing team
+func (uc *TeamUseCase) CreateQueue(ctx context.Context, req QueueRequest) (*domain.Queue, error) {
+	queue, err := domain.NewQueue(req.Name, req.Description, req.TeamID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid queue: %w", err)
+	}
+
+	if _, err := uc.teamRepo.FindByID(ctx, queue.TeamID); err != nil {
+		return nil, fmt.Errorf("failed to get team: %w", err)
+	}
+
+	if err := uc.queueRepo.Create(ctx, queue); err != nil {
+		return nil, fmt.Errorf("failed to create queue: %w", err)
+	}
+
+	return queue, nil
+}
+
+// GetQueue retrieves a queue
+func (uc *TeamUseCase) GetQueue(ctx context.Context, queueID string) (*domain.Queue, error) {
+	queue, err := uc.queueRepo.FindByID(ctx, queueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get queue: %w", err)
+	}
+	return queue, nil
+}
+
+// ListQueues lists queues, optionally only those of one team
+func (uc *TeamUseCase) ListQueues(ctx context.Context, filter domain.QueueFilter) ([]*domain.Queue, error) {
+	queues, err := uc.queueRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list queues: %w", err)
+	}
+	return queues, nil
+}
+
+// UpdateQueue renames a queue or hands it to another team. Tickets stay in the queue.
+func (uc *TeamUseCase) UpdateQueue(ctx context.Context, queueID string, req QueueRequest) (*domain.Queue, error) {
+	queue, err := uc.queueRepo.FindByID(ctx, queueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get queue: %w", err)
+	}
+
+	// Keep the owning team unless another one is given
+	teamID := req.TeamID
+	if teamID == "" {
+		teamID = queue.TeamID
+	}
+	if teamID != queue.TeamID {
+		if _, err := uc.teamRepo.FindByID(ctx, teamID); err != nil {
+			return nil, fmt.Errorf("failed to get team: %w", err)
+		}
+	}
+
+	if err := queue.Update(req.Name, req.Description, teamID); err != nil {
+		return nil, fmt.Errorf("invalid queue: %w", err)
+	}
+
+	if err := uc.queueRepo.Update(ctx, queue); err != nil {
+		return nil, fmt.Errorf("failed to update queue: %w", err)
+	}
+
+	return queue, nil
+}
+
+// QueueTicket places a ticket in a queue for the queue's team to claim. A ticket someone was
+// already working on is released back to OPEN.
+func (uc *TeamUseCase) QueueTicket(ctx context.Context, ticketID, queueID string) (*domain.Ticket, error) {
+	queue, err := uc.queueRepo.FindByID(ctx, queueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get queue: %w", err)
+	}
+
+	ticket, err := uc.ticketRepo.FindByID(ctx, ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket: %w", err)
+	}
+
+	if err := ticket.MoveToQueue(queue.ID); err != nil {
+		return nil, fmt.Errorf("failed to queue ticket: %w", err)
+	}
+
+	if err := uc.ticketRepo.Update(ctx, ticket); err != nil {
+		return nil, fmt.Errorf("failed to update ticket: %w", err)
+	}
+
+	if uc.publisher != nil {
+		event := ports.NewEvent(
+			ports.EventTypeTicketQueued,
+			"ticket",
+			ticket.ID,
+			map[string]interface{}{
+				"queue_id": queue.ID,
+				"team_id":  queue.TeamID,
+			},
+			1,
+		)
+		_ = uc.publisher.Publish(ctx, *event)
+	}
+
+	return ticket, nil
+}
+
+// ClaimTicket assigns a queued ticket to the agent claiming it. Only members of the queue's team
+// can claim, and only tickets nobody has claimed yet.
+func (uc *TeamUseCase) ClaimTicket(ctx context.Context, ticketID, agentID string) (*domain.Ticket, error) {
+	if agentID == "" {
+		return nil, domain.ErrEmptyAgentID
+	}
+
+	ticket, err := uc.ticketRepo.FindByID(ctx, ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket: %w", err)
+	}
+
+	if err := ticket.CanBeClaimed(); err != nil {
+		return nil, err
+	}
+
+	queue, err := uc.queueRepo.FindByID(ctx, *ticket.QueueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get queue: %w", err)
+	}
+
+	team, err := uc.teamRepo.FindByID(ctx, queue.TeamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team: %w", err)
+	}
+
+	if !team.HasMember(agentID) {
+		return nil, domain.ErrNotTeamMember
+	}
+
+	// Claimed in one conditional update so two agents claiming at once cannot both win
+	if err := uc.ticketRepo.Claim(ctx, ticket.ID, agentID); err != nil {
+		return nil, err
+	}
+
+	claimed, err := uc.ticketRepo.FindByID(ctx, ticket.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket: %w", err)
+	}
+
+	// Published and notified like any other assignment
+	uc.tickets.assigned(ctx, claimed, agentID)
+
+	return claimed, nil
+}
+
+// GetQueueDashboard summarizes a queue's tickets and how they are spread across its team
+func (uc *TeamUseCase) GetQueueDashboard(ctx context.Context, queueID string) (*domain.QueueDashboard, error) {
+	queue, err := uc.queueRepo.FindByID(ctx, queueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get queue: %w", err)
+	}
+
+	team, err := uc.teamRepo.FindByID(ctx, queue.TeamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team: %w", err)
+	}
+
+	dashboard := &domain.QueueDashboard{
+		Queue:      queue,
+		Team:       team,
+		ByPriority: make(map[domain.TicketPriority]int),
+		Members:    []domain.QueueMemberLoad{},
+	}
+
+	open := domain.TicketStatusOpen
+	inProgress := domain.TicketStatusInProgress
+	resolved := domain.TicketStatusResolved
+
+	counts := []struct {
+		status *domain.TicketStatus
+		target *int
+	}{
+		{&open, &dashboard.Unclaimed},
+		{&inProgress, &dashboard.InProgress},
+		{&resolved, &dashboard.Resolved},
+	}
+	for _, c := range counts {
+		count, err := uc.ticketRepo.Count(ctx, domain.TicketFilter{QueueID: &queue.ID, Status: c.status})
+		if err != nil {
+			return nil, fmt.Errorf("failed to count queue tickets: %w", err)
+		}
+		*c.target = count
+	}
+
+	priorities := []domain.TicketPriority{
+		domain.TicketPriorityCritical,
+		domain.TicketPriorityHigh,
+		domain.TicketPriorityMedium,
+		domain.TicketPriorityLow,
+	}
+	for i := range priorities {
+		count, err := uc.ticketRepo.Count(ctx, domain.TicketFilter{QueueID: &queue.ID, Status: &open, Priority: &priorities[i]})
+		if err != nil {
+			return nil, fmt.Errorf("failed to count queue tickets: %w", err)
+		}
+		dashboard.ByPriority[priorities[i]] = count
+	}
+
+	for _, agentID := range team.Members {
+		agent, err := uc.agentRepo.FindByID(ctx, agentID)
+		if errors.Is(err, domain.ErrAgentNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get agent: %w", err)
+		}
+
+		id := agent.ID
+		count, err := uc.ticketRepo.Count(ctx, domain.TicketFilter{QueueID: &queue.ID, AssignedTo: &id, Status: &inProgress})
+		if err != nil {
+			return nil, fmt.Errorf("failed to count agent tickets: %w", err)
+		}
+
+		dashboard.Members = append(dashboard.Members, domain.QueueMemberLoad{
+			AgentID:    agent.ID,
+			Name:       agent.Name,
+			Available:  agent.Available,
+			InProgress: count,
+		})
+	}
+
+	return dashboard, nil
+}
@@ -0,0 +1,229 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// SchedulerAuthorID is the author of comments added by scheduled jobs
+const SchedulerAuthorID = "scheduler"
+
+// TicketMaintenanceConfig configures the scheduled ticket maintenance jobs
+type TicketMaintenanceConfig struct {
+	AutoCloseAfter       time.Duration // close RESOLVED tickets unchanged for this long
+	PendingReminderAfter time.Duration // remind requesters of PENDING tickets inactive for this long
+	EscalateAfter        time.Duration // escalate IN_PROGRESS tickets inactive for this long
+	BatchSize            int           // tickets loaded per page
+}
+
+// TicketMaintenance implements the scheduled jobs that keep tickets moving: auto-closing resolved
+// tickets, reminding requesters about pending tickets and escalating stalled ones. A ticket's
+// activity is its last change or comment; reminders and escalations add a comment, so each
+// ticket is acted on again only after another full period without activity.
+type TicketMaintenance struct {
+	ticketRepo    ports.TicketRepository
+	commentRepo   ports.CommentRepository
+	tickets       *TicketUseCase
+	notifyService ports.NotificationService
+	config        TicketMaintenanceConfig
+}
+
+// NewTicketMaintenance creates the ticket maintenance jobs
+func NewTicketMaintenance(
+	ticketRepo ports.TicketRepository,
+	commentRepo ports.CommentRepository,
+	tickets *TicketUseCase,
+	notifyService ports.NotificationService,
+	config TicketMaintenanceConfig,
+) *TicketMaintenance {
+	if config.BatchSize <= 0 {
+		config.BatchSize = 200
+	}
+
+	return &TicketMaintenance{
+		ticketRepo:    ticketRepo,
+		commentRepo:   commentRepo,
+		tickets:       tickets,
+		notifyService: notifyService,
+		config:        config,
+	}
+}
+
+// AutoCloseResolved closes tickets that have been RESOLVED for longer than AutoCloseAfter
+func (m *TicketMaintenance) AutoCloseResolved(ctx context.Context, now time.Time) (int, error) {
+	candidates, err := m.staleTickets(ctx, domain.TicketStatusResolved, now.Add(-m.config.AutoCloseAfter))
+	if err != nil {
+		return 0, err
+	}
+
+	closed := 0
+	var failures []error
+	for _, ticket := range candidates {
+		if _, err := m.tickets.CloseTicket(ctx, ticket.ID); err != nil {
+			failures = append(failures, fmt.Errorf("ticket %s: %w", ticket.ID, err))
+			continue
+		}
+		closed++
+
+		m.addComment(ctx, ticket.ID, fmt.Sprintf("Closed automatically after being resolved for %s.", formatPeriod(m.config.AutoCloseAfter)))
+	}
+
+	return closed, joinFailures("close", failures)
+}
+
+// RemindPendingRequesters reminds requesters of PENDING tickets with no activity for longer than
+// PendingReminderAfter
+func (m *TicketMaintenance) RemindPendingRequesters(ctx context.Context, now time.Time) (int, error) {
+	cutoff := now.Add(-m.config.PendingReminderAfter)
+	candidates, err := m.inactiveTickets(ctx, domain.TicketStatusPending, cutoff)
+	if err != nil {
+		return 0, err
+	}
+
+	reminded := 0
+	var failures []error
+	for _, ticket := range candidates {
+		message := fmt.Sprintf("Reminder: this ticket is waiting on your reply. Please respond so we can continue working on \"%s\".", ticket.Title)
+		if err := m.commentRepo.Create(ctx, domain.NewComment(ticket.ID, SchedulerAuthorID, domain.CommentRoleAdmin, message)); err != nil {
+			failures = append(failures, fmt.Errorf("ticket %s: %w", ticket.ID, err))
+			continue
+		}
+		reminded++
+
+		m.notify(ctx, ticket, ticket.CreatedBy, "Waiting on your reply: "+ticket.Title, message, ports.NotificationPriorityMedium)
+	}
+
+	return reminded, joinFailures("remind", failures)
+}
+
+// EscalateStaleInProgress raises the priority of IN_PROGRESS tickets with no activity for longer
+// than EscalateAfter and alerts their assignee
+func (m *TicketMaintenance) EscalateStaleInProgress(ctx context.Context, now time.Time) (int, error) {
+	cutoff := now.Add(-m.config.EscalateAfter)
+	candidates, err := m.inactiveTickets(ctx, domain.TicketStatusInProgress, cutoff)
+	if err != nil {
+		return 0, err
+	}
+
+	escalated := 0
+	var failures []error
+	for _, ticket := range candidates {
+		message := fmt.Sprintf("No activity for %s.", formatPeriod(m.config.EscalateAfter))
+		if priority, ok := domain.EscalatedPriority(ticket.Priority); ok {
+			if _, err := m.tickets.UpdateTicket(ctx, ticket.ID, map[string]interface{}{"priority": priority}); err != nil {
+				failures = append(failures, fmt.Errorf("ticket %s: %w", ticket.ID, err))
+				continue
+			}
+			message = fmt.Sprintf("Escalated from %s to %s: no activity for %s.", ticket.Priority, priority, formatPeriod(m.config.EscalateAfter))
+		}
+
+		if err := m.commentRepo.Create(ctx, domain.NewComment(ticket.ID, SchedulerAuthorID, domain.CommentRoleAdmin, message)); err != nil {
+			failures = append(failures, fmt.Errorf("ticket %s: %w", ticket.ID, err))
+			continue
+		}
+		escalated++
+
+		if ticket.AssignedTo != nil {
+			m.notify(ctx, ticket, *ticket.AssignedTo, "Stalled ticket: "+ticket.Title, message, ports.NotificationPriorityHigh)
+		}
+	}
+
+	return escalated, joinFailures("escalate", failures)
+}
+
+// inactiveTickets loads tickets in the status with no change or comment since cutoff
+func (m *TicketMaintenance) inactiveTickets(ctx context.Context, status domain.TicketStatus, cutoff time.Time) ([]*domain.Ticket, error) {
+	candidates, err := m.staleTickets(ctx, status, cutoff)
+	if err != nil {
+		return nil, err
+	}
+
+	var inactive []*domain.Ticket
+	for _, ticket := range candidates {
+		comments, err := m.commentRepo.ListByTicket(ctx, ticket.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list comments: %w", err)
+		}
+		if ticket.LastActivity(comments).Before(cutoff) {
+			inactive = append(inactive, ticket)
+		}
+	}
+
+	return inactive, nil
+}
+
+// staleTickets loads every ticket in the status not updated since cutoff. All pages are loaded
+// before any ticket is changed, so changes do not shift the pages.
+func (m *TicketMaintenance) staleTickets(ctx context.Context, status domain.TicketStatus, cutoff time.Time) ([]*domain.Ticket, error) {
+	filter := domain.TicketFilter{
+		Status:        &status,
+		UpdatedBefore: &cutoff,
+		Limit:         m.config.BatchSize,
+	}
+
+	var tickets []*domain.Ticket
+	for {
+		page, err := m.ticketRepo.List(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tickets: %w", err)
+		}
+		tickets = append(tickets, page...)
+		if len(page) < filter.Limit {
+			return tickets, nil
+		}
+		filter.Offset += filter.Limit
+	}
+}
+
+func (m *TicketMaintenance) addComment(ctx context.Context, ticketID, body string) {
+	if err := m.commentRepo.Create(ctx, domain.NewComment(ticketID, SchedulerAuthorID, domain.CommentRoleAdmin, body)); err != nil {
+		log.Printf("Failed to comment on ticket %s: %v", ticketID, err)
+	}
+}
+
+// notify sends a notification when a notification service is configured; the ticket comment
+// already records the reminder or escalation
+func (m *TicketMaintenance) notify(ctx context.Context, ticket *domain.Ticket, recipient, subject, message string, priority ports.NotificationPriority) {
+	if m.notifyService == nil {
+		return
+	}
+
+	notification := ports.NewNotification(
+		ports.NotificationTypeCustom,
+		recipient,
+		subject,
+		message,
+		priority,
+		ports.DefaultNotificationConfig().DefaultChannels,
+	)
+	notification.AddData("ticket_id", ticket.ID)
+
+	if err := m.notifyService.SendCustomNotification(ctx, notification); err != nil {
+		log.Printf("Failed to notify %s about ticket %s: %v", recipient, ticket.ID, err)
+	}
+}
+
+// joinFailures summarizes the tickets a job could not act on
+func joinFailures(action string, failures []error) error {
+	if len(failures) == 0 {
+		return nil
+	}
+	return fmt.Errorf("failed to %s %d tickets, first: %w", action, len(failures), failures[0])
+}
+
+// formatPeriod formats a period in whole days, or hours when shorter than a day
+func formatPeriod(d time.Duration) string {
+	if d >= 24*time.Hour && d%(24*time.Hour) == 0 {
+		days := int(d / (24 * time.Hour))
+		if days == 1 {
+			return "1 day"
+		}
+		return fmt.Sprintf("%d days", days)
+	}
+	return fmt.Sprintf("%.0f hours", d.Hours())
+}
@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// TicketSimilarityConfig configures duplicate and related ticket detection
+type TicketSimilarityConfig struct {
+	DuplicateThreshold float64 // minimum similarity for an open ticket to be reported as a likely duplicate
+	SimilarThreshold   float64 // default minimum similarity for related tickets
+	Limit              int     // default number of tickets returned
+}
+
+// TicketSimilarity embeds tickets and finds duplicates and related tickets among them
+type TicketSimilarity struct {
+	embeddingRepo ports.TicketEmbeddingRepository
+	embeddings    ports.EmbeddingProvider
+	config        TicketSimilarityConfig
+}
+
+// NewTicketSimilarity creates a new ticket similarity service
+func NewTicketSimilarity(
+	embeddingRepo ports.TicketEmbeddingRepository,
+	embeddings ports.EmbeddingProvider,
+	config TicketSimilarityConfig,
+) *TicketSimilarity {
+	if config.DuplicateThreshold <= 0 {
+		config.DuplicateThreshold = 0.88
+	}
+	if config.SimilarThreshold <= 0 {
+		config.SimilarThreshold = 0.75
+	}
+	if config.Limit <= 0 {
+		config.Limit = 5
+	}
+
+	return &TicketSimilarity{
+		embeddingRepo: embeddingRepo,
+		embeddings:    embeddings,
+		config:        config,
+	}
+}
+
+// IndexTicket embeds the ticket's title and description and stores the vector
+func (s *TicketSimilarity) IndexTicket(ctx context.Context, ticket *domain.Ticket) ([]float32, error) {
+	embedding, err := s.embeddings.Embed(ctx, ticketText(ticket))
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed ticket: %w", err)
+	}
+
+	if err := s.embeddingRepo.SaveEmbedding(ctx, ticket.ID, s.embeddings.Model(), embedding); err != nil {
+		return nil, err
+	}
+
+	return embedding, nil
+}
+
+// FindDuplicates indexes a new ticket and returns open tickets similar enough to be the same issue
+func (s *TicketSimilarity) FindDuplicates(ctx context.Context, ticket *domain.Ticket) ([]*domain.SimilarTicket, error) {
+	embedding, err := s.IndexTicket(ctx, ticket)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Search(ctx, embedding, domain.SimilarTicketFilter{
+		Statuses:  []domain.TicketStatus{domain.TicketStatusOpen, domain.TicketStatusInProgress},
+		ExcludeID: ticket.ID,
+		MinScore:  s.config.DuplicateThreshold,
+		Limit:     s.config.Limit,
+	})
+}
+
+// FindSimilar returns tickets related to an existing ticket, indexing it first so tickets created
+// before embeddings were stored are covered too. Unset filter fields use the configured defaults.
+func (s *TicketSimilarity) FindSimilar(ctx context.Context, ticket *domain.Ticket, filter domain.SimilarTicketFilter) ([]*domain.SimilarTicket, error) {
+	embedding, err := s.IndexTicket(ctx, ticket)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.ExcludeID = ticket.ID
+	return s.Search(ctx, embedding, filter)
+}
+
+// Search returns tickets similar to an embedding from the configured provider
+func (s *TicketSimilarity) Search(ctx context.Context, embedding []float32, filter domain.SimilarTicketFilter) ([]*domain.SimilarTicket, error) {
+	if filter.MinScore <= 0 {
+		filter.MinScore = s.config.SimilarThreshold
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = s.config.Limit
+	}
+
+	similar, err := s.embeddingRepo.FindSimilar(ctx, s.embeddings.Model(), embedding, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find similar tickets: %w", err)
+	}
+
+	return similar, nil
+}
+
+// GetSimilarTickets returns tickets related to a ticket by embedding similarity
+func (uc *TicketUseCase) GetSimilarTickets(ctx context.Context, ticketID string, filter domain.SimilarTicketFilter) ([]*domain.SimilarTicket, error) {
+	if uc.similarity == nil {
+		return nil, fmt.Errorf("ticket similarity not available")
+	}
+
+	ticket, err := uc.ticketRepo.FindByID(ctx, ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket: %w", err)
+	}
+
+	return uc.similarity.FindSimilar(ctx, ticket, filter)
+}
+
+// ticketText is the text a ticket is embedded from
+func ticketText(ticket *domain.Ticket) string {
+	return ticket.Title + "\n\n" + ticket.Description
+}
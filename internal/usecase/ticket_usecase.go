@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"log"
 	"time"
 
 	"fixora/internal/domain"
@@ -17,12 +18,16 @@ type CreateTicketRequest struct {
 	Priority    domain.TicketPriority `json:"priority" validate:"required"`
 	CreatedBy   string                `json:"created_by" validate:"required"`
 	UseAI       bool                  `json:"use_ai"`
+	// Suggestion the requester already saw, attached instead of requesting a new one
+	Suggestion *ports.SuggestionResult `json:"-"`
 }
 
 // CreateTicketResponse represents the response after creating a ticket
 type CreateTicketResponse struct {
 	Ticket    *domain.Ticket        `json:"ticket"`
 	AIInsight *ports.SuggestionResult `json:"ai_insight,omitempty"`
+	// Open tickets that are likely the same issue, e.g. during an outage
+	PossibleDuplicates []*domain.SimilarTicket `json:"possible_duplicates,omitempty"`
 }
 
 // TicketUseCase handles ticket-related business logic
@@ -32,15 +37,21 @@ type TicketUseCase struct {
 	aiService     ports.AISuggestionService
 	eventPublisher ports.EventPublisher
 	notifyService ports.NotificationService
+	similarity    *TicketSimilarity
+	surveys       *CSATUseCase
 }
 
-// NewTicketUseCase creates a new ticket use case
+// NewTicketUseCase creates a new ticket use case. Similarity is optional; without it no
+// duplicates are reported. Surveys is optional; without it resolution notifications carry no
+// satisfaction rating links.
 func NewTicketUseCase(
 	ticketRepo ports.TicketRepository,
 	commentRepo ports.CommentRepository,
 	aiService ports.AISuggestionService,
 	eventPublisher ports.EventPublisher,
 	notifyService ports.NotificationService,
+	similarity *TicketSimilarity,
+	surveys *CSATUseCase,
 ) *TicketUseCase {
 	return &TicketUseCase{
 		ticketRepo:    ticketRepo,
@@ -48,6 +59,8 @@ func NewTicketUseCase(
 		aiService:     aiService,
 		eventPublisher: eventPublisher,
 		notifyService: notifyService,
+		similarity:    similarity,
+		surveys:       surveys,
 	}
 }
 
@@ -63,10 +76,13 @@ func (uc *TicketUseCase) CreateTicket(ctx context.Context, req CreateTicketReque
 
 	// Get AI suggestion if requested
 	var aiInsight *ports.SuggestionResult
-	if req.UseAI && uc.aiService != nil {
+	if req.Suggestion != nil {
+		attachAIInsight(ticket, *req.Suggestion)
+		aiInsight = req.Suggestion
+	} else if req.UseAI && uc.aiService != nil {
 		suggestion, err := uc.aiService.SuggestMitigation(ctx, req.Description)
 		if err == nil && suggestion.Confidence >= 0.4 {
-			ticket.SetAIInsight(suggestion.Suggestion, suggestion.Confidence)
+			attachAIInsight(ticket, suggestion)
 			aiInsight = &suggestion
 		}
 		// Log AI suggestion failure but don't fail ticket creation
@@ -100,10 +116,21 @@ func (uc *TicketUseCase) CreateTicket(ctx context.Context, req CreateTicketReque
 		_ = uc.notifyService.NotifyTicketCreated(ctx, ticket) // Log error but don't fail
 	}
 
-	return &CreateTicketResponse{
+	response := &CreateTicketResponse{
 		Ticket:    ticket,
 		AIInsight: aiInsight,
-	}, nil
+	}
+
+	// Look for open tickets about the same issue
+	if uc.similarity != nil {
+		duplicates, err := uc.similarity.FindDuplicates(ctx, ticket)
+		if err != nil {
+			log.Printf("Failed to check ticket %s for duplicates: %v", ticket.ID, err)
+		}
+		response.PossibleDuplicates = duplicates
+	}
+
+	return response, nil
 }
 
 // GetTicket retrieves a ticket by ID
@@ -143,6 +170,61 @@ func (uc *TicketUseCase) ListTickets(ctx context.Context, filter domain.TicketFi
 	return tickets, count, nil
 }
 
+// AddComment adds a comment to a ticket's conversation
+func (uc *TicketUseCase) AddComment(ctx context.Context, ticketID, authorID string, role domain.CommentRole, body string) (*domain.Comment, error) {
+	ticket, err := uc.ticketRepo.FindByID(ctx, ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket: %w", err)
+	}
+
+	comment := domain.NewComment(ticket.ID, authorID, role, body)
+	if err := comment.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.commentRepo.Create(ctx, comment); err != nil {
+		return nil, fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	// Publish event
+	if uc.eventPublisher != nil {
+		event := ports.NewEvent(
+			ports.EventTypeCommentAdded,
+			"ticket",
+			ticket.ID,
+			map[string]interface{}{
+				"comment_id": comment.ID,
+				"author_id":  comment.AuthorID,
+				"role":       comment.Role,
+			},
+			1,
+		)
+		_ = uc.eventPublisher.Publish(ctx, *event)
+	}
+
+	// Send notification
+	if uc.notifyService != nil {
+		_ = uc.notifyService.NotifyCommentAdded(ctx, comment, ticket)
+	}
+
+	return comment, nil
+}
+
+// ListComments lists a ticket's conversation, oldest first
+func (uc *TicketUseCase) ListComments(ctx context.Context, ticketID string) ([]*domain.Comment, error) {
+	ticket, err := uc.ticketRepo.FindByID(ctx, ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket: %w", err)
+	}
+
+	comments, err := uc.commentRepo.ListByTicket(ctx, ticket.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+
+	return comments, nil
+}
+
 // AssignTicket assigns a ticket to an admin
 func (uc *TicketUseCase) AssignTicket(ctx context.Context, ticketID, adminID string) (*domain.Ticket, error) {
 	if ticketID == "" {
@@ -168,6 +250,13 @@ func (uc *TicketUseCase) AssignTicket(ctx context.Context, ticketID, adminID str
 		return nil, fmt.Errorf("failed to update ticket: %w", err)
 	}
 
+	uc.assigned(ctx, ticket, adminID)
+
+	return ticket, nil
+}
+
+// assigned publishes and notifies a ticket assignment
+func (uc *TicketUseCase) assigned(ctx context.Context, ticket *domain.Ticket, assigneeID string) {
 	// Publish event
 	if uc.eventPublisher != nil {
 		event := ports.NewEvent(
@@ -175,7 +264,7 @@ func (uc *TicketUseCase) AssignTicket(ctx context.Context, ticketID, adminID str
 			"ticket",
 			ticket.ID,
 			map[string]interface{}{
-				"assigned_to": adminID,
+				"assigned_to": assigneeID,
 				"assigned_by": "system", // In real implementation, get from context
 			},
 			1,
@@ -185,13 +274,12 @@ func (uc *TicketUseCase) AssignTicket(ctx context.Context, ticketID, adminID str
 
 	// Send notification
 	if uc.notifyService != nil {
-		_ = uc.notifyService.NotifyTicketAssigned(ctx, ticket, adminID)
+		_ = uc.notifyService.NotifyTicketAssigned(ctx, ticket, assigneeID)
 	}
-
-	return ticket, nil
 }
 
-// ResolveTicket marks a ticket as resolved
+// ResolveTicket marks a ticket as resolved. Tickets that resolve together are grouped under an
+// incident; see IncidentUseCase.ResolveIncident.
 func (uc *TicketUseCase) ResolveTicket(ctx context.Context, ticketID, resolution string) (*domain.Ticket, error) {
 	if ticketID == "" {
 		return nil, fmt.Errorf("ticket ID is required")
@@ -206,20 +294,28 @@ func (uc *TicketUseCase) ResolveTicket(ctx context.Context, ticketID, resolution
 		return nil, fmt.Errorf("failed to get ticket: %w", err)
 	}
 
+	if err := uc.resolve(ctx, ticket, resolution); err != nil {
+		return nil, err
+	}
+
+	return ticket, nil
+}
+
+func (uc *TicketUseCase) resolve(ctx context.Context, ticket *domain.Ticket, resolution string) error {
 	// Resolve ticket
 	if err := ticket.Resolve(); err != nil {
-		return nil, fmt.Errorf("failed to resolve ticket: %w", err)
+		return fmt.Errorf("failed to resolve ticket: %w", err)
 	}
 
 	// Save changes
 	if err := uc.ticketRepo.Update(ctx, ticket); err != nil {
-		return nil, fmt.Errorf("failed to update ticket: %w", err)
+		return fmt.Errorf("failed to update ticket: %w", err)
 	}
 
 	// Add resolution comment
 	if uc.commentRepo != nil {
 		comment := domain.NewComment(
-			ticketID,
+			ticket.ID,
 			"system", // In real implementation, get from context
 			domain.CommentRoleAdmin,
 			fmt.Sprintf("Ticket resolved: %s", resolution),
@@ -229,25 +325,34 @@ func (uc *TicketUseCase) ResolveTicket(ctx context.Context, ticketID, resolution
 
 	// Publish event
 	if uc.eventPublisher != nil {
+		data := map[string]interface{}{
+			"resolution": resolution,
+			"resolved_by": "system", // In real implementation, get from context
+		}
+		if ticket.IncidentID != nil {
+			data["incident_id"] = *ticket.IncidentID
+		}
+
 		event := ports.NewEvent(
 			ports.EventTypeTicketResolved,
 			"ticket",
 			ticket.ID,
-			map[string]interface{}{
-				"resolution": resolution,
-				"resolved_by": "system", // In real implementation, get from context
-			},
+			data,
 			1,
 		)
 		_ = uc.eventPublisher.Publish(ctx, *event)
 	}
 
-	// Send notification
+	// Send notification with the requester's satisfaction survey
 	if uc.notifyService != nil {
-		_ = uc.notifyService.NotifyTicketResolved(ctx, ticket)
+		var survey *domain.CSATSurvey
+		if uc.surveys != nil {
+			survey = uc.surveys.NewSurvey(ticket)
+		}
+		_ = uc.notifyService.NotifyTicketResolved(ctx, ticket, survey)
 	}
 
-	return ticket, nil
+	return nil
 }
 
 // CloseTicket closes a ticket (must be resolved first)
@@ -290,6 +395,52 @@ func (uc *TicketUseCase) CloseTicket(ctx context.Context, ticketID string) (*dom
 	return ticket, nil
 }
 
+// MarkTicketPending puts a ticket on hold while waiting on the requester
+func (uc *TicketUseCase) MarkTicketPending(ctx context.Context, ticketID string) (*domain.Ticket, error) {
+	return uc.changeStatus(ctx, ticketID, (*domain.Ticket).MarkPending)
+}
+
+// ResumeTicket takes a pending ticket off hold
+func (uc *TicketUseCase) ResumeTicket(ctx context.Context, ticketID string) (*domain.Ticket, error) {
+	return uc.changeStatus(ctx, ticketID, (*domain.Ticket).Resume)
+}
+
+// changeStatus applies a status transition to a ticket, saves it and publishes ticket_updated
+func (uc *TicketUseCase) changeStatus(ctx context.Context, ticketID string, transition func(*domain.Ticket) error) (*domain.Ticket, error) {
+	if ticketID == "" {
+		return nil, fmt.Errorf("ticket ID is required")
+	}
+
+	ticket, err := uc.ticketRepo.FindByID(ctx, ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket: %w", err)
+	}
+
+	if err := transition(ticket); err != nil {
+		return nil, fmt.Errorf("failed to change ticket status: %w", err)
+	}
+
+	if err := uc.ticketRepo.Update(ctx, ticket); err != nil {
+		return nil, fmt.Errorf("failed to update ticket: %w", err)
+	}
+
+	if uc.eventPublisher != nil {
+		event := ports.NewEvent(
+			ports.EventTypeTicketUpdated,
+			"ticket",
+			ticket.ID,
+			map[string]interface{}{
+				"status":     ticket.Status,
+				"updated_by": "system",
+			},
+			1,
+		)
+		_ = uc.eventPublisher.Publish(ctx, *event)
+	}
+
+	return ticket, nil
+}
+
 // UpdateTicket updates ticket information
 func (uc *TicketUseCase) UpdateTicket(ctx context.Context, ticketID string, updates map[string]interface{}) (*domain.Ticket, error) {
 	if ticketID == "" {
@@ -303,6 +454,8 @@ func (uc *TicketUseCase) UpdateTicket(ctx context.Context, ticketID string, upda
 	}
 
 	// Apply updates
+	text := ticketText(ticket)
+
 	if title, ok := updates["title"].(string); ok && title != "" {
 		ticket.Title = title
 	}
@@ -324,6 +477,13 @@ func (uc *TicketUseCase) UpdateTicket(ctx context.Context, ticketID string, upda
 		return nil, fmt.Errorf("failed to update ticket: %w", err)
 	}
 
+	// Re-embed the ticket when its text changed
+	if uc.similarity != nil && ticketText(ticket) != text {
+		if _, err := uc.similarity.IndexTicket(ctx, ticket); err != nil {
+			log.Printf("Failed to re-index ticket %s: %v", ticket.ID, err)
+		}
+	}
+
 	// Publish event
 	if uc.eventPublisher != nil {
 		event := ports.NewEvent(
@@ -350,6 +510,7 @@ func (uc *TicketUseCase) GetTicketStats(ctx context.Context) (map[string]int, er
 	statusFilters := []domain.TicketStatus{
 		domain.TicketStatusOpen,
 		domain.TicketStatusInProgress,
+		domain.TicketStatusPending,
 		domain.TicketStatusResolved,
 		domain.TicketStatusClosed,
 	}
@@ -424,4 +585,20 @@ func (uc *TicketUseCase) validateCreateRequest(req CreateTicketRequest) error {
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// attachAIInsight sets the suggestion as the ticket's AI insight, keeping the provider, model and
+// citations so feedback on the insight can be attributed
+func attachAIInsight(ticket *domain.Ticket, suggestion ports.SuggestionResult) {
+	ticket.SetAIInsight(suggestion.Suggestion, suggestion.Confidence)
+	ticket.AIInsight.Source = suggestion.Source
+	ticket.AIInsight.Model = suggestion.Model
+
+	for _, citation := range suggestion.Citations {
+		ticket.AIInsight.Citations = append(ticket.AIInsight.Citations, domain.InsightCitation{
+			EntryID:    citation.EntryID,
+			ChunkIndex: citation.ChunkIndex,
+			Score:      citation.Score,
+		})
+	}
+}
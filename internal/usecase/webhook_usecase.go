@@ -0,0 +1,348 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"time"
+
+	"fixora/internal/domain"
+	"fixora/internal/ports"
+)
+
+// WebhookConfig configures webhook delivery
+type WebhookConfig struct {
+	MaxAttempts  int // attempts per delivery before it fails
+	DisableAfter int // failed deliveries in a row that disable a subscription
+	BatchSize    int // due retries attempted per run
+	// Lease keeps other runs off a delivery while it is attempted; it must outlast the client timeout
+	Lease time.Duration
+	// AllowedNetworks are internal networks subscriptions may point at, e.g. the CMDB's
+	AllowedNetworks []*net.IPNet
+}
+
+// WebhookUseCase manages webhook subscriptions and delivers events to them. Each event is sent
+// to the enabled subscriptions for its type as soon as it is published; failed deliveries are
+// retried with backoff by a Scheduler job.
+type WebhookUseCase struct {
+	subscriptionRepo ports.WebhookSubscriptionRepository
+	deliveryRepo     ports.WebhookDeliveryRepository
+	client           ports.WebhookClient
+	config           WebhookConfig
+}
+
+// NewWebhookUseCase creates a new webhook use case
+func NewWebhookUseCase(
+	subscriptionRepo ports.WebhookSubscriptionRepository,
+	deliveryRepo ports.WebhookDeliveryRepository,
+	client ports.WebhookClient,
+	config WebhookConfig,
+) *WebhookUseCase {
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 8
+	}
+	if config.DisableAfter <= 0 {
+		config.DisableAfter = 5
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.Lease <= 0 {
+		config.Lease = time.Minute
+	}
+
+	return &WebhookUseCase{
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+		client:           client,
+		config:           config,
+	}
+}
+
+// WebhookSubscriptionRequest represents a webhook subscription's definition
+type WebhookSubscriptionRequest struct {
+	Name       string   `json:"name"`
+	URL        string   `json:"url"`
+	Secret     string   `json:"secret,omitempty"` // generated on create when empty, kept on update
+	EventTypes []string `json:"event_types"`
+	Enabled    *bool    `json:"enabled,omitempty"` // enabling a disabled subscription resets its failures
+	CreatedBy  string   `json:"-"`
+}
+
+// CreateSubscription creates a subscription. The returned subscription carries its secret,
+// which is not shown again.
+func (uc *WebhookUseCase) CreateSubscription(ctx context.Context, req WebhookSubscriptionRequest) (*domain.WebhookSubscription, error) {
+	if err := validateWebhookEvents(req.EventTypes); err != nil {
+		return nil, err
+	}
+
+	subscription, err := domain.NewWebhookSubscription(req.Name, req.URL, req.Secret, req.EventTypes, req.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook subscription: %w", err)
+	}
+	if err := subscription.CheckAddress(uc.config.AllowedNetworks); err != nil {
+		return nil, fmt.Errorf("invalid webhook subscription: %w", err)
+	}
+	if req.Enabled != nil && !*req.Enabled {
+		subscription.Disable("disabled on creation")
+	}
+
+	if err := uc.subscriptionRepo.Create(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+// GetSubscription retrieves a subscription
+func (uc *WebhookUseCase) GetSubscription(ctx context.Context, subscriptionID string) (*domain.WebhookSubscription, error) {
+	subscription, err := uc.subscriptionRepo.FindByID(ctx, subscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+	return subscription, nil
+}
+
+// ListSubscriptions lists subscriptions
+func (uc *WebhookUseCase) ListSubscriptions(ctx context.Context, filter domain.WebhookSubscriptionFilter) ([]*domain.WebhookSubscription, error) {
+	subscriptions, err := uc.subscriptionRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+// UpdateSubscription replaces a subscription's name, URL and events, and its secret when one is
+// given
+func (uc *WebhookUseCase) UpdateSubscription(ctx context.Context, subscriptionID string, req WebhookSubscriptionRequest) (*domain.WebhookSubscription, error) {
+	if err := validateWebhookEvents(req.EventTypes); err != nil {
+		return nil, err
+	}
+
+	subscription, err := uc.subscriptionRepo.FindByID(ctx, subscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+
+	subscription.Name = req.Name
+	subscription.URL = req.URL
+	subscription.EventTypes = req.EventTypes
+	if req.Secret != "" {
+		subscription.Secret = req.Secret
+	}
+	if err := subscription.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid webhook subscription: %w", err)
+	}
+	if err := subscription.CheckAddress(uc.config.AllowedNetworks); err != nil {
+		return nil, fmt.Errorf("invalid webhook subscription: %w", err)
+	}
+
+	if req.Enabled != nil {
+		switch {
+		case *req.Enabled && !subscription.Enabled:
+			subscription.Enable()
+		case !*req.Enabled && subscription.Enabled:
+			subscription.Disable("disabled by " + req.CreatedBy)
+		}
+	}
+	subscription.UpdatedAt = time.Now()
+
+	if err := uc.subscriptionRepo.Update(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("failed to update webhook subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+// DeleteSubscription deletes a subscription and its delivery log
+func (uc *WebhookUseCase) DeleteSubscription(ctx context.Context, subscriptionID string) error {
+	if err := uc.subscriptionRepo.Delete(ctx, subscriptionID); err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+	return nil
+}
+
+// ListDeliveries lists the delivery log, newest first
+func (uc *WebhookUseCase) ListDeliveries(ctx context.Context, filter domain.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error) {
+	deliveries, err := uc.deliveryRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// GetDelivery retrieves a delivery with the outcome of its last attempt
+func (uc *WebhookUseCase) GetDelivery(ctx context.Context, deliveryID string) (*domain.WebhookDelivery, error) {
+	delivery, err := uc.deliveryRepo.FindByID(ctx, deliveryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	return delivery, nil
+}
+
+// ReplayDelivery sends a delivery's payload again as a new delivery and returns it after its
+// first attempt. Replays go out even to disabled subscriptions, so an endpoint can be checked
+// before its subscription is enabled again.
+func (uc *WebhookUseCase) ReplayDelivery(ctx context.Context, deliveryID string) (*domain.WebhookDelivery, error) {
+	original, err := uc.deliveryRepo.FindByID(ctx, deliveryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	subscription, err := uc.subscriptionRepo.FindByID(ctx, original.SubscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+
+	replay := original.Replay()
+	uc.lease(replay)
+	if err := uc.deliveryRepo.Create(ctx, replay); err != nil {
+		return nil, fmt.Errorf("failed to create webhook delivery: %w", err)
+	}
+
+	uc.attempt(ctx, subscription, replay)
+	return replay, nil
+}
+
+// HandleEvent delivers an event to the enabled subscriptions for its type
+func (uc *WebhookUseCase) HandleEvent(ctx context.Context, event ports.Event) error {
+	enabled := true
+	subscriptions, err := uc.subscriptionRepo.List(ctx, domain.WebhookSubscriptionFilter{Enabled: &enabled, EventType: &event.Type})
+	if err != nil {
+		return fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	for _, subscription := range subscriptions {
+		delivery := domain.NewWebhookDelivery(subscription.ID, event.ID, event.Type, payload)
+		uc.lease(delivery)
+
+		if err := uc.deliveryRepo.Create(ctx, delivery); err != nil {
+			log.Printf("Failed to record webhook delivery of %s to %s: %v", event.ID, subscription.ID, err)
+			continue
+		}
+		uc.attempt(ctx, subscription, delivery)
+	}
+
+	return nil
+}
+
+// RetryDueDeliveries attempts the pending deliveries whose retry is due. Each delivery is claimed
+// for the lease first, so instances running this at once never send it twice. Deliveries to
+// subscriptions disabled meanwhile are failed without an attempt. It returns how many
+// deliveries were attempted.
+func (uc *WebhookUseCase) RetryDueDeliveries(ctx context.Context, now time.Time) (int, error) {
+	deliveries, err := uc.deliveryRepo.ClaimDue(ctx, now, uc.config.Lease, uc.config.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim due webhook deliveries: %w", err)
+	}
+
+	subscriptions := make(map[string]*domain.WebhookSubscription)
+	attempted := 0
+
+	for _, delivery := range deliveries {
+		subscription, ok := subscriptions[delivery.SubscriptionID]
+		if !ok {
+			subscription, err = uc.subscriptionRepo.FindByID(ctx, delivery.SubscriptionID)
+			if err != nil {
+				log.Printf("Failed to get webhook subscription %s: %v", delivery.SubscriptionID, err)
+				continue
+			}
+			subscriptions[delivery.SubscriptionID] = subscription
+		}
+
+		if !subscription.Enabled {
+			delivery.Abandon("subscription disabled")
+			if err := uc.deliveryRepo.Update(ctx, delivery); err != nil {
+				log.Printf("Failed to update webhook delivery %s: %v", delivery.ID, err)
+			}
+			continue
+		}
+
+		if !uc.attempt(ctx, subscription, delivery) {
+			// The subscription was disabled by this failure
+			subscription.Enabled = false
+		}
+		attempted++
+	}
+
+	return attempted, nil
+}
+
+// lease keeps the retry job off a new delivery while its first attempt is in flight
+func (uc *WebhookUseCase) lease(delivery *domain.WebhookDelivery) {
+	leasedUntil := delivery.CreatedAt.Add(uc.config.Lease)
+	delivery.NextAttemptAt = &leasedUntil
+}
+
+// attempt sends a delivery once and records the outcome. When the delivery succeeds or runs out
+// of retries, the subscription's failure count is updated. It reports whether the subscription
+// is still enabled.
+func (uc *WebhookUseCase) attempt(ctx context.Context, subscription *domain.WebhookSubscription, delivery *domain.WebhookDelivery) bool {
+	now := time.Now()
+	headers := map[string]string{
+		"Content-Type":                "application/json",
+		domain.WebhookEventHeader:     delivery.EventType,
+		domain.WebhookDeliveryHeader:  delivery.ID,
+		domain.WebhookTimestampHeader: strconv.FormatInt(now.Unix(), 10),
+		domain.WebhookSignatureHeader: domain.SignWebhookPayload(subscription.Secret, now, delivery.Payload),
+	}
+
+	response, err := uc.client.Post(ctx, subscription.URL, headers, delivery.Payload)
+	duration := time.Since(now)
+
+	switch {
+	case err != nil:
+		delivery.RecordFailure(0, "", err.Error(), duration, now, uc.config.MaxAttempts)
+	case response.StatusCode < 200 || response.StatusCode > 299:
+		delivery.RecordFailure(response.StatusCode, response.Body, fmt.Sprintf("endpoint returned HTTP %d", response.StatusCode), duration, now, uc.config.MaxAttempts)
+	default:
+		delivery.RecordSuccess(response.StatusCode, response.Body, duration, now)
+	}
+
+	if err := uc.deliveryRepo.Update(ctx, delivery); err != nil {
+		log.Printf("Failed to update webhook delivery %s: %v", delivery.ID, err)
+	}
+
+	if delivery.Status == domain.WebhookDeliveryPending {
+		return true
+	}
+
+	reason := fmt.Sprintf("disabled after %d failed deliveries in a row", uc.config.DisableAfter)
+	updated, err := uc.subscriptionRepo.RecordDeliveryResult(ctx, subscription.ID, delivery.Status == domain.WebhookDeliverySucceeded, uc.config.DisableAfter, reason)
+	if err != nil {
+		log.Printf("Failed to record webhook delivery result for %s: %v", subscription.ID, err)
+		return true
+	}
+	if subscription.Enabled && !updated.Enabled {
+		log.Printf("Disabled webhook subscription %s (%s): %s", subscription.ID, subscription.URL, reason)
+	}
+	return updated.Enabled
+}
+
+// validateWebhookEvents checks every event type is one that is published
+func validateWebhookEvents(eventTypes []string) error {
+	for _, eventType := range eventTypes {
+		known := false
+		for _, t := range ports.EventTypes {
+			if t == eventType {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("%w: %s", domain.ErrInvalidWebhookEvent, eventType)
+		}
+	}
+	return nil
+}
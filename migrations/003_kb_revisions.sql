@@ -0,0 +1,39 @@
+-- Knowledge base entry revisions
+-- Version: 003
+-- Created: 2026-10-18
+
+-- Track which version of an entry is currently published (0 = never published)
+ALTER TABLE knowledge_entries
+    ADD COLUMN IF NOT EXISTS published_version INT NOT NULL DEFAULT 0;
+
+-- Immutable snapshots of every saved version of an entry
+CREATE TABLE IF NOT EXISTS knowledge_entry_revisions (
+    id TEXT PRIMARY KEY,
+    entry_id UUID NOT NULL REFERENCES knowledge_entries(id) ON DELETE CASCADE,
+    version INT NOT NULL,
+    title TEXT NOT NULL,
+    content TEXT NOT NULL,
+    category TEXT,
+    tags TEXT[],
+    author_id TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (entry_id, version)
+);
+
+CREATE INDEX IF NOT EXISTS idx_kb_revisions_entry_version
+ON knowledge_entry_revisions(entry_id, version DESC);
+
+-- Backfill: active entries are published at their current version
+UPDATE knowledge_entries
+SET published_version = version
+WHERE status = 'active' AND published_version = 0;
+
+-- Backfill: snapshot the current content of existing entries as their first known revision
+INSERT INTO knowledge_entry_revisions (id, entry_id, version, title, content, category, tags, author_id, created_at)
+SELECT 'kbrev_' || id || '_' || version, id, version, title, content, category, tags, created_by::TEXT, updated_at
+FROM knowledge_entries
+ON CONFLICT (entry_id, version) DO NOTHING;
+
+-- Search uses the published version, which may stay live while a newer draft exists
+CREATE INDEX IF NOT EXISTS idx_kb_entries_published
+ON knowledge_entries(id) WHERE published_version > 0 AND status <> 'archived';
@@ -0,0 +1,25 @@
+-- Background knowledge base indexing jobs
+-- Version: 004
+-- Created: 2026-10-18
+
+CREATE TABLE IF NOT EXISTS kb_index_jobs (
+    id TEXT PRIMARY KEY,
+    entry_id UUID NOT NULL REFERENCES knowledge_entries(id) ON DELETE CASCADE,
+    version INT NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('queued', 'running', 'succeeded', 'failed')),
+    total_chunks INT NOT NULL DEFAULT 0,
+    processed_chunks INT NOT NULL DEFAULT 0,
+    attempts INT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_kb_index_jobs_entry_id ON kb_index_jobs(entry_id, created_at DESC);
+
+-- Workers pick up unfinished jobs on startup
+CREATE INDEX IF NOT EXISTS idx_kb_index_jobs_pending
+ON kb_index_jobs(created_at)
+WHERE status IN ('queued', 'running');
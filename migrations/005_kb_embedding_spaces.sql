@@ -0,0 +1,22 @@
+-- Track the embedding model per chunk and support re-embedding into a shadow column
+-- Version: 005
+-- Created: 2026-10-18
+
+ALTER TABLE kb_chunks
+    ADD COLUMN IF NOT EXISTS embedding_model TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS embedding_dim INT NOT NULL DEFAULT 0,
+    -- Shadow vectors written by `fixora -reindex`; untyped so any dimension fits until cutover
+    ADD COLUMN IF NOT EXISTS embedding_next VECTOR,
+    ADD COLUMN IF NOT EXISTS embedding_next_model TEXT,
+    ADD COLUMN IF NOT EXISTS embedding_next_dim INT;
+
+-- Backfill: existing vectors were produced by the default embedding model
+UPDATE kb_chunks
+SET embedding_model = 'text-embedding-ada-002', embedding_dim = vector_dims(embedding)
+WHERE embedding IS NOT NULL AND embedding_model = '';
+
+-- Search filters by embedding space
+CREATE INDEX IF NOT EXISTS idx_kb_chunks_embedding_space ON kb_chunks(embedding_model, embedding_dim);
+
+-- Reindex scans for chunks still missing a shadow vector
+CREATE INDEX IF NOT EXISTS idx_kb_chunks_embedding_next ON kb_chunks(embedding_next_model, embedding_next_dim);
@@ -0,0 +1,14 @@
+-- Durable embedding cache keyed by model and content hash
+-- Version: 006
+-- Created: 2026-10-18
+
+CREATE TABLE IF NOT EXISTS embedding_cache (
+    model TEXT NOT NULL,
+    content_hash TEXT NOT NULL, -- hex sha256 of the embedded text
+    embedding VECTOR NOT NULL,  -- untyped so entries for models of any dimension fit
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (model, content_hash)
+);
+
+-- Supports pruning old entries after a model change
+CREATE INDEX IF NOT EXISTS idx_embedding_cache_created_at ON embedding_cache(created_at);
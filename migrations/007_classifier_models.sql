@@ -0,0 +1,10 @@
+-- Locally trained classifier models, stored as JSON so they survive restarts
+-- Version: 007
+-- Created: 2026-10-18
+
+CREATE TABLE IF NOT EXISTS classifier_models (
+    name VARCHAR(100) PRIMARY KEY,
+    model JSONB NOT NULL,
+    documents INTEGER NOT NULL DEFAULT 0, -- tickets the model was trained on
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,15 @@
+-- Knowledge entries learned from resolved tickets
+-- Version: 008
+-- Created: 2026-10-18
+
+-- Ticket a LEARNED entry was drafted from
+ALTER TABLE knowledge_entries
+    ADD COLUMN IF NOT EXISTS source_ticket_id UUID REFERENCES tickets(id) ON DELETE SET NULL;
+
+-- At most one learned entry per ticket, so re-resolving a ticket does not draft it twice
+CREATE UNIQUE INDEX IF NOT EXISTS idx_knowledge_entries_source_ticket
+ON knowledge_entries(source_ticket_id)
+WHERE source_ticket_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_knowledge_entries_source_type_status
+ON knowledge_entries(source_type, status);
@@ -0,0 +1,26 @@
+-- Requester feedback on AI suggestions
+-- Version: 009
+-- Created: 2026-10-18
+
+CREATE TABLE IF NOT EXISTS suggestion_feedback (
+    id TEXT PRIMARY KEY,
+    target_type TEXT NOT NULL CHECK (target_type IN ('ticket_insight', 'stream_candidate')),
+    target_id TEXT NOT NULL, -- ticket ID, or query ID and candidate rank
+    ticket_id UUID REFERENCES tickets(id) ON DELETE CASCADE,
+    query_id TEXT,
+    candidate_rank INT,
+    rating TEXT NOT NULL CHECK (rating IN ('helpful', 'not_helpful', 'solved')),
+    comment TEXT NOT NULL DEFAULT '',
+    category TEXT NOT NULL DEFAULT '',
+    provider TEXT NOT NULL DEFAULT '',
+    model TEXT NOT NULL DEFAULT '',
+    citations JSONB NOT NULL DEFAULT '[]',
+    deflected BOOLEAN NOT NULL DEFAULT FALSE,
+    submitted_by TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (target_type, target_id, submitted_by)
+);
+
+CREATE INDEX IF NOT EXISTS idx_suggestion_feedback_created_at ON suggestion_feedback(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_suggestion_feedback_provider ON suggestion_feedback(provider);
+CREATE INDEX IF NOT EXISTS idx_suggestion_feedback_category ON suggestion_feedback(category);
@@ -0,0 +1,20 @@
+-- Self-service deflection sessions before ticket creation
+-- Version: 010
+-- Created: 2026-10-18
+
+CREATE TABLE IF NOT EXISTS deflection_sessions (
+    id TEXT PRIMARY KEY,
+    description TEXT NOT NULL,
+    requested_by TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'deflected', 'escalated')),
+    query_id TEXT, -- query ID of the latest suggestion stream
+    candidates JSONB NOT NULL DEFAULT '[]',
+    selected_rank INT,
+    ticket_id UUID REFERENCES tickets(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    closed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_deflection_sessions_query_id ON deflection_sessions(query_id);
+CREATE INDEX IF NOT EXISTS idx_deflection_sessions_status_created_at ON deflection_sessions(status, created_at DESC);
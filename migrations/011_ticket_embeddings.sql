@@ -0,0 +1,14 @@
+-- Ticket embeddings for duplicate detection
+-- Version: 011
+-- Created: 2026-10-18
+
+-- One vector per ticket; untyped so any embedding dimension fits, searches filter by model and dimension
+CREATE TABLE IF NOT EXISTS ticket_embeddings (
+    ticket_id UUID PRIMARY KEY REFERENCES tickets(id) ON DELETE CASCADE,
+    embedding VECTOR NOT NULL,
+    embedding_model TEXT NOT NULL,
+    embedding_dim INT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_embeddings_space ON ticket_embeddings(embedding_model, embedding_dim);
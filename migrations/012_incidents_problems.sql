@@ -0,0 +1,44 @@
+-- Incidents grouping tickets about one disruption, and problems recording their root cause
+-- Version: 012
+-- Created: 2026-10-18
+
+CREATE TABLE IF NOT EXISTS problems (
+    id TEXT PRIMARY KEY,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'known_error', 'resolved')),
+    root_cause TEXT,
+    workaround TEXT,
+    kb_entry_id UUID REFERENCES knowledge_entries(id) ON DELETE SET NULL,
+    category TEXT,
+    created_by TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_problems_status_created_at ON problems(status, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS incidents (
+    id TEXT PRIMARY KEY,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'investigating'
+        CHECK (status IN ('investigating', 'identified', 'monitoring', 'resolved')),
+    priority TEXT NOT NULL,
+    problem_id TEXT REFERENCES problems(id) ON DELETE SET NULL,
+    resolution TEXT,
+    updates JSONB NOT NULL DEFAULT '[]', -- status updates broadcast to requesters, oldest first
+    created_by TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_incidents_status_created_at ON incidents(status, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_incidents_problem_id ON incidents(problem_id) WHERE problem_id IS NOT NULL;
+
+ALTER TABLE tickets
+    ADD COLUMN IF NOT EXISTS incident_id TEXT REFERENCES incidents(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tickets_incident_id ON tickets(incident_id) WHERE incident_id IS NOT NULL;
@@ -0,0 +1,17 @@
+-- Support agents for automatic ticket assignment
+-- Version: 013
+-- Created: 2026-10-18
+
+CREATE TABLE IF NOT EXISTS agents (
+    id UUID PRIMARY KEY, -- the user ID tickets are assigned to
+    name TEXT NOT NULL,
+    email TEXT,
+    skills JSONB NOT NULL DEFAULT '{}', -- proficiency per ticket category, 1 to 5
+    capacity INT NOT NULL DEFAULT 0 CHECK (capacity >= 0), -- maximum open tickets, 0 for no limit
+    available BOOLEAN NOT NULL DEFAULT TRUE,
+    last_assigned_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_agents_available ON agents(available);
@@ -0,0 +1,37 @@
+-- Teams of agents and the ticket queues they own
+-- Version: 014
+-- Created: 2026-10-18
+
+CREATE TABLE IF NOT EXISTS teams (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    description TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS team_members (
+    team_id TEXT NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    agent_id UUID NOT NULL REFERENCES agents(id) ON DELETE CASCADE,
+    added_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (team_id, agent_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_team_members_agent_id ON team_members(agent_id);
+
+CREATE TABLE IF NOT EXISTS queues (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    description TEXT,
+    team_id TEXT NOT NULL REFERENCES teams(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_queues_team_id ON queues(team_id);
+
+-- Tickets wait in a queue until an agent of its team claims them
+ALTER TABLE tickets
+    ADD COLUMN IF NOT EXISTS queue_id TEXT REFERENCES queues(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tickets_queue_id_status ON tickets(queue_id, status) WHERE queue_id IS NOT NULL;
@@ -0,0 +1,38 @@
+-- Automation rules and their execution log
+-- Version: 015
+-- Created: 2026-10-18
+
+CREATE TABLE IF NOT EXISTS automation_rules (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    description TEXT,
+    trigger TEXT NOT NULL, -- ticket event name, or 'scheduled' for time-based rules
+    conditions JSONB NOT NULL DEFAULT '[]', -- all must match
+    actions JSONB NOT NULL DEFAULT '[]',
+    position INT NOT NULL DEFAULT 0, -- evaluation order, lowest first
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    stop_processing BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_automation_rules_trigger_position ON automation_rules(trigger, position) WHERE enabled;
+
+CREATE TABLE IF NOT EXISTS automation_executions (
+    id TEXT PRIMARY KEY,
+    rule_id TEXT NOT NULL REFERENCES automation_rules(id) ON DELETE CASCADE,
+    rule_name TEXT NOT NULL,
+    ticket_id UUID NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    trigger TEXT NOT NULL,
+    event_id TEXT,
+    status TEXT NOT NULL CHECK (status IN ('succeeded', 'failed', 'skipped')),
+    actions JSONB NOT NULL DEFAULT '[]', -- what each applied action did
+    error TEXT,
+    depth INT NOT NULL DEFAULT 0,
+    executed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_automation_executions_rule_ticket ON automation_executions(rule_id, ticket_id, status);
+CREATE INDEX IF NOT EXISTS idx_automation_executions_ticket_id ON automation_executions(ticket_id, executed_at DESC);
+CREATE INDEX IF NOT EXISTS idx_automation_executions_executed_at ON automation_executions(executed_at DESC);
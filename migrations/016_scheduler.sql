@@ -0,0 +1,24 @@
+-- Background scheduler: PENDING tickets and the job run log
+-- Version: 016
+-- Created: 2026-10-18
+
+-- PENDING: waiting on the requester
+ALTER TABLE tickets DROP CONSTRAINT IF EXISTS tickets_status_check;
+ALTER TABLE tickets ADD CONSTRAINT tickets_status_check
+    CHECK (status IN ('OPEN', 'IN_PROGRESS', 'PENDING', 'RESOLVED', 'CLOSED'));
+
+-- Scheduled jobs look for tickets by status that have not changed for a while
+CREATE INDEX IF NOT EXISTS idx_tickets_status_updated_at ON tickets(status, updated_at);
+
+CREATE TABLE IF NOT EXISTS scheduler_job_runs (
+    id TEXT PRIMARY KEY,
+    job TEXT NOT NULL,
+    instance TEXT NOT NULL, -- instance that held scheduler leadership
+    status TEXT NOT NULL CHECK (status IN ('succeeded', 'failed')),
+    processed INT NOT NULL DEFAULT 0,
+    error TEXT,
+    started_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduler_job_runs_job_started_at ON scheduler_job_runs(job, started_at DESC);
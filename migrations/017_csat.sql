@@ -0,0 +1,18 @@
+-- Requester satisfaction (CSAT) ratings
+-- Version: 017
+-- Created: 2026-10-18
+
+CREATE TABLE IF NOT EXISTS csat_ratings (
+    id TEXT PRIMARY KEY,
+    ticket_id UUID NOT NULL UNIQUE REFERENCES tickets(id) ON DELETE CASCADE, -- one rating per ticket
+    score INT NOT NULL CHECK (score BETWEEN 1 AND 5),
+    comment TEXT,
+    submitted_by TEXT NOT NULL,
+    agent_id TEXT, -- assignee when the ticket was rated
+    category TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_csat_ratings_created_at ON csat_ratings(created_at);
+CREATE INDEX IF NOT EXISTS idx_csat_ratings_agent_id ON csat_ratings(agent_id);
@@ -0,0 +1,16 @@
+-- Emails in ticket conversations, used to thread replies back to their ticket
+-- Version: 018
+-- Created: 2026-10-18
+
+CREATE TABLE IF NOT EXISTS email_messages (
+    id TEXT PRIMARY KEY,
+    message_id TEXT NOT NULL UNIQUE, -- the Message-ID header, with angle brackets
+    ticket_id UUID NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    comment_id UUID REFERENCES comments(id) ON DELETE SET NULL,
+    direction TEXT NOT NULL CHECK (direction IN ('inbound', 'outbound')),
+    address TEXT NOT NULL, -- sender of inbound mail, recipient of outbound mail
+    subject TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_messages_ticket_id ON email_messages(ticket_id, created_at);
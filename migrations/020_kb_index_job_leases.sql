@@ -0,0 +1,6 @@
+-- Lease running indexing jobs so several instances never index the same job at once
+-- Version: 020
+-- Created: 2026-10-18
+
+-- A running job whose lease has passed was left by a stopped worker and may be claimed again
+ALTER TABLE kb_index_jobs ADD COLUMN IF NOT EXISTS lease_until TIMESTAMPTZ;
@@ -0,0 +1,18 @@
+-- Candidates served by the suggestion stream, so feedback is attributed to what was actually offered
+-- Version: 021
+-- Created: 2026-10-18
+
+CREATE TABLE IF NOT EXISTS suggestion_candidates (
+    query_id TEXT NOT NULL,
+    candidate_rank INT NOT NULL,
+    score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    category TEXT NOT NULL DEFAULT '',
+    provider TEXT NOT NULL DEFAULT '',
+    model TEXT NOT NULL DEFAULT '',
+    entry_id TEXT NOT NULL DEFAULT '',
+    chunk_index INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (query_id, candidate_rank)
+);
+
+CREATE INDEX IF NOT EXISTS idx_suggestion_candidates_created_at ON suggestion_candidates(created_at);
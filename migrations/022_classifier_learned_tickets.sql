@@ -0,0 +1,21 @@
+-- Tickets each classifier model was trained on, so a ticket resolved again is not learned twice
+-- Version: 022
+-- Created: 2026-10-18
+
+CREATE TABLE IF NOT EXISTS classifier_learned_tickets (
+    model_name VARCHAR(100) NOT NULL,
+    ticket_id TEXT NOT NULL,
+    learned_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (model_name, ticket_id)
+);
+
+-- Move the ticket IDs previously kept inside the stored model
+INSERT INTO classifier_learned_tickets (model_name, ticket_id)
+SELECT name, jsonb_object_keys(model->'learned_tickets')
+FROM classifier_models
+WHERE jsonb_typeof(model->'learned_tickets') = 'object'
+ON CONFLICT DO NOTHING;
+
+UPDATE classifier_models
+SET model = model - 'learned_tickets'
+WHERE model->'learned_tickets' IS NOT NULL;